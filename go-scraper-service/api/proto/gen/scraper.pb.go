@@ -112,16 +112,25 @@ func (x *FetchRequest) GetOptions() *FetchOptions {
 }
 
 type FetchOptions struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	TimeoutMs       int32                  `protobuf:"varint,1,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
-	ExtractFulltext bool                   `protobuf:"varint,2,opt,name=extract_fulltext,json=extractFulltext,proto3" json:"extract_fulltext,omitempty"`
-	ProcessImages   bool                   `protobuf:"varint,3,opt,name=process_images,json=processImages,proto3" json:"process_images,omitempty"`
-	ImageProxyBase  string                 `protobuf:"bytes,4,opt,name=image_proxy_base,json=imageProxyBase,proto3" json:"image_proxy_base,omitempty"`
-	Headers         map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Strategy        string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"` // cycletls, standard, auto
-	Referer         string                 `protobuf:"bytes,7,opt,name=referer,proto3" json:"referer,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TimeoutMs          int32                  `protobuf:"varint,1,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	ExtractFulltext    bool                   `protobuf:"varint,2,opt,name=extract_fulltext,json=extractFulltext,proto3" json:"extract_fulltext,omitempty"`
+	ProcessImages      bool                   `protobuf:"varint,3,opt,name=process_images,json=processImages,proto3" json:"process_images,omitempty"`
+	ImageProxyBase     string                 `protobuf:"bytes,4,opt,name=image_proxy_base,json=imageProxyBase,proto3" json:"image_proxy_base,omitempty"`
+	Headers            map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Strategy           string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"` // cycletls, standard, auto
+	Referer            string                 `protobuf:"bytes,7,opt,name=referer,proto3" json:"referer,omitempty"`
+	FollowPagination   bool                   `protobuf:"varint,8,opt,name=follow_pagination,json=followPagination,proto3" json:"follow_pagination,omitempty"`        // 抓取并拼接文章的后续分页
+	MaxPages           int32                  `protobuf:"varint,9,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`                                // 最多拼接页数（含首页），0 表示使用服务配置
+	DiscoverAlternates bool                   `protobuf:"varint,10,opt,name=discover_alternates,json=discoverAlternates,proto3" json:"discover_alternates,omitempty"` // 发现 AMP / 打印版本并择优提取
+	Credential         *CredentialRef         `protobuf:"bytes,11,opt,name=credential,proto3" json:"credential,omitempty"`                                            // 加密凭证引用，由服务端解密后作为 Cookie / Bearer Token 使用
+	OutputFormat       string                 `protobuf:"bytes,12,opt,name=output_format,json=outputFormat,proto3" json:"output_format,omitempty"`                    // 正文输出格式：html（默认）, markdown, text
+	RequireArticle     bool                   `protobuf:"varint,13,opt,name=require_article,json=requireArticle,proto3" json:"require_article,omitempty"`             // 拒绝提取非文章页面（列表页、首页、错误页等）
+	EmbedMode          string                 `protobuf:"bytes,14,opt,name=embed_mode,json=embedMode,proto3" json:"embed_mode,omitempty"`                             // 嵌入媒体渲染方式：iframe（默认，沙箱 iframe）, placeholder（缩略图占位）
+	SanitizeProfile    string                 `protobuf:"bytes,15,opt,name=sanitize_profile,json=sanitizeProfile,proto3" json:"sanitize_profile,omitempty"`           // 净化配置：reader（默认）, email-safe, strict-text, archive 或服务端自定义配置
+	Summarize          bool                   `protobuf:"varint,16,opt,name=summarize,proto3" json:"summarize,omitempty"`                                             // 生成抽取式摘要和关键词（不依赖 AI 服务）
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *FetchOptions) Reset() {
@@ -203,6 +212,154 @@ func (x *FetchOptions) GetReferer() string {
 	return ""
 }
 
+func (x *FetchOptions) GetFollowPagination() bool {
+	if x != nil {
+		return x.FollowPagination
+	}
+	return false
+}
+
+func (x *FetchOptions) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+func (x *FetchOptions) GetDiscoverAlternates() bool {
+	if x != nil {
+		return x.DiscoverAlternates
+	}
+	return false
+}
+
+func (x *FetchOptions) GetCredential() *CredentialRef {
+	if x != nil {
+		return x.Credential
+	}
+	return nil
+}
+
+func (x *FetchOptions) GetOutputFormat() string {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetRequireArticle() bool {
+	if x != nil {
+		return x.RequireArticle
+	}
+	return false
+}
+
+func (x *FetchOptions) GetEmbedMode() string {
+	if x != nil {
+		return x.EmbedMode
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetSanitizeProfile() string {
+	if x != nil {
+		return x.SanitizeProfile
+	}
+	return ""
+}
+
+func (x *FetchOptions) GetSummarize() bool {
+	if x != nil {
+		return x.Summarize
+	}
+	return false
+}
+
+// 加密凭证引用（字段与 SiteCredential 一致，密文格式 hex(iv):hex(authTag):hex(ciphertext)）
+type CredentialRef struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Domain            string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`                     // 凭证作用域名，为空时取目标 URL 的主机名
+	AuthType          string                 `protobuf:"bytes,2,opt,name=auth_type,json=authType,proto3" json:"auth_type,omitempty"` // cookie, login, token
+	EncryptedCookie   string                 `protobuf:"bytes,3,opt,name=encrypted_cookie,json=encryptedCookie,proto3" json:"encrypted_cookie,omitempty"`
+	EncryptedToken    string                 `protobuf:"bytes,4,opt,name=encrypted_token,json=encryptedToken,proto3" json:"encrypted_token,omitempty"`
+	EncryptedUsername string                 `protobuf:"bytes,5,opt,name=encrypted_username,json=encryptedUsername,proto3" json:"encrypted_username,omitempty"`
+	EncryptedPassword string                 `protobuf:"bytes,6,opt,name=encrypted_password,json=encryptedPassword,proto3" json:"encrypted_password,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CredentialRef) Reset() {
+	*x = CredentialRef{}
+	mi := &file_scraper_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialRef) ProtoMessage() {}
+
+func (x *CredentialRef) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialRef.ProtoReflect.Descriptor instead.
+func (*CredentialRef) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CredentialRef) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CredentialRef) GetAuthType() string {
+	if x != nil {
+		return x.AuthType
+	}
+	return ""
+}
+
+func (x *CredentialRef) GetEncryptedCookie() string {
+	if x != nil {
+		return x.EncryptedCookie
+	}
+	return ""
+}
+
+func (x *CredentialRef) GetEncryptedToken() string {
+	if x != nil {
+		return x.EncryptedToken
+	}
+	return ""
+}
+
+func (x *CredentialRef) GetEncryptedUsername() string {
+	if x != nil {
+		return x.EncryptedUsername
+	}
+	return ""
+}
+
+func (x *CredentialRef) GetEncryptedPassword() string {
+	if x != nil {
+		return x.EncryptedPassword
+	}
+	return ""
+}
+
 type FetchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -218,13 +375,27 @@ type FetchResponse struct {
 	Strategy      string                 `protobuf:"bytes,11,opt,name=strategy,proto3" json:"strategy,omitempty"`
 	DurationMs    int64                  `protobuf:"varint,12,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
 	Error         string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`
+	Pages         int32                  `protobuf:"varint,14,opt,name=pages,proto3" json:"pages,omitempty"`                                     // 拼接的分页数（PDF 文档为页数）
+	Variant       string                 `protobuf:"bytes,15,opt,name=variant,proto3" json:"variant,omitempty"`                                  // 提取所用的页面版本：canonical, amp, print
+	VariantUrl    string                 `protobuf:"bytes,16,opt,name=variant_url,json=variantUrl,proto3" json:"variant_url,omitempty"`          // 备用版本 URL（final_url 仍为原始页面）
+	Metadata      *ArticleMetadata       `protobuf:"bytes,17,opt,name=metadata,proto3" json:"metadata,omitempty"`                                // 结构化元数据
+	PublishedDate *PublishedDate         `protobuf:"bytes,18,opt,name=published_date,json=publishedDate,proto3" json:"published_date,omitempty"` // 发布时间
+	Rule          string                 `protobuf:"bytes,19,opt,name=rule,proto3" json:"rule,omitempty"`                                        // 匹配的站点规则名称
+	Engine        string                 `protobuf:"bytes,20,opt,name=engine,proto3" json:"engine,omitempty"`                                    // 胜出的提取引擎：rules, readability, jsonld, density（PDF 文档为 pdf）
+	EngineScores  []*EngineScore         `protobuf:"bytes,21,rep,name=engine_scores,json=engineScores,proto3" json:"engine_scores,omitempty"`    // 全部引擎的质量评分（诊断用）
+	Format        string                 `protobuf:"bytes,22,opt,name=format,proto3" json:"format,omitempty"`                                    // content 的格式：html, markdown, text
+	PageType      *PageClass             `protobuf:"bytes,23,opt,name=page_type,json=pageType,proto3" json:"page_type,omitempty"`                // 页面类型（拒绝提取非文章页面时同样返回）
+	Media         []*EmbeddedMedia       `protobuf:"bytes,24,rep,name=media,proto3" json:"media,omitempty"`                                      // 正文中保留的嵌入媒体
+	Fingerprint   *Fingerprint           `protobuf:"bytes,25,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`                          // 正文指纹（精确哈希和 SimHash）
+	Summary       *Summary               `protobuf:"bytes,26,opt,name=summary,proto3" json:"summary,omitempty"`                                  // 抽取式摘要和关键词（summarize 为 true 时）
+	Document      *DocumentInfo          `protobuf:"bytes,27,opt,name=document,proto3" json:"document,omitempty"`                                // 文档信息（PDF 等非网页内容，此时 page_type 为 document）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FetchResponse) Reset() {
 	*x = FetchResponse{}
-	mi := &file_scraper_proto_msgTypes[3]
+	mi := &file_scraper_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -236,7 +407,7 @@ func (x *FetchResponse) String() string {
 func (*FetchResponse) ProtoMessage() {}
 
 func (x *FetchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_scraper_proto_msgTypes[3]
+	mi := &file_scraper_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +420,7 @@ func (x *FetchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchResponse.ProtoReflect.Descriptor instead.
 func (*FetchResponse) Descriptor() ([]byte, []int) {
-	return file_scraper_proto_rawDescGZIP(), []int{3}
+	return file_scraper_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *FetchResponse) GetUrl() string {
@@ -343,98 +514,131 @@ func (x *FetchResponse) GetError() string {
 	return ""
 }
 
-type Image struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
-	ProxyUrl      string                 `protobuf:"bytes,2,opt,name=proxy_url,json=proxyUrl,proto3" json:"proxy_url,omitempty"`
-	Alt           string                 `protobuf:"bytes,3,opt,name=alt,proto3" json:"alt,omitempty"`
-	IsLazy        bool                   `protobuf:"varint,4,opt,name=is_lazy,json=isLazy,proto3" json:"is_lazy,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *FetchResponse) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
 }
 
-func (x *Image) Reset() {
-	*x = Image{}
-	mi := &file_scraper_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *FetchResponse) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
 }
 
-func (x *Image) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *FetchResponse) GetVariantUrl() string {
+	if x != nil {
+		return x.VariantUrl
+	}
+	return ""
 }
 
-func (*Image) ProtoMessage() {}
-
-func (x *Image) ProtoReflect() protoreflect.Message {
-	mi := &file_scraper_proto_msgTypes[4]
+func (x *FetchResponse) GetMetadata() *ArticleMetadata {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Metadata
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Image.ProtoReflect.Descriptor instead.
-func (*Image) Descriptor() ([]byte, []int) {
-	return file_scraper_proto_rawDescGZIP(), []int{4}
+func (x *FetchResponse) GetPublishedDate() *PublishedDate {
+	if x != nil {
+		return x.PublishedDate
+	}
+	return nil
 }
 
-func (x *Image) GetOriginalUrl() string {
+func (x *FetchResponse) GetRule() string {
 	if x != nil {
-		return x.OriginalUrl
+		return x.Rule
 	}
 	return ""
 }
 
-func (x *Image) GetProxyUrl() string {
+func (x *FetchResponse) GetEngine() string {
 	if x != nil {
-		return x.ProxyUrl
+		return x.Engine
 	}
 	return ""
 }
 
-func (x *Image) GetAlt() string {
+func (x *FetchResponse) GetEngineScores() []*EngineScore {
 	if x != nil {
-		return x.Alt
+		return x.EngineScores
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
 	}
 	return ""
 }
 
-func (x *Image) GetIsLazy() bool {
+func (x *FetchResponse) GetPageType() *PageClass {
 	if x != nil {
-		return x.IsLazy
+		return x.PageType
 	}
-	return false
+	return nil
 }
 
-type HealthResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Status          string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	MaxConcurrent   int32                  `protobuf:"varint,2,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
-	Available       int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
-	CycletlsEnabled bool                   `protobuf:"varint,4,opt,name=cycletls_enabled,json=cycletlsEnabled,proto3" json:"cycletls_enabled,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *FetchResponse) GetMedia() []*EmbeddedMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
 }
 
-func (x *HealthResponse) Reset() {
-	*x = HealthResponse{}
+func (x *FetchResponse) GetFingerprint() *Fingerprint {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetSummary() *Summary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetDocument() *DocumentInfo {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+// 文档（非网页）信息
+type DocumentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                         // pdf
+	PageCount     int32                  `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"` // 文档总页数
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Creator       string                 `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`   // 创建文档的应用
+	Producer      string                 `protobuf:"bytes,6,opt,name=producer,proto3" json:"producer,omitempty"` // 生成 PDF 的应用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentInfo) Reset() {
+	*x = DocumentInfo{}
 	mi := &file_scraper_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthResponse) String() string {
+func (x *DocumentInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthResponse) ProtoMessage() {}
+func (*DocumentInfo) ProtoMessage() {}
 
-func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_scraper_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -446,68 +650,77 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
-func (*HealthResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
+func (*DocumentInfo) Descriptor() ([]byte, []int) {
 	return file_scraper_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *HealthResponse) GetStatus() string {
+func (x *DocumentInfo) GetFormat() string {
 	if x != nil {
-		return x.Status
+		return x.Format
 	}
 	return ""
 }
 
-func (x *HealthResponse) GetMaxConcurrent() int32 {
+func (x *DocumentInfo) GetPageCount() int32 {
 	if x != nil {
-		return x.MaxConcurrent
+		return x.PageCount
 	}
 	return 0
 }
 
-func (x *HealthResponse) GetAvailable() int32 {
+func (x *DocumentInfo) GetAuthor() string {
 	if x != nil {
-		return x.Available
+		return x.Author
 	}
-	return 0
+	return ""
 }
 
-func (x *HealthResponse) GetCycletlsEnabled() bool {
+func (x *DocumentInfo) GetSubject() string {
 	if x != nil {
-		return x.CycletlsEnabled
+		return x.Subject
 	}
-	return false
+	return ""
 }
 
-// 原始抓取响应（不经过 Readability 处理）
-type FetchRawResponse struct {
+func (x *DocumentInfo) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+// 抽取式摘要（TextRank）
+type Summary struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	FinalUrl      string                 `protobuf:"bytes,2,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
-	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
-	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
-	Strategy      string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
-	DurationMs    int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
-	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Summary       string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"` // 摘要句按原文顺序拼接
+	Sentences     []string               `protobuf:"bytes,2,rep,name=sentences,proto3" json:"sentences,omitempty"`
+	Keywords      []*Keyword             `protobuf:"bytes,3,rep,name=keywords,proto3" json:"keywords,omitempty"` // 得分从高到低
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FetchRawResponse) Reset() {
-	*x = FetchRawResponse{}
+func (x *Summary) Reset() {
+	*x = Summary{}
 	mi := &file_scraper_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FetchRawResponse) String() string {
+func (x *Summary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchRawResponse) ProtoMessage() {}
+func (*Summary) ProtoMessage() {}
 
-func (x *FetchRawResponse) ProtoReflect() protoreflect.Message {
+func (x *Summary) ProtoReflect() protoreflect.Message {
 	mi := &file_scraper_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -519,61 +732,4544 @@ func (x *FetchRawResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchRawResponse.ProtoReflect.Descriptor instead.
-func (*FetchRawResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
 	return file_scraper_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *FetchRawResponse) GetUrl() string {
+func (x *Summary) GetSummary() string {
 	if x != nil {
-		return x.Url
+		return x.Summary
 	}
 	return ""
 }
 
-func (x *FetchRawResponse) GetFinalUrl() string {
+func (x *Summary) GetSentences() []string {
 	if x != nil {
-		return x.FinalUrl
+		return x.Sentences
 	}
-	return ""
+	return nil
 }
 
-func (x *FetchRawResponse) GetBody() string {
+func (x *Summary) GetKeywords() []*Keyword {
 	if x != nil {
-		return x.Body
+		return x.Keywords
 	}
-	return ""
+	return nil
 }
 
-func (x *FetchRawResponse) GetContentType() string {
-	if x != nil {
-		return x.ContentType
-	}
+type Keyword struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"` // 以最高分为 1 归一化
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Keyword) Reset() {
+	*x = Keyword{}
+	mi := &file_scraper_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Keyword) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keyword) ProtoMessage() {}
+
+func (x *Keyword) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keyword.ProtoReflect.Descriptor instead.
+func (*Keyword) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Keyword) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Keyword) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// 正文指纹（基于规范化后的正文文本）
+type Fingerprint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentHash   string                 `protobuf:"bytes,1,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // 规范化文本的 SHA-256（十六进制）
+	Simhash       string                 `protobuf:"bytes,2,opt,name=simhash,proto3" json:"simhash,omitempty"`                            // 64 位 SimHash（16 位十六进制）
+	Shingles      int32                  `protobuf:"varint,3,opt,name=shingles,proto3" json:"shingles,omitempty"`                         // 参与计算的特征数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fingerprint) Reset() {
+	*x = Fingerprint{}
+	mi := &file_scraper_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fingerprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fingerprint) ProtoMessage() {}
+
+func (x *Fingerprint) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fingerprint.ProtoReflect.Descriptor instead.
+func (*Fingerprint) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Fingerprint) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *Fingerprint) GetSimhash() string {
+	if x != nil {
+		return x.Simhash
+	}
+	return ""
+}
+
+func (x *Fingerprint) GetShingles() int32 {
+	if x != nil {
+		return x.Shingles
+	}
+	return 0
+}
+
+// 嵌入媒体（视频平台、推文、原生音视频）
+type EmbeddedMedia struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                         // video, audio, post
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`                 // youtube, bilibili, vimeo, twitter, native
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`                             // 平台内的 ID（原生媒体为空）
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`                           // 平台上的观看地址（原生媒体为媒体文件地址）
+	EmbedUrl      string                 `protobuf:"bytes,5,opt,name=embed_url,json=embedUrl,proto3" json:"embed_url,omitempty"` // 沙箱 iframe 地址
+	Thumbnail     string                 `protobuf:"bytes,6,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Title         string                 `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`
+	MimeType      string                 `protobuf:"bytes,8,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"` // 原生媒体的 MIME 类型
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddedMedia) Reset() {
+	*x = EmbeddedMedia{}
+	mi := &file_scraper_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddedMedia) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddedMedia) ProtoMessage() {}
+
+func (x *EmbeddedMedia) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddedMedia.ProtoReflect.Descriptor instead.
+func (*EmbeddedMedia) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EmbeddedMedia) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetEmbedUrl() string {
+	if x != nil {
+		return x.EmbedUrl
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetThumbnail() string {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EmbeddedMedia) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+// 提取引擎质量评分
+type EngineScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Engine        string                 `protobuf:"bytes,1,opt,name=engine,proto3" json:"engine,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	TextLength    int32                  `protobuf:"varint,3,opt,name=text_length,json=textLength,proto3" json:"text_length,omitempty"`
+	LinkDensity   float64                `protobuf:"fixed64,4,opt,name=link_density,json=linkDensity,proto3" json:"link_density,omitempty"`
+	Paragraphs    int32                  `protobuf:"varint,5,opt,name=paragraphs,proto3" json:"paragraphs,omitempty"`
+	TitleOverlap  float64                `protobuf:"fixed64,6,opt,name=title_overlap,json=titleOverlap,proto3" json:"title_overlap,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"` // 引擎提取失败的原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EngineScore) Reset() {
+	*x = EngineScore{}
+	mi := &file_scraper_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngineScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineScore) ProtoMessage() {}
+
+func (x *EngineScore) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineScore.ProtoReflect.Descriptor instead.
+func (*EngineScore) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EngineScore) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *EngineScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EngineScore) GetTextLength() int32 {
+	if x != nil {
+		return x.TextLength
+	}
+	return 0
+}
+
+func (x *EngineScore) GetLinkDensity() float64 {
+	if x != nil {
+		return x.LinkDensity
+	}
+	return 0
+}
+
+func (x *EngineScore) GetParagraphs() int32 {
+	if x != nil {
+		return x.Paragraphs
+	}
+	return 0
+}
+
+func (x *EngineScore) GetTitleOverlap() float64 {
+	if x != nil {
+		return x.TitleOverlap
+	}
+	return 0
+}
+
+func (x *EngineScore) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 页面类型识别结果
+type PageClass struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`               // article, listing, homepage, error, other, document
+	Confidence    float64                `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"` // 0-1
+	Signals       []string               `protobuf:"bytes,3,rep,name=signals,proto3" json:"signals,omitempty"`         // 判定依据（诊断用）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageClass) Reset() {
+	*x = PageClass{}
+	mi := &file_scraper_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageClass) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageClass) ProtoMessage() {}
+
+func (x *PageClass) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageClass.ProtoReflect.Descriptor instead.
+func (*PageClass) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PageClass) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PageClass) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *PageClass) GetSignals() []string {
+	if x != nil {
+		return x.Signals
+	}
+	return nil
+}
+
+// 发布时间提取结果
+type PublishedDate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          string                 `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`               // RFC3339
+	Confidence    float64                `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"` // 0-1
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`           // jsonld, meta, time, text, url, rule, pdf
+	Raw           string                 `protobuf:"bytes,4,opt,name=raw,proto3" json:"raw,omitempty"`                 // 原始文本
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishedDate) Reset() {
+	*x = PublishedDate{}
+	mi := &file_scraper_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishedDate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishedDate) ProtoMessage() {}
+
+func (x *PublishedDate) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishedDate.ProtoReflect.Descriptor instead.
+func (*PublishedDate) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PublishedDate) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *PublishedDate) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *PublishedDate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PublishedDate) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+// 文章结构化元数据（来源优先级：JSON-LD > OpenGraph / article:* > Twitter Card > Dublin Core > 通用 meta）
+type ArticleMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CanonicalUrl  string                 `protobuf:"bytes,1,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	PublishedTime string                 `protobuf:"bytes,4,opt,name=published_time,json=publishedTime,proto3" json:"published_time,omitempty"` // 带时区时为 RFC3339，否则为原始值
+	ModifiedTime  string                 `protobuf:"bytes,5,opt,name=modified_time,json=modifiedTime,proto3" json:"modified_time,omitempty"`
+	Authors       []string               `protobuf:"bytes,6,rep,name=authors,proto3" json:"authors,omitempty"`
+	Section       string                 `protobuf:"bytes,7,opt,name=section,proto3" json:"section,omitempty"`
+	Keywords      []string               `protobuf:"bytes,8,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	Image         string                 `protobuf:"bytes,9,opt,name=image,proto3" json:"image,omitempty"` // 头图（绝对 URL）
+	Language      string                 `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	Publisher     string                 `protobuf:"bytes,11,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	Type          string                 `protobuf:"bytes,12,opt,name=type,proto3" json:"type,omitempty"` // JSON-LD @type 或 og:type
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArticleMetadata) Reset() {
+	*x = ArticleMetadata{}
+	mi := &file_scraper_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArticleMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArticleMetadata) ProtoMessage() {}
+
+func (x *ArticleMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArticleMetadata.ProtoReflect.Descriptor instead.
+func (*ArticleMetadata) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ArticleMetadata) GetCanonicalUrl() string {
+	if x != nil {
+		return x.CanonicalUrl
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetPublishedTime() string {
+	if x != nil {
+		return x.PublishedTime
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetModifiedTime() string {
+	if x != nil {
+		return x.ModifiedTime
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *ArticleMetadata) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *ArticleMetadata) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *ArticleMetadata) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Image struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ProxyUrl      string                 `protobuf:"bytes,2,opt,name=proxy_url,json=proxyUrl,proto3" json:"proxy_url,omitempty"`
+	Alt           string                 `protobuf:"bytes,3,opt,name=alt,proto3" json:"alt,omitempty"`
+	IsLazy        bool                   `protobuf:"varint,4,opt,name=is_lazy,json=isLazy,proto3" json:"is_lazy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_scraper_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Image) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Image) ProtoMessage() {}
+
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Image) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *Image) GetProxyUrl() string {
+	if x != nil {
+		return x.ProxyUrl
+	}
+	return ""
+}
+
+func (x *Image) GetAlt() string {
+	if x != nil {
+		return x.Alt
+	}
+	return ""
+}
+
+func (x *Image) GetIsLazy() bool {
+	if x != nil {
+		return x.IsLazy
+	}
+	return false
+}
+
+type HealthResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	MaxConcurrent   int32                  `protobuf:"varint,2,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	Available       int32                  `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
+	CycletlsEnabled bool                   `protobuf:"varint,4,opt,name=cycletls_enabled,json=cycletlsEnabled,proto3" json:"cycletls_enabled,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_scraper_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetCycletlsEnabled() bool {
+	if x != nil {
+		return x.CycletlsEnabled
+	}
+	return false
+}
+
+// 原始抓取响应（不经过 Readability 处理）
+type FetchRawResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	FinalUrl      string                 `protobuf:"bytes,2,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Strategy      string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchRawResponse) Reset() {
+	*x = FetchRawResponse{}
+	mi := &file_scraper_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchRawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchRawResponse) ProtoMessage() {}
+
+func (x *FetchRawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchRawResponse.ProtoReflect.Descriptor instead.
+func (*FetchRawResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FetchRawResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FetchRawResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *FetchRawResponse) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *FetchRawResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FetchRawResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *FetchRawResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *FetchRawResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *FetchRawResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type LinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Options       *FetchOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"` // 仅使用抓取相关字段（timeout_ms, headers, strategy, referer, credential）
+	Include       []string               `protobuf:"bytes,3,rep,name=include,proto3" json:"include,omitempty"` // 保留匹配任一正则的 URL（为空时全部保留）
+	Exclude       []string               `protobuf:"bytes,4,rep,name=exclude,proto3" json:"exclude,omitempty"` // 丢弃匹配任一正则的 URL
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinksRequest) Reset() {
+	*x = LinksRequest{}
+	mi := &file_scraper_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinksRequest) ProtoMessage() {}
+
+func (x *LinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinksRequest.ProtoReflect.Descriptor instead.
+func (*LinksRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LinksRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LinksRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *LinksRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *LinksRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+type LinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	FinalUrl      string                 `protobuf:"bytes,2,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	Links         []*Link                `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+	Strategy      string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinksResponse) Reset() {
+	*x = LinksResponse{}
+	mi := &file_scraper_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinksResponse) ProtoMessage() {}
+
+func (x *LinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinksResponse.ProtoReflect.Descriptor instead.
+func (*LinksResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LinksResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LinksResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *LinksResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *LinksResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *LinksResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *LinksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 页面中的链接
+type Link struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`   // 解析为绝对地址并规范化后的 URL
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"` // 锚文本
+	Rel           []string               `protobuf:"bytes,3,rep,name=rel,proto3" json:"rel,omitempty"`
+	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`                               // 所在区域：nav, header, footer, aside, main, body
+	Internal      bool                   `protobuf:"varint,5,opt,name=internal,proto3" json:"internal,omitempty"`                          // 是否为本站链接（含子域名）
+	ArticleLike   bool                   `protobuf:"varint,6,opt,name=article_like,json=articleLike,proto3" json:"article_like,omitempty"` // URL 形态像文章
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_scraper_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Link) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Link) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Link) GetRel() []string {
+	if x != nil {
+		return x.Rel
+	}
+	return nil
+}
+
+func (x *Link) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Link) GetInternal() bool {
+	if x != nil {
+		return x.Internal
+	}
+	return false
+}
+
+func (x *Link) GetArticleLike() bool {
+	if x != nil {
+		return x.ArticleLike
+	}
+	return false
+}
+
+type ScrapeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Options       *FetchOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"` // 仅使用抓取相关字段（timeout_ms, headers, strategy, referer, credential）
+	Config        *ScrapeConfig          `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrapeRequest) Reset() {
+	*x = ScrapeRequest{}
+	mi := &file_scraper_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeRequest) ProtoMessage() {}
+
+func (x *ScrapeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeRequest.ProtoReflect.Descriptor instead.
+func (*ScrapeRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ScrapeRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ScrapeRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *ScrapeRequest) GetConfig() *ScrapeConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// 列表页选择器配置（与 Source.config.scrape 一致，*_attr 为空时读取元素文本）
+type ScrapeConfig struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ListSelector    string                 `protobuf:"bytes,1,opt,name=list_selector,json=listSelector,proto3" json:"list_selector,omitempty"`    // 必填
+	TitleSelector   string                 `protobuf:"bytes,2,opt,name=title_selector,json=titleSelector,proto3" json:"title_selector,omitempty"` // 为空时使用链接文本
+	TitleAttr       string                 `protobuf:"bytes,3,opt,name=title_attr,json=titleAttr,proto3" json:"title_attr,omitempty"`
+	LinkSelector    string                 `protobuf:"bytes,4,opt,name=link_selector,json=linkSelector,proto3" json:"link_selector,omitempty"` // 为空时使用列表项内第一个 a[href]
+	LinkAttr        string                 `protobuf:"bytes,5,opt,name=link_attr,json=linkAttr,proto3" json:"link_attr,omitempty"`             // 默认 href
+	SummarySelector string                 `protobuf:"bytes,6,opt,name=summary_selector,json=summarySelector,proto3" json:"summary_selector,omitempty"`
+	SummaryAttr     string                 `protobuf:"bytes,7,opt,name=summary_attr,json=summaryAttr,proto3" json:"summary_attr,omitempty"`
+	ImageSelector   string                 `protobuf:"bytes,8,opt,name=image_selector,json=imageSelector,proto3" json:"image_selector,omitempty"`
+	ImageAttr       string                 `protobuf:"bytes,9,opt,name=image_attr,json=imageAttr,proto3" json:"image_attr,omitempty"` // 默认 src
+	AuthorSelector  string                 `protobuf:"bytes,10,opt,name=author_selector,json=authorSelector,proto3" json:"author_selector,omitempty"`
+	AuthorAttr      string                 `protobuf:"bytes,11,opt,name=author_attr,json=authorAttr,proto3" json:"author_attr,omitempty"`
+	DateSelector    string                 `protobuf:"bytes,12,opt,name=date_selector,json=dateSelector,proto3" json:"date_selector,omitempty"`
+	DateAttr        string                 `protobuf:"bytes,13,opt,name=date_attr,json=dateAttr,proto3" json:"date_attr,omitempty"`             // 为空时依次尝试 datetime、content 属性和文本
+	NextSelector    string                 `protobuf:"bytes,14,opt,name=next_selector,json=nextSelector,proto3" json:"next_selector,omitempty"` // 下一页链接，为空时自动识别
+	MaxPages        int32                  `protobuf:"varint,15,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`            // 最多抓取的列表页数（含首页），默认 1
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScrapeConfig) Reset() {
+	*x = ScrapeConfig{}
+	mi := &file_scraper_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeConfig) ProtoMessage() {}
+
+func (x *ScrapeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeConfig.ProtoReflect.Descriptor instead.
+func (*ScrapeConfig) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ScrapeConfig) GetListSelector() string {
+	if x != nil {
+		return x.ListSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetTitleSelector() string {
+	if x != nil {
+		return x.TitleSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetTitleAttr() string {
+	if x != nil {
+		return x.TitleAttr
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetLinkSelector() string {
+	if x != nil {
+		return x.LinkSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetLinkAttr() string {
+	if x != nil {
+		return x.LinkAttr
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetSummarySelector() string {
+	if x != nil {
+		return x.SummarySelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetSummaryAttr() string {
+	if x != nil {
+		return x.SummaryAttr
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetImageSelector() string {
+	if x != nil {
+		return x.ImageSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetImageAttr() string {
+	if x != nil {
+		return x.ImageAttr
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetAuthorSelector() string {
+	if x != nil {
+		return x.AuthorSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetAuthorAttr() string {
+	if x != nil {
+		return x.AuthorAttr
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetDateSelector() string {
+	if x != nil {
+		return x.DateSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetDateAttr() string {
+	if x != nil {
+		return x.DateAttr
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetNextSelector() string {
+	if x != nil {
+		return x.NextSelector
+	}
+	return ""
+}
+
+func (x *ScrapeConfig) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+type ScrapeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Url            string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	FinalUrl       string                 `protobuf:"bytes,2,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	Items          []*ScrapeItem          `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Pages          int32                  `protobuf:"varint,4,opt,name=pages,proto3" json:"pages,omitempty"`
+	PageUrls       []string               `protobuf:"bytes,5,rep,name=page_urls,json=pageUrls,proto3" json:"page_urls,omitempty"`
+	SelectorErrors []*SelectorError       `protobuf:"bytes,6,rep,name=selector_errors,json=selectorErrors,proto3" json:"selector_errors,omitempty"` // 没有匹配的字段选择器
+	Strategy       string                 `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	DurationMs     int64                  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error          string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScrapeResponse) Reset() {
+	*x = ScrapeResponse{}
+	mi := &file_scraper_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeResponse) ProtoMessage() {}
+
+func (x *ScrapeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeResponse.ProtoReflect.Descriptor instead.
+func (*ScrapeResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ScrapeResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ScrapeResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *ScrapeResponse) GetItems() []*ScrapeItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ScrapeResponse) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *ScrapeResponse) GetPageUrls() []string {
+	if x != nil {
+		return x.PageUrls
+	}
+	return nil
+}
+
+func (x *ScrapeResponse) GetSelectorErrors() []*SelectorError {
+	if x != nil {
+		return x.SelectorErrors
+	}
+	return nil
+}
+
+func (x *ScrapeResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ScrapeResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ScrapeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ScrapeItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Summary       string                 `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Date          string                 `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`                                  // 日期原始文本
+	PublishedAt   string                 `protobuf:"bytes,7,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"` // RFC3339，无法解析时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScrapeItem) Reset() {
+	*x = ScrapeItem{}
+	mi := &file_scraper_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScrapeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrapeItem) ProtoMessage() {}
+
+func (x *ScrapeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrapeItem.ProtoReflect.Descriptor instead.
+func (*ScrapeItem) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScrapeItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ScrapeItem) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ScrapeItem) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ScrapeItem) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *ScrapeItem) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ScrapeItem) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *ScrapeItem) GetPublishedAt() string {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return ""
+}
+
+type SelectorError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Selector      string                 `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectorError) Reset() {
+	*x = SelectorError{}
+	mi := &file_scraper_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectorError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectorError) ProtoMessage() {}
+
+func (x *SelectorError) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectorError.ProtoReflect.Descriptor instead.
+func (*SelectorError) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SelectorError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SelectorError) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *SelectorError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Options       *FetchOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"` // 仅使用抓取相关字段（timeout_ms, headers, strategy, referer, credential）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedRequest) Reset() {
+	*x = FeedRequest{}
+	mi := &file_scraper_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedRequest) ProtoMessage() {}
+
+func (x *FeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedRequest.ProtoReflect.Descriptor instead.
+func (*FeedRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FeedRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FeedRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type FeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	FinalUrl      string                 `protobuf:"bytes,2,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	Feed          *Feed                  `protobuf:"bytes,3,opt,name=feed,proto3" json:"feed,omitempty"`
+	Strategy      string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedResponse) Reset() {
+	*x = FeedResponse{}
+	mi := &file_scraper_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedResponse) ProtoMessage() {}
+
+func (x *FeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedResponse.ProtoReflect.Descriptor instead.
+func (*FeedResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FeedResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FeedResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *FeedResponse) GetFeed() *Feed {
+	if x != nil {
+		return x.Feed
+	}
+	return nil
+}
+
+func (x *FeedResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *FeedResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *FeedResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 订阅源
+type Feed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // rss, atom, json
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Link          string                 `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"` // 网站地址
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Language      string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // RFC3339
+	Items         []*FeedItem            `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed) Reset() {
+	*x = Feed{}
+	mi := &file_scraper_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed) ProtoMessage() {}
+
+func (x *Feed) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed.ProtoReflect.Descriptor instead.
+func (*Feed) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Feed) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Feed) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Feed) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Feed) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Feed) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Feed) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Feed) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Feed) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Feed) GetItems() []*FeedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// 订阅源条目
+type FeedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExternalId    string                 `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"` // 稳定的外部 ID，可直接作为 Article.externalId
+	Guid          string                 `protobuf:"bytes,2,opt,name=guid,proto3" json:"guid,omitempty"`
+	Link          string                 `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Summary       string                 `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`                            // 纯文本摘要
+	Content       string                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`                            // HTML 正文
+	PublishedAt   string                 `protobuf:"bytes,7,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"` // RFC3339，无法解析时为空
+	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Authors       []string               `protobuf:"bytes,9,rep,name=authors,proto3" json:"authors,omitempty"`
+	Categories    []string               `protobuf:"bytes,10,rep,name=categories,proto3" json:"categories,omitempty"`
+	Enclosures    []*FeedEnclosure       `protobuf:"bytes,11,rep,name=enclosures,proto3" json:"enclosures,omitempty"`
+	Thumbnail     string                 `protobuf:"bytes,12,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedItem) Reset() {
+	*x = FeedItem{}
+	mi := &file_scraper_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedItem) ProtoMessage() {}
+
+func (x *FeedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedItem.ProtoReflect.Descriptor instead.
+func (*FeedItem) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FeedItem) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *FeedItem) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *FeedItem) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *FeedItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FeedItem) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *FeedItem) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *FeedItem) GetPublishedAt() string {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return ""
+}
+
+func (x *FeedItem) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *FeedItem) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *FeedItem) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *FeedItem) GetEnclosures() []*FeedEnclosure {
+	if x != nil {
+		return x.Enclosures
+	}
+	return nil
+}
+
+func (x *FeedItem) GetThumbnail() string {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return ""
+}
+
+type FeedEnclosure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedEnclosure) Reset() {
+	*x = FeedEnclosure{}
+	mi := &file_scraper_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedEnclosure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedEnclosure) ProtoMessage() {}
+
+func (x *FeedEnclosure) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedEnclosure.ProtoReflect.Descriptor instead.
+func (*FeedEnclosure) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FeedEnclosure) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FeedEnclosure) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FeedEnclosure) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type DiscoverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                                           // 站点首页或任意页面
+	Options       *FetchOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`                                   // 仅使用抓取相关字段（timeout_ms, headers, strategy, referer, credential）
+	MaxCandidates int32                  `protobuf:"varint,3,opt,name=max_candidates,json=maxCandidates,proto3" json:"max_candidates,omitempty"` // 最多验证的候选地址数，默认 20
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverRequest) Reset() {
+	*x = DiscoverRequest{}
+	mi := &file_scraper_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverRequest) ProtoMessage() {}
+
+func (x *DiscoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverRequest.ProtoReflect.Descriptor instead.
+func (*DiscoverRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DiscoverRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DiscoverRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *DiscoverRequest) GetMaxCandidates() int32 {
+	if x != nil {
+		return x.MaxCandidates
+	}
+	return 0
+}
+
+type DiscoverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	FinalUrl      string                 `protobuf:"bytes,2,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	SiteTitle     string                 `protobuf:"bytes,3,opt,name=site_title,json=siteTitle,proto3" json:"site_title,omitempty"`
+	Candidates    []*DiscoveredSource    `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"` // 按得分降序
+	Checked       int32                  `protobuf:"varint,5,opt,name=checked,proto3" json:"checked,omitempty"`
+	Strategy      string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoverResponse) Reset() {
+	*x = DiscoverResponse{}
+	mi := &file_scraper_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoverResponse) ProtoMessage() {}
+
+func (x *DiscoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoverResponse.ProtoReflect.Descriptor instead.
+func (*DiscoverResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DiscoverResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetSiteTitle() string {
+	if x != nil {
+		return x.SiteTitle
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetCandidates() []*DiscoveredSource {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *DiscoverResponse) GetChecked() int32 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *DiscoverResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *DiscoverResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *DiscoverResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 验证通过的候选来源
+type DiscoveredSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // rss（订阅源）, sitecrawl（sitemap），与 Source.type 一致
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"` // rss, atom, json, sitemap, sitemapindex
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	ItemCount     int32                  `protobuf:"varint,5,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // RFC3339
+	Found         string                 `protobuf:"bytes,7,opt,name=found,proto3" json:"found,omitempty"`                          // 发现途径：input, link, anchor, path, robots
+	Score         float64                `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscoveredSource) Reset() {
+	*x = DiscoveredSource{}
+	mi := &file_scraper_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveredSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveredSource) ProtoMessage() {}
+
+func (x *DiscoveredSource) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveredSource.ProtoReflect.Descriptor instead.
+func (*DiscoveredSource) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DiscoveredSource) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DiscoveredSource) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DiscoveredSource) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *DiscoveredSource) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DiscoveredSource) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *DiscoveredSource) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *DiscoveredSource) GetFound() string {
+	if x != nil {
+		return x.Found
+	}
+	return ""
+}
+
+func (x *DiscoveredSource) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SitemapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                                     // sitemap 地址，或站点根地址（从 robots.txt 查找 sitemap）
+	Options       *FetchOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`                             // 仅使用抓取相关字段（timeout_ms, headers, strategy, referer, credential）
+	Since         string                 `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                                 // RFC3339，只返回 lastmod 不早于该时间的 URL
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                                // 最多返回的 URL 数，默认不限制
+	MaxSitemaps   int32                  `protobuf:"varint,5,opt,name=max_sitemaps,json=maxSitemaps,proto3" json:"max_sitemaps,omitempty"` // 最多抓取的 sitemap 文件数，默认 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SitemapRequest) Reset() {
+	*x = SitemapRequest{}
+	mi := &file_scraper_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapRequest) ProtoMessage() {}
+
+func (x *SitemapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapRequest.ProtoReflect.Descriptor instead.
+func (*SitemapRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SitemapRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SitemapRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *SitemapRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *SitemapRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SitemapRequest) GetMaxSitemaps() int32 {
+	if x != nil {
+		return x.MaxSitemaps
+	}
+	return 0
+}
+
+// sitemap 遍历事件
+type SitemapEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // url：发现的 URL；done：结束汇总（始终为最后一条）
+	Entry         *SitemapUrl            `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	Summary       *SitemapSummary        `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SitemapEvent) Reset() {
+	*x = SitemapEvent{}
+	mi := &file_scraper_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapEvent) ProtoMessage() {}
+
+func (x *SitemapEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapEvent.ProtoReflect.Descriptor instead.
+func (*SitemapEvent) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SitemapEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SitemapEvent) GetEntry() *SitemapUrl {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *SitemapEvent) GetSummary() *SitemapSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *SitemapEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *SitemapEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SitemapUrl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Lastmod       string                 `protobuf:"bytes,2,opt,name=lastmod,proto3" json:"lastmod,omitempty"` // RFC3339
+	Changefreq    string                 `protobuf:"bytes,3,opt,name=changefreq,proto3" json:"changefreq,omitempty"`
+	Priority      float64                `protobuf:"fixed64,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	News          *SitemapNews           `protobuf:"bytes,5,opt,name=news,proto3" json:"news,omitempty"`
+	Images        []*SitemapImage        `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Sitemap       string                 `protobuf:"bytes,7,opt,name=sitemap,proto3" json:"sitemap,omitempty"` // 所在的 sitemap
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SitemapUrl) Reset() {
+	*x = SitemapUrl{}
+	mi := &file_scraper_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapUrl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapUrl) ProtoMessage() {}
+
+func (x *SitemapUrl) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapUrl.ProtoReflect.Descriptor instead.
+func (*SitemapUrl) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SitemapUrl) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SitemapUrl) GetLastmod() string {
+	if x != nil {
+		return x.Lastmod
+	}
+	return ""
+}
+
+func (x *SitemapUrl) GetChangefreq() string {
+	if x != nil {
+		return x.Changefreq
+	}
+	return ""
+}
+
+func (x *SitemapUrl) GetPriority() float64 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SitemapUrl) GetNews() *SitemapNews {
+	if x != nil {
+		return x.News
+	}
+	return nil
+}
+
+func (x *SitemapUrl) GetImages() []*SitemapImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *SitemapUrl) GetSitemap() string {
+	if x != nil {
+		return x.Sitemap
+	}
+	return ""
+}
+
+// Google News sitemap 扩展
+type SitemapNews struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Title           string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	PublicationName string                 `protobuf:"bytes,2,opt,name=publication_name,json=publicationName,proto3" json:"publication_name,omitempty"`
+	Language        string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	PublishedAt     string                 `protobuf:"bytes,4,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"` // RFC3339
+	Keywords        []string               `protobuf:"bytes,5,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SitemapNews) Reset() {
+	*x = SitemapNews{}
+	mi := &file_scraper_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapNews) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapNews) ProtoMessage() {}
+
+func (x *SitemapNews) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapNews.ProtoReflect.Descriptor instead.
+func (*SitemapNews) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SitemapNews) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SitemapNews) GetPublicationName() string {
+	if x != nil {
+		return x.PublicationName
+	}
+	return ""
+}
+
+func (x *SitemapNews) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SitemapNews) GetPublishedAt() string {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return ""
+}
+
+func (x *SitemapNews) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+type SitemapImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Caption       string                 `protobuf:"bytes,3,opt,name=caption,proto3" json:"caption,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SitemapImage) Reset() {
+	*x = SitemapImage{}
+	mi := &file_scraper_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapImage) ProtoMessage() {}
+
+func (x *SitemapImage) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapImage.ProtoReflect.Descriptor instead.
+func (*SitemapImage) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SitemapImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SitemapImage) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SitemapImage) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+type SitemapSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sitemaps      int32                  `protobuf:"varint,1,opt,name=sitemaps,proto3" json:"sitemaps,omitempty"` // 抓取的 sitemap 文件数
+	Urls          int32                  `protobuf:"varint,2,opt,name=urls,proto3" json:"urls,omitempty"`
+	Skipped       int32                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"` // 按 lastmod 过滤掉的 URL 数
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Errors        []*SitemapError        `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SitemapSummary) Reset() {
+	*x = SitemapSummary{}
+	mi := &file_scraper_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapSummary) ProtoMessage() {}
+
+func (x *SitemapSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapSummary.ProtoReflect.Descriptor instead.
+func (*SitemapSummary) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SitemapSummary) GetSitemaps() int32 {
+	if x != nil {
+		return x.Sitemaps
+	}
+	return 0
+}
+
+func (x *SitemapSummary) GetUrls() int32 {
+	if x != nil {
+		return x.Urls
+	}
+	return 0
+}
+
+func (x *SitemapSummary) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *SitemapSummary) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *SitemapSummary) GetErrors() []*SitemapError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type SitemapError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SitemapError) Reset() {
+	*x = SitemapError{}
+	mi := &file_scraper_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SitemapError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SitemapError) ProtoMessage() {}
+
+func (x *SitemapError) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SitemapError.ProtoReflect.Descriptor instead.
+func (*SitemapError) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SitemapError) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SitemapError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CrawlRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Seed              string                 `protobuf:"bytes,1,opt,name=seed,proto3" json:"seed,omitempty"`
+	MaxDepth          int32                  `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`                           // 最大深度，0 表示默认 3
+	MaxPages          int32                  `protobuf:"varint,3,opt,name=max_pages,json=maxPages,proto3" json:"max_pages,omitempty"`                           // 页面预算（含种子），0 表示默认 1000，上限 10000
+	Include           []string               `protobuf:"bytes,4,rep,name=include,proto3" json:"include,omitempty"`                                              // 包含规则（正则，非法时按子串匹配）
+	Exclude           []string               `protobuf:"bytes,5,rep,name=exclude,proto3" json:"exclude,omitempty"`                                              // 排除规则（同上）
+	Scope             string                 `protobuf:"bytes,6,opt,name=scope,proto3" json:"scope,omitempty"`                                                  // host, subdomains（默认）, any
+	AllowedSubdomains []string               `protobuf:"bytes,7,rep,name=allowed_subdomains,json=allowedSubdomains,proto3" json:"allowed_subdomains,omitempty"` // subdomains 范围下允许的子域名，为空时不限制
+	SeedPathOnly      bool                   `protobuf:"varint,8,opt,name=seed_path_only,json=seedPathOnly,proto3" json:"seed_path_only,omitempty"`             // 只爬取种子路径下的 URL
+	ExtractArticles   bool                   `protobuf:"varint,9,opt,name=extract_articles,json=extractArticles,proto3" json:"extract_articles,omitempty"`      // 提取文章正文，判定为文章时发送 article 事件
+	Concurrency       int32                  `protobuf:"varint,10,opt,name=concurrency,proto3" json:"concurrency,omitempty"`                                    // 同时抓取的页面数，0 表示默认 2，上限 8
+	DelayMs           int32                  `protobuf:"varint,11,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`                             // 每批页面之间的间隔
+	Options           *FetchOptions          `protobuf:"bytes,12,opt,name=options,proto3" json:"options,omitempty"`                                             // timeout_ms 为单页超时；headers / credential / output_format / embed_mode / sanitize_profile 同样生效
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CrawlRequest) Reset() {
+	*x = CrawlRequest{}
+	mi := &file_scraper_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlRequest) ProtoMessage() {}
+
+func (x *CrawlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlRequest.ProtoReflect.Descriptor instead.
+func (*CrawlRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CrawlRequest) GetSeed() string {
+	if x != nil {
+		return x.Seed
+	}
+	return ""
+}
+
+func (x *CrawlRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *CrawlRequest) GetMaxPages() int32 {
+	if x != nil {
+		return x.MaxPages
+	}
+	return 0
+}
+
+func (x *CrawlRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *CrawlRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *CrawlRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *CrawlRequest) GetAllowedSubdomains() []string {
+	if x != nil {
+		return x.AllowedSubdomains
+	}
+	return nil
+}
+
+func (x *CrawlRequest) GetSeedPathOnly() bool {
+	if x != nil {
+		return x.SeedPathOnly
+	}
+	return false
+}
+
+func (x *CrawlRequest) GetExtractArticles() bool {
+	if x != nil {
+		return x.ExtractArticles
+	}
+	return false
+}
+
+func (x *CrawlRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *CrawlRequest) GetDelayMs() int32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *CrawlRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CrawlControlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrawlControlRequest) Reset() {
+	*x = CrawlControlRequest{}
+	mi := &file_scraper_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlControlRequest) ProtoMessage() {}
+
+func (x *CrawlControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlControlRequest.ProtoReflect.Descriptor instead.
+func (*CrawlControlRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CrawlControlRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CrawlEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // discovered, crawled, article, paused, done
+	CrawlId       string                 `protobuf:"bytes,2,opt,name=crawl_id,json=crawlId,proto3" json:"crawl_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	FinalUrl      string                 `protobuf:"bytes,4,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"` // crawled / article：跳转后的最终 URL
+	Depth         int32                  `protobuf:"varint,5,opt,name=depth,proto3" json:"depth,omitempty"`
+	ParentUrl     string                 `protobuf:"bytes,6,opt,name=parent_url,json=parentUrl,proto3" json:"parent_url,omitempty"` // discovered：发现该 URL 的页面
+	Title         string                 `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`                          // discovered：锚文本
+	Links         int32                  `protobuf:"varint,8,opt,name=links,proto3" json:"links,omitempty"`                         // crawled：新加入队列的链接数
+	Article       *FetchResponse         `protobuf:"bytes,9,opt,name=article,proto3" json:"article,omitempty"`                      // article：提取结果
+	Status        *CrawlStatus           `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`                       // paused / done：爬取状态
+	Error         string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrawlEvent) Reset() {
+	*x = CrawlEvent{}
+	mi := &file_scraper_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlEvent) ProtoMessage() {}
+
+func (x *CrawlEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlEvent.ProtoReflect.Descriptor instead.
+func (*CrawlEvent) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CrawlEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CrawlEvent) GetCrawlId() string {
+	if x != nil {
+		return x.CrawlId
+	}
+	return ""
+}
+
+func (x *CrawlEvent) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CrawlEvent) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *CrawlEvent) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *CrawlEvent) GetParentUrl() string {
+	if x != nil {
+		return x.ParentUrl
+	}
+	return ""
+}
+
+func (x *CrawlEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CrawlEvent) GetLinks() int32 {
+	if x != nil {
+		return x.Links
+	}
+	return 0
+}
+
+func (x *CrawlEvent) GetArticle() *FetchResponse {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+func (x *CrawlEvent) GetStatus() *CrawlStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *CrawlEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CrawlStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seed          string                 `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // pending, running, paused, completed, cancelled
+	Discovered    int32                  `protobuf:"varint,4,opt,name=discovered,proto3" json:"discovered,omitempty"`
+	Crawled       int32                  `protobuf:"varint,5,opt,name=crawled,proto3" json:"crawled,omitempty"`
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Articles      int32                  `protobuf:"varint,7,opt,name=articles,proto3" json:"articles,omitempty"`
+	Pending       int32                  `protobuf:"varint,8,opt,name=pending,proto3" json:"pending,omitempty"`                      // 前沿队列中待抓取的 URL 数
+	Truncated     bool                   `protobuf:"varint,9,opt,name=truncated,proto3" json:"truncated,omitempty"`                  // 页面预算用尽
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC3339
+	UpdatedAt     string                 `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Error         string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrawlStatus) Reset() {
+	*x = CrawlStatus{}
+	mi := &file_scraper_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlStatus) ProtoMessage() {}
+
+func (x *CrawlStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlStatus.ProtoReflect.Descriptor instead.
+func (*CrawlStatus) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CrawlStatus) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CrawlStatus) GetSeed() string {
+	if x != nil {
+		return x.Seed
+	}
+	return ""
+}
+
+func (x *CrawlStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CrawlStatus) GetDiscovered() int32 {
+	if x != nil {
+		return x.Discovered
+	}
+	return 0
+}
+
+func (x *CrawlStatus) GetCrawled() int32 {
+	if x != nil {
+		return x.Crawled
+	}
+	return 0
+}
+
+func (x *CrawlStatus) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *CrawlStatus) GetArticles() int32 {
+	if x != nil {
+		return x.Articles
+	}
+	return 0
+}
+
+func (x *CrawlStatus) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *CrawlStatus) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *CrawlStatus) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CrawlStatus) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *CrawlStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 近似重复检测请求
+type SimilarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SimilarItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`           // 待比较的内容，最多 1000 项
+	Threshold     float64                `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"` // 相似度阈值（0-1），0 表示默认 0.9
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarRequest) Reset() {
+	*x = SimilarRequest{}
+	mi := &file_scraper_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarRequest) ProtoMessage() {}
+
+func (x *SimilarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarRequest.ProtoReflect.Descriptor instead.
+func (*SimilarRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SimilarRequest) GetItems() []*SimilarItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *SimilarRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+// 待比较的内容：提供 text 时计算指纹，否则使用已保存的 simhash（可附带 content_hash）
+type SimilarItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 调用方的标识，为空时使用下标
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Simhash       string                 `protobuf:"bytes,3,opt,name=simhash,proto3" json:"simhash,omitempty"`
+	ContentHash   string                 `protobuf:"bytes,4,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarItem) Reset() {
+	*x = SimilarItem{}
+	mi := &file_scraper_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarItem) ProtoMessage() {}
+
+func (x *SimilarItem) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarItem.ProtoReflect.Descriptor instead.
+func (*SimilarItem) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SimilarItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SimilarItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SimilarItem) GetSimhash() string {
+	if x != nil {
+		return x.Simhash
+	}
+	return ""
+}
+
+func (x *SimilarItem) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type SimilarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SimilarItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`       // 各项的指纹（不含 text）
+	Pairs         []*SimilarPair         `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`       // 相似度不低于阈值的内容对
+	Clusters      []*SimilarCluster      `protobuf:"bytes,3,rep,name=clusters,proto3" json:"clusters,omitempty"` // 近似重复聚类（两项以上）
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarResponse) Reset() {
+	*x = SimilarResponse{}
+	mi := &file_scraper_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarResponse) ProtoMessage() {}
+
+func (x *SimilarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarResponse.ProtoReflect.Descriptor instead.
+func (*SimilarResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SimilarResponse) GetItems() []*SimilarItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *SimilarResponse) GetPairs() []*SimilarPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *SimilarResponse) GetClusters() []*SimilarCluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+func (x *SimilarResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SimilarPair struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             string                 `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             string                 `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	Distance      int32                  `protobuf:"varint,3,opt,name=distance,proto3" json:"distance,omitempty"`      // SimHash 汉明距离
+	Similarity    float64                `protobuf:"fixed64,4,opt,name=similarity,proto3" json:"similarity,omitempty"` // 1 - distance/64
+	Exact         bool                   `protobuf:"varint,5,opt,name=exact,proto3" json:"exact,omitempty"`            // 规范化文本完全相同
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarPair) Reset() {
+	*x = SimilarPair{}
+	mi := &file_scraper_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarPair) ProtoMessage() {}
+
+func (x *SimilarPair) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarPair.ProtoReflect.Descriptor instead.
+func (*SimilarPair) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SimilarPair) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *SimilarPair) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+func (x *SimilarPair) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *SimilarPair) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+func (x *SimilarPair) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+type SimilarCluster struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarCluster) Reset() {
+	*x = SimilarCluster{}
+	mi := &file_scraper_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarCluster) ProtoMessage() {}
+
+func (x *SimilarCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarCluster.ProtoReflect.Descriptor instead.
+func (*SimilarCluster) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SimilarCluster) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// 文章修订检测请求（提供 previous_text 时返回段落级差异，否则只比较 previous_hash）
+type RevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Options       *FetchOptions          `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	PreviousHash  string                 `protobuf:"bytes,3,opt,name=previous_hash,json=previousHash,proto3" json:"previous_hash,omitempty"` // 之前保存的 Fingerprint.content_hash
+	PreviousText  string                 `protobuf:"bytes,4,opt,name=previous_text,json=previousText,proto3" json:"previous_text,omitempty"` // 之前保存的 text_content（优先于 previous_hash）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevisionRequest) Reset() {
+	*x = RevisionRequest{}
+	mi := &file_scraper_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevisionRequest) ProtoMessage() {}
+
+func (x *RevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevisionRequest.ProtoReflect.Descriptor instead.
+func (*RevisionRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RevisionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RevisionRequest) GetOptions() *FetchOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *RevisionRequest) GetPreviousHash() string {
+	if x != nil {
+		return x.PreviousHash
+	}
+	return ""
+}
+
+func (x *RevisionRequest) GetPreviousText() string {
+	if x != nil {
+		return x.PreviousText
+	}
+	return ""
+}
+
+type RevisionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Article       *FetchResponse         `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`                               // 重新提取的文章（正文未变化时不含 content / text_content）
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`                              // 规范化正文的内容哈希是否变化
+	PreviousHash  string                 `protobuf:"bytes,3,opt,name=previous_hash,json=previousHash,proto3" json:"previous_hash,omitempty"` // 比较所用的旧内容哈希
+	Diff          *TextDiff              `protobuf:"bytes,4,opt,name=diff,proto3" json:"diff,omitempty"`                                     // 段落级差异（仅提供 previous_text 时）
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevisionResponse) Reset() {
+	*x = RevisionResponse{}
+	mi := &file_scraper_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevisionResponse) ProtoMessage() {}
+
+func (x *RevisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevisionResponse.ProtoReflect.Descriptor instead.
+func (*RevisionResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RevisionResponse) GetArticle() *FetchResponse {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+func (x *RevisionResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *RevisionResponse) GetPreviousHash() string {
+	if x != nil {
+		return x.PreviousHash
+	}
+	return ""
+}
+
+func (x *RevisionResponse) GetDiff() *TextDiff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+func (x *RevisionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 段落级文本差异
+type TextDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int32                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed       int32                  `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Modified      int32                  `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,4,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Blocks        []*DiffBlock           `protobuf:"bytes,5,rep,name=blocks,proto3" json:"blocks,omitempty"` // 变化的段落（按位置排序）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextDiff) Reset() {
+	*x = TextDiff{}
+	mi := &file_scraper_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextDiff) ProtoMessage() {}
+
+func (x *TextDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextDiff.ProtoReflect.Descriptor instead.
+func (*TextDiff) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TextDiff) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *TextDiff) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *TextDiff) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *TextDiff) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *TextDiff) GetBlocks() []*DiffBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type DiffBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`                              // added, removed, modified
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`                       // 在新文本中的段落下标（removed 为 -1）
+	OldIndex      int32                  `protobuf:"varint,3,opt,name=old_index,json=oldIndex,proto3" json:"old_index,omitempty"` // 在旧文本中的段落下标（added 为 -1）
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	OldText       string                 `protobuf:"bytes,5,opt,name=old_text,json=oldText,proto3" json:"old_text,omitempty"`
+	Similarity    float64                `protobuf:"fixed64,6,opt,name=similarity,proto3" json:"similarity,omitempty"` // 修改前后的相似度（仅 modified）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffBlock) Reset() {
+	*x = DiffBlock{}
+	mi := &file_scraper_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBlock) ProtoMessage() {}
+
+func (x *DiffBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBlock.ProtoReflect.Descriptor instead.
+func (*DiffBlock) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DiffBlock) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *DiffBlock) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DiffBlock) GetOldIndex() int32 {
+	if x != nil {
+		return x.OldIndex
+	}
+	return 0
+}
+
+func (x *DiffBlock) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DiffBlock) GetOldText() string {
+	if x != nil {
+		return x.OldText
+	}
+	return ""
+}
+
+func (x *DiffBlock) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+// 摘要请求（text 和 html 二选一）
+type SummarizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`            // 纯文本（如 text_content）
+	Html          string                 `protobuf:"bytes,2,opt,name=html,proto3" json:"html,omitempty"`            // 正文 HTML（净化后转为结构化纯文本）
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`          // 标题（可选，提高相关句子和词的权重）
+	Sentences     int32                  `protobuf:"varint,4,opt,name=sentences,proto3" json:"sentences,omitempty"` // 摘要句数，默认 3，最多 20
+	Keywords      int32                  `protobuf:"varint,5,opt,name=keywords,proto3" json:"keywords,omitempty"`   // 关键词数，默认 8，最多 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeRequest) Reset() {
+	*x = SummarizeRequest{}
+	mi := &file_scraper_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeRequest) ProtoMessage() {}
+
+func (x *SummarizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeRequest.ProtoReflect.Descriptor instead.
+func (*SummarizeRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SummarizeRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SummarizeRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *SummarizeRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SummarizeRequest) GetSentences() int32 {
+	if x != nil {
+		return x.Sentences
+	}
+	return 0
+}
+
+func (x *SummarizeRequest) GetKeywords() int32 {
+	if x != nil {
+		return x.Keywords
+	}
+	return 0
+}
+
+type SummarizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *Summary               `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummarizeResponse) Reset() {
+	*x = SummarizeResponse{}
+	mi := &file_scraper_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummarizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummarizeResponse) ProtoMessage() {}
+
+func (x *SummarizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummarizeResponse.ProtoReflect.Descriptor instead.
+func (*SummarizeResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SummarizeResponse) GetSummary() *Summary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *SummarizeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 表单登录选择器（与 SiteCredential.loginSelectors 的 JSON 结构一致）
+type LoginSelectors struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Username         string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password         string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Submit           string                 `protobuf:"bytes,3,opt,name=submit,proto3" json:"submit,omitempty"`
+	SuccessIndicator string                 `protobuf:"bytes,4,opt,name=success_indicator,json=successIndicator,proto3" json:"success_indicator,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LoginSelectors) Reset() {
+	*x = LoginSelectors{}
+	mi := &file_scraper_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginSelectors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginSelectors) ProtoMessage() {}
+
+func (x *LoginSelectors) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginSelectors.ProtoReflect.Descriptor instead.
+func (*LoginSelectors) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *LoginSelectors) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginSelectors) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LoginSelectors) GetSubmit() string {
+	if x != nil {
+		return x.Submit
+	}
+	return ""
+}
+
+func (x *LoginSelectors) GetSuccessIndicator() string {
+	if x != nil {
+		return x.SuccessIndicator
+	}
+	return ""
+}
+
+// 表单登录请求
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LoginUrl      string                 `protobuf:"bytes,1,opt,name=login_url,json=loginUrl,proto3" json:"login_url,omitempty"`
+	Selectors     *LoginSelectors        `protobuf:"bytes,2,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	ExtraFields   map[string]string      `protobuf:"bytes,5,rep,name=extra_fields,json=extraFields,proto3" json:"extra_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 额外提交的表单字段
+	Headers       map[string]string      `protobuf:"bytes,6,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Strategy      string                 `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"` // cycletls, standard
+	TimeoutMs     int32                  `protobuf:"varint,8,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	Credential    *CredentialRef         `protobuf:"bytes,9,opt,name=credential,proto3" json:"credential,omitempty"` // username/password 为空时从凭证引用解密
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_scraper_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *LoginRequest) GetLoginUrl() string {
+	if x != nil {
+		return x.LoginUrl
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetSelectors() *LoginSelectors {
+	if x != nil {
+		return x.Selectors
+	}
+	return nil
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetExtraFields() map[string]string {
+	if x != nil {
+		return x.ExtraFields
+	}
+	return nil
+}
+
+func (x *LoginRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *LoginRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetTimeoutMs() int32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *LoginRequest) GetCredential() *CredentialRef {
+	if x != nil {
+		return x.Credential
+	}
+	return nil
+}
+
+// Cookie 元信息（不含值）
+type CookieInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Expires       string                 `protobuf:"bytes,4,opt,name=expires,proto3" json:"expires,omitempty"` // RFC3339，会话 Cookie 为空
+	HttpOnly      bool                   `protobuf:"varint,5,opt,name=http_only,json=httpOnly,proto3" json:"http_only,omitempty"`
+	Secure        bool                   `protobuf:"varint,6,opt,name=secure,proto3" json:"secure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CookieInfo) Reset() {
+	*x = CookieInfo{}
+	mi := &file_scraper_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CookieInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CookieInfo) ProtoMessage() {}
+
+func (x *CookieInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CookieInfo.ProtoReflect.Descriptor instead.
+func (*CookieInfo) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CookieInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CookieInfo) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CookieInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CookieInfo) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
+func (x *CookieInfo) GetHttpOnly() bool {
+	if x != nil {
+		return x.HttpOnly
+	}
+	return false
+}
+
+func (x *CookieInfo) GetSecure() bool {
+	if x != nil {
+		return x.Secure
+	}
+	return false
+}
+
+// 表单登录响应
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Cookies       string                 `protobuf:"bytes,2,opt,name=cookies,proto3" json:"cookies,omitempty"` // Cookie 字符串（name=value; ...）
+	CookieList    []*CookieInfo          `protobuf:"bytes,3,rep,name=cookie_list,json=cookieList,proto3" json:"cookie_list,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339，会话 Cookie 时为空
+	FinalUrl      string                 `protobuf:"bytes,5,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_scraper_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *LoginResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LoginResponse) GetCookies() string {
+	if x != nil {
+		return x.Cookies
+	}
 	return ""
 }
 
-func (x *FetchRawResponse) GetStatusCode() int32 {
+func (x *LoginResponse) GetCookieList() []*CookieInfo {
 	if x != nil {
-		return x.StatusCode
+		return x.CookieList
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
 	}
 	return 0
 }
 
-func (x *FetchRawResponse) GetStrategy() string {
+func (x *LoginResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// 站点登录状态判断规则
+type CredentialRule struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	LoggedInSelector string                 `protobuf:"bytes,1,opt,name=logged_in_selector,json=loggedInSelector,proto3" json:"logged_in_selector,omitempty"` // 登录后才会出现的元素选择器
+	LoginUrlPattern  string                 `protobuf:"bytes,2,opt,name=login_url_pattern,json=loginUrlPattern,proto3" json:"login_url_pattern,omitempty"`    // 登录页 URL 正则，为空时使用内置规则
+	ExpectedText     string                 `protobuf:"bytes,3,opt,name=expected_text,json=expectedText,proto3" json:"expected_text,omitempty"`               // 登录后页面中应包含的文本
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CredentialRule) Reset() {
+	*x = CredentialRule{}
+	mi := &file_scraper_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialRule) ProtoMessage() {}
+
+func (x *CredentialRule) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialRule.ProtoReflect.Descriptor instead.
+func (*CredentialRule) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CredentialRule) GetLoggedInSelector() string {
+	if x != nil {
+		return x.LoggedInSelector
+	}
+	return ""
+}
+
+func (x *CredentialRule) GetLoginUrlPattern() string {
+	if x != nil {
+		return x.LoginUrlPattern
+	}
+	return ""
+}
+
+func (x *CredentialRule) GetExpectedText() string {
+	if x != nil {
+		return x.ExpectedText
+	}
+	return ""
+}
+
+// 凭证有效性检测请求
+type CredentialCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // 检测 URL，默认 https://{domain}/
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Cookies       string                 `protobuf:"bytes,4,opt,name=cookies,proto3" json:"cookies,omitempty"` // Cookie 字符串，等价于 headers["Cookie"]
+	Rule          *CredentialRule        `protobuf:"bytes,5,opt,name=rule,proto3" json:"rule,omitempty"`
+	Strategy      string                 `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	TimeoutMs     int32                  `protobuf:"varint,7,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	Credential    *CredentialRef         `protobuf:"bytes,8,opt,name=credential,proto3" json:"credential,omitempty"` // 加密凭证引用，由服务端解密后附加到检测请求
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialCheckRequest) Reset() {
+	*x = CredentialCheckRequest{}
+	mi := &file_scraper_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialCheckRequest) ProtoMessage() {}
+
+func (x *CredentialCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialCheckRequest.ProtoReflect.Descriptor instead.
+func (*CredentialCheckRequest) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CredentialCheckRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CredentialCheckRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CredentialCheckRequest) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *CredentialCheckRequest) GetCookies() string {
+	if x != nil {
+		return x.Cookies
+	}
+	return ""
+}
+
+func (x *CredentialCheckRequest) GetRule() *CredentialRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *CredentialCheckRequest) GetStrategy() string {
 	if x != nil {
 		return x.Strategy
 	}
 	return ""
 }
 
-func (x *FetchRawResponse) GetDurationMs() int64 {
+func (x *CredentialCheckRequest) GetTimeoutMs() int32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *CredentialCheckRequest) GetCredential() *CredentialRef {
+	if x != nil {
+		return x.Credential
+	}
+	return nil
+}
+
+// 凭证有效性检测响应
+type CredentialCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // valid, expired, unknown
+	Evidence      []string               `protobuf:"bytes,3,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	FinalUrl      string                 `protobuf:"bytes,4,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialCheckResponse) Reset() {
+	*x = CredentialCheckResponse{}
+	mi := &file_scraper_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialCheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialCheckResponse) ProtoMessage() {}
+
+func (x *CredentialCheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scraper_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialCheckResponse.ProtoReflect.Descriptor instead.
+func (*CredentialCheckResponse) Descriptor() ([]byte, []int) {
+	return file_scraper_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CredentialCheckResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CredentialCheckResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CredentialCheckResponse) GetEvidence() []string {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *CredentialCheckResponse) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *CredentialCheckResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CredentialCheckResponse) GetDurationMs() int64 {
 	if x != nil {
 		return x.DurationMs
 	}
 	return 0
 }
 
-func (x *FetchRawResponse) GetError() string {
+func (x *CredentialCheckResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
@@ -588,7 +5284,7 @@ const file_scraper_proto_rawDesc = "" +
 	"\x05Empty\"Q\n" +
 	"\fFetchRequest\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
-	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\"\xd9\x02\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\"\xc2\x05\n" +
 	"\fFetchOptions\x12\x1d\n" +
 	"\n" +
 	"timeout_ms\x18\x01 \x01(\x05R\ttimeoutMs\x12)\n" +
@@ -597,10 +5293,30 @@ const file_scraper_proto_rawDesc = "" +
 	"\x10image_proxy_base\x18\x04 \x01(\tR\x0eimageProxyBase\x12<\n" +
 	"\aheaders\x18\x05 \x03(\v2\".scraper.FetchOptions.HeadersEntryR\aheaders\x12\x1a\n" +
 	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x12\x18\n" +
-	"\areferer\x18\a \x01(\tR\areferer\x1a:\n" +
+	"\areferer\x18\a \x01(\tR\areferer\x12+\n" +
+	"\x11follow_pagination\x18\b \x01(\bR\x10followPagination\x12\x1b\n" +
+	"\tmax_pages\x18\t \x01(\x05R\bmaxPages\x12/\n" +
+	"\x13discover_alternates\x18\n" +
+	" \x01(\bR\x12discoverAlternates\x126\n" +
+	"\n" +
+	"credential\x18\v \x01(\v2\x16.scraper.CredentialRefR\n" +
+	"credential\x12#\n" +
+	"\routput_format\x18\f \x01(\tR\foutputFormat\x12'\n" +
+	"\x0frequire_article\x18\r \x01(\bR\x0erequireArticle\x12\x1d\n" +
+	"\n" +
+	"embed_mode\x18\x0e \x01(\tR\tembedMode\x12)\n" +
+	"\x10sanitize_profile\x18\x0f \x01(\tR\x0fsanitizeProfile\x12\x1c\n" +
+	"\tsummarize\x18\x10 \x01(\bR\tsummarize\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfe\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf6\x01\n" +
+	"\rCredentialRef\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x1b\n" +
+	"\tauth_type\x18\x02 \x01(\tR\bauthType\x12)\n" +
+	"\x10encrypted_cookie\x18\x03 \x01(\tR\x0fencryptedCookie\x12'\n" +
+	"\x0fencrypted_token\x18\x04 \x01(\tR\x0eencryptedToken\x12-\n" +
+	"\x12encrypted_username\x18\x05 \x01(\tR\x11encryptedUsername\x12-\n" +
+	"\x12encrypted_password\x18\x06 \x01(\tR\x11encryptedPassword\"\xb9\a\n" +
 	"\rFetchResponse\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
 	"\tfinal_url\x18\x02 \x01(\tR\bfinalUrl\x12\x14\n" +
@@ -616,7 +5332,88 @@ const file_scraper_proto_rawDesc = "" +
 	"\bstrategy\x18\v \x01(\tR\bstrategy\x12\x1f\n" +
 	"\vduration_ms\x18\f \x01(\x03R\n" +
 	"durationMs\x12\x14\n" +
-	"\x05error\x18\r \x01(\tR\x05error\"r\n" +
+	"\x05error\x18\r \x01(\tR\x05error\x12\x14\n" +
+	"\x05pages\x18\x0e \x01(\x05R\x05pages\x12\x18\n" +
+	"\avariant\x18\x0f \x01(\tR\avariant\x12\x1f\n" +
+	"\vvariant_url\x18\x10 \x01(\tR\n" +
+	"variantUrl\x124\n" +
+	"\bmetadata\x18\x11 \x01(\v2\x18.scraper.ArticleMetadataR\bmetadata\x12=\n" +
+	"\x0epublished_date\x18\x12 \x01(\v2\x16.scraper.PublishedDateR\rpublishedDate\x12\x12\n" +
+	"\x04rule\x18\x13 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06engine\x18\x14 \x01(\tR\x06engine\x129\n" +
+	"\rengine_scores\x18\x15 \x03(\v2\x14.scraper.EngineScoreR\fengineScores\x12\x16\n" +
+	"\x06format\x18\x16 \x01(\tR\x06format\x12/\n" +
+	"\tpage_type\x18\x17 \x01(\v2\x12.scraper.PageClassR\bpageType\x12,\n" +
+	"\x05media\x18\x18 \x03(\v2\x16.scraper.EmbeddedMediaR\x05media\x126\n" +
+	"\vfingerprint\x18\x19 \x01(\v2\x14.scraper.FingerprintR\vfingerprint\x12*\n" +
+	"\asummary\x18\x1a \x01(\v2\x10.scraper.SummaryR\asummary\x121\n" +
+	"\bdocument\x18\x1b \x01(\v2\x15.scraper.DocumentInfoR\bdocument\"\xad\x01\n" +
+	"\fDocumentInfo\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\x02 \x01(\x05R\tpageCount\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x18\n" +
+	"\acreator\x18\x05 \x01(\tR\acreator\x12\x1a\n" +
+	"\bproducer\x18\x06 \x01(\tR\bproducer\"o\n" +
+	"\aSummary\x12\x18\n" +
+	"\asummary\x18\x01 \x01(\tR\asummary\x12\x1c\n" +
+	"\tsentences\x18\x02 \x03(\tR\tsentences\x12,\n" +
+	"\bkeywords\x18\x03 \x03(\v2\x10.scraper.KeywordR\bkeywords\"3\n" +
+	"\aKeyword\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"f\n" +
+	"\vFingerprint\x12!\n" +
+	"\fcontent_hash\x18\x01 \x01(\tR\vcontentHash\x12\x18\n" +
+	"\asimhash\x18\x02 \x01(\tR\asimhash\x12\x1a\n" +
+	"\bshingles\x18\x03 \x01(\x05R\bshingles\"\xcf\x01\n" +
+	"\rEmbeddedMedia\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x1b\n" +
+	"\tembed_url\x18\x05 \x01(\tR\bembedUrl\x12\x1c\n" +
+	"\tthumbnail\x18\x06 \x01(\tR\tthumbnail\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x12\x1b\n" +
+	"\tmime_type\x18\b \x01(\tR\bmimeType\"\xda\x01\n" +
+	"\vEngineScore\x12\x16\n" +
+	"\x06engine\x18\x01 \x01(\tR\x06engine\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1f\n" +
+	"\vtext_length\x18\x03 \x01(\x05R\n" +
+	"textLength\x12!\n" +
+	"\flink_density\x18\x04 \x01(\x01R\vlinkDensity\x12\x1e\n" +
+	"\n" +
+	"paragraphs\x18\x05 \x01(\x05R\n" +
+	"paragraphs\x12#\n" +
+	"\rtitle_overlap\x18\x06 \x01(\x01R\ftitleOverlap\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"Y\n" +
+	"\tPageClass\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x01R\n" +
+	"confidence\x12\x18\n" +
+	"\asignals\x18\x03 \x03(\tR\asignals\"m\n" +
+	"\rPublishedDate\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\tR\x04time\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x01R\n" +
+	"confidence\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x10\n" +
+	"\x03raw\x18\x04 \x01(\tR\x03raw\"\xee\x02\n" +
+	"\x0fArticleMetadata\x12#\n" +
+	"\rcanonical_url\x18\x01 \x01(\tR\fcanonicalUrl\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\x0epublished_time\x18\x04 \x01(\tR\rpublishedTime\x12#\n" +
+	"\rmodified_time\x18\x05 \x01(\tR\fmodifiedTime\x12\x18\n" +
+	"\aauthors\x18\x06 \x03(\tR\aauthors\x12\x18\n" +
+	"\asection\x18\a \x01(\tR\asection\x12\x1a\n" +
+	"\bkeywords\x18\b \x03(\tR\bkeywords\x12\x14\n" +
+	"\x05image\x18\t \x01(\tR\x05image\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x12\x1c\n" +
+	"\tpublisher\x18\v \x01(\tR\tpublisher\x12\x12\n" +
+	"\x04type\x18\f \x01(\tR\x04type\"r\n" +
 	"\x05Image\x12!\n" +
 	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
 	"\tproxy_url\x18\x02 \x01(\tR\bproxyUrl\x12\x10\n" +
@@ -637,12 +5434,393 @@ const file_scraper_proto_rawDesc = "" +
 	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x12\x1f\n" +
 	"\vduration_ms\x18\a \x01(\x03R\n" +
 	"durationMs\x12\x14\n" +
-	"\x05error\x18\b \x01(\tR\x05error2\x89\x02\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\x85\x01\n" +
+	"\fLinksRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\x12\x18\n" +
+	"\ainclude\x18\x03 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x04 \x03(\tR\aexclude\"\xb6\x01\n" +
+	"\rLinksResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
+	"\tfinal_url\x18\x02 \x01(\tR\bfinalUrl\x12#\n" +
+	"\x05links\x18\x03 \x03(\v2\r.scraper.LinkR\x05links\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\x95\x01\n" +
+	"\x04Link\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x10\n" +
+	"\x03rel\x18\x03 \x03(\tR\x03rel\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1a\n" +
+	"\binternal\x18\x05 \x01(\bR\binternal\x12!\n" +
+	"\farticle_like\x18\x06 \x01(\bR\varticleLike\"\x81\x01\n" +
+	"\rScrapeRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\x12-\n" +
+	"\x06config\x18\x03 \x01(\v2\x15.scraper.ScrapeConfigR\x06config\"\x9d\x04\n" +
+	"\fScrapeConfig\x12#\n" +
+	"\rlist_selector\x18\x01 \x01(\tR\flistSelector\x12%\n" +
+	"\x0etitle_selector\x18\x02 \x01(\tR\rtitleSelector\x12\x1d\n" +
+	"\n" +
+	"title_attr\x18\x03 \x01(\tR\ttitleAttr\x12#\n" +
+	"\rlink_selector\x18\x04 \x01(\tR\flinkSelector\x12\x1b\n" +
+	"\tlink_attr\x18\x05 \x01(\tR\blinkAttr\x12)\n" +
+	"\x10summary_selector\x18\x06 \x01(\tR\x0fsummarySelector\x12!\n" +
+	"\fsummary_attr\x18\a \x01(\tR\vsummaryAttr\x12%\n" +
+	"\x0eimage_selector\x18\b \x01(\tR\rimageSelector\x12\x1d\n" +
+	"\n" +
+	"image_attr\x18\t \x01(\tR\timageAttr\x12'\n" +
+	"\x0fauthor_selector\x18\n" +
+	" \x01(\tR\x0eauthorSelector\x12\x1f\n" +
+	"\vauthor_attr\x18\v \x01(\tR\n" +
+	"authorAttr\x12#\n" +
+	"\rdate_selector\x18\f \x01(\tR\fdateSelector\x12\x1b\n" +
+	"\tdate_attr\x18\r \x01(\tR\bdateAttr\x12#\n" +
+	"\rnext_selector\x18\x0e \x01(\tR\fnextSelector\x12\x1b\n" +
+	"\tmax_pages\x18\x0f \x01(\x05R\bmaxPages\"\xb1\x02\n" +
+	"\x0eScrapeResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
+	"\tfinal_url\x18\x02 \x01(\tR\bfinalUrl\x12)\n" +
+	"\x05items\x18\x03 \x03(\v2\x13.scraper.ScrapeItemR\x05items\x12\x14\n" +
+	"\x05pages\x18\x04 \x01(\x05R\x05pages\x12\x1b\n" +
+	"\tpage_urls\x18\x05 \x03(\tR\bpageUrls\x12?\n" +
+	"\x0fselector_errors\x18\x06 \x03(\v2\x16.scraper.SelectorErrorR\x0eselectorErrors\x12\x1a\n" +
+	"\bstrategy\x18\a \x01(\tR\bstrategy\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xba\x01\n" +
+	"\n" +
+	"ScrapeItem\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x18\n" +
+	"\asummary\x18\x03 \x01(\tR\asummary\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x12\n" +
+	"\x04date\x18\x06 \x01(\tR\x04date\x12!\n" +
+	"\fpublished_at\x18\a \x01(\tR\vpublishedAt\"[\n" +
+	"\rSelectorError\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
+	"\bselector\x18\x02 \x01(\tR\bselector\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"P\n" +
+	"\vFeedRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\"\xb3\x01\n" +
+	"\fFeedResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
+	"\tfinal_url\x18\x02 \x01(\tR\bfinalUrl\x12!\n" +
+	"\x04feed\x18\x03 \x01(\v2\r.scraper.FeedR\x04feed\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\xfe\x01\n" +
+	"\x04Feed\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04link\x18\x04 \x01(\tR\x04link\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05image\x18\a \x01(\tR\x05image\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12'\n" +
+	"\x05items\x18\t \x03(\v2\x11.scraper.FeedItemR\x05items\"\xef\x02\n" +
+	"\bFeedItem\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04guid\x18\x02 \x01(\tR\x04guid\x12\x12\n" +
+	"\x04link\x18\x03 \x01(\tR\x04link\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x18\n" +
+	"\asummary\x18\x05 \x01(\tR\asummary\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\tR\acontent\x12!\n" +
+	"\fpublished_at\x18\a \x01(\tR\vpublishedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x18\n" +
+	"\aauthors\x18\t \x03(\tR\aauthors\x12\x1e\n" +
+	"\n" +
+	"categories\x18\n" +
+	" \x03(\tR\n" +
+	"categories\x126\n" +
+	"\n" +
+	"enclosures\x18\v \x03(\v2\x16.scraper.FeedEnclosureR\n" +
+	"enclosures\x12\x1c\n" +
+	"\tthumbnail\x18\f \x01(\tR\tthumbnail\"M\n" +
+	"\rFeedEnclosure\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"{\n" +
+	"\x0fDiscoverRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\x12%\n" +
+	"\x0emax_candidates\x18\x03 \x01(\x05R\rmaxCandidates\"\x88\x02\n" +
+	"\x10DiscoverResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1b\n" +
+	"\tfinal_url\x18\x02 \x01(\tR\bfinalUrl\x12\x1d\n" +
+	"\n" +
+	"site_title\x18\x03 \x01(\tR\tsiteTitle\x129\n" +
+	"\n" +
+	"candidates\x18\x04 \x03(\v2\x19.scraper.DiscoveredSourceR\n" +
+	"candidates\x12\x18\n" +
+	"\achecked\x18\x05 \x01(\x05R\achecked\x12\x1a\n" +
+	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\xd0\x01\n" +
+	"\x10DiscoveredSource\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x05 \x01(\x05R\titemCount\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x14\n" +
+	"\x05found\x18\a \x01(\tR\x05found\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\"\xa2\x01\n" +
+	"\x0eSitemapRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\tR\x05since\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12!\n" +
+	"\fmax_sitemaps\x18\x05 \x01(\x05R\vmaxSitemaps\"\xb7\x01\n" +
+	"\fSitemapEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12)\n" +
+	"\x05entry\x18\x02 \x01(\v2\x13.scraper.SitemapUrlR\x05entry\x121\n" +
+	"\asummary\x18\x03 \x01(\v2\x17.scraper.SitemapSummaryR\asummary\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xe7\x01\n" +
+	"\n" +
+	"SitemapUrl\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x18\n" +
+	"\alastmod\x18\x02 \x01(\tR\alastmod\x12\x1e\n" +
+	"\n" +
+	"changefreq\x18\x03 \x01(\tR\n" +
+	"changefreq\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x01R\bpriority\x12(\n" +
+	"\x04news\x18\x05 \x01(\v2\x14.scraper.SitemapNewsR\x04news\x12-\n" +
+	"\x06images\x18\x06 \x03(\v2\x15.scraper.SitemapImageR\x06images\x12\x18\n" +
+	"\asitemap\x18\a \x01(\tR\asitemap\"\xa9\x01\n" +
+	"\vSitemapNews\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12)\n" +
+	"\x10publication_name\x18\x02 \x01(\tR\x0fpublicationName\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12!\n" +
+	"\fpublished_at\x18\x04 \x01(\tR\vpublishedAt\x12\x1a\n" +
+	"\bkeywords\x18\x05 \x03(\tR\bkeywords\"P\n" +
+	"\fSitemapImage\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acaption\x18\x03 \x01(\tR\acaption\"\xa7\x01\n" +
+	"\x0eSitemapSummary\x12\x1a\n" +
+	"\bsitemaps\x18\x01 \x01(\x05R\bsitemaps\x12\x12\n" +
+	"\x04urls\x18\x02 \x01(\x05R\x04urls\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x05R\askipped\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\x12-\n" +
+	"\x06errors\x18\x05 \x03(\v2\x15.scraper.SitemapErrorR\x06errors\"6\n" +
+	"\fSitemapError\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x94\x03\n" +
+	"\fCrawlRequest\x12\x12\n" +
+	"\x04seed\x18\x01 \x01(\tR\x04seed\x12\x1b\n" +
+	"\tmax_depth\x18\x02 \x01(\x05R\bmaxDepth\x12\x1b\n" +
+	"\tmax_pages\x18\x03 \x01(\x05R\bmaxPages\x12\x18\n" +
+	"\ainclude\x18\x04 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x05 \x03(\tR\aexclude\x12\x14\n" +
+	"\x05scope\x18\x06 \x01(\tR\x05scope\x12-\n" +
+	"\x12allowed_subdomains\x18\a \x03(\tR\x11allowedSubdomains\x12$\n" +
+	"\x0eseed_path_only\x18\b \x01(\bR\fseedPathOnly\x12)\n" +
+	"\x10extract_articles\x18\t \x01(\bR\x0fextractArticles\x12 \n" +
+	"\vconcurrency\x18\n" +
+	" \x01(\x05R\vconcurrency\x12\x19\n" +
+	"\bdelay_ms\x18\v \x01(\x05R\adelayMs\x12/\n" +
+	"\aoptions\x18\f \x01(\v2\x15.scraper.FetchOptionsR\aoptions\"%\n" +
+	"\x13CrawlControlRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc1\x02\n" +
+	"\n" +
+	"CrawlEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\bcrawl_id\x18\x02 \x01(\tR\acrawlId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1b\n" +
+	"\tfinal_url\x18\x04 \x01(\tR\bfinalUrl\x12\x14\n" +
+	"\x05depth\x18\x05 \x01(\x05R\x05depth\x12\x1d\n" +
+	"\n" +
+	"parent_url\x18\x06 \x01(\tR\tparentUrl\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x12\x14\n" +
+	"\x05links\x18\b \x01(\x05R\x05links\x120\n" +
+	"\aarticle\x18\t \x01(\v2\x16.scraper.FetchResponseR\aarticle\x12,\n" +
+	"\x06status\x18\n" +
+	" \x01(\v2\x14.scraper.CrawlStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\"\xc3\x02\n" +
+	"\vCrawlStatus\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04seed\x18\x02 \x01(\tR\x04seed\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"discovered\x18\x04 \x01(\x05R\n" +
+	"discovered\x12\x18\n" +
+	"\acrawled\x18\x05 \x01(\x05R\acrawled\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x12\x1a\n" +
+	"\barticles\x18\a \x01(\x05R\barticles\x12\x18\n" +
+	"\apending\x18\b \x01(\x05R\apending\x12\x1c\n" +
+	"\ttruncated\x18\t \x01(\bR\ttruncated\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\tR\tupdatedAt\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\"Z\n" +
+	"\x0eSimilarRequest\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.scraper.SimilarItemR\x05items\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x01R\tthreshold\"n\n" +
+	"\vSimilarItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x18\n" +
+	"\asimhash\x18\x03 \x01(\tR\asimhash\x12!\n" +
+	"\fcontent_hash\x18\x04 \x01(\tR\vcontentHash\"\xb4\x01\n" +
+	"\x0fSimilarResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.scraper.SimilarItemR\x05items\x12*\n" +
+	"\x05pairs\x18\x02 \x03(\v2\x14.scraper.SimilarPairR\x05pairs\x123\n" +
+	"\bclusters\x18\x03 \x03(\v2\x17.scraper.SimilarClusterR\bclusters\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"{\n" +
+	"\vSimilarPair\x12\f\n" +
+	"\x01a\x18\x01 \x01(\tR\x01a\x12\f\n" +
+	"\x01b\x18\x02 \x01(\tR\x01b\x12\x1a\n" +
+	"\bdistance\x18\x03 \x01(\x05R\bdistance\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x04 \x01(\x01R\n" +
+	"similarity\x12\x14\n" +
+	"\x05exact\x18\x05 \x01(\bR\x05exact\"\"\n" +
+	"\x0eSimilarCluster\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\x9e\x01\n" +
+	"\x0fRevisionRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.scraper.FetchOptionsR\aoptions\x12#\n" +
+	"\rprevious_hash\x18\x03 \x01(\tR\fpreviousHash\x12#\n" +
+	"\rprevious_text\x18\x04 \x01(\tR\fpreviousText\"\xc0\x01\n" +
+	"\x10RevisionResponse\x120\n" +
+	"\aarticle\x18\x01 \x01(\v2\x16.scraper.FetchResponseR\aarticle\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\x12#\n" +
+	"\rprevious_hash\x18\x03 \x01(\tR\fpreviousHash\x12%\n" +
+	"\x04diff\x18\x04 \x01(\v2\x11.scraper.TextDiffR\x04diff\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xa0\x01\n" +
+	"\bTextDiff\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\x12\x18\n" +
+	"\aremoved\x18\x02 \x01(\x05R\aremoved\x12\x1a\n" +
+	"\bmodified\x18\x03 \x01(\x05R\bmodified\x12\x1c\n" +
+	"\tunchanged\x18\x04 \x01(\x05R\tunchanged\x12*\n" +
+	"\x06blocks\x18\x05 \x03(\v2\x12.scraper.DiffBlockR\x06blocks\"\x9d\x01\n" +
+	"\tDiffBlock\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x1b\n" +
+	"\told_index\x18\x03 \x01(\x05R\boldIndex\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x19\n" +
+	"\bold_text\x18\x05 \x01(\tR\aoldText\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x06 \x01(\x01R\n" +
+	"similarity\"\x8a\x01\n" +
+	"\x10SummarizeRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04html\x18\x02 \x01(\tR\x04html\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1c\n" +
+	"\tsentences\x18\x04 \x01(\x05R\tsentences\x12\x1a\n" +
+	"\bkeywords\x18\x05 \x01(\x05R\bkeywords\"U\n" +
+	"\x11SummarizeResponse\x12*\n" +
+	"\asummary\x18\x01 \x01(\v2\x10.scraper.SummaryR\asummary\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x8d\x01\n" +
+	"\x0eLoginSelectors\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x16\n" +
+	"\x06submit\x18\x03 \x01(\tR\x06submit\x12+\n" +
+	"\x11success_indicator\x18\x04 \x01(\tR\x10successIndicator\"\x92\x04\n" +
+	"\fLoginRequest\x12\x1b\n" +
+	"\tlogin_url\x18\x01 \x01(\tR\bloginUrl\x125\n" +
+	"\tselectors\x18\x02 \x01(\v2\x17.scraper.LoginSelectorsR\tselectors\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12I\n" +
+	"\fextra_fields\x18\x05 \x03(\v2&.scraper.LoginRequest.ExtraFieldsEntryR\vextraFields\x12<\n" +
+	"\aheaders\x18\x06 \x03(\v2\".scraper.LoginRequest.HeadersEntryR\aheaders\x12\x1a\n" +
+	"\bstrategy\x18\a \x01(\tR\bstrategy\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\b \x01(\x05R\ttimeoutMs\x126\n" +
+	"\n" +
+	"credential\x18\t \x01(\v2\x16.scraper.CredentialRefR\n" +
+	"credential\x1a>\n" +
+	"\x10ExtraFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9b\x01\n" +
+	"\n" +
+	"CookieInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x18\n" +
+	"\aexpires\x18\x04 \x01(\tR\aexpires\x12\x1b\n" +
+	"\thttp_only\x18\x05 \x01(\bR\bhttpOnly\x12\x16\n" +
+	"\x06secure\x18\x06 \x01(\bR\x06secure\"\xec\x01\n" +
+	"\rLoginResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\acookies\x18\x02 \x01(\tR\acookies\x124\n" +
+	"\vcookie_list\x18\x03 \x03(\v2\x13.scraper.CookieInfoR\n" +
+	"cookieList\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\x12\x1b\n" +
+	"\tfinal_url\x18\x05 \x01(\tR\bfinalUrl\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\x8f\x01\n" +
+	"\x0eCredentialRule\x12,\n" +
+	"\x12logged_in_selector\x18\x01 \x01(\tR\x10loggedInSelector\x12*\n" +
+	"\x11login_url_pattern\x18\x02 \x01(\tR\x0floginUrlPattern\x12#\n" +
+	"\rexpected_text\x18\x03 \x01(\tR\fexpectedText\"\x80\x03\n" +
+	"\x16CredentialCheckRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12F\n" +
+	"\aheaders\x18\x03 \x03(\v2,.scraper.CredentialCheckRequest.HeadersEntryR\aheaders\x12\x18\n" +
+	"\acookies\x18\x04 \x01(\tR\acookies\x12+\n" +
+	"\x04rule\x18\x05 \x01(\v2\x17.scraper.CredentialRuleR\x04rule\x12\x1a\n" +
+	"\bstrategy\x18\x06 \x01(\tR\bstrategy\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\a \x01(\x05R\ttimeoutMs\x126\n" +
+	"\n" +
+	"credential\x18\b \x01(\v2\x16.scraper.CredentialRefR\n" +
+	"credential\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xda\x01\n" +
+	"\x17CredentialCheckResponse\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bevidence\x18\x03 \x03(\tR\bevidence\x12\x1b\n" +
+	"\tfinal_url\x18\x04 \x01(\tR\bfinalUrl\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error2\xe5\t\n" +
 	"\x0eScraperService\x12=\n" +
 	"\fFetchArticle\x12\x15.scraper.FetchRequest\x1a\x16.scraper.FetchResponse\x12B\n" +
 	"\rFetchArticles\x12\x15.scraper.FetchRequest\x1a\x16.scraper.FetchResponse(\x010\x01\x12<\n" +
 	"\bFetchRaw\x12\x15.scraper.FetchRequest\x1a\x19.scraper.FetchRawResponse\x126\n" +
-	"\vHealthCheck\x12\x0e.scraper.Empty\x1a\x17.scraper.HealthResponseB2Z0github.com/newsflow/go-scraper-service/api/protob\x06proto3"
+	"\vHealthCheck\x12\x0e.scraper.Empty\x1a\x17.scraper.HealthResponse\x126\n" +
+	"\x05Login\x12\x15.scraper.LoginRequest\x1a\x16.scraper.LoginResponse\x12T\n" +
+	"\x0fCheckCredential\x12\x1f.scraper.CredentialCheckRequest\x1a .scraper.CredentialCheckResponse\x12=\n" +
+	"\fExtractLinks\x12\x15.scraper.LinksRequest\x1a\x16.scraper.LinksResponse\x129\n" +
+	"\x06Scrape\x12\x16.scraper.ScrapeRequest\x1a\x17.scraper.ScrapeResponse\x128\n" +
+	"\tFetchFeed\x12\x14.scraper.FeedRequest\x1a\x15.scraper.FeedResponse\x12D\n" +
+	"\rDiscoverFeeds\x12\x18.scraper.DiscoverRequest\x1a\x19.scraper.DiscoverResponse\x12A\n" +
+	"\rStreamSitemap\x12\x17.scraper.SitemapRequest\x1a\x15.scraper.SitemapEvent0\x01\x12:\n" +
+	"\n" +
+	"StartCrawl\x12\x15.scraper.CrawlRequest\x1a\x13.scraper.CrawlEvent0\x01\x12B\n" +
+	"\vResumeCrawl\x12\x1c.scraper.CrawlControlRequest\x1a\x13.scraper.CrawlEvent0\x01\x12@\n" +
+	"\n" +
+	"PauseCrawl\x12\x1c.scraper.CrawlControlRequest\x1a\x14.scraper.CrawlStatus\x12A\n" +
+	"\vCancelCrawl\x12\x1c.scraper.CrawlControlRequest\x1a\x14.scraper.CrawlStatus\x12>\n" +
+	"\bGetCrawl\x12\x1c.scraper.CrawlControlRequest\x1a\x14.scraper.CrawlStatus\x12@\n" +
+	"\vFindSimilar\x12\x17.scraper.SimilarRequest\x1a\x18.scraper.SimilarResponse\x12D\n" +
+	"\rCheckRevision\x12\x18.scraper.RevisionRequest\x1a\x19.scraper.RevisionResponse\x12B\n" +
+	"\tSummarize\x12\x19.scraper.SummarizeRequest\x1a\x1a.scraper.SummarizeResponseB2Z0github.com/newsflow/go-scraper-service/api/protob\x06proto3"
 
 var (
 	file_scraper_proto_rawDescOnce sync.Once
@@ -656,34 +5834,170 @@ func file_scraper_proto_rawDescGZIP() []byte {
 	return file_scraper_proto_rawDescData
 }
 
-var file_scraper_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_scraper_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
 var file_scraper_proto_goTypes = []any{
-	(*Empty)(nil),            // 0: scraper.Empty
-	(*FetchRequest)(nil),     // 1: scraper.FetchRequest
-	(*FetchOptions)(nil),     // 2: scraper.FetchOptions
-	(*FetchResponse)(nil),    // 3: scraper.FetchResponse
-	(*Image)(nil),            // 4: scraper.Image
-	(*HealthResponse)(nil),   // 5: scraper.HealthResponse
-	(*FetchRawResponse)(nil), // 6: scraper.FetchRawResponse
-	nil,                      // 7: scraper.FetchOptions.HeadersEntry
+	(*Empty)(nil),                   // 0: scraper.Empty
+	(*FetchRequest)(nil),            // 1: scraper.FetchRequest
+	(*FetchOptions)(nil),            // 2: scraper.FetchOptions
+	(*CredentialRef)(nil),           // 3: scraper.CredentialRef
+	(*FetchResponse)(nil),           // 4: scraper.FetchResponse
+	(*DocumentInfo)(nil),            // 5: scraper.DocumentInfo
+	(*Summary)(nil),                 // 6: scraper.Summary
+	(*Keyword)(nil),                 // 7: scraper.Keyword
+	(*Fingerprint)(nil),             // 8: scraper.Fingerprint
+	(*EmbeddedMedia)(nil),           // 9: scraper.EmbeddedMedia
+	(*EngineScore)(nil),             // 10: scraper.EngineScore
+	(*PageClass)(nil),               // 11: scraper.PageClass
+	(*PublishedDate)(nil),           // 12: scraper.PublishedDate
+	(*ArticleMetadata)(nil),         // 13: scraper.ArticleMetadata
+	(*Image)(nil),                   // 14: scraper.Image
+	(*HealthResponse)(nil),          // 15: scraper.HealthResponse
+	(*FetchRawResponse)(nil),        // 16: scraper.FetchRawResponse
+	(*LinksRequest)(nil),            // 17: scraper.LinksRequest
+	(*LinksResponse)(nil),           // 18: scraper.LinksResponse
+	(*Link)(nil),                    // 19: scraper.Link
+	(*ScrapeRequest)(nil),           // 20: scraper.ScrapeRequest
+	(*ScrapeConfig)(nil),            // 21: scraper.ScrapeConfig
+	(*ScrapeResponse)(nil),          // 22: scraper.ScrapeResponse
+	(*ScrapeItem)(nil),              // 23: scraper.ScrapeItem
+	(*SelectorError)(nil),           // 24: scraper.SelectorError
+	(*FeedRequest)(nil),             // 25: scraper.FeedRequest
+	(*FeedResponse)(nil),            // 26: scraper.FeedResponse
+	(*Feed)(nil),                    // 27: scraper.Feed
+	(*FeedItem)(nil),                // 28: scraper.FeedItem
+	(*FeedEnclosure)(nil),           // 29: scraper.FeedEnclosure
+	(*DiscoverRequest)(nil),         // 30: scraper.DiscoverRequest
+	(*DiscoverResponse)(nil),        // 31: scraper.DiscoverResponse
+	(*DiscoveredSource)(nil),        // 32: scraper.DiscoveredSource
+	(*SitemapRequest)(nil),          // 33: scraper.SitemapRequest
+	(*SitemapEvent)(nil),            // 34: scraper.SitemapEvent
+	(*SitemapUrl)(nil),              // 35: scraper.SitemapUrl
+	(*SitemapNews)(nil),             // 36: scraper.SitemapNews
+	(*SitemapImage)(nil),            // 37: scraper.SitemapImage
+	(*SitemapSummary)(nil),          // 38: scraper.SitemapSummary
+	(*SitemapError)(nil),            // 39: scraper.SitemapError
+	(*CrawlRequest)(nil),            // 40: scraper.CrawlRequest
+	(*CrawlControlRequest)(nil),     // 41: scraper.CrawlControlRequest
+	(*CrawlEvent)(nil),              // 42: scraper.CrawlEvent
+	(*CrawlStatus)(nil),             // 43: scraper.CrawlStatus
+	(*SimilarRequest)(nil),          // 44: scraper.SimilarRequest
+	(*SimilarItem)(nil),             // 45: scraper.SimilarItem
+	(*SimilarResponse)(nil),         // 46: scraper.SimilarResponse
+	(*SimilarPair)(nil),             // 47: scraper.SimilarPair
+	(*SimilarCluster)(nil),          // 48: scraper.SimilarCluster
+	(*RevisionRequest)(nil),         // 49: scraper.RevisionRequest
+	(*RevisionResponse)(nil),        // 50: scraper.RevisionResponse
+	(*TextDiff)(nil),                // 51: scraper.TextDiff
+	(*DiffBlock)(nil),               // 52: scraper.DiffBlock
+	(*SummarizeRequest)(nil),        // 53: scraper.SummarizeRequest
+	(*SummarizeResponse)(nil),       // 54: scraper.SummarizeResponse
+	(*LoginSelectors)(nil),          // 55: scraper.LoginSelectors
+	(*LoginRequest)(nil),            // 56: scraper.LoginRequest
+	(*CookieInfo)(nil),              // 57: scraper.CookieInfo
+	(*LoginResponse)(nil),           // 58: scraper.LoginResponse
+	(*CredentialRule)(nil),          // 59: scraper.CredentialRule
+	(*CredentialCheckRequest)(nil),  // 60: scraper.CredentialCheckRequest
+	(*CredentialCheckResponse)(nil), // 61: scraper.CredentialCheckResponse
+	nil,                             // 62: scraper.FetchOptions.HeadersEntry
+	nil,                             // 63: scraper.LoginRequest.ExtraFieldsEntry
+	nil,                             // 64: scraper.LoginRequest.HeadersEntry
+	nil,                             // 65: scraper.CredentialCheckRequest.HeadersEntry
 }
 var file_scraper_proto_depIdxs = []int32{
-	2, // 0: scraper.FetchRequest.options:type_name -> scraper.FetchOptions
-	7, // 1: scraper.FetchOptions.headers:type_name -> scraper.FetchOptions.HeadersEntry
-	4, // 2: scraper.FetchResponse.images:type_name -> scraper.Image
-	1, // 3: scraper.ScraperService.FetchArticle:input_type -> scraper.FetchRequest
-	1, // 4: scraper.ScraperService.FetchArticles:input_type -> scraper.FetchRequest
-	1, // 5: scraper.ScraperService.FetchRaw:input_type -> scraper.FetchRequest
-	0, // 6: scraper.ScraperService.HealthCheck:input_type -> scraper.Empty
-	3, // 7: scraper.ScraperService.FetchArticle:output_type -> scraper.FetchResponse
-	3, // 8: scraper.ScraperService.FetchArticles:output_type -> scraper.FetchResponse
-	6, // 9: scraper.ScraperService.FetchRaw:output_type -> scraper.FetchRawResponse
-	5, // 10: scraper.ScraperService.HealthCheck:output_type -> scraper.HealthResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: scraper.FetchRequest.options:type_name -> scraper.FetchOptions
+	62, // 1: scraper.FetchOptions.headers:type_name -> scraper.FetchOptions.HeadersEntry
+	3,  // 2: scraper.FetchOptions.credential:type_name -> scraper.CredentialRef
+	14, // 3: scraper.FetchResponse.images:type_name -> scraper.Image
+	13, // 4: scraper.FetchResponse.metadata:type_name -> scraper.ArticleMetadata
+	12, // 5: scraper.FetchResponse.published_date:type_name -> scraper.PublishedDate
+	10, // 6: scraper.FetchResponse.engine_scores:type_name -> scraper.EngineScore
+	11, // 7: scraper.FetchResponse.page_type:type_name -> scraper.PageClass
+	9,  // 8: scraper.FetchResponse.media:type_name -> scraper.EmbeddedMedia
+	8,  // 9: scraper.FetchResponse.fingerprint:type_name -> scraper.Fingerprint
+	6,  // 10: scraper.FetchResponse.summary:type_name -> scraper.Summary
+	5,  // 11: scraper.FetchResponse.document:type_name -> scraper.DocumentInfo
+	7,  // 12: scraper.Summary.keywords:type_name -> scraper.Keyword
+	2,  // 13: scraper.LinksRequest.options:type_name -> scraper.FetchOptions
+	19, // 14: scraper.LinksResponse.links:type_name -> scraper.Link
+	2,  // 15: scraper.ScrapeRequest.options:type_name -> scraper.FetchOptions
+	21, // 16: scraper.ScrapeRequest.config:type_name -> scraper.ScrapeConfig
+	23, // 17: scraper.ScrapeResponse.items:type_name -> scraper.ScrapeItem
+	24, // 18: scraper.ScrapeResponse.selector_errors:type_name -> scraper.SelectorError
+	2,  // 19: scraper.FeedRequest.options:type_name -> scraper.FetchOptions
+	27, // 20: scraper.FeedResponse.feed:type_name -> scraper.Feed
+	28, // 21: scraper.Feed.items:type_name -> scraper.FeedItem
+	29, // 22: scraper.FeedItem.enclosures:type_name -> scraper.FeedEnclosure
+	2,  // 23: scraper.DiscoverRequest.options:type_name -> scraper.FetchOptions
+	32, // 24: scraper.DiscoverResponse.candidates:type_name -> scraper.DiscoveredSource
+	2,  // 25: scraper.SitemapRequest.options:type_name -> scraper.FetchOptions
+	35, // 26: scraper.SitemapEvent.entry:type_name -> scraper.SitemapUrl
+	38, // 27: scraper.SitemapEvent.summary:type_name -> scraper.SitemapSummary
+	36, // 28: scraper.SitemapUrl.news:type_name -> scraper.SitemapNews
+	37, // 29: scraper.SitemapUrl.images:type_name -> scraper.SitemapImage
+	39, // 30: scraper.SitemapSummary.errors:type_name -> scraper.SitemapError
+	2,  // 31: scraper.CrawlRequest.options:type_name -> scraper.FetchOptions
+	4,  // 32: scraper.CrawlEvent.article:type_name -> scraper.FetchResponse
+	43, // 33: scraper.CrawlEvent.status:type_name -> scraper.CrawlStatus
+	45, // 34: scraper.SimilarRequest.items:type_name -> scraper.SimilarItem
+	45, // 35: scraper.SimilarResponse.items:type_name -> scraper.SimilarItem
+	47, // 36: scraper.SimilarResponse.pairs:type_name -> scraper.SimilarPair
+	48, // 37: scraper.SimilarResponse.clusters:type_name -> scraper.SimilarCluster
+	2,  // 38: scraper.RevisionRequest.options:type_name -> scraper.FetchOptions
+	4,  // 39: scraper.RevisionResponse.article:type_name -> scraper.FetchResponse
+	51, // 40: scraper.RevisionResponse.diff:type_name -> scraper.TextDiff
+	52, // 41: scraper.TextDiff.blocks:type_name -> scraper.DiffBlock
+	6,  // 42: scraper.SummarizeResponse.summary:type_name -> scraper.Summary
+	55, // 43: scraper.LoginRequest.selectors:type_name -> scraper.LoginSelectors
+	63, // 44: scraper.LoginRequest.extra_fields:type_name -> scraper.LoginRequest.ExtraFieldsEntry
+	64, // 45: scraper.LoginRequest.headers:type_name -> scraper.LoginRequest.HeadersEntry
+	3,  // 46: scraper.LoginRequest.credential:type_name -> scraper.CredentialRef
+	57, // 47: scraper.LoginResponse.cookie_list:type_name -> scraper.CookieInfo
+	65, // 48: scraper.CredentialCheckRequest.headers:type_name -> scraper.CredentialCheckRequest.HeadersEntry
+	59, // 49: scraper.CredentialCheckRequest.rule:type_name -> scraper.CredentialRule
+	3,  // 50: scraper.CredentialCheckRequest.credential:type_name -> scraper.CredentialRef
+	1,  // 51: scraper.ScraperService.FetchArticle:input_type -> scraper.FetchRequest
+	1,  // 52: scraper.ScraperService.FetchArticles:input_type -> scraper.FetchRequest
+	1,  // 53: scraper.ScraperService.FetchRaw:input_type -> scraper.FetchRequest
+	0,  // 54: scraper.ScraperService.HealthCheck:input_type -> scraper.Empty
+	56, // 55: scraper.ScraperService.Login:input_type -> scraper.LoginRequest
+	60, // 56: scraper.ScraperService.CheckCredential:input_type -> scraper.CredentialCheckRequest
+	17, // 57: scraper.ScraperService.ExtractLinks:input_type -> scraper.LinksRequest
+	20, // 58: scraper.ScraperService.Scrape:input_type -> scraper.ScrapeRequest
+	25, // 59: scraper.ScraperService.FetchFeed:input_type -> scraper.FeedRequest
+	30, // 60: scraper.ScraperService.DiscoverFeeds:input_type -> scraper.DiscoverRequest
+	33, // 61: scraper.ScraperService.StreamSitemap:input_type -> scraper.SitemapRequest
+	40, // 62: scraper.ScraperService.StartCrawl:input_type -> scraper.CrawlRequest
+	41, // 63: scraper.ScraperService.ResumeCrawl:input_type -> scraper.CrawlControlRequest
+	41, // 64: scraper.ScraperService.PauseCrawl:input_type -> scraper.CrawlControlRequest
+	41, // 65: scraper.ScraperService.CancelCrawl:input_type -> scraper.CrawlControlRequest
+	41, // 66: scraper.ScraperService.GetCrawl:input_type -> scraper.CrawlControlRequest
+	44, // 67: scraper.ScraperService.FindSimilar:input_type -> scraper.SimilarRequest
+	49, // 68: scraper.ScraperService.CheckRevision:input_type -> scraper.RevisionRequest
+	53, // 69: scraper.ScraperService.Summarize:input_type -> scraper.SummarizeRequest
+	4,  // 70: scraper.ScraperService.FetchArticle:output_type -> scraper.FetchResponse
+	4,  // 71: scraper.ScraperService.FetchArticles:output_type -> scraper.FetchResponse
+	16, // 72: scraper.ScraperService.FetchRaw:output_type -> scraper.FetchRawResponse
+	15, // 73: scraper.ScraperService.HealthCheck:output_type -> scraper.HealthResponse
+	58, // 74: scraper.ScraperService.Login:output_type -> scraper.LoginResponse
+	61, // 75: scraper.ScraperService.CheckCredential:output_type -> scraper.CredentialCheckResponse
+	18, // 76: scraper.ScraperService.ExtractLinks:output_type -> scraper.LinksResponse
+	22, // 77: scraper.ScraperService.Scrape:output_type -> scraper.ScrapeResponse
+	26, // 78: scraper.ScraperService.FetchFeed:output_type -> scraper.FeedResponse
+	31, // 79: scraper.ScraperService.DiscoverFeeds:output_type -> scraper.DiscoverResponse
+	34, // 80: scraper.ScraperService.StreamSitemap:output_type -> scraper.SitemapEvent
+	42, // 81: scraper.ScraperService.StartCrawl:output_type -> scraper.CrawlEvent
+	42, // 82: scraper.ScraperService.ResumeCrawl:output_type -> scraper.CrawlEvent
+	43, // 83: scraper.ScraperService.PauseCrawl:output_type -> scraper.CrawlStatus
+	43, // 84: scraper.ScraperService.CancelCrawl:output_type -> scraper.CrawlStatus
+	43, // 85: scraper.ScraperService.GetCrawl:output_type -> scraper.CrawlStatus
+	46, // 86: scraper.ScraperService.FindSimilar:output_type -> scraper.SimilarResponse
+	50, // 87: scraper.ScraperService.CheckRevision:output_type -> scraper.RevisionResponse
+	54, // 88: scraper.ScraperService.Summarize:output_type -> scraper.SummarizeResponse
+	70, // [70:89] is the sub-list for method output_type
+	51, // [51:70] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_scraper_proto_init() }
@@ -697,7 +6011,7 @@ func file_scraper_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scraper_proto_rawDesc), len(file_scraper_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   66,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ScraperService_FetchArticle_FullMethodName  = "/scraper.ScraperService/FetchArticle"
-	ScraperService_FetchArticles_FullMethodName = "/scraper.ScraperService/FetchArticles"
-	ScraperService_FetchRaw_FullMethodName      = "/scraper.ScraperService/FetchRaw"
-	ScraperService_HealthCheck_FullMethodName   = "/scraper.ScraperService/HealthCheck"
+	ScraperService_FetchArticle_FullMethodName    = "/scraper.ScraperService/FetchArticle"
+	ScraperService_FetchArticles_FullMethodName   = "/scraper.ScraperService/FetchArticles"
+	ScraperService_FetchRaw_FullMethodName        = "/scraper.ScraperService/FetchRaw"
+	ScraperService_HealthCheck_FullMethodName     = "/scraper.ScraperService/HealthCheck"
+	ScraperService_Login_FullMethodName           = "/scraper.ScraperService/Login"
+	ScraperService_CheckCredential_FullMethodName = "/scraper.ScraperService/CheckCredential"
+	ScraperService_ExtractLinks_FullMethodName    = "/scraper.ScraperService/ExtractLinks"
+	ScraperService_Scrape_FullMethodName          = "/scraper.ScraperService/Scrape"
+	ScraperService_FetchFeed_FullMethodName       = "/scraper.ScraperService/FetchFeed"
+	ScraperService_DiscoverFeeds_FullMethodName   = "/scraper.ScraperService/DiscoverFeeds"
+	ScraperService_StreamSitemap_FullMethodName   = "/scraper.ScraperService/StreamSitemap"
+	ScraperService_StartCrawl_FullMethodName      = "/scraper.ScraperService/StartCrawl"
+	ScraperService_ResumeCrawl_FullMethodName     = "/scraper.ScraperService/ResumeCrawl"
+	ScraperService_PauseCrawl_FullMethodName      = "/scraper.ScraperService/PauseCrawl"
+	ScraperService_CancelCrawl_FullMethodName     = "/scraper.ScraperService/CancelCrawl"
+	ScraperService_GetCrawl_FullMethodName        = "/scraper.ScraperService/GetCrawl"
+	ScraperService_FindSimilar_FullMethodName     = "/scraper.ScraperService/FindSimilar"
+	ScraperService_CheckRevision_FullMethodName   = "/scraper.ScraperService/CheckRevision"
+	ScraperService_Summarize_FullMethodName       = "/scraper.ScraperService/Summarize"
 )
 
 // ScraperServiceClient is the client API for ScraperService service.
@@ -39,6 +54,36 @@ type ScraperServiceClient interface {
 	FetchRaw(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchRawResponse, error)
 	// 健康检查
 	HealthCheck(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthResponse, error)
+	// 原生表单登录（按 SiteCredential.loginSelectors 提交登录表单，返回 Cookie）
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// 凭证有效性检测（Cookie / Token 是否仍处于登录状态）
+	CheckCredential(ctx context.Context, in *CredentialCheckRequest, opts ...grpc.CallOption) (*CredentialCheckResponse, error)
+	// 链接提取（抓取页面并返回全部链接，供站点爬取发现新 URL）
+	ExtractLinks(ctx context.Context, in *LinksRequest, opts ...grpc.CallOption) (*LinksResponse, error)
+	// 列表页抓取（按 CSS 选择器提取条目，支持翻页）
+	Scrape(ctx context.Context, in *ScrapeRequest, opts ...grpc.CallOption) (*ScrapeResponse, error)
+	// 订阅源解析（RSS 0.9x / 1.0 / 2.0、Atom、JSON Feed）
+	FetchFeed(ctx context.Context, in *FeedRequest, opts ...grpc.CallOption) (*FeedResponse, error)
+	// 订阅源自动发现（<link rel="alternate">、常见路径、robots.txt 和 sitemap，逐个验证后排序）
+	DiscoverFeeds(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*DiscoverResponse, error)
+	// sitemap 遍历（递归展开 sitemapindex，流式返回发现的 URL，最后一条为汇总）
+	StreamSitemap(ctx context.Context, in *SitemapRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SitemapEvent], error)
+	// 站点爬取（广度优先，流式返回发现 / 抓取的 URL 和提取出的文章；客户端断开时自动暂停）
+	StartCrawl(ctx context.Context, in *CrawlRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CrawlEvent], error)
+	// 恢复已暂停的爬取，从断点继续流式返回
+	ResumeCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CrawlEvent], error)
+	// 暂停爬取（正在进行的流以 paused 事件结束）
+	PauseCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (*CrawlStatus, error)
+	// 取消爬取（不可恢复）
+	CancelCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (*CrawlStatus, error)
+	// 查询爬取状态
+	GetCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (*CrawlStatus, error)
+	// 近似重复检测：比较指纹或文本，返回近似重复的内容对和聚类
+	FindSimilar(ctx context.Context, in *SimilarRequest, opts ...grpc.CallOption) (*SimilarResponse, error)
+	// 文章修订检测：重新抓取并提取，与之前保存的内容哈希或文本比较，返回是否变化和段落级差异
+	CheckRevision(ctx context.Context, in *RevisionRequest, opts ...grpc.CallOption) (*RevisionResponse, error)
+	// 抽取式摘要和关键词提取（TextRank，离线，可作为 AI 摘要的兜底）
+	Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (*SummarizeResponse, error)
 }
 
 type scraperServiceClient struct {
@@ -92,6 +137,183 @@ func (c *scraperServiceClient) HealthCheck(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *scraperServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, ScraperService_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) CheckCredential(ctx context.Context, in *CredentialCheckRequest, opts ...grpc.CallOption) (*CredentialCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CredentialCheckResponse)
+	err := c.cc.Invoke(ctx, ScraperService_CheckCredential_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) ExtractLinks(ctx context.Context, in *LinksRequest, opts ...grpc.CallOption) (*LinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinksResponse)
+	err := c.cc.Invoke(ctx, ScraperService_ExtractLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) Scrape(ctx context.Context, in *ScrapeRequest, opts ...grpc.CallOption) (*ScrapeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScrapeResponse)
+	err := c.cc.Invoke(ctx, ScraperService_Scrape_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) FetchFeed(ctx context.Context, in *FeedRequest, opts ...grpc.CallOption) (*FeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedResponse)
+	err := c.cc.Invoke(ctx, ScraperService_FetchFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) DiscoverFeeds(ctx context.Context, in *DiscoverRequest, opts ...grpc.CallOption) (*DiscoverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscoverResponse)
+	err := c.cc.Invoke(ctx, ScraperService_DiscoverFeeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) StreamSitemap(ctx context.Context, in *SitemapRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SitemapEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScraperService_ServiceDesc.Streams[1], ScraperService_StreamSitemap_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SitemapRequest, SitemapEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScraperService_StreamSitemapClient = grpc.ServerStreamingClient[SitemapEvent]
+
+func (c *scraperServiceClient) StartCrawl(ctx context.Context, in *CrawlRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CrawlEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScraperService_ServiceDesc.Streams[2], ScraperService_StartCrawl_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CrawlRequest, CrawlEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScraperService_StartCrawlClient = grpc.ServerStreamingClient[CrawlEvent]
+
+func (c *scraperServiceClient) ResumeCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CrawlEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScraperService_ServiceDesc.Streams[3], ScraperService_ResumeCrawl_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CrawlControlRequest, CrawlEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScraperService_ResumeCrawlClient = grpc.ServerStreamingClient[CrawlEvent]
+
+func (c *scraperServiceClient) PauseCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (*CrawlStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrawlStatus)
+	err := c.cc.Invoke(ctx, ScraperService_PauseCrawl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) CancelCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (*CrawlStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrawlStatus)
+	err := c.cc.Invoke(ctx, ScraperService_CancelCrawl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) GetCrawl(ctx context.Context, in *CrawlControlRequest, opts ...grpc.CallOption) (*CrawlStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrawlStatus)
+	err := c.cc.Invoke(ctx, ScraperService_GetCrawl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) FindSimilar(ctx context.Context, in *SimilarRequest, opts ...grpc.CallOption) (*SimilarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimilarResponse)
+	err := c.cc.Invoke(ctx, ScraperService_FindSimilar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) CheckRevision(ctx context.Context, in *RevisionRequest, opts ...grpc.CallOption) (*RevisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevisionResponse)
+	err := c.cc.Invoke(ctx, ScraperService_CheckRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scraperServiceClient) Summarize(ctx context.Context, in *SummarizeRequest, opts ...grpc.CallOption) (*SummarizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SummarizeResponse)
+	err := c.cc.Invoke(ctx, ScraperService_Summarize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScraperServiceServer is the server API for ScraperService service.
 // All implementations must embed UnimplementedScraperServiceServer
 // for forward compatibility.
@@ -106,6 +328,36 @@ type ScraperServiceServer interface {
 	FetchRaw(context.Context, *FetchRequest) (*FetchRawResponse, error)
 	// 健康检查
 	HealthCheck(context.Context, *Empty) (*HealthResponse, error)
+	// 原生表单登录（按 SiteCredential.loginSelectors 提交登录表单，返回 Cookie）
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// 凭证有效性检测（Cookie / Token 是否仍处于登录状态）
+	CheckCredential(context.Context, *CredentialCheckRequest) (*CredentialCheckResponse, error)
+	// 链接提取（抓取页面并返回全部链接，供站点爬取发现新 URL）
+	ExtractLinks(context.Context, *LinksRequest) (*LinksResponse, error)
+	// 列表页抓取（按 CSS 选择器提取条目，支持翻页）
+	Scrape(context.Context, *ScrapeRequest) (*ScrapeResponse, error)
+	// 订阅源解析（RSS 0.9x / 1.0 / 2.0、Atom、JSON Feed）
+	FetchFeed(context.Context, *FeedRequest) (*FeedResponse, error)
+	// 订阅源自动发现（<link rel="alternate">、常见路径、robots.txt 和 sitemap，逐个验证后排序）
+	DiscoverFeeds(context.Context, *DiscoverRequest) (*DiscoverResponse, error)
+	// sitemap 遍历（递归展开 sitemapindex，流式返回发现的 URL，最后一条为汇总）
+	StreamSitemap(*SitemapRequest, grpc.ServerStreamingServer[SitemapEvent]) error
+	// 站点爬取（广度优先，流式返回发现 / 抓取的 URL 和提取出的文章；客户端断开时自动暂停）
+	StartCrawl(*CrawlRequest, grpc.ServerStreamingServer[CrawlEvent]) error
+	// 恢复已暂停的爬取，从断点继续流式返回
+	ResumeCrawl(*CrawlControlRequest, grpc.ServerStreamingServer[CrawlEvent]) error
+	// 暂停爬取（正在进行的流以 paused 事件结束）
+	PauseCrawl(context.Context, *CrawlControlRequest) (*CrawlStatus, error)
+	// 取消爬取（不可恢复）
+	CancelCrawl(context.Context, *CrawlControlRequest) (*CrawlStatus, error)
+	// 查询爬取状态
+	GetCrawl(context.Context, *CrawlControlRequest) (*CrawlStatus, error)
+	// 近似重复检测：比较指纹或文本，返回近似重复的内容对和聚类
+	FindSimilar(context.Context, *SimilarRequest) (*SimilarResponse, error)
+	// 文章修订检测：重新抓取并提取，与之前保存的内容哈希或文本比较，返回是否变化和段落级差异
+	CheckRevision(context.Context, *RevisionRequest) (*RevisionResponse, error)
+	// 抽取式摘要和关键词提取（TextRank，离线，可作为 AI 摘要的兜底）
+	Summarize(context.Context, *SummarizeRequest) (*SummarizeResponse, error)
 	mustEmbedUnimplementedScraperServiceServer()
 }
 
@@ -128,6 +380,51 @@ func (UnimplementedScraperServiceServer) FetchRaw(context.Context, *FetchRequest
 func (UnimplementedScraperServiceServer) HealthCheck(context.Context, *Empty) (*HealthResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method HealthCheck not implemented")
 }
+func (UnimplementedScraperServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedScraperServiceServer) CheckCredential(context.Context, *CredentialCheckRequest) (*CredentialCheckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckCredential not implemented")
+}
+func (UnimplementedScraperServiceServer) ExtractLinks(context.Context, *LinksRequest) (*LinksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExtractLinks not implemented")
+}
+func (UnimplementedScraperServiceServer) Scrape(context.Context, *ScrapeRequest) (*ScrapeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Scrape not implemented")
+}
+func (UnimplementedScraperServiceServer) FetchFeed(context.Context, *FeedRequest) (*FeedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FetchFeed not implemented")
+}
+func (UnimplementedScraperServiceServer) DiscoverFeeds(context.Context, *DiscoverRequest) (*DiscoverResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscoverFeeds not implemented")
+}
+func (UnimplementedScraperServiceServer) StreamSitemap(*SitemapRequest, grpc.ServerStreamingServer[SitemapEvent]) error {
+	return status.Error(codes.Unimplemented, "method StreamSitemap not implemented")
+}
+func (UnimplementedScraperServiceServer) StartCrawl(*CrawlRequest, grpc.ServerStreamingServer[CrawlEvent]) error {
+	return status.Error(codes.Unimplemented, "method StartCrawl not implemented")
+}
+func (UnimplementedScraperServiceServer) ResumeCrawl(*CrawlControlRequest, grpc.ServerStreamingServer[CrawlEvent]) error {
+	return status.Error(codes.Unimplemented, "method ResumeCrawl not implemented")
+}
+func (UnimplementedScraperServiceServer) PauseCrawl(context.Context, *CrawlControlRequest) (*CrawlStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseCrawl not implemented")
+}
+func (UnimplementedScraperServiceServer) CancelCrawl(context.Context, *CrawlControlRequest) (*CrawlStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelCrawl not implemented")
+}
+func (UnimplementedScraperServiceServer) GetCrawl(context.Context, *CrawlControlRequest) (*CrawlStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCrawl not implemented")
+}
+func (UnimplementedScraperServiceServer) FindSimilar(context.Context, *SimilarRequest) (*SimilarResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindSimilar not implemented")
+}
+func (UnimplementedScraperServiceServer) CheckRevision(context.Context, *RevisionRequest) (*RevisionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckRevision not implemented")
+}
+func (UnimplementedScraperServiceServer) Summarize(context.Context, *SummarizeRequest) (*SummarizeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Summarize not implemented")
+}
 func (UnimplementedScraperServiceServer) mustEmbedUnimplementedScraperServiceServer() {}
 func (UnimplementedScraperServiceServer) testEmbeddedByValue()                        {}
 
@@ -210,6 +507,255 @@ func _ScraperService_HealthCheck_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScraperService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_CheckCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CredentialCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).CheckCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_CheckCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).CheckCredential(ctx, req.(*CredentialCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_ExtractLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).ExtractLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_ExtractLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).ExtractLinks(ctx, req.(*LinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_Scrape_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScrapeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).Scrape(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_Scrape_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).Scrape(ctx, req.(*ScrapeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_FetchFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).FetchFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_FetchFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).FetchFeed(ctx, req.(*FeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_DiscoverFeeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).DiscoverFeeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_DiscoverFeeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).DiscoverFeeds(ctx, req.(*DiscoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_StreamSitemap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SitemapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScraperServiceServer).StreamSitemap(m, &grpc.GenericServerStream[SitemapRequest, SitemapEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScraperService_StreamSitemapServer = grpc.ServerStreamingServer[SitemapEvent]
+
+func _ScraperService_StartCrawl_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CrawlRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScraperServiceServer).StartCrawl(m, &grpc.GenericServerStream[CrawlRequest, CrawlEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScraperService_StartCrawlServer = grpc.ServerStreamingServer[CrawlEvent]
+
+func _ScraperService_ResumeCrawl_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CrawlControlRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScraperServiceServer).ResumeCrawl(m, &grpc.GenericServerStream[CrawlControlRequest, CrawlEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScraperService_ResumeCrawlServer = grpc.ServerStreamingServer[CrawlEvent]
+
+func _ScraperService_PauseCrawl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrawlControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).PauseCrawl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_PauseCrawl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).PauseCrawl(ctx, req.(*CrawlControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_CancelCrawl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrawlControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).CancelCrawl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_CancelCrawl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).CancelCrawl(ctx, req.(*CrawlControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_GetCrawl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrawlControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).GetCrawl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_GetCrawl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).GetCrawl(ctx, req.(*CrawlControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_FindSimilar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimilarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).FindSimilar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_FindSimilar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).FindSimilar(ctx, req.(*SimilarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_CheckRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).CheckRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_CheckRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).CheckRevision(ctx, req.(*RevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScraperService_Summarize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScraperServiceServer).Summarize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScraperService_Summarize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScraperServiceServer).Summarize(ctx, req.(*SummarizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScraperService_ServiceDesc is the grpc.ServiceDesc for ScraperService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +775,54 @@ var ScraperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HealthCheck",
 			Handler:    _ScraperService_HealthCheck_Handler,
 		},
+		{
+			MethodName: "Login",
+			Handler:    _ScraperService_Login_Handler,
+		},
+		{
+			MethodName: "CheckCredential",
+			Handler:    _ScraperService_CheckCredential_Handler,
+		},
+		{
+			MethodName: "ExtractLinks",
+			Handler:    _ScraperService_ExtractLinks_Handler,
+		},
+		{
+			MethodName: "Scrape",
+			Handler:    _ScraperService_Scrape_Handler,
+		},
+		{
+			MethodName: "FetchFeed",
+			Handler:    _ScraperService_FetchFeed_Handler,
+		},
+		{
+			MethodName: "DiscoverFeeds",
+			Handler:    _ScraperService_DiscoverFeeds_Handler,
+		},
+		{
+			MethodName: "PauseCrawl",
+			Handler:    _ScraperService_PauseCrawl_Handler,
+		},
+		{
+			MethodName: "CancelCrawl",
+			Handler:    _ScraperService_CancelCrawl_Handler,
+		},
+		{
+			MethodName: "GetCrawl",
+			Handler:    _ScraperService_GetCrawl_Handler,
+		},
+		{
+			MethodName: "FindSimilar",
+			Handler:    _ScraperService_FindSimilar_Handler,
+		},
+		{
+			MethodName: "CheckRevision",
+			Handler:    _ScraperService_CheckRevision_Handler,
+		},
+		{
+			MethodName: "Summarize",
+			Handler:    _ScraperService_Summarize_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -237,6 +831,21 @@ var ScraperService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamSitemap",
+			Handler:       _ScraperService_StreamSitemap_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StartCrawl",
+			Handler:       _ScraperService_StartCrawl_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ResumeCrawl",
+			Handler:       _ScraperService_ResumeCrawl_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "scraper.proto",
 }
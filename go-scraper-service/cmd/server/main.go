@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // 运行镜像（alpine）不含时区数据库，DEFAULT_TIMEZONE 依赖内嵌数据
 
 	"google.golang.org/grpc"
 
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// 凭证状态
+const (
+	// CredentialValid 凭证有效（确认处于登录状态）
+	CredentialValid = "valid"
+	// CredentialExpired 凭证已失效（被重定向到登录页、返回 401、登录标识缺失等）
+	CredentialExpired = "expired"
+	// CredentialUnknown 无法判断（网络错误、服务端错误、未配置规则等）
+	CredentialUnknown = "unknown"
+)
+
+// defaultLoginURLPattern 默认的登录页 URL 特征
+var defaultLoginURLPattern = regexp.MustCompile(`(?i)(login|signin|sign-in|sign_in|passport|/sso/|/auth/|logon)`)
+
+// CheckRule 站点的登录状态判断规则
+//
+// 三项规则可以组合使用，配置了的正向规则（LoggedInSelector、ExpectedText）必须全部满足才判定为有效。
+type CheckRule struct {
+	// 登录后才会出现的元素选择器（如头像），与 loginSelectors.successIndicator 含义一致
+	LoggedInSelector string `json:"loggedInSelector,omitempty"`
+	// 登录页 URL 正则，请求被重定向到匹配的 URL 即判定失效（为空时使用内置规则）
+	LoginURLPattern string `json:"loginUrlPattern,omitempty"`
+	// 登录后页面中应包含的文本（如「退出登录」、用户名）
+	ExpectedText string `json:"expectedText,omitempty"`
+}
+
+// CheckRequest 凭证有效性检测请求
+type CheckRequest struct {
+	// 检测 URL（需要登录才能访问的页面或接口）
+	URL string
+	// 附带的 Headers（Cookie、Authorization 等），重定向离开检测 URL 的域名后不再携带 Cookie / Authorization
+	Headers map[string]string
+	Rule    CheckRule
+	// 抓取策略（cycletls, standard），为空时优先 CycleTLS
+	Strategy string
+	// 解密后的凭证 Headers，只发往 CredentialDomain 及其子域名（每一跳重新判断）
+	CredentialHeaders map[string]string
+	CredentialDomain  string
+}
+
+// CheckResult 凭证有效性检测结果
+type CheckResult struct {
+	Status     string   // valid, expired, unknown
+	Evidence   []string // 判断依据（不包含任何凭证内容）
+	FinalURL   string
+	StatusCode int
+}
+
+// CredentialChecker 凭证有效性探测器
+//
+// 只发起一次 GET 请求（手动跟随重定向），依据重定向链、状态码和页面内容判断
+// Cookie / Token 是否仍然有效，供 Node.js 端定时任务低成本调用。
+type CredentialChecker struct {
+	doer Doer
+}
+
+// NewCredentialChecker 创建凭证有效性探测器
+func NewCredentialChecker(doer Doer) *CredentialChecker {
+	return &CredentialChecker{doer: doer}
+}
+
+// Check 检测凭证有效性
+//
+// 判断顺序：
+//  1. 请求失败 → unknown
+//  2. 重定向到登录页 → expired
+//  3. HTTP 401 → expired；其他 4xx/5xx → unknown（403 常见于 WAF 拦截，不能据此判定失效）
+//  4. 正向规则（LoggedInSelector / ExpectedText）全部满足 → valid，任一不满足 → expired
+//  5. 未配置正向规则：页面包含密码输入框 → expired，否则 → unknown
+//
+// 返回的 error 只表示规则本身无效（如 LoginURLPattern 不是合法正则）。
+func (c *CredentialChecker) Check(ctx context.Context, req *CheckRequest) (*CheckResult, error) {
+	loginPattern := defaultLoginURLPattern
+	if req.Rule.LoginURLPattern != "" {
+		pattern, err := regexp.Compile(req.Rule.LoginURLPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid loginUrlPattern: %w", err)
+		}
+		loginPattern = pattern
+	}
+
+	result := &CheckResult{Status: CredentialUnknown}
+
+	// 1. 请求
+	session := NewSession(c.doer, fetcher.Options{
+		Strategy:          req.Strategy,
+		Headers:           req.Headers,
+		CredentialHeaders: req.CredentialHeaders,
+		CredentialDomain:  req.CredentialDomain,
+	})
+	page, err := session.Get(ctx, req.URL, nil)
+	if err != nil {
+		result.Evidence = append(result.Evidence, "request failed: "+err.Error())
+		return result, nil
+	}
+	result.FinalURL = page.URL
+	result.StatusCode = page.StatusCode
+
+	// 2. 重定向到登录页
+	for _, redirect := range page.Redirects {
+		if loginPattern.MatchString(redirect) {
+			result.Status = CredentialExpired
+			result.Evidence = append(result.Evidence, "redirected to login page: "+redirect)
+			return result, nil
+		}
+	}
+
+	// 3. 状态码
+	switch {
+	case page.StatusCode == http.StatusUnauthorized:
+		result.Status = CredentialExpired
+		result.Evidence = append(result.Evidence, "HTTP 401 Unauthorized")
+		return result, nil
+	case page.StatusCode >= 400:
+		result.Evidence = append(result.Evidence, fmt.Sprintf("HTTP %d, cannot determine login state", page.StatusCode))
+		return result, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page.Body))
+	if err != nil {
+		result.Evidence = append(result.Evidence, "failed to parse response body")
+		return result, nil
+	}
+
+	// 4. 正向规则
+	checked, passed := 0, 0
+	if selector := req.Rule.LoggedInSelector; selector != "" {
+		checked++
+		if doc.Find(selector).Length() > 0 {
+			passed++
+			result.Evidence = append(result.Evidence, fmt.Sprintf("logged-in marker %q found", selector))
+		} else {
+			result.Evidence = append(result.Evidence, fmt.Sprintf("logged-in marker %q not found", selector))
+		}
+	}
+	if text := req.Rule.ExpectedText; text != "" {
+		checked++
+		if strings.Contains(page.Body, text) {
+			passed++
+			result.Evidence = append(result.Evidence, fmt.Sprintf("expected text %q found", text))
+		} else {
+			result.Evidence = append(result.Evidence, fmt.Sprintf("expected text %q not found", text))
+		}
+	}
+	if checked > 0 {
+		if passed == checked {
+			result.Status = CredentialValid
+		} else {
+			result.Status = CredentialExpired
+		}
+		return result, nil
+	}
+
+	// 5. 无正向规则时的兜底判断
+	if doc.Find(`input[type="password"]`).Length() > 0 {
+		result.Status = CredentialExpired
+		result.Evidence = append(result.Evidence, "page contains a password input (login form)")
+		return result, nil
+	}
+	result.Evidence = append(result.Evidence, fmt.Sprintf("HTTP %d without login redirect, but no logged-in rule configured", page.StatusCode))
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/newsflow/go-scraper-service/internal/config"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+func TestCredentialChecker(t *testing.T) {
+	site := newFakeLoginSite(t)
+	defer site.Close()
+
+	checker := NewCredentialChecker(fetcher.NewStandardClient(config.DefaultConfig()))
+
+	tests := []struct {
+		name     string
+		cookie   string
+		rule     CheckRule
+		expected string
+	}{
+		{
+			name:     "有效 Cookie + 登录标识",
+			cookie:   "user_session=token-abc",
+			rule:     CheckRule{LoggedInSelector: ".avatar"},
+			expected: CredentialValid,
+		},
+		{
+			name:     "有效 Cookie + 期望文本",
+			cookie:   "user_session=token-abc",
+			rule:     CheckRule{ExpectedText: "alice"},
+			expected: CredentialValid,
+		},
+		{
+			name:     "过期 Cookie 被重定向到登录页",
+			cookie:   "user_session=stale",
+			rule:     CheckRule{LoggedInSelector: ".avatar"},
+			expected: CredentialExpired,
+		},
+		{
+			name:     "自定义登录页规则不匹配时依据登录标识判断",
+			cookie:   "user_session=stale",
+			rule:     CheckRule{LoginURLPattern: `/passport/`, LoggedInSelector: ".avatar"},
+			expected: CredentialExpired,
+		},
+		{
+			name:     "未配置规则",
+			cookie:   "user_session=token-abc",
+			rule:     CheckRule{LoginURLPattern: `/passport/`},
+			expected: CredentialUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checker.Check(context.Background(), &CheckRequest{
+				URL:     site.URL + "/account",
+				Headers: map[string]string{"Cookie": tt.cookie},
+				Rule:    tt.rule,
+			})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if result.Status != tt.expected {
+				t.Errorf("Status = %q, want %q (evidence: %v)", result.Status, tt.expected, result.Evidence)
+			}
+			if len(result.Evidence) == 0 {
+				t.Error("Evidence 不应为空")
+			}
+		})
+	}
+}
+
+func TestCredentialCheckerCrossDomainRedirect(t *testing.T) {
+	// 第三方站点（localhost）记录收到的身份 Headers
+	var thirdParty http.Header
+	sso := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		thirdParty = r.Header.Clone()
+		w.Write([]byte(`<html><body><form><input type="password"></form></body></html>`))
+	}))
+	defer sso.Close()
+	ssoURL := strings.Replace(sso.URL, "127.0.0.1", "localhost", 1)
+
+	var origin http.Header
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin = r.Header.Clone()
+		http.Redirect(w, r, ssoURL+"/sso/login", http.StatusFound)
+	}))
+	defer site.Close()
+
+	checker := NewCredentialChecker(fetcher.NewStandardClient(config.DefaultConfig()))
+	result, err := checker.Check(context.Background(), &CheckRequest{
+		URL:               site.URL + "/account",
+		Headers:           map[string]string{"Cookie": "lang=zh", "Authorization": "Bearer caller-token"},
+		CredentialHeaders: map[string]string{"Cookie": "user_session=secret"},
+		CredentialDomain:  "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if result.Status != CredentialExpired {
+		t.Errorf("Status = %q, want %q (evidence: %v)", result.Status, CredentialExpired, result.Evidence)
+	}
+	if got := origin.Get("Cookie"); got != "lang=zh; user_session=secret" || origin.Get("Authorization") == "" {
+		t.Errorf("起始站点 Cookie = %q, Authorization = %q", got, origin.Get("Authorization"))
+	}
+	if thirdParty == nil {
+		t.Fatal("未跟随跨域重定向")
+	}
+	if thirdParty.Get("Cookie") != "" || thirdParty.Get("Authorization") != "" {
+		t.Errorf("跨域重定向携带了凭证: Cookie = %q, Authorization = %q", thirdParty.Get("Cookie"), thirdParty.Get("Authorization"))
+	}
+}
@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// 与 Node.js 端 src/lib/auth/credential-crypto.ts 保持一致的加密参数
+const (
+	// credentialSalt scrypt 派生密钥使用的盐
+	credentialSalt = "newsflow-credential-salt"
+	// credentialIVSize IV 长度（Node.js 端使用 randomBytes(16)）
+	credentialIVSize = 16
+	// credentialTagSize GCM 认证标签长度
+	credentialTagSize = 16
+)
+
+// 凭证解密错误
+//
+// 错误信息中不包含任何密文或明文，可以安全地返回给调用方和写入日志。
+var (
+	ErrCredentialNotConfigured  = errors.New("credential decryption is not configured (CREDENTIAL_SECRET not set)")
+	ErrInvalidCredentialFormat  = errors.New("invalid encrypted credential format")
+	ErrCredentialDecryption     = errors.New("credential decryption failed")
+	ErrCredentialMissing        = errors.New("credential reference has no usable secret for its authType")
+	ErrCredentialDomainMismatch = errors.New("credential domain does not match request URL")
+)
+
+// 凭证认证方式（与 SiteCredential.authType 一致）
+const (
+	AuthTypeCookie = "cookie"
+	AuthTypeLogin  = "login"
+	AuthTypeToken  = "token"
+)
+
+// CredentialRef 加密凭证引用
+//
+// 调用方直接传递 SiteCredential 中的加密字段，由 Go 服务解密后使用，
+// 明文凭证不再经过网络传输，也不会出现在调用方的请求日志中。
+type CredentialRef struct {
+	Domain            string `json:"domain,omitempty"`
+	AuthType          string `json:"authType"` // cookie, login, token
+	EncryptedCookie   string `json:"encryptedCookie,omitempty"`
+	EncryptedToken    string `json:"encryptedToken,omitempty"`
+	EncryptedUsername string `json:"encryptedUsername,omitempty"`
+	EncryptedPassword string `json:"encryptedPassword,omitempty"`
+}
+
+// CredentialCipher 凭证加解密器（AES-256-GCM）
+//
+// 密钥由 CREDENTIAL_SECRET 经 scrypt（N=16384, r=8, p=1，与 Node.js scryptSync 默认值一致）派生，
+// 密文格式为 hex(iv):hex(authTag):hex(ciphertext)。
+type CredentialCipher struct {
+	aead cipher.AEAD
+}
+
+// NewCredentialCipher 创建凭证加解密器
+//
+// secret 为空时返回 ErrCredentialNotConfigured。
+func NewCredentialCipher(secret string) (*CredentialCipher, error) {
+	if secret == "" {
+		return nil, ErrCredentialNotConfigured
+	}
+	key, err := scrypt.Key([]byte(secret), []byte(credentialSalt), 16384, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, credentialIVSize)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialCipher{aead: aead}, nil
+}
+
+// Encrypt 加密凭证，输出格式与 Node.js 端 encryptCredential 一致
+func (c *CredentialCipher) Encrypt(plaintext string) (string, error) {
+	iv := make([]byte, credentialIVSize)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nil, iv, []byte(plaintext), nil)
+	ciphertext, tag := sealed[:len(sealed)-credentialTagSize], sealed[len(sealed)-credentialTagSize:]
+	return hex.EncodeToString(iv) + ":" + hex.EncodeToString(tag) + ":" + hex.EncodeToString(ciphertext), nil
+}
+
+// Decrypt 解密 Node.js 端 encryptCredential 生成的密文
+func (c *CredentialCipher) Decrypt(encrypted string) (string, error) {
+	parts := strings.Split(encrypted, ":")
+	if len(parts) != 3 {
+		return "", ErrInvalidCredentialFormat
+	}
+	iv, err := hex.DecodeString(parts[0])
+	if err != nil || len(iv) != credentialIVSize {
+		return "", ErrInvalidCredentialFormat
+	}
+	tag, err := hex.DecodeString(parts[1])
+	if err != nil || len(tag) != credentialTagSize {
+		return "", ErrInvalidCredentialFormat
+	}
+	ciphertext, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrInvalidCredentialFormat
+	}
+
+	plaintext, err := c.aead.Open(nil, iv, append(ciphertext, tag...), nil)
+	if err != nil {
+		return "", ErrCredentialDecryption
+	}
+	return string(plaintext), nil
+}
+
+// ApplyHeaders 解密凭证并写入请求 Headers
+//
+// 按 authType 应用：
+//   - cookie / login: encryptedCookie → Cookie（与已有 Cookie 合并）
+//   - token: encryptedToken → Authorization: Bearer <token>（token 自带认证方案时原样使用）
+//
+// 返回新的 Headers，不修改传入的 map。
+func (c *CredentialCipher) ApplyHeaders(ref *CredentialRef, headers map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		result[k] = v
+	}
+
+	switch ref.AuthType {
+	case AuthTypeToken:
+		if ref.EncryptedToken == "" {
+			return nil, ErrCredentialMissing
+		}
+		token, err := c.Decrypt(ref.EncryptedToken)
+		if err != nil {
+			return nil, err
+		}
+		token = strings.TrimSpace(token)
+		if !strings.Contains(token, " ") {
+			token = "Bearer " + token
+		}
+		setHeader(result, "Authorization", token)
+	case AuthTypeCookie, AuthTypeLogin, "":
+		if ref.EncryptedCookie == "" {
+			return nil, ErrCredentialMissing
+		}
+		cookie, err := c.Decrypt(ref.EncryptedCookie)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range result {
+			if strings.EqualFold(k, "Cookie") {
+				delete(result, k)
+				if v != "" {
+					cookie = v + "; " + cookie
+				}
+			}
+		}
+		result["Cookie"] = strings.TrimSpace(cookie)
+	default:
+		return nil, ErrCredentialMissing
+	}
+
+	return result, nil
+}
+
+// Resolve 解析凭证引用，返回凭证 Headers 及其作用域名
+//
+// 凭证未指定域名时以目标 URL 的主机名为作用域；指定了域名但与目标 URL 不符时
+// 返回 ErrCredentialDomainMismatch，防止凭证被发送到其他站点。
+// 接收者为 nil（服务未配置 CREDENTIAL_SECRET）时返回 ErrCredentialNotConfigured。
+func (c *CredentialCipher) Resolve(ref *CredentialRef, targetURL string) (map[string]string, string, error) {
+	if c == nil {
+		return nil, "", ErrCredentialNotConfigured
+	}
+
+	domain := ref.Domain
+	if domain == "" {
+		u, err := url.Parse(targetURL)
+		if err != nil || u.Hostname() == "" {
+			return nil, "", ErrCredentialDomainMismatch
+		}
+		domain = u.Hostname()
+	} else if !fetcher.MatchesDomain(domain, targetURL) {
+		return nil, "", ErrCredentialDomainMismatch
+	}
+
+	headers, err := c.ApplyHeaders(ref, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return headers, domain, nil
+}
+
+// DecryptLogin 解密登录账号和密码
+func (c *CredentialCipher) DecryptLogin(ref *CredentialRef) (string, string, error) {
+	if ref.EncryptedUsername == "" || ref.EncryptedPassword == "" {
+		return "", "", ErrCredentialMissing
+	}
+	username, err := c.Decrypt(ref.EncryptedUsername)
+	if err != nil {
+		return "", "", err
+	}
+	password, err := c.Decrypt(ref.EncryptedPassword)
+	if err != nil {
+		return "", "", err
+	}
+	return username, password, nil
+}
+
+// setHeader 设置 Header（替换已有的同名 Header，不区分大小写）
+func setHeader(headers map[string]string, name, value string) {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			delete(headers, k)
+		}
+	}
+	headers[name] = value
+}
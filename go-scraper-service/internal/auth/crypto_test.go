@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// nodeEncrypted 由 Node.js 端 encryptCredential 生成（CREDENTIAL_SECRET=test-secret，固定 IV）
+const nodeEncrypted = "07070707070707070707070707070707:d05b95e59b4cfb66d9538306d37f6f1d:8427fdfbd0f026602b1f57abc9d9ba9f0f972cfd64f683030025244313fac9"
+
+func TestCredentialCipherNodeCompatibility(t *testing.T) {
+	c, err := NewCredentialCipher("test-secret")
+	if err != nil {
+		t.Fatalf("NewCredentialCipher() error = %v", err)
+	}
+
+	plaintext, err := c.Decrypt(nodeEncrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if plaintext != "sessionid=abc123; csrftoken=xyz" {
+		t.Errorf("Decrypt() = %q", plaintext)
+	}
+
+	// 往返加解密
+	encrypted, err := c.Encrypt("token-value")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if decrypted, _ := c.Decrypt(encrypted); decrypted != "token-value" {
+		t.Errorf("round trip = %q", decrypted)
+	}
+
+	// 错误密钥：错误信息不能泄露任何密文或明文
+	wrong, _ := NewCredentialCipher("other-secret")
+	_, err = wrong.Decrypt(nodeEncrypted)
+	if !errors.Is(err, ErrCredentialDecryption) {
+		t.Errorf("wrong key error = %v", err)
+	}
+	if strings.Contains(err.Error(), "abc123") || strings.Contains(err.Error(), "8427fd") {
+		t.Errorf("error leaks secret: %v", err)
+	}
+}
+
+func TestApplyHeaders(t *testing.T) {
+	c, _ := NewCredentialCipher("test-secret")
+	token, _ := c.Encrypt("tok123")
+
+	headers, err := c.ApplyHeaders(&CredentialRef{AuthType: AuthTypeCookie, EncryptedCookie: nodeEncrypted},
+		map[string]string{"cookie": "lang=zh"})
+	if err != nil {
+		t.Fatalf("ApplyHeaders(cookie) error = %v", err)
+	}
+	if headers["Cookie"] != "lang=zh; sessionid=abc123; csrftoken=xyz" {
+		t.Errorf("Cookie = %q", headers["Cookie"])
+	}
+
+	headers, err = c.ApplyHeaders(&CredentialRef{AuthType: AuthTypeToken, EncryptedToken: token}, nil)
+	if err != nil {
+		t.Fatalf("ApplyHeaders(token) error = %v", err)
+	}
+	if headers["Authorization"] != "Bearer tok123" {
+		t.Errorf("Authorization = %q", headers["Authorization"])
+	}
+
+	if _, err := c.ApplyHeaders(&CredentialRef{AuthType: AuthTypeToken}, nil); !errors.Is(err, ErrCredentialMissing) {
+		t.Errorf("missing token error = %v", err)
+	}
+}
+
+func TestResolveDomainScope(t *testing.T) {
+	c, _ := NewCredentialCipher("test-secret")
+	ref := &CredentialRef{Domain: "example.com", AuthType: AuthTypeCookie, EncryptedCookie: nodeEncrypted}
+
+	headers, domain, err := c.Resolve(ref, "https://www.example.com/a")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	opts := fetcher.Options{Headers: map[string]string{"Cookie": "lang=zh"}, CredentialHeaders: headers, CredentialDomain: domain}
+	if got := opts.HeadersFor("https://news.example.com/b")["Cookie"]; got != "lang=zh; sessionid=abc123; csrftoken=xyz" {
+		t.Errorf("子域名 Cookie = %q", got)
+	}
+	if got := opts.HeadersFor("https://cdn.other.com/c")["Cookie"]; got != "lang=zh" {
+		t.Errorf("第三方域名不应携带凭证, Cookie = %q", got)
+	}
+
+	if _, _, err := c.Resolve(ref, "https://evil.com/"); !errors.Is(err, ErrCredentialDomainMismatch) {
+		t.Errorf("domain mismatch error = %v", err)
+	}
+
+	var unconfigured *CredentialCipher
+	if _, _, err := unconfigured.Resolve(ref, "https://example.com/"); !errors.Is(err, ErrCredentialNotConfigured) {
+		t.Errorf("unconfigured error = %v", err)
+	}
+}
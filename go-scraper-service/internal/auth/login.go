@@ -0,0 +1,356 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// LoginSelectors 登录表单选择器
+//
+// 与 SiteCredential.loginSelectors（JSON）及 Node.js 端 LoginConfig.selectors 保持一致。
+type LoginSelectors struct {
+	Username         string `json:"username"`
+	Password         string `json:"password"`
+	Submit           string `json:"submit,omitempty"`
+	SuccessIndicator string `json:"successIndicator,omitempty"`
+}
+
+// LoginRequest 表单登录请求
+type LoginRequest struct {
+	LoginURL  string
+	Selectors LoginSelectors
+	Username  string
+	Password  string
+	// 额外提交的表单字段（覆盖表单中的同名字段）
+	ExtraFields map[string]string
+	// 每次请求附带的自定义 Headers
+	Headers map[string]string
+	// 抓取策略（cycletls, standard），为空时优先 CycleTLS
+	Strategy string
+}
+
+// LoginResult 表单登录结果
+type LoginResult struct {
+	Success bool
+	// Cookie 字符串（name=value; ...），与 Node.js 端 autoLogin 返回格式一致
+	CookieString string
+	// Cookie 元信息（不含值）
+	Cookies []Cookie
+	// 登录 Cookie 的过期时间（提交登录后设置的持久 Cookie 中最早的过期时间），
+	// 全部为会话 Cookie 时为零值
+	ExpiresAt time.Time
+	// 登录后的最终页面 URL
+	FinalURL string
+	// 失败原因
+	Error string
+}
+
+// 登录失败原因
+var (
+	ErrLoginFormNotFound = errors.New("login form not found")
+	ErrFieldNotFound     = errors.New("login field not found")
+)
+
+// loginErrorSelector 页面上常见的登录错误提示元素
+const loginErrorSelector = `.error, .errors, .alert-danger, .alert-error, .form-error, .login-error, [role="alert"]`
+
+// csrfMetaNames 页面 meta 中常见的 CSRF Token 名称
+var csrfMetaNames = []string{"csrf-token", "_csrf", "csrf_token", "x-csrf-token"}
+
+// LoginExecutor 原生表单登录执行器
+//
+// 适用于简单的服务端渲染表单登录站点，无需浏览器：
+//  1. 抓取登录页（保存 Cookie）
+//  2. 按选择器定位用户名、密码输入框及所属表单
+//  3. 收集表单中的隐藏字段（CSRF Token 等），填入账号密码
+//  4. 通过 TLS 指纹伪造客户端提交表单，手动跟随重定向并保存每一跳的 Cookie
+//  5. 判断登录是否成功，返回 Cookie 及过期时间
+//
+// 需要执行 JS 才能完成的登录（验证码、前端加密等）仍需走 Node.js 端的浏览器流程。
+type LoginExecutor struct {
+	doer Doer
+}
+
+// NewLoginExecutor 创建表单登录执行器
+func NewLoginExecutor(doer Doer) *LoginExecutor {
+	return &LoginExecutor{doer: doer}
+}
+
+// Login 执行表单登录
+//
+// 返回的 error 只表示请求层面的失败（网络错误、超时等）；
+// 表单定位失败、账号密码错误等登录失败通过 LoginResult.Error 返回。
+func (e *LoginExecutor) Login(ctx context.Context, req *LoginRequest) (*LoginResult, error) {
+	if req.Selectors.Username == "" || req.Selectors.Password == "" {
+		return &LoginResult{Error: "username and password selectors are required"}, nil
+	}
+
+	session := NewSession(e.doer, fetcher.Options{Strategy: req.Strategy, Headers: req.Headers})
+
+	// 1. 抓取登录页
+	loginPage, err := session.Get(ctx, req.LoginURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if loginPage.StatusCode >= 400 {
+		return &LoginResult{FinalURL: loginPage.URL, Error: fmt.Sprintf("login page returned HTTP %d", loginPage.StatusCode)}, nil
+	}
+
+	// 2-3. 定位表单并构建提交内容
+	form, err := buildLoginForm(loginPage, req)
+	if err != nil {
+		return &LoginResult{FinalURL: loginPage.URL, Error: err.Error()}, nil
+	}
+
+	// 4. 提交表单
+	before := session.snapshot()
+	headers := map[string]string{"Referer": loginPage.URL}
+	if origin := originOf(loginPage.URL); origin != "" {
+		headers["Origin"] = origin
+	}
+	for k, v := range form.headers {
+		headers[k] = v
+	}
+	// 前端框架常用的 XSRF-TOKEN Cookie，需要回传到请求头
+	if xsrf := cookieValue(session, loginPage.URL, "XSRF-TOKEN"); xsrf != "" {
+		if unescaped, err := url.QueryUnescape(xsrf); err == nil {
+			xsrf = unescaped
+		}
+		headers["X-XSRF-TOKEN"] = xsrf
+	}
+
+	var resultPage *Page
+	body := form.values.Encode()
+	if form.method == http.MethodGet {
+		actionURL, parseErr := url.Parse(form.action)
+		if parseErr != nil {
+			return &LoginResult{FinalURL: loginPage.URL, Error: parseErr.Error()}, nil
+		}
+		actionURL.RawQuery = body
+		resultPage, err = session.Do(ctx, http.MethodGet, actionURL.String(), "", headers)
+	} else {
+		headers["Content-Type"] = "application/x-www-form-urlencoded"
+		resultPage, err = session.Do(ctx, http.MethodPost, form.action, body, headers)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. 判断登录结果
+	changed := session.changedSince(before)
+	result := &LoginResult{
+		CookieString: session.CookieString(resultPage.URL, req.LoginURL),
+		Cookies:      session.Cookies(),
+		FinalURL:     resultPage.URL,
+	}
+	for _, c := range changed {
+		if !c.Expires.IsZero() && (result.ExpiresAt.IsZero() || c.Expires.Before(result.ExpiresAt)) {
+			result.ExpiresAt = c.Expires
+		}
+	}
+
+	if reason := detectLoginFailure(resultPage, req.Selectors, len(changed) > 0); reason != "" {
+		result.Error = reason
+		return result, nil
+	}
+	result.Success = true
+	return result, nil
+}
+
+// loginForm 待提交的登录表单
+type loginForm struct {
+	action  string
+	method  string
+	values  url.Values
+	headers map[string]string
+}
+
+// buildLoginForm 根据选择器定位表单，收集字段并填入账号密码
+func buildLoginForm(page *Page, req *LoginRequest) (*loginForm, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page.Body))
+	if err != nil {
+		return nil, err
+	}
+	pageURL, err := url.Parse(page.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	usernameField := doc.Find(req.Selectors.Username).First()
+	if usernameField.Length() == 0 {
+		return nil, fmt.Errorf("%w: username selector %q matched nothing", ErrFieldNotFound, req.Selectors.Username)
+	}
+	passwordField := doc.Find(req.Selectors.Password).First()
+	if passwordField.Length() == 0 {
+		return nil, fmt.Errorf("%w: password selector %q matched nothing", ErrFieldNotFound, req.Selectors.Password)
+	}
+	usernameName, _ := usernameField.Attr("name")
+	passwordName, _ := passwordField.Attr("name")
+	if usernameName == "" || passwordName == "" {
+		return nil, fmt.Errorf("%w: username/password inputs must have a name attribute", ErrFieldNotFound)
+	}
+
+	// 定位表单：优先密码框所属表单，其次提交按钮所属表单
+	formSel := passwordField.Closest("form")
+	var submitButton *goquery.Selection
+	if req.Selectors.Submit != "" {
+		if s := doc.Find(req.Selectors.Submit).First(); s.Length() > 0 {
+			submitButton = s
+			if formSel.Length() == 0 {
+				formSel = s.Closest("form")
+			}
+		}
+	}
+	if formSel.Length() == 0 {
+		return nil, ErrLoginFormNotFound
+	}
+
+	form := &loginForm{
+		action:  page.URL,
+		method:  http.MethodPost,
+		values:  url.Values{},
+		headers: map[string]string{},
+	}
+	if action := strings.TrimSpace(formSel.AttrOr("action", "")); action != "" {
+		if resolved, err := pageURL.Parse(action); err == nil {
+			form.action = resolved.String()
+		}
+	}
+	if strings.EqualFold(formSel.AttrOr("method", ""), "get") {
+		form.method = http.MethodGet
+	}
+
+	// 收集表单字段（隐藏字段、CSRF Token、已勾选的复选框等）
+	formSel.Find("input, select, textarea").Each(func(i int, s *goquery.Selection) {
+		name, ok := s.Attr("name")
+		if !ok || name == "" {
+			return
+		}
+		if _, disabled := s.Attr("disabled"); disabled {
+			return
+		}
+		switch goquery.NodeName(s) {
+		case "select":
+			option := s.Find("option[selected]").First()
+			if option.Length() == 0 {
+				option = s.Find("option").First()
+			}
+			if option.Length() > 0 {
+				form.values.Add(name, option.AttrOr("value", strings.TrimSpace(option.Text())))
+			}
+		case "textarea":
+			form.values.Add(name, s.Text())
+		default:
+			switch strings.ToLower(s.AttrOr("type", "text")) {
+			case "submit", "button", "image", "reset", "file":
+				return
+			case "checkbox", "radio":
+				if _, checked := s.Attr("checked"); checked {
+					form.values.Add(name, s.AttrOr("value", "on"))
+				}
+			default:
+				form.values.Add(name, s.AttrOr("value", ""))
+			}
+		}
+	})
+
+	// 点击的提交按钮本身也会作为字段提交
+	if submitButton != nil {
+		if name, ok := submitButton.Attr("name"); ok && name != "" {
+			form.values.Set(name, submitButton.AttrOr("value", ""))
+		}
+	}
+
+	form.values.Set(usernameName, req.Username)
+	form.values.Set(passwordName, req.Password)
+	for k, v := range req.ExtraFields {
+		form.values.Set(k, v)
+	}
+
+	// meta 中的 CSRF Token（Rails、Laravel 等），表单中没有时通过请求头提交
+	for _, metaName := range csrfMetaNames {
+		token := doc.Find(`meta[name="`+metaName+`"]`).AttrOr("content", "")
+		if token != "" {
+			form.headers["X-CSRF-Token"] = token
+			break
+		}
+	}
+
+	return form, nil
+}
+
+// detectLoginFailure 判断登录是否失败，返回失败原因（成功时返回空字符串）
+//
+// 判断规则：
+//   - 指定了 successIndicator：最终页面必须匹配该选择器
+//   - 未指定：最终页面不能是错误状态，不能仍然包含密码输入框，
+//     且提交后必须设置或更新了 Cookie
+func detectLoginFailure(page *Page, selectors LoginSelectors, cookiesChanged bool) string {
+	if page.StatusCode >= 400 {
+		return fmt.Sprintf("login submission returned HTTP %d", page.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page.Body))
+	if err != nil {
+		return "failed to parse login result page"
+	}
+
+	if selectors.SuccessIndicator != "" {
+		if doc.Find(selectors.SuccessIndicator).Length() > 0 {
+			return ""
+		}
+		return withPageError(doc, fmt.Sprintf("success indicator %q not found", selectors.SuccessIndicator))
+	}
+
+	if doc.Find(`input[type="password"]`).Length() > 0 {
+		return withPageError(doc, "login form is still present after submission")
+	}
+	if !cookiesChanged {
+		return "no cookies were set after submission"
+	}
+	return ""
+}
+
+// withPageError 附加页面上的错误提示文本
+func withPageError(doc *goquery.Document, reason string) string {
+	msg := strings.Join(strings.Fields(doc.Find(loginErrorSelector).First().Text()), " ")
+	if msg == "" {
+		return reason
+	}
+	if runes := []rune(msg); len(runes) > 200 {
+		msg = string(runes[:200])
+	}
+	return reason + ": " + msg
+}
+
+// cookieValue 获取发往指定 URL 的某个 Cookie 的值
+func cookieValue(session *Session, rawURL, name string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	for _, c := range session.jar.Cookies(u) {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// originOf 返回 URL 的 Origin（scheme://host）
+func originOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
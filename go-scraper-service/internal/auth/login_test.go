@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/config"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// newFakeLoginSite 创建一个本地假登录站点
+//
+//   - GET  /login   登录页，设置预会话 Cookie，表单包含隐藏 CSRF Token
+//   - POST /session 校验 CSRF、预会话 Cookie 和账号密码，成功后 302 到 /account 并设置会话 Cookie
+//   - GET  /account 需要会话 Cookie
+func newFakeLoginSite(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "presession", Value: "p1", Path: "/"})
+		w.Write([]byte(`<html><head><meta name="csrf-token" content="meta-token"></head><body>
+			<form id="login" action="/session" method="post">
+				<input type="hidden" name="authenticity_token" value="csrf-123">
+				<input type="text" id="user" name="login">
+				<input type="password" id="pass" name="password">
+				<input type="checkbox" name="remember" value="1" checked>
+				<button type="submit" name="commit" value="Sign in" class="btn">Sign in</button>
+			</form></body></html>`))
+	})
+
+	mux.HandleFunc("/session", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		pre, err := r.Cookie("presession")
+		valid := r.Method == http.MethodPost && err == nil && pre.Value == "p1" &&
+			r.PostForm.Get("authenticity_token") == "csrf-123" &&
+			r.PostForm.Get("remember") == "1" &&
+			r.PostForm.Get("commit") == "Sign in" &&
+			r.Header.Get("X-CSRF-Token") == "meta-token" &&
+			r.PostForm.Get("login") == "alice" &&
+			r.PostForm.Get("password") == "s3cret"
+		if !valid {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`<div class="alert-danger">Incorrect username or password.</div>
+				<form action="/session" method="post"><input type="password" name="password"></form>`))
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "user_session", Value: "token-abc", Path: "/", HttpOnly: true, MaxAge: 3600})
+		http.Redirect(w, r, "/account", http.StatusFound)
+	})
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("user_session"); err != nil || c.Value != "token-abc" {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		w.Write([]byte(`<html><body><a class="avatar" href="/alice">alice</a></body></html>`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestLoginExecutor(t *testing.T) {
+	site := newFakeLoginSite(t)
+	defer site.Close()
+
+	executor := NewLoginExecutor(fetcher.NewStandardClient(config.DefaultConfig()))
+	selectors := LoginSelectors{
+		Username:         "#user",
+		Password:         "#pass",
+		Submit:           "button[type=submit]",
+		SuccessIndicator: ".avatar",
+	}
+
+	t.Run("登录成功", func(t *testing.T) {
+		result, err := executor.Login(context.Background(), &LoginRequest{
+			LoginURL:  site.URL + "/login",
+			Selectors: selectors,
+			Username:  "alice",
+			Password:  "s3cret",
+		})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("Login() 失败: %s", result.Error)
+		}
+		if !strings.Contains(result.CookieString, "user_session=token-abc") {
+			t.Errorf("CookieString = %q, 缺少会话 Cookie", result.CookieString)
+		}
+		if result.FinalURL != site.URL+"/account" {
+			t.Errorf("FinalURL = %q", result.FinalURL)
+		}
+		if until := time.Until(result.ExpiresAt); until < 50*time.Minute || until > 61*time.Minute {
+			t.Errorf("ExpiresAt = %v, want about 1h from now", result.ExpiresAt)
+		}
+	})
+
+	t.Run("密码错误", func(t *testing.T) {
+		result, err := executor.Login(context.Background(), &LoginRequest{
+			LoginURL:  site.URL + "/login",
+			Selectors: selectors,
+			Username:  "alice",
+			Password:  "wrong",
+		})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		if result.Success {
+			t.Fatal("Login() 应该失败")
+		}
+		if !strings.Contains(result.Error, "Incorrect username or password") {
+			t.Errorf("Error = %q, 应包含页面错误提示", result.Error)
+		}
+	})
+
+	t.Run("选择器不匹配", func(t *testing.T) {
+		result, err := executor.Login(context.Background(), &LoginRequest{
+			LoginURL:  site.URL + "/login",
+			Selectors: LoginSelectors{Username: "#email", Password: "#pass"},
+		})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		if result.Success || !strings.Contains(result.Error, "#email") {
+			t.Errorf("Error = %q", result.Error)
+		}
+	})
+}
@@ -0,0 +1,305 @@
+// Package auth 提供站点认证相关功能
+//
+// 与 Node.js 端 src/lib/auth 对应，负责表单登录、Cookie 管理等需要
+// 多次往返请求的认证流程。所有请求都通过 fetcher 发出（优先 CycleTLS 指纹伪造）。
+//
+// 安全约定：Cookie 值、密码等凭证只出现在请求/响应体中，绝不写入日志。
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// maxRedirects 最大重定向次数
+const maxRedirects = 10
+
+// ErrTooManyRedirects 重定向次数过多
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// Doer 执行单次 HTTP 请求（不跟随重定向）
+//
+// fetcher.Fetcher 和 fetcher.StandardClient 均实现了该接口。
+type Doer interface {
+	Do(ctx context.Context, req *fetcher.Request) (*fetcher.Response, error)
+}
+
+// Cookie 登录获得的 Cookie 元信息（不含值，值见 Cookie 字符串）
+type Cookie struct {
+	Name     string `json:"name"`
+	Domain   string `json:"domain"`
+	Path     string `json:"path,omitempty"`
+	Expires  string `json:"expires,omitempty"` // RFC3339，会话 Cookie 为空
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+// Page 一次请求（含重定向）的最终页面
+type Page struct {
+	URL        string   // 最终 URL
+	StatusCode int      // 最终响应状态码
+	Body       string   // 最终响应内容
+	Redirects  []string // 依次经过的重定向目标
+}
+
+// Session 带 Cookie 管理的请求会话
+//
+// 手动跟随重定向，确保每一跳响应中的 Set-Cookie 都被保存
+// （登录提交后的 302 响应通常携带会话 Cookie）。
+type Session struct {
+	doer Doer
+	opts fetcher.Options
+	jar  *cookiejar.Jar
+	// 记录的 Cookie 元信息，key 为 name|domain|path
+	cookies map[string]*http.Cookie
+	// 记录的 Cookie 设置时间顺序
+	order []string
+}
+
+// NewSession 创建请求会话
+//
+// 使用 opts 中的以下字段：
+//   - Strategy: 抓取策略（cycletls, standard），为空时优先 CycleTLS
+//   - Headers: 每次请求附带的自定义 Headers（可包含初始 Cookie）
+//   - CredentialHeaders / CredentialDomain: 解密后的凭证 Headers，每一跳按目标 URL 重新判断是否携带
+func NewSession(doer Doer, opts fetcher.Options) *Session {
+	jar, _ := cookiejar.New(nil)
+	return &Session{
+		doer:    doer,
+		opts:    opts,
+		jar:     jar,
+		cookies: make(map[string]*http.Cookie),
+	}
+}
+
+// Get 发起 GET 请求
+func (s *Session) Get(ctx context.Context, rawURL string, headers map[string]string) (*Page, error) {
+	return s.Do(ctx, http.MethodGet, rawURL, "", headers)
+}
+
+// Do 发起请求并跟随重定向
+//
+// 301/302/303 重定向按浏览器行为改为 GET 并丢弃请求体，307/308 保持原方法和请求体。
+// 凭证 Headers 只发往凭证域名；重定向离开起始域名后，调用方传入的 Cookie / Authorization 也不再携带。
+func (s *Session) Do(ctx context.Context, method, rawURL, body string, headers map[string]string) (*Page, error) {
+	origin, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	var redirects []string
+	for i := 0; i <= maxRedirects; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		reqURL, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := s.doer.Do(ctx, &fetcher.Request{
+			Method:   method,
+			URL:      rawURL,
+			Headers:  s.requestHeaders(reqURL, origin.Hostname(), headers),
+			Body:     body,
+			Strategy: s.opts.Strategy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.store(reqURL, resp.Cookies)
+
+		location := resp.Header.Get("Location")
+		if !isRedirect(resp.StatusCode) || location == "" {
+			return &Page{
+				URL:        rawURL,
+				StatusCode: resp.StatusCode,
+				Body:       resp.Body,
+				Redirects:  redirects,
+			}, nil
+		}
+
+		next, err := reqURL.Parse(location)
+		if err != nil {
+			return nil, err
+		}
+		rawURL = next.String()
+		redirects = append(redirects, rawURL)
+
+		if resp.StatusCode != http.StatusTemporaryRedirect && resp.StatusCode != http.StatusPermanentRedirect {
+			if method != http.MethodGet && method != http.MethodHead {
+				method = http.MethodGet
+				body = ""
+				headers = withoutHeader(headers, "Content-Type")
+			}
+		}
+	}
+	return nil, ErrTooManyRedirects
+}
+
+// requestHeaders 合并会话 Headers、本次 Headers、凭证 Headers 和 Cookie
+//
+// originHost 为本次请求（重定向之前）的域名，目标离开该域名时不携带调用方传入的 Cookie / Authorization。
+func (s *Session) requestHeaders(u *url.URL, originHost string, extra map[string]string) map[string]string {
+	sameOrigin := fetcher.MatchesDomain(originHost, u.String())
+	custom := make(map[string]string, len(s.opts.Headers)+len(extra))
+	for _, src := range []map[string]string{s.opts.Headers, extra} {
+		for k, v := range src {
+			if !sameOrigin && fetcher.IsSensitiveHeader(k) {
+				continue
+			}
+			custom[k] = v
+		}
+	}
+	opts := s.opts
+	opts.Headers = custom
+	headers := make(map[string]string, len(custom)+len(opts.CredentialHeaders)+1)
+	for k, v := range opts.HeadersFor(u.String()) {
+		headers[k] = v
+	}
+
+	if jarCookie := cookieString(s.jar.Cookies(u)); jarCookie != "" {
+		for k, v := range headers {
+			if strings.EqualFold(k, "Cookie") && v != "" {
+				delete(headers, k)
+				jarCookie = v + "; " + jarCookie
+			}
+		}
+		headers["Cookie"] = jarCookie
+	}
+	return headers
+}
+
+// store 保存响应设置的 Cookie
+func (s *Session) store(u *url.URL, cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+	s.jar.SetCookies(u, cookies)
+
+	for _, c := range cookies {
+		stored := *c
+		if stored.Domain == "" {
+			stored.Domain = u.Hostname()
+		}
+		stored.Domain = strings.TrimPrefix(stored.Domain, ".")
+		if stored.Path == "" {
+			stored.Path = "/"
+		}
+		if stored.MaxAge > 0 {
+			stored.Expires = time.Now().Add(time.Duration(stored.MaxAge) * time.Second)
+		}
+
+		key := stored.Name + "|" + stored.Domain + "|" + stored.Path
+		if stored.MaxAge < 0 || (!stored.Expires.IsZero() && stored.Expires.Before(time.Now())) {
+			delete(s.cookies, key)
+			continue
+		}
+		if _, exists := s.cookies[key]; !exists {
+			s.order = append(s.order, key)
+		}
+		s.cookies[key] = &stored
+	}
+}
+
+// CookieString 返回发往指定 URL 的 Cookie 字符串（name=value; ...）
+func (s *Session) CookieString(urls ...string) string {
+	seen := make(map[string]bool)
+	var cookies []*http.Cookie
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil {
+			continue
+		}
+		for _, c := range s.jar.Cookies(u) {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				cookies = append(cookies, c)
+			}
+		}
+	}
+	return cookieString(cookies)
+}
+
+// Cookies 返回会话中所有有效 Cookie 的元信息（按首次设置顺序）
+func (s *Session) Cookies() []Cookie {
+	var result []Cookie
+	for _, key := range s.order {
+		c, ok := s.cookies[key]
+		if !ok {
+			continue
+		}
+		meta := Cookie{
+			Name:     c.Name,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		if !c.Expires.IsZero() {
+			meta.Expires = c.Expires.UTC().Format(time.RFC3339)
+		}
+		result = append(result, meta)
+	}
+	return result
+}
+
+// snapshot 记录当前 Cookie 状态（用于判断之后哪些 Cookie 被设置或更新）
+func (s *Session) snapshot() map[string]string {
+	state := make(map[string]string, len(s.cookies))
+	for key, c := range s.cookies {
+		state[key] = c.Value + "|" + c.Expires.String()
+	}
+	return state
+}
+
+// changedSince 返回快照之后新设置或更新的 Cookie
+func (s *Session) changedSince(state map[string]string) []*http.Cookie {
+	var changed []*http.Cookie
+	for _, key := range s.order {
+		c, ok := s.cookies[key]
+		if !ok {
+			continue
+		}
+		if state[key] != c.Value+"|"+c.Expires.String() {
+			changed = append(changed, c)
+		}
+	}
+	return changed
+}
+
+// cookieString 拼接 Cookie 请求头
+func cookieString(cookies []*http.Cookie) string {
+	parts := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		parts = append(parts, c.Name+"="+c.Value)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// isRedirect 判断是否为重定向状态码
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// withoutHeader 复制 Headers 并移除指定项（不区分大小写）
+func withoutHeader(headers map[string]string, name string) map[string]string {
+	result := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if !strings.EqualFold(k, name) {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -24,6 +24,16 @@ type Config struct {
 	BrowserlessURL string
 	// Redis URL（用于队列消费）
 	RedisURL string
+	// 多页文章拼接的默认最大页数（含首页）
+	PaginationMaxPages int
+	// 凭证加密密钥（与 Node.js 端 CREDENTIAL_SECRET 一致），为空时不支持凭证引用
+	CredentialSecret string
+	// 默认时区，用于解释页面中不带时区的日期和相对时间（如「3 小时前」）
+	DefaultTimezone *time.Location
+	// 站点提取规则文件（YAML/JSON），为空时不启用；文件修改后自动重新加载
+	SiteRulesPath string
+	// 自定义净化配置文件（YAML/JSON），为空时只提供内置的 reader / email-safe / strict-text / archive
+	SanitizeProfilesPath string
 }
 
 // DefaultConfig 默认配置
@@ -37,6 +47,13 @@ func DefaultConfig() *Config {
 		UserAgent:       getEnv("USER_AGENT", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
 		BrowserlessURL:  getEnv("BROWSERLESS_URL", "http://browserless:3000"),
 		RedisURL:        getEnv("REDIS_URL", ""),
+
+		PaginationMaxPages: getEnvInt("PAGINATION_MAX_PAGES", 5),
+		CredentialSecret:   getEnv("CREDENTIAL_SECRET", ""),
+		DefaultTimezone:    getEnvLocation("DEFAULT_TIMEZONE", "Asia/Shanghai"),
+		SiteRulesPath:      getEnv("SITE_RULES_PATH", ""),
+
+		SanitizeProfilesPath: getEnv("SANITIZE_PROFILES_PATH", ""),
 	}
 }
 
@@ -55,3 +72,13 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvLocation(key, defaultValue string) *time.Location {
+	if loc, err := time.LoadLocation(getEnv(key, defaultValue)); err == nil {
+		return loc
+	}
+	if loc, err := time.LoadLocation(defaultValue); err == nil {
+		return loc
+	}
+	return time.UTC
+}
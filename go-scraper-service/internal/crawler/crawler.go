@@ -0,0 +1,481 @@
+// Package crawler 提供站点爬取引擎
+//
+// 从种子 URL 出发按广度优先遍历站内链接，自行维护去重的前沿队列（frontier），
+// 按深度、范围（同主机 / 子域名）、包含 / 排除规则和页面预算决定抓取哪些页面，
+// 发现的 URL、抓取结果和提取出的文章以事件形式流式返回。爬取可以按 ID 暂停和恢复。
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// 默认值和上限
+const (
+	DefaultMaxDepth    = 3
+	DefaultMaxPages    = 1000
+	MaxPagesLimit      = 10000
+	DefaultConcurrency = 2
+	MaxConcurrency     = 8
+)
+
+// 爬取状态
+const (
+	StatusPending   = "pending"   // 已创建，尚未开始
+	StatusRunning   = "running"   // 正在爬取
+	StatusPaused    = "paused"    // 已暂停，前沿队列保留，可恢复
+	StatusCompleted = "completed" // 前沿队列已清空或预算用尽
+	StatusCancelled = "cancelled" // 已取消，不可恢复
+)
+
+// 事件类型
+const (
+	EventDiscovered = "discovered" // 发现新 URL（已加入前沿队列）
+	EventCrawled    = "crawled"    // 页面抓取完成（失败时 Error 非空）
+	EventArticle    = "article"    // 从页面提取出文章
+	EventPaused     = "paused"     // 爬取暂停（本次运行结束，可恢复）
+	EventDone       = "done"       // 爬取结束（完成或取消）
+)
+
+var (
+	// ErrCrawlNotFound 爬取任务不存在或已过期
+	ErrCrawlNotFound = errors.New("crawl not found")
+	// ErrCrawlRunning 爬取任务正在运行
+	ErrCrawlRunning = errors.New("crawl is already running")
+	// ErrCrawlFinished 爬取任务已完成或已取消
+	ErrCrawlFinished = errors.New("crawl is finished")
+)
+
+// Options 爬取选项
+type Options struct {
+	// Seed 种子 URL（深度为 0，不受包含 / 排除规则限制）
+	Seed string
+	// MaxDepth 最大爬取深度，<=0 时使用 DefaultMaxDepth（只抓取种子可将 MaxPages 设为 1）
+	MaxDepth int
+	// MaxPages 页面预算（发现并抓取的 URL 总数，含种子），<=0 时使用 DefaultMaxPages，不超过 MaxPagesLimit
+	MaxPages int
+	// Include 规范化后的 URL 至少匹配其中一个规则才加入队列（为空时不限制）
+	Include []string
+	// Exclude 规范化后的 URL 匹配任一规则即跳过
+	Exclude []string
+	// Scope 主机范围：host, subdomains（默认）, any
+	Scope string
+	// AllowedSubdomains subdomains 范围下允许的子域名（如 "blog"、"news"），为空时允许全部
+	AllowedSubdomains []string
+	// SeedPathOnly 只爬取种子路径下的 URL
+	SeedPathOnly bool
+	// ExtractArticles 对抓取的页面提取正文，判定为文章时发送 article 事件
+	ExtractArticles bool
+	// OutputFormat 文章正文输出格式（FormatHTML / FormatMarkdown / FormatText）
+	OutputFormat string
+	// EmbedMode 文章嵌入媒体渲染方式（EmbedModeIframe / EmbedModePlaceholder）
+	EmbedMode string
+	// SanitizeProfile 文章正文的净化配置名称，为空时为 reader
+	SanitizeProfile string
+	// Summarize 为文章生成抽取式摘要和关键词
+	Summarize bool
+	// Concurrency 同时抓取的页面数，<=0 时使用 DefaultConcurrency，不超过 MaxConcurrency
+	Concurrency int
+	// Delay 每批页面之间的间隔（礼貌爬取）
+	Delay time.Duration
+	// PageTimeout 单个页面的抓取超时，<=0 时不单独限制
+	PageTimeout time.Duration
+}
+
+// normalize 补全默认值
+func (o Options) normalize() Options {
+	if o.MaxDepth <= 0 {
+		o.MaxDepth = DefaultMaxDepth
+	}
+	if o.MaxPages <= 0 {
+		o.MaxPages = DefaultMaxPages
+	}
+	o.MaxPages = min(o.MaxPages, MaxPagesLimit)
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	o.Concurrency = min(o.Concurrency, MaxConcurrency)
+	if o.Scope == "" {
+		o.Scope = ScopeSubdomains
+	}
+	return o
+}
+
+// ValidScope 判断范围取值是否合法（空字符串表示默认）
+func ValidScope(s string) bool {
+	return s == "" || s == ScopeHost || s == ScopeSubdomains || s == ScopeAny
+}
+
+// Stats 爬取统计
+type Stats struct {
+	Discovered int  `json:"discovered"`          // 加入前沿队列的 URL 数（含种子）
+	Crawled    int  `json:"crawled"`             // 抓取成功的页面数
+	Failed     int  `json:"failed"`              // 抓取失败的页面数
+	Articles   int  `json:"articles"`            // 提取出的文章数
+	Pending    int  `json:"pending"`             // 前沿队列中待抓取的 URL 数
+	Truncated  bool `json:"truncated,omitempty"` // 页面预算用尽，有 URL 未加入队列
+}
+
+// Event 爬取事件
+type Event struct {
+	Type     string                   `json:"type"`
+	URL      string                   `json:"url,omitempty"`
+	FinalURL string                   `json:"finalUrl,omitempty"` // crawled / article：跳转后的最终 URL
+	Depth    int                      `json:"depth"`
+	Parent   string                   `json:"parent,omitempty"` // discovered：发现该 URL 的页面
+	Title    string                   `json:"title,omitempty"`  // discovered：锚文本
+	Links    int                      `json:"links,omitempty"`  // crawled：新加入队列的链接数
+	Article  *extractor.ExtractResult `json:"article,omitempty"`
+	Status   *Snapshot                `json:"status,omitempty"` // paused / done：爬取状态
+	Error    string                   `json:"error,omitempty"`
+}
+
+// Snapshot 爬取状态快照
+type Snapshot struct {
+	ID        string    `json:"id"`
+	Seed      string    `json:"seed"`
+	Status    string    `json:"status"`
+	Stats     Stats     `json:"stats"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// entry 前沿队列中的 URL
+type entry struct {
+	url    string
+	depth  int
+	parent string
+	title  string
+}
+
+// pageResult 单个页面的抓取结果
+type pageResult struct {
+	html     string
+	finalURL string
+	err      error
+}
+
+// Crawl 一次爬取任务
+//
+// 同一时间只能有一次 Run；Pause 结束当前运行并保留前沿队列，之后再次 Run 即从断点继续。
+type Crawl struct {
+	ID string
+
+	opts      Options
+	seed      *url.URL
+	scope     *scope
+	fetch     extractor.PageFetcher
+	extractor *extractor.Extractor
+	limiter   chan struct{} // 服务级并发信号量，每次抓取占用一个槽位（可为 nil）
+	createdAt time.Time
+
+	mu          sync.Mutex
+	status      string
+	frontier    []entry
+	seen        map[string]bool
+	stats       Stats
+	started     bool
+	cancel      context.CancelFunc // 运行中时非 nil
+	cancelled   bool               // Cancel 已调用
+	undelivered []Event            // 客户端断开时未送达的事件
+	updatedAt   time.Time
+}
+
+// newCrawl 创建爬取任务
+func newCrawl(id string, opts Options, fetch extractor.PageFetcher, ext *extractor.Extractor, limiter chan struct{}) (*Crawl, error) {
+	seed, err := url.Parse(opts.Seed)
+	if err != nil || (seed.Scheme != "http" && seed.Scheme != "https") || seed.Host == "" {
+		return nil, errors.New("invalid seed url")
+	}
+	opts = opts.normalize()
+	now := time.Now()
+	return &Crawl{
+		ID:        id,
+		opts:      opts,
+		seed:      seed,
+		scope:     newScope(seed, opts),
+		fetch:     fetch,
+		extractor: ext,
+		limiter:   limiter,
+		createdAt: now,
+		status:    StatusPending,
+		seen:      map[string]bool{},
+		updatedAt: now,
+	}, nil
+}
+
+// Snapshot 返回当前状态
+func (c *Crawl) Snapshot() Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.snapshotLocked()
+}
+
+// snapshotLocked 返回当前状态（调用方持有锁）
+func (c *Crawl) snapshotLocked() Snapshot {
+	stats := c.stats
+	stats.Pending = len(c.frontier)
+	return Snapshot{
+		ID:        c.ID,
+		Seed:      c.opts.Seed,
+		Status:    c.status,
+		Stats:     stats,
+		CreatedAt: c.createdAt,
+		UpdatedAt: c.updatedAt,
+	}
+}
+
+// Pause 暂停爬取（正在抓取的页面会被中断并重新放回队列）
+func (c *Crawl) Pause() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.status {
+	case StatusCompleted, StatusCancelled:
+		return ErrCrawlFinished
+	case StatusRunning:
+		c.cancel()
+	default:
+		c.status = StatusPaused
+		c.updatedAt = time.Now()
+	}
+	return nil
+}
+
+// Cancel 取消爬取（不可恢复）
+func (c *Crawl) Cancel() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.status {
+	case StatusCompleted, StatusCancelled:
+		return ErrCrawlFinished
+	case StatusRunning:
+		c.cancelled = true
+		c.cancel()
+	default:
+		c.status = StatusCancelled
+		c.frontier = nil
+		c.updatedAt = time.Now()
+	}
+	return nil
+}
+
+// Run 运行爬取，直到前沿队列清空、预算用尽、被暂停 / 取消或 ctx 结束
+//
+// 事件按顺序同步传给 emit；emit 返回错误（如客户端断开）时爬取暂停并返回该错误，
+// 未送达的事件在恢复时补发。ctx 结束同样视为暂停，之后可再次 Run 恢复。最后一个事件为 paused 或 done。
+func (c *Crawl) Run(ctx context.Context, emit func(Event) error) error {
+	c.mu.Lock()
+	switch c.status {
+	case StatusRunning:
+		c.mu.Unlock()
+		return ErrCrawlRunning
+	case StatusCompleted, StatusCancelled:
+		c.mu.Unlock()
+		return ErrCrawlFinished
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c.cancel = cancel
+	c.status = StatusRunning
+	c.updatedAt = time.Now()
+	events := c.undelivered
+	c.undelivered = nil
+	if !c.started {
+		c.started = true
+		events = append(events, c.admitLocked(entry{url: c.opts.Seed}, extractor.NormalizeURL(c.seed))...)
+	}
+	c.mu.Unlock()
+
+	err := c.emitAll(emit, events)
+	for err == nil && ctx.Err() == nil {
+		batch := c.next()
+		if len(batch) == 0 {
+			break
+		}
+		results := c.fetchBatch(ctx, batch)
+		if ctx.Err() != nil {
+			c.requeue(batch)
+			break
+		}
+		for i, e := range batch {
+			if err = c.emitAll(emit, c.process(ctx, e, results[i])); err != nil {
+				c.requeue(batch[i+1:])
+				break
+			}
+		}
+		if err == nil && c.opts.Delay > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.opts.Delay):
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.cancel = nil
+	c.updatedAt = time.Now()
+	final := Event{Type: EventPaused}
+	switch {
+	case c.cancelled:
+		c.status = StatusCancelled
+		c.frontier = nil
+		final.Type = EventDone
+	case err != nil || ctx.Err() != nil:
+		c.status = StatusPaused
+	default:
+		c.status = StatusCompleted
+		final.Type = EventDone
+	}
+	snapshot := c.snapshotLocked()
+	final.Status = &snapshot
+	c.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return emit(final)
+}
+
+// emitAll 依次发送事件，发送失败时保留未送达的事件，下次 Run 时优先补发
+func (c *Crawl) emitAll(emit func(Event) error, events []Event) error {
+	for i, ev := range events {
+		if err := emit(ev); err != nil {
+			c.mu.Lock()
+			c.undelivered = append(c.undelivered, events[i:]...)
+			c.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+// admitLocked 将 URL 加入前沿队列（调用方持有锁），返回 discovered 事件
+func (c *Crawl) admitLocked(e entry, normalized string) []Event {
+	if c.seen[normalized] {
+		return nil
+	}
+	if c.stats.Discovered >= c.opts.MaxPages {
+		c.stats.Truncated = true
+		return nil
+	}
+	c.seen[normalized] = true
+	c.frontier = append(c.frontier, e)
+	c.stats.Discovered++
+	return []Event{{Type: EventDiscovered, URL: e.url, Depth: e.depth, Parent: e.parent, Title: e.title}}
+}
+
+// next 从前沿队列取出一批 URL
+func (c *Crawl) next() []entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := min(c.opts.Concurrency, len(c.frontier))
+	batch := append([]entry(nil), c.frontier[:n]...)
+	c.frontier = c.frontier[n:]
+	return batch
+}
+
+// requeue 将未处理的 URL 放回队首（保持广度优先顺序）
+func (c *Crawl) requeue(batch []entry) {
+	if len(batch) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frontier = append(append([]entry(nil), batch...), c.frontier...)
+}
+
+// fetchBatch 并发抓取一批页面，结果与 batch 一一对应
+//
+// 每个页面抓取前先占用服务级信号量的一个槽位（等待期间不计入单页超时）。
+func (c *Crawl) fetchBatch(ctx context.Context, batch []entry) []pageResult {
+	results := make([]pageResult, len(batch))
+	var wg sync.WaitGroup
+	for i, e := range batch {
+		wg.Add(1)
+		go func(i int, pageURL string) {
+			defer wg.Done()
+			if c.limiter != nil {
+				select {
+				case c.limiter <- struct{}{}:
+					defer func() { <-c.limiter }()
+				case <-ctx.Done():
+					results[i] = pageResult{err: ctx.Err()}
+					return
+				}
+			}
+			pageCtx := ctx
+			if c.opts.PageTimeout > 0 {
+				var cancel context.CancelFunc
+				pageCtx, cancel = context.WithTimeout(ctx, c.opts.PageTimeout)
+				defer cancel()
+			}
+			html, finalURL, err := c.fetch(pageCtx, pageURL)
+			results[i] = pageResult{html: html, finalURL: finalURL, err: err}
+		}(i, e.url)
+	}
+	wg.Wait()
+	return results
+}
+
+// process 处理单个页面：记录抓取结果、提取文章、发现新链接
+func (c *Crawl) process(ctx context.Context, e entry, r pageResult) []Event {
+	crawled := Event{Type: EventCrawled, URL: e.url, FinalURL: r.finalURL, Depth: e.depth}
+	if r.err != nil {
+		crawled.Error = r.err.Error()
+		c.mu.Lock()
+		c.stats.Failed++
+		c.mu.Unlock()
+		return []Event{crawled}
+	}
+	if crawled.FinalURL == "" {
+		crawled.FinalURL = e.url
+	}
+
+	var events []Event
+	if c.opts.ExtractArticles {
+		// 非文章页面（列表页、首页等）和提取失败的页面不发送 article 事件
+		result, err := c.extractor.ExtractWithOptions(ctx, r.html, crawled.FinalURL, extractor.ExtractOptions{
+			RequireArticle:  true,
+			OutputFormat:    c.opts.OutputFormat,
+			EmbedMode:       c.opts.EmbedMode,
+			SanitizeProfile: c.opts.SanitizeProfile,
+			Summarize:       c.opts.Summarize,
+		})
+		if err == nil {
+			events = append(events, Event{Type: EventArticle, URL: e.url, FinalURL: crawled.FinalURL, Depth: e.depth, Article: result})
+		}
+	}
+
+	var links []extractor.Link
+	if e.depth < c.opts.MaxDepth {
+		links, _ = extractor.ExtractLinks(r.html, crawled.FinalURL, extractor.LinkOptions{})
+	}
+
+	c.mu.Lock()
+	c.stats.Crawled++
+	if len(events) > 0 {
+		c.stats.Articles++
+	}
+	// 跳转后的最终 URL 同样视为已访问
+	if final, err := url.Parse(crawled.FinalURL); err == nil {
+		c.seen[extractor.NormalizeURL(final)] = true
+	}
+	var discovered []Event
+	for _, link := range links {
+		u, err := url.Parse(link.URL)
+		if err != nil || !c.scope.allows(u, link.URL) {
+			continue
+		}
+		discovered = append(discovered, c.admitLocked(entry{url: link.URL, depth: e.depth + 1, parent: crawled.FinalURL, title: link.Text}, link.URL)...)
+	}
+	c.updatedAt = time.Now()
+	c.mu.Unlock()
+
+	crawled.Links = len(discovered)
+	return append(append([]Event{crawled}, events...), discovered...)
+}
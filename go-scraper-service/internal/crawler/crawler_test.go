@@ -0,0 +1,267 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// fakeSite 测试站点：URL -> 页面中的链接
+var fakeSite = map[string][]string{
+	"https://example.com/":          {"/news/", "/about", "https://blog.example.com/", "https://other.com/"},
+	"https://example.com/news/":     {"/news/1", "/news/2", "/"},
+	"https://example.com/about":     {"/news/1"},
+	"https://blog.example.com/":     {"/post"},
+	"https://example.com/news/1":    {"/news/1/comments"},
+	"https://example.com/news/2":    {},
+	"https://blog.example.com/post": {},
+}
+
+// fakeFetch 按 fakeSite 返回页面
+func fakeFetch(_ context.Context, pageURL string) (string, string, error) {
+	links, ok := fakeSite[pageURL]
+	if !ok {
+		return "", "", errors.New("404")
+	}
+	body := "<html><body>"
+	for _, l := range links {
+		body += `<a href="` + l + `">` + l + `</a>`
+	}
+	return body + "</body></html>", pageURL, nil
+}
+
+// collect 运行爬取并收集事件
+func collect(t *testing.T, c *Crawl) []Event {
+	t.Helper()
+	var events []Event
+	if err := c.Run(context.Background(), func(ev Event) error {
+		events = append(events, ev)
+		return nil
+	}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return events
+}
+
+// crawledURLs 按顺序返回抓取过的 URL
+func crawledURLs(events []Event) []string {
+	var urls []string
+	for _, ev := range events {
+		if ev.Type == EventCrawled {
+			urls = append(urls, ev.URL)
+		}
+	}
+	return urls
+}
+
+func TestCrawl(t *testing.T) {
+	m := NewManager(extractor.New(), 0, nil)
+
+	t.Run("广度优先遍历，默认包含子域名", func(t *testing.T) {
+		c, err := m.Create(Options{Seed: "https://example.com/", MaxDepth: 2}, fakeFetch)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		events := collect(t, c)
+		want := []string{
+			"https://example.com/",
+			"https://example.com/news/",
+			"https://example.com/about",
+			"https://blog.example.com/",
+			"https://example.com/news/1",
+			"https://example.com/news/2",
+			"https://blog.example.com/post",
+		}
+		if got := crawledURLs(events); !reflect.DeepEqual(got, want) {
+			t.Errorf("crawled = %v, want %v", got, want)
+		}
+		last := events[len(events)-1]
+		if last.Type != EventDone || last.Status.Status != StatusCompleted {
+			t.Fatalf("last = %+v", last)
+		}
+		if s := last.Status.Stats; s.Discovered != 7 || s.Crawled != 7 || s.Failed != 0 || s.Pending != 0 || s.Truncated {
+			t.Errorf("stats = %+v", s)
+		}
+		for _, ev := range events {
+			if ev.Type == EventDiscovered && ev.URL == "https://example.com/news/1" && (ev.Depth != 2 || ev.Parent != "https://example.com/news/") {
+				t.Errorf("discovered = %+v", ev)
+			}
+		}
+	})
+
+	t.Run("页面预算用尽", func(t *testing.T) {
+		c, _ := m.Create(Options{Seed: "https://example.com/", MaxPages: 3, Scope: ScopeHost}, fakeFetch)
+		events := collect(t, c)
+		want := []string{"https://example.com/", "https://example.com/news/", "https://example.com/about"}
+		if got := crawledURLs(events); !reflect.DeepEqual(got, want) {
+			t.Errorf("crawled = %v, want %v", got, want)
+		}
+		if s := events[len(events)-1].Status.Stats; !s.Truncated || s.Discovered != 3 {
+			t.Errorf("stats = %+v", s)
+		}
+	})
+
+	t.Run("客户端断开后暂停并从断点恢复", func(t *testing.T) {
+		c, _ := m.Create(Options{Seed: "https://example.com/", MaxDepth: 2, Concurrency: 1}, fakeFetch)
+		var first []Event
+		errGone := errors.New("client gone")
+		err := c.Run(context.Background(), func(ev Event) error {
+			if ev.Type == EventCrawled && len(crawledURLs(first)) == 2 {
+				return errGone
+			}
+			first = append(first, ev)
+			return nil
+		})
+		if !errors.Is(err, errGone) {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if s := c.Snapshot(); s.Status != StatusPaused || s.Stats.Pending == 0 {
+			t.Fatalf("snapshot = %+v", s)
+		}
+
+		resumed, err := m.Get(c.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		second := collect(t, resumed)
+		got := append(crawledURLs(first), crawledURLs(second)...)
+		if len(got) != 7 {
+			t.Errorf("crawled = %v", got)
+		}
+		seen := map[string]bool{}
+		for _, u := range got {
+			if seen[u] {
+				t.Errorf("重复抓取 %s", u)
+			}
+			seen[u] = true
+		}
+		if err := resumed.Run(context.Background(), func(Event) error { return nil }); !errors.Is(err, ErrCrawlFinished) {
+			t.Errorf("Run() after completed error = %v", err)
+		}
+	})
+
+	t.Run("取消", func(t *testing.T) {
+		c, _ := m.Create(Options{Seed: "https://example.com/", Concurrency: 1}, fakeFetch)
+		var last Event
+		c.Run(context.Background(), func(ev Event) error {
+			if ev.Type == EventCrawled {
+				c.Cancel()
+			}
+			last = ev
+			return nil
+		})
+		if last.Type != EventDone || last.Status.Status != StatusCancelled || last.Status.Stats.Crawled != 1 {
+			t.Errorf("last = %+v", last)
+		}
+	})
+
+	t.Run("抓取失败计入统计", func(t *testing.T) {
+		c, _ := m.Create(Options{Seed: "https://example.com/missing"}, fakeFetch)
+		events := collect(t, c)
+		if events[1].Type != EventCrawled || events[1].Error != "404" || events[len(events)-1].Status.Stats.Failed != 1 {
+			t.Errorf("events = %+v", events)
+		}
+	})
+
+	t.Run("非法种子和不存在的任务", func(t *testing.T) {
+		if _, err := m.Create(Options{Seed: "ftp://example.com/"}, fakeFetch); err == nil {
+			t.Error("Create() 应拒绝非 HTTP 种子")
+		}
+		if _, err := m.Get("missing"); !errors.Is(err, ErrCrawlNotFound) {
+			t.Errorf("Get() error = %v", err)
+		}
+	})
+}
+
+func TestCrawlLimiter(t *testing.T) {
+	// 两个爬取各自并发 8，共享容量为 2 的服务级信号量
+	limiter := make(chan struct{}, 2)
+	m := NewManager(extractor.New(), 0, limiter)
+
+	var inFlight, peak atomic.Int32
+	fetch := func(_ context.Context, pageURL string) (string, string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(5 * time.Millisecond)
+		body := "<html><body>"
+		if u, _ := url.Parse(pageURL); u.Path == "/" {
+			for i := 0; i < 12; i++ {
+				body += fmt.Sprintf(`<a href="/p/%d">%d</a>`, i, i)
+			}
+		}
+		return body + "</body></html>", pageURL, nil
+	}
+
+	var wg sync.WaitGroup
+	for _, seed := range []string{"https://a.example.com/", "https://b.example.com/"} {
+		c, err := m.Create(Options{Seed: seed, Concurrency: MaxConcurrency}, fetch)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var events []Event
+			if err := c.Run(context.Background(), func(ev Event) error {
+				events = append(events, ev)
+				return nil
+			}); err != nil {
+				t.Errorf("Run() error = %v", err)
+			}
+			if got := len(crawledURLs(events)); got != 13 {
+				t.Errorf("%s crawled %d pages, want 13", seed, got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > int32(cap(limiter)) {
+		t.Errorf("同时抓取 %d 个页面，超过信号量容量 %d", got, cap(limiter))
+	}
+	if len(limiter) != 0 {
+		t.Errorf("信号量未释放: %d", len(limiter))
+	}
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		url  string
+		want bool
+	}{
+		{"同主机", Options{Scope: ScopeHost}, "https://www.example.com/a", true},
+		{"host 范围拒绝子域名", Options{Scope: ScopeHost}, "https://blog.example.com/a", false},
+		{"默认允许子域名", Options{}, "https://blog.example.com/a", true},
+		{"默认拒绝外站", Options{}, "https://example.org/a", false},
+		{"子域名白名单", Options{AllowedSubdomains: []string{"news"}}, "https://blog.example.com/a", false},
+		{"子域名白名单命中", Options{AllowedSubdomains: []string{"news"}}, "https://news.example.com/a", true},
+		{"any 范围", Options{Scope: ScopeAny}, "https://example.org/a", true},
+		{"种子路径前缀", Options{SeedPathOnly: true}, "https://example.com/docs/guide", true},
+		{"种子路径之外", Options{SeedPathOnly: true}, "https://example.com/docsx", false},
+		{"包含规则", Options{Include: []string{`/docs/\w+`}}, "https://example.com/blog", false},
+		{"排除规则", Options{Exclude: []string{`\?page=`}}, "https://example.com/docs/?page=2", false},
+		{"非法正则按子串匹配", Options{Exclude: []string{"/docs/("}}, "https://example.com/docs/(draft)", false},
+	}
+
+	seed, _ := url.Parse("https://example.com/docs/")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScope(seed, tt.opts.normalize())
+			u, _ := url.Parse(tt.url)
+			if got := s.allows(u, tt.url); got != tt.want {
+				t.Errorf("allows(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package crawler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// DefaultRetention 未运行的爬取任务（暂停、完成、取消）的保留时间
+const DefaultRetention = time.Hour
+
+// Manager 爬取任务管理器（按 ID 保存任务，供暂停 / 恢复 / 查询）
+//
+// 任务状态只保存在内存中，服务重启后丢失；未运行的任务超过保留时间后被清理。
+type Manager struct {
+	extractor *extractor.Extractor
+	retention time.Duration
+	limiter   chan struct{}
+
+	mu     sync.Mutex
+	crawls map[string]*Crawl
+}
+
+// NewManager 创建爬取任务管理器，retention <=0 时使用 DefaultRetention
+//
+// limiter 为服务级并发信号量（可为 nil），所有爬取的每次页面抓取都占用其中一个槽位，
+// 多个爬取同时运行时总抓取数不会超过服务的并发上限。
+func NewManager(ext *extractor.Extractor, retention time.Duration, limiter chan struct{}) *Manager {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Manager{
+		extractor: ext,
+		retention: retention,
+		limiter:   limiter,
+		crawls:    map[string]*Crawl{},
+	}
+}
+
+// Create 创建爬取任务（不会自动运行，需调用 Crawl.Run）
+//
+// fetch 为页面抓取函数，恢复爬取时沿用同一函数（包括其中的请求头和凭证）。
+// Scope 为 any 时会抓取其他主机，fetch 应只向种子站点发送 Cookie / Authorization（见 fetcher.Fetcher.PageFetcher）。
+func (m *Manager) Create(opts Options, fetch extractor.PageFetcher) (*Crawl, error) {
+	c, err := newCrawl(newCrawlID(), opts, fetch, m.extractor, m.limiter)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked()
+	m.crawls[c.ID] = c
+	return c, nil
+}
+
+// Get 按 ID 获取爬取任务
+func (m *Manager) Get(id string) (*Crawl, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked()
+	c, ok := m.crawls[id]
+	if !ok {
+		return nil, ErrCrawlNotFound
+	}
+	return c, nil
+}
+
+// pruneLocked 清理超过保留时间的未运行任务（调用方持有锁）
+func (m *Manager) pruneLocked() {
+	deadline := time.Now().Add(-m.retention)
+	for id, c := range m.crawls {
+		s := c.Snapshot()
+		if s.Status != StatusRunning && s.UpdatedAt.Before(deadline) {
+			delete(m.crawls, id)
+		}
+	}
+}
+
+// newCrawlID 生成随机任务 ID
+func newCrawlID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
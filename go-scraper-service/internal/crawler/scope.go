@@ -0,0 +1,135 @@
+package crawler
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// 爬取范围
+const (
+	ScopeHost       = "host"       // 仅种子主机（忽略 www. 前缀）
+	ScopeSubdomains = "subdomains" // 种子所在根域名及其子域名（可用 AllowedSubdomains 限定）
+	ScopeAny        = "any"        // 不限制主机
+)
+
+// pattern URL 匹配规则（合法正则按正则匹配，否则按子串匹配，与 Node.js 端的 SiteCrawlConfig 行为一致）
+type pattern struct {
+	re  *regexp.Regexp
+	raw string
+}
+
+// match 判断 URL 是否匹配
+func (p pattern) match(u string) bool {
+	if p.re != nil {
+		return p.re.MatchString(u)
+	}
+	return strings.Contains(u, p.raw)
+}
+
+// compilePatterns 编译匹配规则
+func compilePatterns(raws []string) []pattern {
+	var patterns []pattern
+	for _, raw := range raws {
+		if raw == "" {
+			continue
+		}
+		re, _ := regexp.Compile(raw) // 非法正则时 re 为 nil，退化为子串匹配
+		patterns = append(patterns, pattern{re: re, raw: raw})
+	}
+	return patterns
+}
+
+// scope 编译后的范围规则
+type scope struct {
+	mode       string
+	host       string          // 种子主机（小写，去掉 www.）
+	root       string          // 种子根域名（eTLD+1）
+	subdomains map[string]bool // 允许的子域名（为空时不限制）
+	pathPrefix string          // 种子路径前缀（SeedPathOnly 时非空）
+	include    []pattern
+	exclude    []pattern
+}
+
+// newScope 根据种子 URL 和选项创建范围规则
+func newScope(seed *url.URL, opts Options) *scope {
+	s := &scope{
+		mode:    opts.Scope,
+		host:    strings.TrimPrefix(strings.ToLower(seed.Hostname()), "www."),
+		include: compilePatterns(opts.Include),
+		exclude: compilePatterns(opts.Exclude),
+	}
+	s.root = rootDomain(s.host)
+	if len(opts.AllowedSubdomains) > 0 {
+		s.subdomains = map[string]bool{}
+		for _, sub := range opts.AllowedSubdomains {
+			s.subdomains[strings.ToLower(strings.TrimSpace(sub))] = true
+		}
+	}
+	if opts.SeedPathOnly {
+		s.pathPrefix = strings.TrimSuffix(seed.EscapedPath(), "/")
+	}
+	return s
+}
+
+// rootDomain 返回主机的根域名（无法判断时返回主机本身）
+func rootDomain(host string) string {
+	if root, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return root
+	}
+	return host
+}
+
+// allows 判断 URL 是否在爬取范围内
+//
+// 依次检查主机范围、种子路径前缀（仅限种子主机）、排除规则和包含规则。
+func (s *scope) allows(u *url.URL, normalized string) bool {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	switch s.mode {
+	case ScopeHost:
+		if host != s.host {
+			return false
+		}
+	case ScopeAny:
+	default:
+		if host != s.host && !s.allowsSubdomain(host) {
+			return false
+		}
+	}
+
+	if s.pathPrefix != "" {
+		path := strings.TrimSuffix(u.EscapedPath(), "/")
+		if host != s.host || (path != s.pathPrefix && !strings.HasPrefix(path, s.pathPrefix+"/")) {
+			return false
+		}
+	}
+
+	for _, p := range s.exclude {
+		if p.match(normalized) {
+			return false
+		}
+	}
+	if len(s.include) == 0 {
+		return true
+	}
+	for _, p := range s.include {
+		if p.match(normalized) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsSubdomain 判断主机是否为种子根域名下允许的子域名
+func (s *scope) allowsSubdomain(host string) bool {
+	if host != s.root && !strings.HasSuffix(host, "."+s.root) {
+		return false
+	}
+	if s.subdomains == nil {
+		return true
+	}
+	sub := strings.TrimSuffix(strings.TrimSuffix(host, s.root), ".")
+	return sub == "" || s.subdomains[sub]
+}
@@ -0,0 +1,178 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现 AMP / 打印版等备用版本的发现与选择
+
+package extractor
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 提取所用的页面版本
+const (
+	// VariantCanonical 原始页面（规范 URL）
+	VariantCanonical = "canonical"
+	// VariantAMP AMP 版本（<link rel="amphtml">）
+	VariantAMP = "amp"
+	// VariantPrint 打印版本
+	VariantPrint = "print"
+)
+
+// Alternate 页面的备用版本
+type Alternate struct {
+	Variant string `json:"variant"`
+	URL     string `json:"url"`
+}
+
+// printTextPattern 打印版链接文本
+var printTextPattern = regexp.MustCompile(`(?i)^\s*(打印|打印本页|打印本文|打印文章|打印版|print|print\s+this(\s+(article|page|story))?|print\s+version|printer[\s-]friendly(\s+version)?)\s*$`)
+
+// FindAlternates 查找页面的 AMP 和打印版本
+//
+// AMP 版本和打印版本通常去除了侧边栏、评论、广告等干扰元素，
+// 且反爬保护较弱，Readability 在这类页面上的提取效果更好。
+//
+// 识别规则：
+//   - AMP: <link rel="amphtml" href="...">
+//   - 打印版: <link rel="alternate" media="print">，或文本为「打印」「Print」等的链接
+//     （href 为 javascript:window.print() 的按钮会被忽略）
+//
+// 只接受与页面同一站点（含子域名）的地址，避免页面把提取引向其他站点。
+//
+// 返回：
+//   - 备用版本列表，AMP 在前、打印版在后，每种最多一个
+func FindAlternates(html, pageURL string) []Alternate {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	return findAlternates(doc, base)
+}
+
+// findAlternates 在已解析的文档中查找备用版本
+func findAlternates(doc *goquery.Document, base *url.URL) []Alternate {
+	var alternates []Alternate
+	self := normalizePageURL(base)
+
+	resolve := func(href string) string {
+		href = strings.TrimSpace(href)
+		if href == "" || strings.HasPrefix(href, "#") {
+			return ""
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			return ""
+		}
+		abs := base.ResolveReference(ref)
+		if (abs.Scheme != "http" && abs.Scheme != "https") || !sameSite(abs.Hostname(), base.Hostname()) {
+			return ""
+		}
+		normalized := normalizePageURL(abs)
+		if normalized == self {
+			return ""
+		}
+		return normalized
+	}
+
+	// 1. AMP
+	if amp := resolve(doc.Find(`link[rel~="amphtml"]`).First().AttrOr("href", "")); amp != "" {
+		alternates = append(alternates, Alternate{Variant: VariantAMP, URL: amp})
+	}
+
+	// 2. 打印版
+	var printURL string
+	doc.Find(`link[rel~="alternate"][media="print"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		printURL = resolve(s.AttrOr("href", ""))
+		return printURL == ""
+	})
+	if printURL == "" {
+		doc.Find("a[href]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+			text := strings.TrimSpace(s.Text())
+			if text == "" {
+				text = s.AttrOr("title", "")
+			}
+			if !printTextPattern.MatchString(text) {
+				return true
+			}
+			printURL = resolve(s.AttrOr("href", ""))
+			return printURL == ""
+		})
+	}
+	if printURL != "" {
+		alternates = append(alternates, Alternate{Variant: VariantPrint, URL: printURL})
+	}
+
+	return alternates
+}
+
+// sameSite 判断两个主机名是否属于同一站点（忽略 www. 前缀，允许子域名）
+func sameSite(a, b string) bool {
+	a = strings.TrimPrefix(strings.ToLower(a), "www.")
+	b = strings.TrimPrefix(strings.ToLower(b), "www.")
+	return a == b || strings.HasSuffix(a, "."+b) || strings.HasSuffix(b, "."+a)
+}
+
+// preferAlternate 抓取备用版本并与原始页面比较提取质量
+//
+// 依次抓取 AMP、打印版，使用相同流程提取后按 scoreExtraction 打分，
+// 得分更高的版本胜出。原始页面提取失败时，任一成功的备用版本都会被采用。
+//
+// 参数：
+//   - preprocessedHTML: 原始页面预处理后的 HTML（用于发现备用版本）
+//   - canonical: 原始页面的提取结果（提取失败时为 nil）
+//   - opts: 提取选项（使用其中的 PageFetcher、嵌入媒体渲染方式和净化配置）
+//
+// 返回：
+//   - 最终采用的提取结果（Variant/VariantURL 已标注），全部失败时为 nil
+func (e *Extractor) preferAlternate(ctx context.Context, preprocessedHTML string, pageURL *url.URL, canonical *ExtractResult, opts ExtractOptions) *ExtractResult {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(preprocessedHTML))
+	if err != nil {
+		return canonical
+	}
+	alternates := findAlternates(doc, pageURL)
+	if len(alternates) == 0 {
+		return canonical
+	}
+
+	best := canonical
+	bestScore := -1.0
+	if canonical != nil {
+		bestScore = scoreExtraction(canonical)
+	}
+
+	for _, alt := range alternates {
+		if ctx.Err() != nil {
+			break
+		}
+		html, finalURL, err := opts.PageFetcher(ctx, alt.URL)
+		if err != nil {
+			log.Printf("[Alternate] 抓取 %s 版本失败 %s: %v", alt.Variant, alt.URL, err)
+			continue
+		}
+		if finalURL == "" {
+			finalURL = alt.URL
+		}
+		result, _, err := e.extractDocument(ctx, html, finalURL, ExtractOptions{EmbedMode: opts.EmbedMode, SanitizeProfile: opts.SanitizeProfile})
+		if err != nil {
+			log.Printf("[Alternate] 提取 %s 版本失败 %s: %v", alt.Variant, finalURL, err)
+			continue
+		}
+		if score := scoreExtraction(result); score > bestScore {
+			result.Variant = alt.Variant
+			result.VariantURL = finalURL
+			best, bestScore = result, score
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,54 @@
+package extractor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindAlternates(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected []Alternate
+	}{
+		{
+			name: "AMP 和打印版",
+			html: `<html><head><link rel="amphtml" href="https://example.com/amp/news/1.html"></head>
+				<body><a href="javascript:window.print()">打印</a><a href="/print/news/1.html">打印本页</a></body></html>`,
+			expected: []Alternate{
+				{Variant: VariantAMP, URL: "https://example.com/amp/news/1.html"},
+				{Variant: VariantPrint, URL: "https://example.com/print/news/1.html"},
+			},
+		},
+		{
+			name: "link media=print",
+			html: `<html><head><link rel="alternate" media="print" href="?print=1"></head><body></body></html>`,
+			expected: []Alternate{
+				{Variant: VariantPrint, URL: "https://example.com/news/1.html?print=1"},
+			},
+		},
+		{
+			name:     "外站打印链接忽略",
+			html:     `<a href="https://printer.example.org/news/1">Print</a>`,
+			expected: nil,
+		},
+		{
+			name: "外站 AMP 和 link media=print 忽略",
+			html: `<html><head><link rel="amphtml" href="https://amp.attacker.net/news/1.html">
+				<link rel="alternate" media="print" href="https://attacker.net/print/1"></head>
+				<body><a href="https://m.example.com/print/news/1.html">Print</a></body></html>`,
+			expected: []Alternate{
+				{Variant: VariantPrint, URL: "https://m.example.com/print/news/1.html"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FindAlternates(tt.html, "https://example.com/news/1.html")
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("FindAlternates() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
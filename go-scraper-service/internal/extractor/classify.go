@@ -0,0 +1,322 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现页面类型识别（文章、列表页、首页、错误页）
+
+package extractor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// 页面类型
+const (
+	PageTypeArticle  = "article"
+	PageTypeListing  = "listing"
+	PageTypeHomepage = "homepage"
+	PageTypeError    = "error"
+	PageTypeOther    = "other"
+	// PageTypeDocument 文档（PDF 等非网页内容，不参与网页分类）
+	PageTypeDocument = "document"
+)
+
+// PageClassRefuseConfidence 拒绝提取非文章页面的最低置信度
+//
+// 开启 RequireArticle 时，只有以不低于该置信度判定为非文章的页面才会被拒绝，
+// 判断不确定的页面仍按文章提取。
+const PageClassRefuseConfidence = 0.6
+
+// ErrNotArticle 页面不是文章（开启 RequireArticle 时返回，可用 errors.As 取得 *NotArticleError）
+var ErrNotArticle = errors.New("page is not an article")
+
+// NotArticleError 拒绝提取非文章页面的错误
+type NotArticleError struct {
+	Class *PageClass
+}
+
+func (e *NotArticleError) Error() string {
+	return fmt.Sprintf("page is not an article: classified as %s (confidence %.2f)", e.Class.Type, e.Class.Confidence)
+}
+
+// Is 支持 errors.Is(err, ErrNotArticle)
+func (e *NotArticleError) Is(target error) bool {
+	return target == ErrNotArticle
+}
+
+// PageClass 页面分类结果
+type PageClass struct {
+	Type       string  `json:"type"`       // article, listing, homepage, error, other, document
+	Confidence float64 `json:"confidence"` // 0-1
+	// 判定依据（诊断用），如 "jsonld:NewsArticle"、"url:listing"、"linkDensity:0.72"
+	Signals []string `json:"signals,omitempty"`
+}
+
+// pageFeatures 分类特征
+type pageFeatures struct {
+	ldTypes       []string
+	ogType        string
+	title         string
+	url           *url.URL
+	textLength    int     // 提取出的正文长度（字符）
+	paragraphs    int     // 正文中的长段落数
+	linkDensity   float64 // 整页链接文本占比
+	repeatedItems int     // 最大一组重复结构（卡片、列表项）的数量
+}
+
+// 错误页标题 / 标题特征
+var errorPagePattern = regexp.MustCompile(`(?i)\b(404|410|500|503)\b|not found|page (does not|doesn't) exist|no longer available|access denied|internal server error|页面不存在|找不到|未找到|已删除|不存在或已|出错了|访问出错|服务器错误`)
+
+// URL 路径特征
+var (
+	listingPathPattern = regexp.MustCompile(`(?i)/(tag|tags|category|categories|topic|topics|section|channel|archive|archives|author|authors|search|list|index|column|page|labels?)(/|$|\.|_)|/page/\d+/?$|/(list|index)_\d+\.s?html?$`)
+	listingQueryKeys   = []string{"page", "q", "s", "query", "keyword", "tag", "category", "cat"}
+	rootPathPattern    = regexp.MustCompile(`(?i)^/(index|default|home)(\.\w+)?$`)
+	articlePathPattern = regexp.MustCompile(`/(19|20)\d{2}[/-]?\d{2}([/-]?\d{2})?/|/\d{5,}(\.s?html?|/|$)|[a-z0-9]+(-[a-z0-9]+){3,}(\.s?html?|/)?$|/(article|articles|post|posts|story|stories|news|detail|content|a|p|doc|blog|node)/[^/]+`)
+	articleIDPattern   = regexp.MustCompile(`^\d+$`)
+)
+
+// ClassifyPage 识别页面类型
+//
+// 综合以下信号为各类型打分，置信度为第一名得分占前两名得分之和的比例：
+//   - JSON-LD @type 与 og:type（Article / CollectionPage / SearchResultsPage / WebSite 等）
+//   - URL 形态：根路径为首页；tag、category、search、分页参数为列表页；日期、长 slug、数字 ID 为文章
+//   - 整页链接密度：列表页和首页的可见文字大多是链接
+//   - 重复块结构：同一父元素下大量结构相同且含链接的子元素（卡片、条目列表）
+//   - 正文长度与段落数：文章有成段的连续正文
+//   - 标题中的 404、Not Found、页面不存在等错误特征（配合很短的正文）
+//
+// 得分最高的类型不足以和其他类型拉开差距时返回 other。
+func ClassifyPage(pageHTML, pageURL string) *PageClass {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return &PageClass{Type: PageTypeOther}
+	}
+	u, _ := url.Parse(pageURL)
+	if u == nil {
+		u = &url.URL{}
+	}
+	f := collectPageFeatures(doc, u)
+	if article, err := ExtractWithReadability(pageHTML, pageURL); err == nil {
+		f.textLength, f.paragraphs = contentStats(article.Content, article.TextContent)
+	}
+	return classify(f)
+}
+
+// classifyDocument 使用提取流程已有的解析结果和正文识别页面类型
+func classifyDocument(doc *goquery.Document, u *url.URL, contentHTML, text string) *PageClass {
+	f := collectPageFeatures(doc, u)
+	f.textLength, f.paragraphs = contentStats(contentHTML, text)
+	return classify(f)
+}
+
+// contentStats 正文长度与长段落数
+func contentStats(contentHTML, text string) (int, int) {
+	length := utf8.RuneCountInString(strings.Join(strings.Fields(text), ""))
+	paragraphs := 0
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(contentHTML)); err == nil {
+		doc.Find("p").Each(func(_ int, p *goquery.Selection) {
+			if utf8.RuneCountInString(strings.TrimSpace(p.Text())) >= 60 {
+				paragraphs++
+			}
+		})
+	}
+	return length, paragraphs
+}
+
+// collectPageFeatures 收集整页特征
+func collectPageFeatures(doc *goquery.Document, u *url.URL) *pageFeatures {
+	f := &pageFeatures{
+		url:    u,
+		ogType: strings.ToLower(strings.TrimSpace(doc.Find(`meta[property="og:type"]`).AttrOr("content", ""))),
+		title:  strings.TrimSpace(firstNonEmpty(doc.Find("title").First().Text(), doc.Find("h1").First().Text())),
+	}
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, s *goquery.Selection) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &data); err != nil {
+			return
+		}
+		for _, node := range flattenLD(data, nil) {
+			f.ldTypes = append(f.ldTypes, ldStrings(node["@type"])...)
+		}
+	})
+
+	body := doc.Find("body")
+	textLen, linkLen := 0, 0
+	for _, node := range body.Nodes {
+		countVisibleText(node, false, &textLen, &linkLen)
+	}
+	if textLen > 0 {
+		f.linkDensity = float64(linkLen) / float64(textLen)
+	}
+	f.repeatedItems = maxRepeatedItems(body)
+	return f
+}
+
+// countVisibleText 统计可见文字长度及其中链接文字的长度（不修改文档，提取流程会复用同一文档）
+func countVisibleText(n *html.Node, inLink bool, textLen, linkLen *int) {
+	switch n.Type {
+	case html.TextNode:
+		length := utf8.RuneCountInString(strings.Join(strings.Fields(n.Data), ""))
+		*textLen += length
+		if inLink {
+			*linkLen += length
+		}
+		return
+	case html.ElementNode:
+		switch n.Data {
+		case "script", "style", "noscript", "template":
+			return
+		case "a":
+			inLink = true
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		countVisibleText(child, inLink, textLen, linkLen)
+	}
+}
+
+// maxRepeatedItems 统计同一父元素下结构相同（标签 + class）且含链接的子元素的最大数量
+func maxRepeatedItems(body *goquery.Selection) int {
+	best := 0
+	body.Find("*").Each(func(_ int, parent *goquery.Selection) {
+		counts := map[string]int{}
+		for child := parent.Get(0).FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			item := goquery.NewDocumentFromNode(child).Selection
+			if item.Find("a[href]").Length() == 0 && child.Data != "a" {
+				continue
+			}
+			// 纯链接列表（导航、页脚）的条目很短，卡片 / 条目通常带标题或摘要
+			if utf8.RuneCountInString(strings.TrimSpace(item.Text())) < 8 {
+				continue
+			}
+			key := child.Data + "." + attr(child, "class")
+			counts[key]++
+			best = max(best, counts[key])
+		}
+	})
+	return best
+}
+
+// classify 按特征打分
+func classify(f *pageFeatures) *PageClass {
+	scores := map[string]float64{
+		PageTypeArticle: 0, PageTypeListing: 0, PageTypeHomepage: 0, PageTypeError: 0, PageTypeOther: 0.5,
+	}
+	var signals []string
+	add := func(pageType string, weight float64, signal string) {
+		scores[pageType] += weight
+		signals = append(signals, signal)
+	}
+
+	// JSON-LD / OpenGraph 类型
+	for _, t := range f.ldTypes {
+		switch {
+		case isArticleType(t):
+			add(PageTypeArticle, 2, "jsonld:"+t)
+		case t == "CollectionPage" || t == "ItemList" || t == "SearchResultsPage":
+			add(PageTypeListing, 1.5, "jsonld:"+t)
+		}
+	}
+	if f.ogType == "article" {
+		add(PageTypeArticle, 1, "og:article")
+	}
+
+	// URL 形态
+	path := strings.TrimSuffix(f.url.EscapedPath(), "/")
+	switch {
+	case hasArticleQuery(f.url):
+		add(PageTypeArticle, 1, "url:article")
+	case path == "" || rootPathPattern.MatchString(path):
+		if !hasListingQuery(f.url) {
+			add(PageTypeHomepage, 3, "url:root")
+		}
+	case listingPathPattern.MatchString(path + "/"):
+		add(PageTypeListing, 1.2, "url:listing")
+	case articlePathPattern.MatchString(path):
+		add(PageTypeArticle, 1, "url:article")
+	}
+	if hasListingQuery(f.url) {
+		add(PageTypeListing, 1, "url:query")
+	}
+
+	// 链接密度与重复结构
+	switch {
+	case f.linkDensity >= 0.5:
+		add(PageTypeListing, 1, fmt.Sprintf("linkDensity:%.2f", f.linkDensity))
+		scores[PageTypeHomepage] += 0.5
+	case f.linkDensity <= 0.25 && f.linkDensity > 0:
+		add(PageTypeArticle, 0.5, fmt.Sprintf("linkDensity:%.2f", f.linkDensity))
+	}
+	if f.repeatedItems >= 8 {
+		add(PageTypeListing, 1.2, fmt.Sprintf("repeatedItems:%d", f.repeatedItems))
+		scores[PageTypeHomepage] += 0.5
+	}
+
+	// 正文长度
+	switch {
+	case f.textLength >= 1500 && f.paragraphs >= 3:
+		add(PageTypeArticle, 1.5, fmt.Sprintf("text:%d/%d", f.textLength, f.paragraphs))
+	case f.textLength >= 500 && f.paragraphs >= 2:
+		add(PageTypeArticle, 0.8, fmt.Sprintf("text:%d/%d", f.textLength, f.paragraphs))
+	case f.textLength < 200:
+		add(PageTypeOther, 0.3, fmt.Sprintf("text:%d", f.textLength))
+		scores[PageTypeError] += 0.2
+	}
+
+	// 错误页
+	if errorPagePattern.MatchString(f.title) {
+		weight := 1.5
+		if f.textLength < 500 {
+			weight = 3
+		}
+		add(PageTypeError, weight, "title:error")
+	}
+
+	types := make([]string, 0, len(scores))
+	for t := range scores {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if scores[types[i]] != scores[types[j]] {
+			return scores[types[i]] > scores[types[j]]
+		}
+		return types[i] < types[j]
+	})
+
+	// 置信度 = 第一名得分 / (第一名 + 第二名)；前两名接近时不下结论，归为 other
+	best, second := types[0], types[1]
+	if best != PageTypeOther && scores[best]-scores[second] < 0.5 {
+		best, second = PageTypeOther, types[0]
+	}
+	confidence := scores[best] / (scores[best] + scores[second])
+	return &PageClass{Type: best, Confidence: math.Round(confidence*100) / 100, Signals: signals}
+}
+
+// hasListingQuery 判断查询参数是否为分页 / 搜索 / 筛选
+func hasListingQuery(u *url.URL) bool {
+	query := u.Query()
+	for _, key := range listingQueryKeys {
+		if query.Has(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasArticleQuery 判断是否为 WordPress 默认固定链接（?p=文章ID）
+func hasArticleQuery(u *url.URL) bool {
+	return articleIDPattern.MatchString(u.Query().Get("p"))
+}
@@ -0,0 +1,95 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestClassifyPage(t *testing.T) {
+	story := strings.Repeat("<p>The council approved the new transit plan on Tuesday after months of debate among residents and officials.</p>\n", 15)
+
+	body := strings.Repeat("<p>The council approved the new transit plan on Tuesday after months of debate among residents and officials.</p>\n", 6)
+
+	var cards strings.Builder
+	for i := 0; i < 12; i++ {
+		fmt.Fprintf(&cards, `<div class="card"><a href="/news/2026/03/story-%d">Headline number %d about the city</a><span>Short teaser</span></div>`, i, i)
+	}
+
+	tests := []struct {
+		name string
+		html string
+		url  string
+		want string
+	}{
+		{
+			name: "新闻文章",
+			html: `<html><head><title>Council approves plan</title><meta property="og:type" content="article">
+				<script type="application/ld+json">{"@type":"NewsArticle","headline":"Council approves plan"}</script></head>
+				<body><nav><a href="/">Home</a></nav><article><h1>Council approves plan</h1>` + story + `</article></body></html>`,
+			url:  "https://example.com/news/2026/03/05/council-approves-new-transit-plan",
+			want: PageTypeArticle,
+		},
+		{
+			name: "WordPress ?p= 文章",
+			html: `<html><head><title>Council approves plan</title></head><body><article>` + body + `</article></body></html>`,
+			url:  "https://blog.example.com/?p=123",
+			want: PageTypeArticle,
+		},
+		{
+			name: "Drupal /node/ 文章",
+			html: `<html><head><title>Council approves plan</title></head><body><article>` + body + `</article></body></html>`,
+			url:  "https://example.org/node/4512",
+			want: PageTypeArticle,
+		},
+		{
+			name: "标签列表页",
+			html: `<html><head><title>Tag: transit</title></head><body><h1>transit</h1><div class="list">` + cards.String() + `</div></body></html>`,
+			url:  "https://example.com/tag/transit?page=2",
+			want: PageTypeListing,
+		},
+		{
+			name: "首页",
+			html: `<html><head><title>Example News</title><script type="application/ld+json">{"@type":"WebSite","name":"Example"}</script></head>
+				<body><div class="grid">` + cards.String() + `</div></body></html>`,
+			url:  "https://example.com/",
+			want: PageTypeHomepage,
+		},
+		{
+			name: "软 404 页面",
+			html: `<html><head><title>页面不存在 - 示例网</title></head><body><h1>抱歉，您访问的页面不存在</h1><p><a href="/">返回首页</a></p></body></html>`,
+			url:  "https://example.com/news/123456.html",
+			want: PageTypeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyPage(tt.html, tt.url)
+			if got.Type != tt.want {
+				t.Errorf("ClassifyPage() = %+v, want %s", got, tt.want)
+			}
+			if got.Confidence < PageClassRefuseConfidence {
+				t.Errorf("Confidence = %v, signals = %v", got.Confidence, got.Signals)
+			}
+		})
+	}
+}
+
+func TestRequireArticle(t *testing.T) {
+	var cards strings.Builder
+	for i := 0; i < 12; i++ {
+		fmt.Fprintf(&cards, `<li class="item"><a href="/p/%d">Entry number %d in the archive</a></li>`, i, i)
+	}
+	page := `<html><head><title>Archive</title></head><body><ul>` + cards.String() + `</ul></body></html>`
+
+	_, err := New().ExtractWithOptions(t.Context(), page, "https://example.com/archives/2026", ExtractOptions{RequireArticle: true})
+	var notArticle *NotArticleError
+	if !errors.Is(err, ErrNotArticle) || !errors.As(err, &notArticle) {
+		t.Fatalf("ExtractWithOptions() error = %v, want ErrNotArticle", err)
+	}
+	if notArticle.Class.Type != PageTypeListing {
+		t.Errorf("Class = %+v", notArticle.Class)
+	}
+}
@@ -0,0 +1,515 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现文章发布时间提取
+
+package extractor
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 发布时间来源
+const (
+	// DateSourceJSONLD JSON-LD datePublished
+	DateSourceJSONLD = "jsonld"
+	// DateSourceMeta meta 标签（article:published_time、Dublin Core 等）
+	DateSourceMeta = "meta"
+	// DateSourceTimeTag <time datetime> 或 itemprop="datePublished" 元素
+	DateSourceTimeTag = "time"
+	// DateSourceText 标题附近的可见文本（署名行、日期栏）
+	DateSourceText = "text"
+	// DateSourceURL URL 路径中的日期
+	DateSourceURL = "url"
+	// DateSourceRule 站点规则 date 选择器
+	DateSourceRule = "rule"
+	// DateSourcePDF PDF 文档信息中的创建时间
+	DateSourcePDF = "pdf"
+)
+
+// DateResult 发布时间提取结果
+type DateResult struct {
+	Time       time.Time `json:"time"`          // RFC3339
+	Confidence float64   `json:"confidence"`    // 0-1
+	Source     string    `json:"source"`        // jsonld, meta, time, text, url, rule, pdf
+	Raw        string    `json:"raw,omitempty"` // 原始文本
+}
+
+// 各来源的基础置信度
+//
+// 不带时区的值按默认时区解释，只精确到日的值缺少时刻，均会降低置信度；
+// 与 URL 中的日期一致时提高置信度。
+var dateSourceConfidence = map[string]float64{
+	DateSourceJSONLD:  0.95,
+	DateSourceMeta:    0.9,
+	DateSourceTimeTag: 0.85,
+	DateSourceText:    0.7,
+	DateSourceURL:     0.5,
+	DateSourceRule:    0.95,
+	DateSourcePDF:     0.8,
+}
+
+// publishedMetaKeys 发布时间 meta 标签（按优先级）
+var publishedMetaKeys = []string{
+	"article:published_time", "og:published_time", "datepublished", "dcterms.created", "dc.date.issued",
+	"dc.date", "pubdate", "publishdate", "publish_date", "parsely-pub-date", "sailthru.date",
+}
+
+// DateExtractor 发布时间提取器
+type DateExtractor struct {
+	location *time.Location
+	now      func() time.Time
+}
+
+// NewDateExtractor 创建发布时间提取器
+//
+// loc 为不带时区的日期（如「2026年3月5日 14:30」）和相对时间使用的默认时区，为 nil 时使用 UTC。
+func NewDateExtractor(loc *time.Location) *DateExtractor {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &DateExtractor{location: loc, now: time.Now}
+}
+
+// ExtractDate 从 HTML 中提取发布时间
+func (d *DateExtractor) ExtractDate(html, pageURL string) *DateResult {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil
+	}
+	base, _ := url.Parse(pageURL)
+	return d.extract(doc, base)
+}
+
+// extract 在已解析的文档中提取发布时间
+//
+// 按来源可信度依次尝试：JSON-LD → meta 标签 → <time> 元素 → 标题附近文本 → URL 路径，
+// 取第一个成功解析的结果；URL 日期同时用于交叉验证。
+func (d *DateExtractor) extract(doc *goquery.Document, base *url.URL) *DateResult {
+	var urlDate *DateResult
+	if base != nil {
+		urlDate = d.fromURL(base.Path)
+	}
+
+	result := d.fromJSONLD(doc)
+	if result == nil {
+		result = d.fromMeta(doc)
+	}
+	if result == nil {
+		result = d.fromTimeTags(doc)
+	}
+	if result == nil {
+		result = d.fromText(doc)
+	}
+	if result == nil {
+		return urlDate
+	}
+
+	if urlDate != nil && sameDay(result.Time.In(d.location), urlDate.Time) {
+		result.Confidence = min(result.Confidence+0.05, 1)
+	}
+	return result
+}
+
+// fromJSONLD 从 JSON-LD 提取
+func (d *DateExtractor) fromJSONLD(doc *goquery.Document) *DateResult {
+	return d.parseAs(findArticleLD(doc).str("datePublished"), DateSourceJSONLD)
+}
+
+// fromMeta 从 meta 标签提取
+func (d *DateExtractor) fromMeta(doc *goquery.Document) *DateResult {
+	meta := collectMetaTags(doc)
+	for _, key := range publishedMetaKeys {
+		if result := d.parseAs(meta.get(key), DateSourceMeta); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// fromTimeTags 从 <time datetime> 和 itemprop="datePublished" 元素提取
+//
+// 优先带 pubdate / itemprop / published 标识的元素，其次正文区域内的第一个 <time>。
+func (d *DateExtractor) fromTimeTags(doc *goquery.Document) *DateResult {
+	selectors := []string{
+		`[itemprop="datePublished"]`,
+		`time[pubdate]`,
+		`time[class*="publish"], time[class*="date"]`,
+		`article time[datetime], header time[datetime]`,
+		`time[datetime]`,
+	}
+	for _, selector := range selectors {
+		var result *DateResult
+		doc.Find(selector).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+			value := firstNonEmpty(s.AttrOr("datetime", ""), s.AttrOr("content", ""), s.Text())
+			result = d.parseAs(value, DateSourceTimeTag)
+			return result == nil
+		})
+		if result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// dateBlockPattern 日期栏 / 署名行的 class 或 id 特征
+var dateBlockPattern = regexp.MustCompile(`(?i)(date|time|publish|pubtime|post-?meta|byline|article-?info|article-?meta|source|info)`)
+
+// fromText 从标题附近的可见文本提取
+//
+// 先查找 class / id 具有日期特征的短文本元素，再查看 <h1> 之后相邻的元素。
+func (d *DateExtractor) fromText(doc *goquery.Document) *DateResult {
+	var result *DateResult
+	try := func(s *goquery.Selection) bool {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || len([]rune(text)) > 300 {
+			return true
+		}
+		result = d.parseText(text)
+		return result == nil
+	}
+
+	doc.Find("body *").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if goquery.NodeName(s) == "script" || goquery.NodeName(s) == "style" {
+			return true
+		}
+		if !dateBlockPattern.MatchString(s.AttrOr("class", "") + " " + s.AttrOr("id", "")) {
+			return true
+		}
+		return try(s)
+	})
+	if result != nil {
+		return result
+	}
+
+	h1 := doc.Find("h1").First()
+	if h1.Length() == 0 {
+		return nil
+	}
+	candidates := h1.NextAll().Slice(0, min(5, h1.NextAll().Length()))
+	candidates = candidates.AddSelection(h1.Parent().NextAll().Slice(0, min(3, h1.Parent().NextAll().Length())))
+	candidates.EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		return try(s)
+	})
+	return result
+}
+
+// URL 中的日期：/2026/03/05/、/2026-03-05/、/20260305/、t20260305_123.html
+var (
+	urlDateSlashPattern   = regexp.MustCompile(`/((?:19|20)\d{2})/(\d{1,2})/(\d{1,2})(?:/|$)`)
+	urlDateDashPattern    = regexp.MustCompile(`(?:^|[/_-])((?:19|20)\d{2})-(\d{2})-(\d{2})(?:[/_.-]|$)`)
+	urlDateCompactPattern = regexp.MustCompile(`(?:/|/t|_)((?:19|20)\d{2})(\d{2})(\d{2})(?:[/_.]|$)`)
+)
+
+// fromURL 从 URL 路径提取日期
+func (d *DateExtractor) fromURL(path string) *DateResult {
+	for _, pattern := range []*regexp.Regexp{urlDateSlashPattern, urlDateDashPattern, urlDateCompactPattern} {
+		m := pattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		if t, ok := d.buildDate(m[1], m[2], m[3], "", "", "", ""); ok {
+			return &DateResult{Time: t, Confidence: dateSourceConfidence[DateSourceURL], Source: DateSourceURL, Raw: m[0]}
+		}
+	}
+	return nil
+}
+
+// parseAs 解析结构化日期值（meta、JSON-LD、datetime 属性），失败时按可见文本解析
+func (d *DateExtractor) parseAs(value, source string) *DateResult {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	t, precise, ok := d.parseStructured(value)
+	if !ok {
+		result := d.parseText(value)
+		if result == nil {
+			return nil
+		}
+		t = result.Time
+	}
+
+	confidence := dateSourceConfidence[source]
+	if !precise {
+		confidence -= 0.1
+	}
+	return &DateResult{Time: t, Confidence: confidence, Source: source, Raw: value}
+}
+
+// zonedLayouts 带时区的日期格式
+var zonedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.000Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -0700 MST",
+	time.RFC1123Z,
+	// RSS / 邮件常见的 RFC 822 变体：日期不补零、缺少星期或秒、两位年份
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 06 15:04:05 -0700",
+}
+
+// namedZoneLayouts 以时区缩写表示时区的格式
+//
+// 按默认时区解析：缩写与默认时区一致时使用其偏移（如 Asia/Shanghai 下的 CST 为 +0800，
+// 而不是美国中部时间），GMT / UTC 等其余缩写按 Go 的规则处理。
+var namedZoneLayouts = []string{
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC850,
+}
+
+// localLayouts 不带时区的日期格式（按默认时区解释）
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.000",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+}
+
+// dateOnlyLayouts 只精确到日的格式
+var dateOnlyLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"20060102",
+}
+
+// parseStructured 解析机器可读的日期
+//
+// precise 表示值同时包含时刻和时区（或时间戳）。
+func (d *DateExtractor) parseStructured(value string) (t time.Time, precise bool, ok bool) {
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true, true
+		}
+	}
+	for _, layout := range namedZoneLayouts {
+		if t, err := time.ParseInLocation(layout, value, d.location); err == nil {
+			return t, true, true
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, d.location); err == nil {
+			return t, false, true
+		}
+	}
+	for _, layout := range dateOnlyLayouts {
+		if t, err := time.ParseInLocation(layout, value, d.location); err == nil {
+			return t, false, true
+		}
+	}
+	// Unix 时间戳（秒或毫秒）
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && len(value) >= 10 {
+		if len(value) >= 13 {
+			return time.UnixMilli(n).In(d.location), true, true
+		}
+		return time.Unix(n, 0).In(d.location), true, true
+	}
+	return time.Time{}, false, false
+}
+
+// 可见文本中的日期格式
+var (
+	// 2026年3月5日 14:30、2026年03月05日14时30分
+	zhDatePattern = regexp.MustCompile(`((?:19|20)\d{2})\s*年\s*(\d{1,2})\s*月\s*(\d{1,2})\s*[日号]?(?:\s*(\d{1,2})\s*[:：时]\s*(\d{1,2})(?:\s*[:：分]\s*(\d{1,2}))?)?`)
+	// 2026-03-05 14:30:00、2026/3/5、2026.03.05
+	numericDatePattern = regexp.MustCompile(`((?:19|20)\d{2})[-/.](\d{1,2})[-/.](\d{1,2})(?:[T\s]+(\d{1,2}):(\d{2})(?::(\d{2}))?)?`)
+	// March 5, 2026 2:30 PM、Mar. 5th, 2026
+	enMonthFirstPattern = regexp.MustCompile(`(?i)\b(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+((?:19|20)\d{2})(?:,?\s+(?:at\s+)?(\d{1,2}):(\d{2})(?::(\d{2}))?\s*([ap]\.?m\.?)?)?`)
+	// 5 March 2026 14:30
+	enDayFirstPattern = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?,?\s+((?:19|20)\d{2})(?:,?\s+(?:at\s+)?(\d{1,2}):(\d{2})(?::(\d{2}))?\s*([ap]\.?m\.?)?)?`)
+
+	// 3 小时前、5分钟前、2 天前
+	zhRelativePattern = regexp.MustCompile(`(\d+)\s*(秒|分钟|分|小时|个小时|天|日|周|星期|个月|月|年)前`)
+	// 昨天 14:30、前天、今天 08:00
+	zhDayPattern = regexp.MustCompile(`(今天|昨天|前天)\s*(?:(\d{1,2})[:：](\d{2}))?`)
+	// 3 hours ago、an hour ago
+	enRelativePattern = regexp.MustCompile(`(?i)\b(\d+|an?|one)\s+(second|sec|minute|min|hour|hr|day|week|month|year)s?\s+ago\b`)
+	// yesterday at 2:30 PM、today 08:00
+	enDayPattern = regexp.MustCompile(`(?i)\b(today|yesterday)\b(?:\s*(?:at\s+)?(\d{1,2}):(\d{2})\s*([ap]\.?m\.?)?)?`)
+	// 刚刚、just now
+	justNowPattern = regexp.MustCompile(`(?i)(刚刚|just now|moments? ago)`)
+)
+
+// parseText 解析可见文本中的日期（中文、英文、数字格式及相对时间）
+//
+// 带时刻的绝对日期置信度为 text 基础值，只有日期时降低 0.1，相对时间降低 0.15。
+func (d *DateExtractor) parseText(text string) *DateResult {
+	base := dateSourceConfidence[DateSourceText]
+	build := func(raw string, t time.Time, hasTime bool) *DateResult {
+		confidence := base
+		if !hasTime {
+			confidence -= 0.1
+		}
+		return &DateResult{Time: t, Confidence: confidence, Source: DateSourceText, Raw: raw}
+	}
+
+	if m := zhDatePattern.FindStringSubmatch(text); m != nil {
+		if t, ok := d.buildDate(m[1], m[2], m[3], m[4], m[5], m[6], ""); ok {
+			return build(m[0], t, m[4] != "")
+		}
+	}
+	if m := numericDatePattern.FindStringSubmatch(text); m != nil {
+		if t, ok := d.buildDate(m[1], m[2], m[3], m[4], m[5], m[6], ""); ok {
+			return build(m[0], t, m[4] != "")
+		}
+	}
+	if m := enMonthFirstPattern.FindStringSubmatch(text); m != nil {
+		if t, ok := d.buildDate(m[3], monthNumber(m[1]), m[2], m[4], m[5], m[6], m[7]); ok {
+			return build(m[0], t, m[4] != "")
+		}
+	}
+	if m := enDayFirstPattern.FindStringSubmatch(text); m != nil {
+		if t, ok := d.buildDate(m[3], monthNumber(m[2]), m[1], m[4], m[5], m[6], m[7]); ok {
+			return build(m[0], t, m[4] != "")
+		}
+	}
+
+	if t, raw, ok := d.parseRelative(text); ok {
+		return &DateResult{Time: t, Confidence: base - 0.15, Source: DateSourceText, Raw: raw}
+	}
+	return nil
+}
+
+// parseRelative 解析相对时间（基于当前时间和默认时区）
+func (d *DateExtractor) parseRelative(text string) (time.Time, string, bool) {
+	now := d.now().In(d.location)
+
+	if m := justNowPattern.FindString(text); m != "" {
+		return now, m, true
+	}
+	if m := zhRelativePattern.FindStringSubmatch(text); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return subtractUnit(now, n, m[2]), m[0], true
+	}
+	if m := enRelativePattern.FindStringSubmatch(text); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			n = 1 // a / an / one
+		}
+		return subtractUnit(now, n, strings.ToLower(m[2])), m[0], true
+	}
+
+	days := -1
+	var hour, minute, meridiem, raw string
+	if m := zhDayPattern.FindStringSubmatch(text); m != nil {
+		days = map[string]int{"今天": 0, "昨天": 1, "前天": 2}[m[1]]
+		raw, hour, minute = m[0], m[2], m[3]
+	} else if m := enDayPattern.FindStringSubmatch(text); m != nil {
+		days = map[string]int{"today": 0, "yesterday": 1}[strings.ToLower(m[1])]
+		raw, hour, minute, meridiem = m[0], m[2], m[3], m[4]
+	}
+	if days < 0 {
+		return time.Time{}, "", false
+	}
+	day := now.AddDate(0, 0, -days)
+	h, m, ok := clockTime(hour, minute, meridiem)
+	if !ok {
+		h, m = 0, 0
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, d.location), raw, true
+}
+
+// subtractUnit 从当前时间减去 n 个时间单位
+func subtractUnit(now time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "秒", "second", "sec":
+		return now.Add(-time.Duration(n) * time.Second)
+	case "分钟", "分", "minute", "min":
+		return now.Add(-time.Duration(n) * time.Minute)
+	case "小时", "个小时", "hour", "hr":
+		return now.Add(-time.Duration(n) * time.Hour)
+	case "天", "日", "day":
+		return now.AddDate(0, 0, -n)
+	case "周", "星期", "week":
+		return now.AddDate(0, 0, -7*n)
+	case "个月", "月", "month":
+		return now.AddDate(0, -n, 0)
+	default: // 年, year
+		return now.AddDate(-n, 0, 0)
+	}
+}
+
+// buildDate 由年月日时分秒构造时间（按默认时区），校验取值范围且不晚于明天
+func (d *DateExtractor) buildDate(year, month, day, hour, minute, second, meridiem string) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	mo, _ := strconv.Atoi(month)
+	dd, _ := strconv.Atoi(day)
+	if mo < 1 || mo > 12 || dd < 1 || dd > 31 {
+		return time.Time{}, false
+	}
+	h, mi, ok := clockTime(hour, minute, meridiem)
+	if !ok {
+		h, mi = 0, 0
+	}
+	s, _ := strconv.Atoi(second)
+	if s > 59 {
+		s = 0
+	}
+
+	t := time.Date(y, time.Month(mo), dd, h, mi, s, 0, d.location)
+	if t.Day() != dd || t.After(d.now().AddDate(0, 0, 1)) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// clockTime 解析时刻（支持 12 小时制 AM / PM）
+func clockTime(hour, minute, meridiem string) (int, int, bool) {
+	if hour == "" {
+		return 0, 0, false
+	}
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minute)
+	switch strings.ToLower(strings.ReplaceAll(meridiem, ".", "")) {
+	case "pm":
+		if h < 12 {
+			h += 12
+		}
+	case "am":
+		if h == 12 {
+			h = 0
+		}
+	}
+	if h > 23 || m > 59 {
+		return 0, 0, false
+	}
+	return h, m, true
+}
+
+// monthNumber 英文月份转数字字符串
+func monthNumber(name string) string {
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	prefix := strings.ToLower(name)
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+	for i, m := range months {
+		if m == prefix {
+			return strconv.Itoa(i + 1)
+		}
+	}
+	return ""
+}
+
+// sameDay 判断两个时间是否为同一天（按前者的时区）
+func sameDay(a, b time.Time) bool {
+	b = b.In(a.Location())
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,103 @@
+package extractor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateExtractor(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	d := NewDateExtractor(shanghai)
+	d.now = func() time.Time { return time.Date(2026, 3, 5, 18, 0, 0, 0, shanghai) }
+
+	tests := []struct {
+		name     string
+		html     string
+		url      string
+		expected time.Time
+		source   string
+	}{
+		{
+			name:     "JSON-LD 带时区",
+			html:     `<script type="application/ld+json">{"@type":"NewsArticle","datePublished":"2026-03-01T08:00:00Z"}</script>`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+			source:   DateSourceJSONLD,
+		},
+		{
+			name:     "meta 不带时区按默认时区",
+			html:     `<meta property="article:published_time" content="2026-03-02 09:30:00">`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 3, 2, 9, 30, 0, 0, shanghai),
+			source:   DateSourceMeta,
+		},
+		{
+			name:     "time 标签",
+			html:     `<article><h1>标题</h1><time datetime="2026-02-28T10:00:00+08:00">2月28日</time></article>`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 2, 28, 10, 0, 0, 0, shanghai),
+			source:   DateSourceTimeTag,
+		},
+		{
+			name:     "中文署名行",
+			html:     `<h1>标题</h1><div class="article-info">来源：新华社 2026年3月5日 14:30</div>`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 3, 5, 14, 30, 0, 0, shanghai),
+			source:   DateSourceText,
+		},
+		{
+			name:     "英文日期 12 小时制",
+			html:     `<h1>Title</h1><p class="byline">By Alice | March 4, 2026 at 2:15 PM</p>`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 3, 4, 14, 15, 0, 0, shanghai),
+			source:   DateSourceText,
+		},
+		{
+			name:     "相对时间",
+			html:     `<h1>Title</h1><span class="time">3 hours ago</span>`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 3, 5, 15, 0, 0, 0, shanghai),
+			source:   DateSourceText,
+		},
+		{
+			name:     "中文相对日期",
+			html:     `<h1>标题</h1><span class="pubtime">昨天 08:20</span>`,
+			url:      "https://example.com/a",
+			expected: time.Date(2026, 3, 4, 8, 20, 0, 0, shanghai),
+			source:   DateSourceText,
+		},
+		{
+			name:     "URL 路径",
+			html:     `<h1>Title</h1><p>No date here.</p>`,
+			url:      "https://example.com/2026/03/01/story.html",
+			expected: time.Date(2026, 3, 1, 0, 0, 0, 0, shanghai),
+			source:   DateSourceURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := d.ExtractDate("<html><body>"+tt.html+"</body></html>", tt.url)
+			if result == nil {
+				t.Fatal("ExtractDate() = nil")
+			}
+			if !result.Time.Equal(tt.expected) || result.Source != tt.source {
+				t.Errorf("ExtractDate() = %v (%s), want %v (%s)", result.Time, result.Source, tt.expected, tt.source)
+			}
+			if result.Confidence <= 0 || result.Confidence > 1 {
+				t.Errorf("Confidence = %v", result.Confidence)
+			}
+		})
+	}
+}
+
+func TestDateConfidenceCrossCheck(t *testing.T) {
+	d := NewDateExtractor(time.UTC)
+	html := `<html><head><meta property="article:published_time" content="2026-03-01T08:00:00Z"></head></html>`
+
+	matched := d.ExtractDate(html, "https://example.com/2026/03/01/a.html")
+	unmatched := d.ExtractDate(html, "https://example.com/a.html")
+	if matched.Confidence <= unmatched.Confidence {
+		t.Errorf("URL 日期一致时置信度应更高: %v <= %v", matched.Confidence, unmatched.Confidence)
+	}
+}
@@ -0,0 +1,322 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现订阅源和 sitemap 自动发现
+
+package extractor
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 候选地址的发现途径
+const (
+	DiscoverFoundInput  = "input"  // 请求的 URL 本身就是订阅源
+	DiscoverFoundLink   = "link"   // <link rel="alternate">
+	DiscoverFoundAnchor = "anchor" // 页面中指向订阅源的链接（RSS 图标、「订阅」等）
+	DiscoverFoundPath   = "path"   // 常见路径（/feed、/rss.xml、/atom.xml 等）
+	DiscoverFoundRobots = "robots" // robots.txt 中声明的 sitemap
+)
+
+// 候选对应的来源类型（与 Source.type 一致）
+const (
+	DiscoverTypeRSS       = "rss"
+	DiscoverTypeSiteCrawl = "sitecrawl"
+)
+
+// 发现选项默认值
+const (
+	DefaultDiscoverCandidates = 20 // 最多验证的候选地址数
+	discoverConcurrency       = 4  // 并发验证数
+)
+
+// DiscoveredSource 验证通过的候选来源
+type DiscoveredSource struct {
+	URL       string     `json:"url"`
+	Type      string     `json:"type"`   // rss（订阅源）, sitecrawl（sitemap）
+	Format    string     `json:"format"` // rss, atom, json, sitemap, sitemapindex
+	Title     string     `json:"title,omitempty"`
+	ItemCount int        `json:"itemCount"`           // 订阅源条目数或 sitemap 条目数
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"` // 最近更新时间
+	Found     string     `json:"found"`               // 发现途径：input, link, anchor, path, robots
+	Score     float64    `json:"score"`               // 排序得分
+}
+
+// DiscoverResult 自动发现结果
+type DiscoverResult struct {
+	SiteTitle  string             `json:"siteTitle,omitempty"`
+	Candidates []DiscoveredSource `json:"candidates"` // 按得分降序
+	Checked    int                `json:"checked"`    // 实际验证的候选地址数
+}
+
+// discoverCandidate 待验证的候选地址
+type discoverCandidate struct {
+	url   string
+	found string
+	title string // <link> 或锚点的标题
+}
+
+// feedPaths 常见订阅源路径（WordPress、Hugo、Jekyll、Hexo、Ghost 等）
+var feedPaths = []string{"/feed", "/rss.xml", "/atom.xml", "/feed.xml", "/index.xml", "/rss", "/feed.json"}
+
+// feedLinkTypes <link rel="alternate"> 中的订阅源类型
+var feedLinkTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/rdf+xml":   true,
+	"application/feed+json": true,
+	"application/json":      true,
+	"application/xml":       true,
+	"text/xml":              true,
+}
+
+var (
+	// feedHrefPattern 形如订阅源的链接
+	feedHrefPattern = regexp.MustCompile(`(?i)(/feeds?/?$|/rss/?$|/atom/?$|\.(rss|atom|rdf)$|(rss|atom|feed|index)\.(xml|json)$|feedburner\.com/|/feeds?/)`)
+	// feedTextPattern 订阅链接的锚文本
+	feedTextPattern = regexp.MustCompile(`(?i)^\s*(rss|atom|feed|rss\s*feed|rss\s*订阅|订阅|订阅本站)\s*$`)
+	// commentFeedPattern 评论订阅源（排序靠后）
+	commentFeedPattern = regexp.MustCompile(`(?i)(comments?|评论)`)
+)
+
+// Discover 发现站点的订阅源和 sitemap
+//
+// pageHTML 为 pageURL 的抓取结果（由调用方抓取），其余地址通过 fetch 抓取：
+//  1. pageURL 本身就是订阅源时直接返回
+//  2. 收集 <link rel="alternate">、页面中的订阅链接、站点根目录（及页面所在目录）的常见路径、
+//     robots.txt 中声明的 sitemap（未声明时尝试 /sitemap.xml）
+//  3. 并发抓取并解析每个候选，丢弃无法解析的地址，按最终 URL 去重
+//  4. 按发现途径、条目数和更新时间排序，订阅源排在 sitemap 之前
+//
+// maxCandidates 为最多验证的候选地址数，<= 0 时使用 DefaultDiscoverCandidates。
+func (e *Extractor) Discover(ctx context.Context, pageHTML, pageURL string, fetch PageFetcher, maxCandidates int) (*DiscoverResult, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	if maxCandidates <= 0 {
+		maxCandidates = DefaultDiscoverCandidates
+	}
+	result := &DiscoverResult{Candidates: []DiscoveredSource{}}
+
+	if feed, err := e.ParseFeed(pageHTML, "", pageURL); err == nil {
+		result.SiteTitle = feed.Title
+		result.Checked = 1
+		result.Candidates = append(result.Candidates, feedSource(pageURL, feed, discoverCandidate{found: DiscoverFoundInput}))
+		return result, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, err
+	}
+	result.SiteTitle = firstNonEmpty(doc.Find(`meta[property="og:site_name"]`).AttrOr("content", ""), doc.Find("title").First().Text())
+
+	candidates := feedCandidates(doc, base)
+	for _, s := range sitemapRoots(ctx, base, fetch) {
+		candidates = append(candidates, discoverCandidate{url: s, found: DiscoverFoundRobots})
+	}
+
+	// 去重并截断
+	seen := map[string]bool{NormalizeURL(base): true}
+	unique := candidates[:0]
+	for _, c := range candidates {
+		u, err := url.Parse(c.url)
+		if err != nil || seen[NormalizeURL(u)] {
+			continue
+		}
+		seen[NormalizeURL(u)] = true
+		unique = append(unique, c)
+	}
+	candidates = unique[:min(len(unique), maxCandidates)]
+	if fetch == nil {
+		return result, nil
+	}
+
+	// 并发验证
+	sources := make([]*DiscoveredSource, len(candidates))
+	sem := make(chan struct{}, discoverConcurrency)
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		go func(i int, c discoverCandidate) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			sources[i] = e.validateCandidate(ctx, c, fetch)
+		}(i, c)
+	}
+	wg.Wait()
+	result.Checked = len(candidates)
+
+	// 按最终 URL 去重（/feed 与 /feed/ 等重定向到同一地址），保留先发现的途径
+	final := map[string]bool{}
+	for _, s := range sources {
+		if s == nil {
+			continue
+		}
+		u, _ := url.Parse(s.URL)
+		if key := NormalizeURL(u); !final[key] {
+			final[key] = true
+			result.Candidates = append(result.Candidates, *s)
+		}
+	}
+	sort.SliceStable(result.Candidates, func(i, j int) bool {
+		return result.Candidates[i].Score > result.Candidates[j].Score
+	})
+	return result, nil
+}
+
+// feedCandidates 从页面收集订阅源候选地址（按可信度排列）
+func feedCandidates(doc *goquery.Document, base *url.URL) []discoverCandidate {
+	pageBase := base
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if b, err := base.Parse(strings.TrimSpace(href)); err == nil {
+			pageBase = b
+		}
+	}
+
+	var candidates []discoverCandidate
+	doc.Find("link[href]").Each(func(_ int, l *goquery.Selection) {
+		rel := strings.Fields(strings.ToLower(l.AttrOr("rel", "")))
+		mediaType := strings.ToLower(strings.TrimSpace(strings.Split(l.AttrOr("type", ""), ";")[0]))
+		isFeed := false
+		for _, r := range rel {
+			if r == "feed" || (r == "alternate" && feedLinkTypes[mediaType]) {
+				isFeed = true
+			}
+		}
+		if u := resolveFeedURL(pageBase, l.AttrOr("href", "")); isFeed && u != "" {
+			candidates = append(candidates, discoverCandidate{url: u, found: DiscoverFoundLink, title: strings.TrimSpace(l.AttrOr("title", ""))})
+		}
+	})
+
+	anchors := 0
+	doc.Find("a[href]").EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		u := resolveFeedURL(pageBase, a.AttrOr("href", ""))
+		if u == "" {
+			return true
+		}
+		target, _ := url.Parse(u)
+		text := strings.Join(strings.Fields(a.Text()), " ")
+		if !feedHrefPattern.MatchString(target.Path) && !feedTextPattern.MatchString(text) && !strings.Contains(target.Host, "feedburner") {
+			return true
+		}
+		if !sameSite(base.Hostname(), target.Hostname()) && !strings.Contains(target.Host, "feedburner") {
+			return true
+		}
+		candidates = append(candidates, discoverCandidate{url: u, found: DiscoverFoundAnchor, title: text})
+		anchors++
+		return anchors < 5
+	})
+
+	dirs := []string{"/"}
+	if dir := base.Path[:strings.LastIndex(base.Path, "/")+1]; dir != "/" && dir != "" {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range dirs {
+		for _, p := range feedPaths {
+			u := &url.URL{Scheme: base.Scheme, Host: base.Host, Path: strings.TrimSuffix(dir, "/") + p}
+			candidates = append(candidates, discoverCandidate{url: u.String(), found: DiscoverFoundPath})
+		}
+	}
+	return candidates
+}
+
+// validateCandidate 抓取候选地址，能解析为订阅源或 sitemap 时返回来源信息
+func (e *Extractor) validateCandidate(ctx context.Context, c discoverCandidate, fetch PageFetcher) *DiscoveredSource {
+	body, finalURL, err := fetch(ctx, c.url)
+	if err != nil {
+		if c.found != DiscoverFoundPath {
+			log.Printf("[Discover] 候选地址抓取失败 %s: %v", c.url, err)
+		}
+		return nil
+	}
+	if finalURL == "" {
+		finalURL = c.url
+	}
+	if feed, err := e.ParseFeed(body, "", finalURL); err == nil {
+		source := feedSource(finalURL, feed, c)
+		return &source
+	}
+	if sitemap, err := e.ParseSitemap(body, finalURL); err == nil && len(sitemap.Entries) > 0 {
+		source := DiscoveredSource{
+			URL:       finalURL,
+			Type:      DiscoverTypeSiteCrawl,
+			Format:    "sitemap",
+			ItemCount: len(sitemap.Entries),
+			UpdatedAt: sitemap.LastModified(),
+			Found:     c.found,
+		}
+		if sitemap.Index {
+			source.Format = "sitemapindex"
+		}
+		// sitemap 用于站点爬取，排在订阅源之后
+		source.Score = discoverScore(source) - 3
+		return &source
+	}
+	return nil
+}
+
+// feedSource 由解析后的订阅源构造来源信息
+func feedSource(feedURL string, feed *Feed, c discoverCandidate) DiscoveredSource {
+	source := DiscoveredSource{
+		URL:       feedURL,
+		Type:      DiscoverTypeRSS,
+		Format:    feed.Format,
+		Title:     firstNonEmpty(feed.Title, c.title),
+		ItemCount: len(feed.Items),
+		UpdatedAt: feed.UpdatedAt,
+		Found:     c.found,
+	}
+	for _, item := range feed.Items {
+		for _, t := range []*time.Time{item.PublishedAt, item.UpdatedAt} {
+			if t != nil && (source.UpdatedAt == nil || t.After(*source.UpdatedAt)) {
+				source.UpdatedAt = t
+			}
+		}
+	}
+	source.Score = discoverScore(source)
+	if commentFeedPattern.MatchString(source.Title) || commentFeedPattern.MatchString(feedURL) {
+		source.Score--
+	}
+	return source
+}
+
+// discoverFoundWeight 各发现途径的基础得分
+var discoverFoundWeight = map[string]float64{
+	DiscoverFoundInput:  4,
+	DiscoverFoundLink:   3,
+	DiscoverFoundAnchor: 2,
+	DiscoverFoundPath:   1.5,
+	DiscoverFoundRobots: 1,
+}
+
+// discoverScore 排序得分：发现途径 + 条目数（最多 1 分）+ 更新时间（30 天内 1 分，一年内 0.5 分）
+func discoverScore(s DiscoveredSource) float64 {
+	score := discoverFoundWeight[s.Found] + float64(min(s.ItemCount, 20))/20
+	if s.ItemCount == 0 {
+		score--
+	}
+	if s.UpdatedAt != nil {
+		switch age := time.Since(*s.UpdatedAt); {
+		case age < 30*24*time.Hour:
+			score++
+		case age < 365*24*time.Hour:
+			score += 0.5
+		}
+	}
+	return score
+}
@@ -0,0 +1,85 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiscover(t *testing.T) {
+	recent := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC1123Z)
+	rss := func(title string, items int) string {
+		body := `<rss version="2.0"><channel><title>` + title + `</title>`
+		for i := 0; i < items; i++ {
+			body += `<item><title>t</title><link>https://blog.example.com/p/` + string(rune('a'+i)) + `</link><pubDate>` + recent + `</pubDate></item>`
+		}
+		return body + `</channel></rss>`
+	}
+	pages := map[string]string{
+		"https://blog.example.com/feed":          rss("Main Feed", 3),
+		"https://blog.example.com/comments/feed": rss("Comments", 3),
+		"https://blog.example.com/atom.xml":      `<feed xmlns="http://www.w3.org/2005/Atom"><title>Atom</title></feed>`,
+		"https://blog.example.com/robots.txt":    "User-agent: *\nSitemap: https://blog.example.com/sitemap_index.xml\n",
+		"https://blog.example.com/sitemap_index.xml": `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+			<sitemap><loc>https://blog.example.com/post-sitemap.xml</loc><lastmod>2026-03-01</lastmod></sitemap></sitemapindex>`,
+		"https://blog.example.com/rss.xml": "<html><body>Not found</body></html>",
+	}
+	fetch := func(_ context.Context, pageURL string) (string, string, error) {
+		if body, ok := pages[pageURL]; ok {
+			return body, pageURL, nil
+		}
+		// /feed/ 重定向到 /feed
+		if pageURL == "https://blog.example.com/feed/" {
+			return pages["https://blog.example.com/feed"], "https://blog.example.com/feed", nil
+		}
+		return "", "", errors.New("404")
+	}
+
+	page := `<html><head><title>Example Blog</title>
+		<link rel="alternate" type="application/rss+xml" title="Example » Feed" href="/feed/">
+		<link rel="alternate" type="application/rss+xml" title="Example » Comments Feed" href="/comments/feed">
+		<link rel="stylesheet" href="/style.css">
+		</head><body><a href="/atom.xml">Atom</a></body></html>`
+
+	e := New()
+	result, err := e.Discover(context.Background(), page, "https://blog.example.com/", fetch, 0)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if result.SiteTitle != "Example Blog" {
+		t.Errorf("SiteTitle = %q", result.SiteTitle)
+	}
+
+	var got []string
+	for _, c := range result.Candidates {
+		got = append(got, c.Found+" "+c.URL)
+	}
+	want := []string{
+		"link https://blog.example.com/feed",
+		"link https://blog.example.com/comments/feed",
+		"anchor https://blog.example.com/atom.xml",
+		"robots https://blog.example.com/sitemap_index.xml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Candidates = %v, want %v", got, want)
+	}
+	main := result.Candidates[0]
+	if main.Type != DiscoverTypeRSS || main.Title != "Main Feed" || main.ItemCount != 3 || main.UpdatedAt == nil {
+		t.Errorf("main = %+v", main)
+	}
+	if sm := result.Candidates[3]; sm.Type != DiscoverTypeSiteCrawl || sm.Format != "sitemapindex" || sm.ItemCount != 1 {
+		t.Errorf("sitemap = %+v", sm)
+	}
+
+	t.Run("请求的 URL 本身是订阅源", func(t *testing.T) {
+		result, err := e.Discover(context.Background(), rss("Direct", 1), "https://blog.example.com/feed", nil, 0)
+		if err != nil {
+			t.Fatalf("Discover() error = %v", err)
+		}
+		if len(result.Candidates) != 1 || result.Candidates[0].Found != DiscoverFoundInput || result.Candidates[0].Title != "Direct" {
+			t.Errorf("Candidates = %+v", result.Candidates)
+		}
+	})
+}
@@ -0,0 +1,469 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现嵌入媒体的保留：识别白名单内的视频 / 社交平台嵌入和安全的原生 <video> / <audio>，
+// 在正文提取前替换为占位 <figure>（各提取引擎都会保留），净化前再渲染为规范的沙箱 iframe 或占位图
+
+package extractor
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/newsflow/go-scraper-service/internal/processor"
+)
+
+// 嵌入媒体渲染方式
+const (
+	// EmbedModeIframe 渲染为沙箱 iframe（默认；Twitter 等不支持 iframe 的平台渲染为占位图）
+	EmbedModeIframe = "iframe"
+	// EmbedModePlaceholder 渲染为带缩略图和链接的占位图（适用于邮件等不支持 iframe 的场景）
+	EmbedModePlaceholder = "placeholder"
+)
+
+// 嵌入媒体来源
+const (
+	ProviderYouTube  = "youtube"
+	ProviderBilibili = "bilibili"
+	ProviderVimeo    = "vimeo"
+	ProviderTwitter  = "twitter"
+	ProviderNative   = "native" // 页面自身的 <video> / <audio>
+)
+
+// 嵌入媒体类型
+const (
+	MediaTypeVideo = "video"
+	MediaTypeAudio = "audio"
+	MediaTypePost  = "post" // 社交平台帖子
+)
+
+// EmbeddedMedia 正文中保留的嵌入媒体
+type EmbeddedMedia struct {
+	Type      string `json:"type"`                // video, audio, post
+	Provider  string `json:"provider"`            // youtube, bilibili, vimeo, twitter, native
+	ID        string `json:"id,omitempty"`        // 平台内的 ID（原生媒体为空）
+	URL       string `json:"url"`                 // 平台上的观看地址（原生媒体为媒体文件地址）
+	EmbedURL  string `json:"embedUrl,omitempty"`  // 沙箱 iframe 地址（仅支持 iframe 的平台）
+	Thumbnail string `json:"thumbnail,omitempty"` // 缩略图 / 海报
+	Title     string `json:"title,omitempty"`
+	MimeType  string `json:"mimeType,omitempty"` // 原生媒体的 MIME 类型
+}
+
+// ParseEmbedMode 解析嵌入媒体渲染方式，空字符串为默认的 iframe
+func ParseEmbedMode(mode string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", EmbedModeIframe:
+		return EmbedModeIframe, true
+	case EmbedModePlaceholder:
+		return EmbedModePlaceholder, true
+	}
+	return "", false
+}
+
+var (
+	// youtubeIDPattern YouTube 视频 ID
+	youtubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	// bilibiliBVPattern Bilibili BV 号
+	bilibiliBVPattern = regexp.MustCompile(`^BV[0-9A-Za-z]{10}$`)
+	// digitsPattern 纯数字 ID（Vimeo、Bilibili av 号、推文）
+	digitsPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+	// tweetPathPattern 推文地址路径
+	tweetPathPattern = regexp.MustCompile(`^/(?:[A-Za-z0-9_]{1,15}|i(?:/web)?)/status(?:es)?/([0-9]{1,20})`)
+)
+
+// safeMediaTypes 允许保留的原生媒体 MIME 类型
+var safeMediaTypes = map[string]bool{
+	"video/mp4": true, "video/webm": true, "video/ogg": true, "video/quicktime": true,
+	"audio/mpeg": true, "audio/mp4": true, "audio/aac": true, "audio/ogg": true,
+	"audio/webm": true, "audio/wav": true, "audio/x-m4a": true, "audio/flac": true,
+}
+
+// safeMediaExtensions 无 type 属性时按扩展名判断的 MIME 类型
+var safeMediaExtensions = map[string]string{
+	".mp4": "video/mp4", ".m4v": "video/mp4", ".webm": "video/webm", ".ogv": "video/ogg", ".mov": "video/quicktime",
+	".mp3": "audio/mpeg", ".m4a": "audio/mp4", ".aac": "audio/aac", ".oga": "audio/ogg", ".ogg": "audio/ogg",
+	".opus": "audio/ogg", ".wav": "audio/wav", ".flac": "audio/flac",
+}
+
+// matchEmbed 按 URL 识别白名单平台，返回平台和 ID
+func matchEmbed(raw string) (string, string) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "//") {
+		raw = "https:" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		id := u.Query().Get("v")
+		if len(segments) >= 2 && (segments[0] == "embed" || segments[0] == "v" || segments[0] == "shorts" || segments[0] == "live") {
+			id = segments[1]
+		}
+		if youtubeIDPattern.MatchString(id) {
+			return ProviderYouTube, id
+		}
+	case "youtu.be":
+		if youtubeIDPattern.MatchString(segments[0]) {
+			return ProviderYouTube, segments[0]
+		}
+	case "player.bilibili.com":
+		q := u.Query()
+		if bvid := q.Get("bvid"); bilibiliBVPattern.MatchString(bvid) {
+			return ProviderBilibili, bvid
+		}
+		if aid := q.Get("aid"); digitsPattern.MatchString(aid) {
+			return ProviderBilibili, "av" + aid
+		}
+	case "bilibili.com", "m.bilibili.com":
+		if len(segments) >= 2 && segments[0] == "video" {
+			if bilibiliBVPattern.MatchString(segments[1]) {
+				return ProviderBilibili, segments[1]
+			}
+			if aid := strings.TrimPrefix(segments[1], "av"); aid != segments[1] && digitsPattern.MatchString(aid) {
+				return ProviderBilibili, segments[1]
+			}
+		}
+	case "player.vimeo.com":
+		if len(segments) >= 2 && segments[0] == "video" && digitsPattern.MatchString(segments[1]) {
+			return ProviderVimeo, segments[1]
+		}
+	case "vimeo.com":
+		if last := segments[len(segments)-1]; digitsPattern.MatchString(last) {
+			return ProviderVimeo, last
+		}
+	case "twitter.com", "x.com", "mobile.twitter.com", "mobile.x.com":
+		if m := tweetPathPattern.FindStringSubmatch(u.Path); m != nil {
+			return ProviderTwitter, m[1]
+		}
+	case "platform.twitter.com":
+		if id := u.Query().Get("id"); digitsPattern.MatchString(id) {
+			return ProviderTwitter, id
+		}
+	}
+	return "", ""
+}
+
+// embedMedia 由平台和 ID 生成规范的媒体信息
+func embedMedia(provider, id, title string) EmbeddedMedia {
+	m := EmbeddedMedia{Provider: provider, ID: id, Title: title, Type: MediaTypeVideo}
+	switch provider {
+	case ProviderYouTube:
+		m.URL = "https://www.youtube.com/watch?v=" + id
+		m.EmbedURL = "https://www.youtube-nocookie.com/embed/" + id
+		m.Thumbnail = "https://i.ytimg.com/vi/" + id + "/hqdefault.jpg"
+	case ProviderBilibili:
+		m.URL = "https://www.bilibili.com/video/" + id
+		if aid, ok := strings.CutPrefix(id, "av"); ok {
+			m.EmbedURL = "https://player.bilibili.com/player.html?aid=" + aid + "&autoplay=0"
+		} else {
+			m.EmbedURL = "https://player.bilibili.com/player.html?bvid=" + id + "&autoplay=0"
+		}
+	case ProviderVimeo:
+		m.URL = "https://vimeo.com/" + id
+		m.EmbedURL = "https://player.vimeo.com/video/" + id + "?dnt=1"
+	case ProviderTwitter:
+		m.Type = MediaTypePost
+		m.URL = "https://twitter.com/i/status/" + id
+	}
+	return m
+}
+
+// embedIframePattern 净化器允许的 iframe 地址（与 embedMedia 生成的 EmbedURL 一致）
+var embedIframePattern = regexp.MustCompile(`^https://(?:www\.youtube-nocookie\.com/embed/[A-Za-z0-9_-]{11}|player\.bilibili\.com/player\.html\?(?:bvid=BV[0-9A-Za-z]{10}|aid=[0-9]{1,20})&autoplay=0|player\.vimeo\.com/video/[0-9]{1,20}\?dnt=1)$`)
+
+// embedSandbox 嵌入 iframe 的沙箱权限（播放器需要脚本和同源存储，禁止顶层跳转和表单）
+const embedSandbox = "allow-scripts allow-same-origin allow-presentation allow-popups"
+
+// markEmbeds 将嵌入媒体替换为占位 <figure data-nf-embed>
+//
+// 识别 iframe / embed / object 中的白名单平台、Twitter 的 blockquote.twitter-tweet，
+// 以及来源为 http(s) 且格式安全的 <video> / <audio>。占位元素只含 figcaption 链接
+// （推文保留正文），各提取引擎都会作为普通内容保留；未识别的嵌入保持原样，由 renderEmbeds 移除。
+func markEmbeds(pageHTML string, base *url.URL) string {
+	if !strings.Contains(pageHTML, "<iframe") && !strings.Contains(pageHTML, "<video") &&
+		!strings.Contains(pageHTML, "<audio") && !strings.Contains(pageHTML, "<embed") &&
+		!strings.Contains(pageHTML, "<object") && !strings.Contains(pageHTML, "twitter-tweet") {
+		return pageHTML
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return pageHTML
+	}
+
+	changed := false
+	doc.Find("iframe, embed, object").Each(func(_ int, s *goquery.Selection) {
+		src := firstNonEmpty(s.AttrOr("src", ""), s.AttrOr("data-src", ""), s.AttrOr("data", ""), s.Find(`param[name="movie"]`).AttrOr("value", ""))
+		provider, id := matchEmbed(src)
+		if provider == "" {
+			return
+		}
+		s.ReplaceWithHtml(embedPlaceholder(embedMedia(provider, id, strings.TrimSpace(s.AttrOr("title", ""))), ""))
+		changed = true
+	})
+
+	doc.Find("blockquote.twitter-tweet, blockquote.twitter-video").Each(func(_ int, s *goquery.Selection) {
+		var id string
+		s.Find("a[href]").EachWithBreak(func(_ int, a *goquery.Selection) bool {
+			if provider, tweetID := matchEmbed(a.AttrOr("href", "")); provider == ProviderTwitter {
+				id = tweetID
+				return false
+			}
+			return true
+		})
+		if id == "" {
+			return
+		}
+		text := strings.TrimSpace(s.Find("p").First().Text())
+		s.ReplaceWithHtml(embedPlaceholder(embedMedia(ProviderTwitter, id, ""), text))
+		changed = true
+	})
+
+	doc.Find("video, audio").Each(func(_ int, s *goquery.Selection) {
+		m, ok := nativeMedia(s, base)
+		if !ok {
+			return
+		}
+		s.ReplaceWithHtml(embedPlaceholder(m, ""))
+		changed = true
+	})
+
+	if !changed {
+		return pageHTML
+	}
+	result, err := doc.Html()
+	if err != nil {
+		return pageHTML
+	}
+	return result
+}
+
+// nativeMedia 识别安全的原生 <video> / <audio>（取第一个 http(s) 且格式允许的来源）
+func nativeMedia(s *goquery.Selection, base *url.URL) (EmbeddedMedia, bool) {
+	m := EmbeddedMedia{Provider: ProviderNative, Type: MediaTypeVideo, Title: strings.TrimSpace(s.AttrOr("title", s.AttrOr("aria-label", "")))}
+	if goquery.NodeName(s) == "audio" {
+		m.Type = MediaTypeAudio
+	}
+
+	candidates := [][2]string{{s.AttrOr("src", ""), s.AttrOr("type", "")}}
+	s.Find("source").Each(func(_ int, src *goquery.Selection) {
+		candidates = append(candidates, [2]string{src.AttrOr("src", ""), src.AttrOr("type", "")})
+	})
+	for _, c := range candidates {
+		if mediaURL, mimeType := safeMediaSource(c[0], c[1], base); mediaURL != "" {
+			m.URL, m.MimeType = mediaURL, mimeType
+			break
+		}
+	}
+	if m.URL == "" {
+		return m, false
+	}
+	if m.Type == MediaTypeVideo {
+		m.Thumbnail = resolveMediaURL(s.AttrOr("poster", ""), base)
+	}
+	return m, true
+}
+
+// safeMediaSource 校验媒体来源，返回绝对 URL 和 MIME 类型（不安全时返回空字符串）
+func safeMediaSource(raw, mimeType string, base *url.URL) (string, string) {
+	mediaURL := resolveMediaURL(raw, base)
+	if mediaURL == "" {
+		return "", ""
+	}
+	mimeType = strings.ToLower(strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0]))
+	if mimeType == "" {
+		u, _ := url.Parse(mediaURL)
+		mimeType = safeMediaExtensions[strings.ToLower(path.Ext(u.Path))]
+	}
+	if !safeMediaTypes[mimeType] {
+		return "", ""
+	}
+	return mediaURL, mimeType
+}
+
+// resolveMediaURL 解析为绝对 http(s) URL（data:、blob: 等返回空字符串）
+func resolveMediaURL(raw string, base *url.URL) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+// embedPlaceholder 生成占位元素（媒体信息全部保存在 data-nf-* 属性中）
+func embedPlaceholder(m EmbeddedMedia, text string) string {
+	attr := html.EscapeString
+	var b strings.Builder
+	fmt.Fprintf(&b, `<figure data-nf-embed="%s" data-nf-type="%s" data-nf-id="%s" data-nf-url="%s"`, attr(m.Provider), attr(m.Type), attr(m.ID), attr(m.URL))
+	if m.Title != "" {
+		fmt.Fprintf(&b, ` data-nf-title="%s"`, attr(m.Title))
+	}
+	if m.Thumbnail != "" {
+		fmt.Fprintf(&b, ` data-nf-thumbnail="%s"`, attr(m.Thumbnail))
+	}
+	if m.MimeType != "" {
+		fmt.Fprintf(&b, ` data-nf-mime="%s"`, attr(m.MimeType))
+	}
+	b.WriteString(">")
+	if text != "" {
+		fmt.Fprintf(&b, "<blockquote><p>%s</p></blockquote>", attr(text))
+	}
+	fmt.Fprintf(&b, `<figcaption><a href="%s">%s</a></figcaption></figure>`, attr(m.URL), attr(embedCaption(m)))
+	return b.String()
+}
+
+// embedCaption 占位元素的说明文字
+func embedCaption(m EmbeddedMedia) string {
+	if m.Title != "" {
+		return m.Title
+	}
+	switch m.Provider {
+	case ProviderYouTube:
+		return "YouTube 视频"
+	case ProviderBilibili:
+		return "哔哩哔哩视频"
+	case ProviderVimeo:
+		return "Vimeo 视频"
+	case ProviderTwitter:
+		return "查看推文"
+	}
+	if m.Type == MediaTypeAudio {
+		return "音频"
+	}
+	return "视频"
+}
+
+// renderEmbeds 将占位元素渲染为最终的嵌入媒体，返回正文和媒体列表
+//
+// 在图片处理之后、HTML 净化之前执行（缩略图不进入图片列表和图片代理）。
+// 提取引擎保留下来的其他 iframe / embed / object / video / audio 一律移除。
+func renderEmbeds(content, mode string) (string, []EmbeddedMedia) {
+	if !strings.Contains(content, "data-nf-embed") && !strings.Contains(content, "<iframe") &&
+		!strings.Contains(content, "<video") && !strings.Contains(content, "<audio") &&
+		!strings.Contains(content, "<embed") && !strings.Contains(content, "<object") {
+		return content, nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content, nil
+	}
+	doc.Find("iframe, embed, object, video, audio").Remove()
+
+	var media []EmbeddedMedia
+	seen := map[string]bool{}
+	doc.Find("figure[data-nf-embed]").Each(func(_ int, s *goquery.Selection) {
+		m := EmbeddedMedia{
+			Provider:  s.AttrOr("data-nf-embed", ""),
+			Type:      s.AttrOr("data-nf-type", ""),
+			ID:        s.AttrOr("data-nf-id", ""),
+			Title:     s.AttrOr("data-nf-title", ""),
+			URL:       s.AttrOr("data-nf-url", ""),
+			Thumbnail: s.AttrOr("data-nf-thumbnail", ""),
+			MimeType:  s.AttrOr("data-nf-mime", ""),
+		}
+		if m.Provider != ProviderNative {
+			// 占位属性可能来自页面本身，按平台和 ID 重新生成地址
+			provider, id := matchEmbed(embedMedia(m.Provider, m.ID, "").URL)
+			if provider != m.Provider || id != m.ID {
+				s.Remove()
+				return
+			}
+			thumbnail := m.Thumbnail
+			m = embedMedia(m.Provider, m.ID, m.Title)
+			m.Thumbnail = firstNonEmpty(m.Thumbnail, thumbnail)
+		} else if mediaURL, mimeType := safeMediaSource(m.URL, m.MimeType, nil); mediaURL == "" {
+			s.Remove()
+			return
+		} else {
+			m.URL, m.MimeType = mediaURL, mimeType
+			m.Thumbnail = resolveMediaURL(m.Thumbnail, nil)
+		}
+		s.ReplaceWithHtml(renderEmbed(m, strings.TrimSpace(s.Find("blockquote").First().Text()), mode))
+		if key := m.Provider + ":" + m.ID + ":" + m.URL; !seen[key] {
+			seen[key] = true
+			media = append(media, m)
+		}
+	})
+
+	result, err := doc.Find("body").Html()
+	if err != nil {
+		return content, nil
+	}
+	return result, media
+}
+
+// withoutThumbnails 从图片列表中去除嵌入媒体的缩略图
+//
+// Readability 会为属性中含图片地址的 <figure> 补上 <img>，占位元素的缩略图因此可能进入图片列表。
+func withoutThumbnails(images []processor.Image, media []EmbeddedMedia) []processor.Image {
+	if len(media) == 0 {
+		return images
+	}
+	thumbnails := map[string]bool{}
+	for _, m := range media {
+		if m.Thumbnail != "" {
+			thumbnails[m.Thumbnail] = true
+		}
+	}
+	kept := images[:0]
+	for _, img := range images {
+		if !thumbnails[img.OriginalURL] {
+			kept = append(kept, img)
+		}
+	}
+	return kept
+}
+
+// renderEmbed 渲染单个嵌入媒体
+func renderEmbed(m EmbeddedMedia, text, mode string) string {
+	attr := html.EscapeString
+	caption := embedCaption(m)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<figure class="embed embed-%s" data-provider="%s"`, m.Provider, m.Provider)
+	if m.ID != "" {
+		fmt.Fprintf(&b, ` data-id="%s"`, attr(m.ID))
+	}
+	b.WriteString(">")
+
+	switch {
+	case m.Provider == ProviderNative && mode != EmbedModePlaceholder:
+		if m.Type == MediaTypeAudio {
+			fmt.Fprintf(&b, `<audio controls="" preload="none" src="%s"></audio>`, attr(m.URL))
+		} else {
+			b.WriteString(`<video controls="" preload="none"`)
+			if m.Thumbnail != "" {
+				fmt.Fprintf(&b, ` poster="%s"`, attr(m.Thumbnail))
+			}
+			fmt.Fprintf(&b, `><source src="%s" type="%s"/></video>`, attr(m.URL), attr(m.MimeType))
+		}
+	case m.EmbedURL != "" && mode != EmbedModePlaceholder:
+		fmt.Fprintf(&b, `<iframe src="%s" title="%s" sandbox="%s" allow="fullscreen; picture-in-picture; encrypted-media" allowfullscreen="" loading="lazy" referrerpolicy="strict-origin-when-cross-origin" width="640" height="360"></iframe>`,
+			attr(m.EmbedURL), attr(caption), embedSandbox)
+	case m.Thumbnail != "":
+		fmt.Fprintf(&b, `<a href="%s"><img src="%s" alt="%s" loading="lazy" decoding="async"/></a>`, attr(m.URL), attr(m.Thumbnail), attr(caption))
+	}
+	if text != "" {
+		fmt.Fprintf(&b, "<blockquote><p>%s</p></blockquote>", attr(text))
+	}
+	fmt.Fprintf(&b, `<figcaption><a href="%s">%s</a></figcaption></figure>`, attr(m.URL), attr(caption))
+	return b.String()
+}
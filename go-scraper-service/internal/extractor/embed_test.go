@@ -0,0 +1,137 @@
+package extractor
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMatchEmbed(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		provider string
+		id       string
+	}{
+		{"YouTube embed", "https://www.youtube.com/embed/dQw4w9WgXcQ?autoplay=1", ProviderYouTube, "dQw4w9WgXcQ"},
+		{"YouTube nocookie 协议相对", "//www.youtube-nocookie.com/embed/dQw4w9WgXcQ", ProviderYouTube, "dQw4w9WgXcQ"},
+		{"YouTube watch", "https://m.youtube.com/watch?v=dQw4w9WgXcQ&t=10", ProviderYouTube, "dQw4w9WgXcQ"},
+		{"YouTube 短链", "https://youtu.be/dQw4w9WgXcQ", ProviderYouTube, "dQw4w9WgXcQ"},
+		{"YouTube 非法 ID", "https://www.youtube.com/embed/short", "", ""},
+		{"Bilibili 播放器 bvid 优先", "//player.bilibili.com/player.html?aid=170001&bvid=BV17x411w7KC&page=1", ProviderBilibili, "BV17x411w7KC"},
+		{"Bilibili 播放器 aid", "https://player.bilibili.com/player.html?aid=170001", ProviderBilibili, "av170001"},
+		{"Bilibili 视频页", "https://www.bilibili.com/video/BV17x411w7KC/", ProviderBilibili, "BV17x411w7KC"},
+		{"Vimeo 播放器", "https://player.vimeo.com/video/76979871?h=8272103f6e", ProviderVimeo, "76979871"},
+		{"Vimeo 视频页", "https://vimeo.com/channels/staffpicks/76979871", ProviderVimeo, "76979871"},
+		{"推文", "https://x.com/jack/status/20?s=20", ProviderTwitter, "20"},
+		{"推文 iframe", "https://platform.twitter.com/embed/Tweet.html?id=20", ProviderTwitter, "20"},
+		{"用户主页不是推文", "https://twitter.com/jack", "", ""},
+		{"非白名单", "https://evil.example.com/embed/dQw4w9WgXcQ", "", ""},
+		{"javascript", "javascript:alert(1)", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, id := matchEmbed(tt.src)
+			if provider != tt.provider || id != tt.id {
+				t.Errorf("matchEmbed(%q) = %q, %q, want %q, %q", tt.src, provider, id, tt.provider, tt.id)
+			}
+		})
+	}
+}
+
+func TestEmbedPreservation(t *testing.T) {
+	para := "<p>" + strings.Repeat("这是一段足够长的正文内容，用于让提取引擎识别为文章。", 8) + "</p>"
+	page := `<html><head><title>视频文章</title></head><body><article><h1>视频文章</h1>` + para +
+		`<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ?autoplay=1" title="Demo"></iframe>` + para +
+		`<blockquote class="twitter-tweet"><p lang="en">Hello tweet</p>&mdash; Jack <a href="https://twitter.com/jack/status/20">March 21, 2006</a></blockquote>` + para +
+		`<video poster="/poster.jpg"><source src="/clip.m3u8"><source src="/clip.mp4"></video>` + para +
+		`<audio src="data:audio/mpeg;base64,AAAA"></audio><iframe src="https://evil.example.com/x"></iframe>` + para +
+		`</article></body></html>`
+
+	e := New()
+	t.Run("默认渲染为沙箱 iframe", func(t *testing.T) {
+		result, err := e.ExtractWithOptions(context.Background(), page, "https://news.example.com/a/1.html", ExtractOptions{})
+		if err != nil {
+			t.Fatalf("ExtractWithOptions() error = %v", err)
+		}
+		for _, want := range []string{
+			`<iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" title="Demo" sandbox="allow-scripts allow-same-origin allow-presentation allow-popups"`,
+			`<blockquote><p>Hello tweet</p></blockquote>`,
+			`poster="https://news.example.com/poster.jpg"><source src="https://news.example.com/clip.mp4" type="video/mp4"/></video>`,
+		} {
+			if !strings.Contains(result.Content, want) {
+				t.Errorf("Content 缺少 %s:\n%s", want, result.Content)
+			}
+		}
+		for _, unwanted := range []string{"evil.example.com", "data:audio", "<audio", "data-nf-"} {
+			if strings.Contains(result.Content, unwanted) {
+				t.Errorf("Content 不应包含 %s", unwanted)
+			}
+		}
+
+		var got []string
+		for _, m := range result.Media {
+			got = append(got, m.Provider+":"+m.Type+":"+m.URL)
+		}
+		want := []string{
+			"youtube:video:https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+			"twitter:post:https://twitter.com/i/status/20",
+			"native:video:https://news.example.com/clip.mp4",
+		}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("Media = %v, want %v", got, want)
+		}
+		if len(result.Images) != 0 {
+			t.Errorf("缩略图不应计入 Images: %+v", result.Images)
+		}
+	})
+
+	t.Run("占位图模式", func(t *testing.T) {
+		result, err := e.ExtractWithOptions(context.Background(), page, "https://news.example.com/a/1.html", ExtractOptions{EmbedMode: EmbedModePlaceholder})
+		if err != nil {
+			t.Fatalf("ExtractWithOptions() error = %v", err)
+		}
+		if strings.Contains(result.Content, "<iframe") || strings.Contains(result.Content, "<video") {
+			t.Errorf("占位图模式不应包含 iframe / video:\n%s", result.Content)
+		}
+		if !strings.Contains(result.Content, `<img src="https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg" alt="Demo"`) {
+			t.Errorf("缺少 YouTube 缩略图:\n%s", result.Content)
+		}
+	})
+}
+
+func TestSanitizeEmbeds(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"白名单播放器强制沙箱",
+			`<iframe src="https://player.vimeo.com/video/1?dnt=1" sandbox="allow-top-navigation allow-scripts" onload="x()"></iframe>`,
+			`<iframe src="https://player.vimeo.com/video/1?dnt=1" sandbox="allow-scripts"></iframe>`,
+		},
+		{
+			"非白名单 iframe 整体移除",
+			`<p><iframe src="https://evil.example.com/embed"></iframe></p>`,
+			`<p></p>`,
+		},
+		{
+			"原生视频拒绝 javascript 来源",
+			`<video controls src="javascript:alert(1)" autoplay></video>`,
+			`<video controls=""></video>`,
+		},
+		{
+			"伪造的容器 class",
+			`<figure class="embed evil" data-provider="youtube" data-id="x&quot;y">t</figure>`,
+			`<figure data-provider="youtube">t</figure>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
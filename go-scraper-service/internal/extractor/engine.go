@@ -0,0 +1,296 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现多提取引擎与择优
+
+package extractor
+
+import (
+	"errors"
+	"html"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// 提取引擎名称
+const (
+	EngineReadability = "readability"
+	EngineDensity     = "density"
+	EngineJSONLD      = "jsonld"
+	EngineRules       = "rules"
+	// EnginePDF PDF 文档提取（按内容类型直接选用，不参与择优）
+	EnginePDF = "pdf"
+)
+
+// ErrEngineNotApplicable 引擎不适用于该页面（如页面没有 JSON-LD articleBody、没有匹配的站点规则）
+var ErrEngineNotApplicable = errors.New("engine not applicable")
+
+// EngineResult 引擎提取结果
+type EngineResult struct {
+	ReadabilityResult
+	// 引擎识别出的发布时间原始文本（可选）
+	DateText string
+	// 命中的站点规则（仅 rules 引擎）
+	Rule *SiteRule
+}
+
+// Engine 正文提取引擎
+type Engine interface {
+	// Name 引擎名称（出现在响应的 engine / engineScores 中）
+	Name() string
+	// Extract 从预处理后的 HTML 中提取正文，不适用时返回 ErrEngineNotApplicable
+	Extract(html string, pageURL *url.URL) (*EngineResult, error)
+}
+
+// readabilityEngine go-readability（Mozilla Readability 算法）
+type readabilityEngine struct{}
+
+func (readabilityEngine) Name() string { return EngineReadability }
+
+func (readabilityEngine) Extract(html string, pageURL *url.URL) (*EngineResult, error) {
+	article, err := ExtractWithReadability(html, pageURL.String())
+	if err != nil {
+		return nil, err
+	}
+	return &EngineResult{ReadabilityResult: *article}, nil
+}
+
+// rulesEngine 站点规则引擎
+type rulesEngine struct {
+	rules *SiteRules
+}
+
+func (rulesEngine) Name() string { return EngineRules }
+
+func (r rulesEngine) Extract(html string, pageURL *url.URL) (*EngineResult, error) {
+	rule := r.rules.Match(pageURL)
+	if rule == nil {
+		return nil, ErrEngineNotApplicable
+	}
+	result, err := applySiteRule(html, pageURL.String(), rule)
+	if err != nil {
+		return nil, err
+	}
+	return &EngineResult{ReadabilityResult: *result.article, DateText: result.dateText, Rule: rule}, nil
+}
+
+// jsonLDEngine 使用 JSON-LD articleBody 作为正文
+//
+// 很多新闻站点在 JSON-LD 中输出完整正文，结构干净，但只有纯文本，不含图片和格式。
+type jsonLDEngine struct{}
+
+func (jsonLDEngine) Name() string { return EngineJSONLD }
+
+func (jsonLDEngine) Extract(pageHTML string, pageURL *url.URL) (*EngineResult, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, err
+	}
+	ld := findArticleLD(doc)
+	body := ld.str("articleBody")
+	if body == "" {
+		return nil, ErrEngineNotApplicable
+	}
+
+	var content strings.Builder
+	var text []string
+	for _, para := range strings.Split(body, "\n") {
+		if para = strings.TrimSpace(para); para != "" {
+			content.WriteString("<p>" + html.EscapeString(para) + "</p>\n")
+			text = append(text, para)
+		}
+	}
+
+	result := &EngineResult{}
+	result.Title = ld.str("headline")
+	result.Content = content.String()
+	result.TextContent = strings.Join(text, "\n\n")
+	result.Excerpt = firstNonEmpty(ld.str("description"), excerptOf(result.TextContent))
+	result.Byline = strings.Join(ld.names("author"), ", ")
+	result.SiteName = ld.publisher()
+	result.Length = utf8.RuneCountInString(result.TextContent)
+	result.DateText = ld.str("datePublished")
+	return result, nil
+}
+
+// densityEngine 文本密度引擎
+//
+// 去除导航、页眉页脚等模板区域后，把每个段落的非链接文本长度累加到父元素（全额）
+// 和祖父元素（半额），得分最高的容器即正文区域。与 Readability 相比不依赖
+// class / id 命名，适合类名混淆或不规范的页面。
+type densityEngine struct{}
+
+func (densityEngine) Name() string { return EngineDensity }
+
+// boilerplateSelector 模板区域
+const boilerplateSelector = `script, style, noscript, iframe, nav, header, footer, aside, form, [role="navigation"], [role="banner"], [role="contentinfo"], [aria-hidden="true"]`
+
+func (densityEngine) Extract(pageHTML string, pageURL *url.URL) (*EngineResult, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, err
+	}
+	title := firstNonEmpty(doc.Find(`meta[property="og:title"]`).AttrOr("content", ""),
+		doc.Find("h1").First().Text(), doc.Find("title").First().Text())
+	doc.Find(boilerplateSelector).Remove()
+
+	type candidate struct {
+		sel   *goquery.Selection
+		score float64
+	}
+	candidates := map[*nethtml.Node]*candidate{}
+	var order []*candidate
+	add := func(s *goquery.Selection, score float64) {
+		if s.Length() == 0 {
+			return
+		}
+		node := s.Get(0)
+		if node.DataAtom == atom.Body || node.DataAtom == atom.Html {
+			return
+		}
+		if c, ok := candidates[node]; ok {
+			c.score += score
+			return
+		}
+		c := &candidate{sel: s, score: score}
+		candidates[node] = c
+		order = append(order, c)
+	}
+
+	doc.Find("p, pre, blockquote, li").Each(func(_ int, p *goquery.Selection) {
+		textLen := utf8.RuneCountInString(strings.Join(strings.Fields(p.Text()), ""))
+		if textLen < 20 {
+			return
+		}
+		linkLen := 0
+		p.Find("a").Each(func(_ int, a *goquery.Selection) {
+			linkLen += utf8.RuneCountInString(strings.Join(strings.Fields(a.Text()), ""))
+		})
+		score := float64(textLen - linkLen)
+		if score <= 0 {
+			return
+		}
+		add(p.Parent(), score)
+		add(p.Parent().Parent(), score/2)
+	})
+	if len(order) == 0 {
+		return nil, ErrEngineNotApplicable
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return order[i].score > order[j].score })
+	best := order[0].sel
+	content, err := goquery.OuterHtml(best)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EngineResult{}
+	result.Title = strings.TrimSpace(title)
+	result.Content = content
+	result.TextContent = strings.TrimSpace(best.Text())
+	result.Excerpt = excerptOf(result.TextContent)
+	result.Length = utf8.RuneCountInString(result.TextContent)
+	return result, nil
+}
+
+// engines 返回当前启用的提取引擎（配置了站点规则时包含 rules 引擎）
+func (e *Extractor) engines() []Engine {
+	engines := make([]Engine, 0, 4)
+	if e.siteRules != nil {
+		engines = append(engines, rulesEngine{rules: e.siteRules})
+	}
+	return append(engines, readabilityEngine{}, jsonLDEngine{}, densityEngine{})
+}
+
+// engineSwitchMargin 后续引擎的得分须超过当前最佳的倍数才会替换，
+// 得分接近时优先排在前面的引擎（Readability 的清理规则更成熟）
+const engineSwitchMargin = 1.1
+
+// engineSelection 多引擎择优结果
+type engineSelection struct {
+	best   *EngineResult
+	engine string
+	scores []QualityScore
+}
+
+// runEngines 运行全部引擎并择优
+//
+// 每个成功的结果按 measureQuality 打分，得分最高者胜出（见 engineSwitchMargin）；命中站点规则且提取到正文时
+// 直接采用规则结果（规则是人工配置的覆盖）。胜出结果缺少的标题、署名、站点名、摘要
+// 由其他引擎的结果补齐。所有引擎的得分（含失败原因）都会返回用于诊断，
+// 不适用的引擎不计入。
+func (e *Extractor) runEngines(html string, pageURL *url.URL) (*engineSelection, error) {
+	refTitle := ""
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(html)); err == nil {
+		refTitle = firstNonEmpty(doc.Find(`meta[property="og:title"]`).AttrOr("content", ""), doc.Find("title").First().Text())
+	}
+
+	sel := &engineSelection{}
+	var results []*EngineResult
+	var firstErr error
+	var bestScore float64
+	ruleApplied := false
+	for _, engine := range e.engines() {
+		result, err := engine.Extract(html, pageURL)
+		if errors.Is(err, ErrEngineNotApplicable) {
+			continue
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			log.Printf("[Engine] %s 提取失败 %s: %v", engine.Name(), pageURL, err)
+			sel.scores = append(sel.scores, QualityScore{Engine: engine.Name(), Error: err.Error()})
+			continue
+		}
+
+		score := measureQuality(result.Content, result.TextContent, result.Title, refTitle)
+		score.Engine = engine.Name()
+		sel.scores = append(sel.scores, score)
+		results = append(results, result)
+
+		if ruleApplied {
+			continue
+		}
+		if engine.Name() == EngineRules && score.TextLength > 0 {
+			ruleApplied = true
+			sel.best, sel.engine, bestScore = result, engine.Name(), score.Score
+		} else if sel.best == nil || score.Score > bestScore*engineSwitchMargin {
+			sel.best, sel.engine, bestScore = result, engine.Name(), score.Score
+		}
+	}
+
+	if sel.best == nil {
+		if firstErr == nil {
+			firstErr = errors.New("no extraction engine produced a result")
+		}
+		return nil, firstErr
+	}
+
+	for _, other := range results {
+		fillMissing(&sel.best.ReadabilityResult, &other.ReadabilityResult)
+	}
+	return sel, nil
+}
+
+// fillMissing 用其他引擎的结果补齐缺失的元信息字段
+func fillMissing(dst, src *ReadabilityResult) {
+	if dst.Title == "" {
+		dst.Title = src.Title
+	}
+	if dst.Byline == "" {
+		dst.Byline = src.Byline
+	}
+	if dst.SiteName == "" {
+		dst.SiteName = src.SiteName
+	}
+	if dst.Excerpt == "" {
+		dst.Excerpt = src.Excerpt
+	}
+}
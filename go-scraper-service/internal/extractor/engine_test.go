@@ -0,0 +1,78 @@
+package extractor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEngineSelection(t *testing.T) {
+	paragraphs := strings.Repeat("<p>This paragraph of the story carries enough words to count as real body text for scoring.</p>\n", 6)
+
+	tests := []struct {
+		name     string
+		html     string
+		engine   string
+		contains string
+	}{
+		{
+			name: "普通文章页 Readability 或密度引擎胜出",
+			html: `<html><head><title>Story Title - Site</title></head><body>
+				<nav><a href="/">Home</a><a href="/a">World</a></nav>
+				<article><h1>Story Title</h1>` + paragraphs + `</article></body></html>`,
+			contains: "real body text",
+		},
+		{
+			name: "正文只在 JSON-LD articleBody 中",
+			html: `<html><head><title>Only LD</title>
+				<script type="application/ld+json">{"@type":"NewsArticle","headline":"Only LD",
+				"articleBody":"First paragraph of the article body delivered through JSON-LD only, long enough to win.\nSecond paragraph with even more text so that the quality score is clearly the best among engines.\nThird paragraph closes the story with a conclusion that readers appreciate."}</script>
+				</head><body><div id="app"></div><footer><a href="/">Home</a> <a href="/about">About us and company information</a></footer></body></html>`,
+			engine:   EngineJSONLD,
+			contains: "Second paragraph",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New().Extract(tt.html, "https://example.com/story")
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if tt.engine != "" && result.Engine != tt.engine {
+				t.Errorf("Engine = %q, want %q (scores: %+v)", result.Engine, tt.engine, result.EngineScores)
+			}
+			if !strings.Contains(result.TextContent, tt.contains) {
+				t.Errorf("TextContent = %q", result.TextContent)
+			}
+			if len(result.EngineScores) < 2 {
+				t.Errorf("EngineScores = %+v, 应包含所有适用引擎的评分", result.EngineScores)
+			}
+			for _, score := range result.EngineScores {
+				if score.Engine == result.Engine && score.Error != "" {
+					t.Errorf("胜出引擎不应有错误: %+v", score)
+				}
+			}
+		})
+	}
+}
+
+func TestTitleOverlap(t *testing.T) {
+	tests := []struct {
+		name      string
+		refTitle  string
+		candidate string
+		min, max  float64
+	}{
+		{"中文标题去除站点名", "国产大飞机完成首航_新华网", "国产大飞机完成首航 记者从……", 0.99, 1},
+		{"英文标题", "Rocket Launch Succeeds | Example News", "rocket launch succeeds after delay", 0.99, 1},
+		{"无关文本", "Rocket Launch Succeeds", "Trending: celebrity gossip", 0, 0.3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titleOverlap(tt.refTitle, tt.candidate)
+			if got < tt.min || got > tt.max {
+				t.Errorf("titleOverlap() = %v, want [%v, %v]", got, tt.min, tt.max)
+			}
+		})
+	}
+}
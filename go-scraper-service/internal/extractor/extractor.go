@@ -1,37 +1,97 @@
 package extractor
 
 import (
+	"context"
+	"errors"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
 
 	"github.com/newsflow/go-scraper-service/internal/processor"
 )
 
 // ExtractResult 提取结果
 type ExtractResult struct {
-	Content     string            `json:"content"`
-	TextContent string            `json:"textContent"`
+	Content     string            `json:"content"`     // 正文（格式见 Format）
+	Format      string            `json:"format"`      // 正文格式：html, markdown, text
+	TextContent string            `json:"textContent"` // 结构化纯文本（见 HTMLToText）
 	Title       string            `json:"title"`
 	Excerpt     string            `json:"excerpt"`
 	Byline      string            `json:"byline"`
 	SiteName    string            `json:"siteName"`
 	Images      []processor.Image `json:"images"`
 	ReadingTime int               `json:"readingTime"`
+	Pages       int               `json:"pages"`                // 拼接的分页数（未分页为 1；PDF 文档为页数）
+	Variant     string            `json:"variant"`              // 提取所用的页面版本：canonical, amp, print
+	VariantURL  string            `json:"variantUrl,omitempty"` // 备用版本的 URL（Variant 非 canonical 时）
+	Metadata    *Metadata         `json:"metadata,omitempty"`   // 结构化元数据（OpenGraph、JSON-LD 等）
+	// 发布时间（含置信度和来源）
+	PublishedDate *DateResult `json:"publishedDate,omitempty"`
+	// 匹配的站点规则名称（未匹配时为空）
+	Rule string `json:"rule,omitempty"`
+	// 胜出的提取引擎及全部引擎的质量评分（诊断用）
+	Engine       string         `json:"engine"`
+	EngineScores []QualityScore `json:"engineScores,omitempty"`
+	// 页面类型（文章、列表页、首页、错误页等）
+	PageType *PageClass `json:"pageType,omitempty"`
+	// 正文中保留的嵌入媒体（视频平台、推文、原生音视频）
+	Media []EmbeddedMedia `json:"media,omitempty"`
+	// 正文指纹（精确哈希和 SimHash，用于识别多来源的同一篇稿件）
+	Fingerprint *Fingerprint `json:"fingerprint,omitempty"`
+	// 抽取式摘要和关键词（开启 Summarize 时）
+	Summary *Summary `json:"summary,omitempty"`
+	// 文档信息（PDF 等非网页内容；此时 PageType 为 document，Pages 为文档页数）
+	Document *DocumentInfo `json:"document,omitempty"`
+}
+
+// ExtractOptions 提取选项
+type ExtractOptions struct {
+	// FollowPagination 是否抓取并拼接文章的后续分页
+	FollowPagination bool
+	// MaxPages 最多拼接的页数（含首页），<=0 时使用 DefaultMaxPages
+	MaxPages int
+	// DiscoverAlternates 是否发现并尝试 AMP / 打印版本，择优使用
+	DiscoverAlternates bool
+	// PageFetcher 后续分页和备用版本的抓取函数，
+	// FollowPagination 或 DiscoverAlternates 为 true 时必须提供
+	PageFetcher PageFetcher
+	// RequireArticle 拒绝提取非文章页面（列表页、首页、错误页等），
+	// 页面以不低于 PageClassRefuseConfidence 的置信度判定为非文章时返回 *NotArticleError
+	RequireArticle bool
+	// OutputFormat 正文输出格式（FormatHTML / FormatMarkdown / FormatText），为空时为 HTML
+	OutputFormat string
+	// EmbedMode 嵌入媒体渲染方式（EmbedModeIframe / EmbedModePlaceholder），为空时为 iframe；
+	// 净化配置强制了渲染方式时以配置为准
+	EmbedMode string
+	// SanitizeProfile 净化配置名称（内置的 reader / email-safe / strict-text / archive 或自定义配置），
+	// 为空时为 reader
+	SanitizeProfile string
+	// Summarize 生成抽取式摘要和关键词（见 Summarize，使用默认句数和关键词数）
+	Summarize bool
+	// ContentType 响应的 Content-Type，application/pdf（或内容为 PDF）时按文档提取
+	ContentType string
 }
 
 // Extractor 内容提取器（整合 readability + sanitizer + image processor）
 type Extractor struct {
-	sanitizer      *Sanitizer
+	profiles       map[string]*SanitizeProfile
+	profileNames   []string
 	imageProcessor *processor.ImageProcessor
+	dateExtractor  *DateExtractor
+	siteRules      *SiteRules
 }
 
 // New 创建提取器
 func New() *Extractor {
-	return &Extractor{
-		sanitizer:      NewSanitizer(),
+	e := &Extractor{
 		imageProcessor: processor.NewImageProcessor(),
+		dateExtractor:  NewDateExtractor(time.UTC),
 	}
+	e.SetSanitizeProfiles(nil)
+	return e
 }
 
 // Extract 提取文章内容
@@ -39,10 +99,15 @@ func New() *Extractor {
 // 对原始 HTML 进行完整的内容提取处理流程：
 //  1. Cloudflare Email Protection 解码 - 还原被混淆的邮箱地址
 //  2. 懒加载图片预处理 - 将 data-src 等属性转换为 src
-//  3. Readability 正文提取 - 使用 Mozilla Readability 算法提取文章主体
+//  3. 正文提取 - 站点规则、Readability、JSON-LD articleBody、文本密度多引擎提取，
+//     按质量评分择优（见 runEngines）
 //  4. 图片 URL 处理 - 转换为绝对 URL，添加懒加载属性
-//  5. HTML 净化 - 移除不安全的标签和属性
-//  6. 阅读时间计算 - 根据中英文字数估算
+//  5. 嵌入媒体 - 白名单平台的视频 / 推文和安全的原生音视频渲染为规范形式（见 embed.go）；
+//     代码块语言、脚注锚点和数学公式规范化（见 technical.go）
+//  6. HTML 净化 - 按净化配置移除不安全的标签和属性（见 profile.go）
+//  7. 阅读时间计算 - 根据中英文字数估算
+//  8. 结构化元数据提取 - 解析 OpenGraph、Twitter Card、Dublin Core、JSON-LD
+//  9. 发布时间提取 - 综合 meta、JSON-LD、<time>、标题附近文本和 URL
 //
 // 参数：
 //   - html: 原始 HTML 字符串
@@ -52,45 +117,179 @@ func New() *Extractor {
 //   - *ExtractResult: 提取结果，包含净化后的内容、标题、摘要等
 //   - error: 处理过程中的错误
 func (e *Extractor) Extract(html, pageURL string) (*ExtractResult, error) {
-	parsedURL, err := url.Parse(pageURL)
-	if err != nil {
+	return e.ExtractWithOptions(context.Background(), html, pageURL, ExtractOptions{})
+}
+
+// ExtractWithOptions 按选项提取文章内容
+//
+// 在 Extract 流程的基础上支持：
+//   - 多页文章拼接：开启 FollowPagination 时，在图片处理之后、HTML 净化之前，
+//     通过 PageFetcher 抓取后续分页，去除跨页重复段落后将正文追加到首页之后
+//   - 备用版本择优：开启 DiscoverAlternates 时，发现页面的 AMP / 打印版本，
+//     用同样流程提取后与原始页面比较质量，采用得分更高的结果（见 Variant 字段）
+//   - PDF 文档：Content-Type 为 application/pdf 或内容为 PDF 时改用 PDF 文本提取，
+//     结果的 PageType 为 document，Pages 为文档页数（见 pdf.go）
+func (e *Extractor) ExtractWithOptions(ctx context.Context, html, pageURL string, opts ExtractOptions) (*ExtractResult, error) {
+	if IsPDF(opts.ContentType, html) {
+		// PDF 文档不经过 Readability，也不做分页拼接、备用版本和文章判定
+		result, err := e.extractPDF(html, pageURL, opts)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Summarize {
+			result.Summary = Summarize(result.TextContent, SummaryOptions{Title: result.Title})
+		}
+		convertFormat(result, opts.OutputFormat)
+		return result, nil
+	}
+
+	result, preprocessedHTML, err := e.extractDocument(ctx, html, pageURL, opts)
+	if opts.DiscoverAlternates && opts.PageFetcher != nil && preprocessedHTML != "" && !errors.Is(err, ErrNotArticle) {
+		parsedURL, _ := url.Parse(pageURL)
+		result = e.preferAlternate(ctx, preprocessedHTML, parsedURL, result, opts)
+	}
+	if result == nil {
 		return nil, err
 	}
+	if opts.Summarize {
+		result.Summary = Summarize(result.TextContent, SummaryOptions{Title: result.Title})
+	}
+	convertFormat(result, opts.OutputFormat)
+	return result, nil
+}
 
+// convertFormat 将净化后的 HTML 正文转换为请求的输出格式
+//
+// 转换在备用版本择优之后进行，质量评分始终基于 HTML。
+func convertFormat(result *ExtractResult, format string) {
+	format, _ = ParseOutputFormat(format)
+	switch format {
+	case FormatMarkdown:
+		result.Content = HTMLToMarkdown(result.Content)
+	case FormatText:
+		result.Content = result.TextContent
+	}
+	result.Format = format
+}
 
-	// 0. 解码 Cloudflare Email Protection 混淆的邮箱
-	// Cloudflare 会将 mailto: 链接和邮箱文本替换为 /cdn-cgi/l/email-protection#... 格式
-	// 静态抓取无法执行 JS 解码，需要手动还原
-	html = DecodeCloudflareEmails(html)
+// extractDocument 对单个页面（及其分页）执行提取流程
+//
+// 除提取结果外还返回预处理后的 HTML，供备用版本发现复用。
+func (e *Extractor) extractDocument(ctx context.Context, html, pageURL string, opts ExtractOptions) (*ExtractResult, string, error) {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, "", err
+	}
 
-	// 1. 预处理懒加载图片
-	preprocessedHTML := e.imageProcessor.ProcessLazyImages(html)
+	// 0-1. 解码 Cloudflare 邮箱、预处理懒加载图片，嵌入媒体替换为占位元素
+	preprocessedHTML := markEmbeds(e.preprocess(html), parsedURL)
 
-	// 2. 使用 Readability 提取正文
-	article, err := ExtractWithReadability(preprocessedHTML, pageURL)
+	// 2. 多引擎提取正文并择优（站点规则、Readability、JSON-LD、文本密度）
+	selection, err := e.runEngines(preprocessedHTML, parsedURL)
 	if err != nil {
-		return nil, err
+		return nil, preprocessedHTML, err
+	}
+	article := selection.best
+
+	// 2.1 页面类型识别，结构化元数据和发布时间（均取自首页）
+	var metadata *Metadata
+	var publishedDate *DateResult
+	pageClass := &PageClass{Type: PageTypeOther}
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(preprocessedHTML)); err == nil {
+		pageClass = classifyDocument(doc, parsedURL, article.Content, article.TextContent)
+		metadata = extractMetadata(doc, parsedURL)
+		publishedDate = e.dateExtractor.extract(doc, parsedURL)
+	}
+	if opts.RequireArticle && pageClass.Type != PageTypeArticle && pageClass.Confidence >= PageClassRefuseConfidence {
+		return nil, preprocessedHTML, &NotArticleError{Class: pageClass}
 	}
 
 	// 3. 处理图片（URL 绝对化）
 	processedHTML, images := e.imageProcessor.ProcessImages(article.Content, parsedURL)
+	textContent := article.TextContent
+
+	// 3.1 拼接后续分页
+	pages := 1
+	if opts.FollowPagination && opts.PageFetcher != nil {
+		for _, page := range e.fetchNextPages(ctx, preprocessedHTML, parsedURL, article.Content, opts) {
+			processedHTML += "\n" + page.content
+			if page.textContent != "" {
+				textContent += "\n\n" + page.textContent
+			}
+			images = appendUniqueImages(images, page.images)
+			pages++
+		}
+	}
+
+	// 3.2 渲染嵌入媒体（沙箱 iframe / 占位图 / 原生音视频）
+	profile, ok := e.SanitizeProfile(opts.SanitizeProfile)
+	if !ok {
+		profile, _ = e.SanitizeProfile(ProfileReader)
+	}
+	embedMode, _ := ParseEmbedMode(opts.EmbedMode)
+	if profile.EmbedMode != "" {
+		embedMode = profile.EmbedMode
+	}
+	processedHTML, media := renderEmbeds(processedHTML, embedMode)
+	images = withoutThumbnails(images, media)
+
+	// 3.3 代码块语言转为 class，锚点 id 加命名空间
+	processedHTML = normalizeTechnical(processedHTML, parsedURL)
 
-	// 4. HTML 净化
-	sanitizedHTML := e.sanitizer.Sanitize(processedHTML)
+	// 4. HTML 净化（按请求的净化配置）
+	sanitizedHTML := profile.Sanitize(processedHTML)
+
+	// 4.1 由净化后的正文渲染结构化纯文本（保留段落、标题、列表），失败时沿用引擎的文本
+	if text := HTMLToText(sanitizedHTML); text != "" {
+		textContent = text
+	}
 
 	// 5. 计算阅读时间
-	readingTime := calculateReadingTime(article.TextContent)
+	readingTime := calculateReadingTime(textContent)
+
+	// 6. 站点规则指定的发布时间优先
+	ruleName := ""
+	if article.Rule != nil {
+		ruleName = article.Rule.Name
+		if d := e.dateExtractor.parseAs(article.DateText, DateSourceRule); d != nil {
+			publishedDate = d
+		}
+	}
 
 	return &ExtractResult{
-		Content:     sanitizedHTML,
-		TextContent: article.TextContent,
-		Title:       article.Title,
-		Excerpt:     article.Excerpt,
-		Byline:      article.Byline,
-		SiteName:    article.SiteName,
-		Images:      images,
-		ReadingTime: readingTime,
-	}, nil
+		Content:       sanitizedHTML,
+		TextContent:   textContent,
+		Title:         article.Title,
+		Excerpt:       article.Excerpt,
+		Byline:        article.Byline,
+		SiteName:      article.SiteName,
+		Images:        images,
+		ReadingTime:   readingTime,
+		Pages:         pages,
+		Variant:       VariantCanonical,
+		Metadata:      metadata,
+		PublishedDate: publishedDate,
+		Rule:          ruleName,
+		Engine:        selection.engine,
+		EngineScores:  selection.scores,
+		PageType:      pageClass,
+		Media:         media,
+		Fingerprint:   ComputeFingerprint(textContent),
+	}, preprocessedHTML, nil
+}
+
+// preprocess Readability 之前的 HTML 预处理
+func (e *Extractor) preprocess(html string) string {
+	// 解码 Cloudflare Email Protection 混淆的邮箱
+	// Cloudflare 会将 mailto: 链接和邮箱文本替换为 /cdn-cgi/l/email-protection#... 格式
+	// 静态抓取无法执行 JS 解码，需要手动还原
+	html = DecodeCloudflareEmails(html)
+
+	// 预处理懒加载图片
+	html = e.imageProcessor.ProcessLazyImages(html)
+
+	// 规范化代码块语言、脚注容器和数学公式，使其能通过提取引擎保留（见 technical.go）
+	return markTechnical(html)
 }
 
 // SetImageProxyConfig 设置图片代理配置
@@ -98,6 +297,16 @@ func (e *Extractor) SetImageProxyConfig(enable bool, baseURL string) {
 	e.imageProcessor.SetProxyConfig(enable, baseURL)
 }
 
+// SetSiteRules 设置站点提取规则
+func (e *Extractor) SetSiteRules(rules *SiteRules) {
+	e.siteRules = rules
+}
+
+// SetDefaultTimezone 设置发布时间提取的默认时区
+func (e *Extractor) SetDefaultTimezone(loc *time.Location) {
+	e.dateExtractor = NewDateExtractor(loc)
+}
+
 // calculateReadingTime 计算阅读时间（分钟）
 func calculateReadingTime(text string) int {
 	// 中文约 400 字/分钟，英文约 200 词/分钟
@@ -0,0 +1,882 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现 RSS / Atom / JSON Feed 订阅源解析
+
+package extractor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// 订阅源格式
+const (
+	FeedFormatRSS  = "rss"  // RSS 0.9x / 1.0（RDF）/ 2.0
+	FeedFormatAtom = "atom" // Atom 0.3 / 1.0
+	FeedFormatJSON = "json" // JSON Feed 1.0 / 1.1
+)
+
+// ErrNotFeed 内容不是可识别的订阅源（如 HTML 页面）
+var ErrNotFeed = errors.New("not an RSS, Atom or JSON feed")
+
+// Feed 订阅源
+type Feed struct {
+	Format      string     `json:"format"`            // rss, atom, json
+	Version     string     `json:"version,omitempty"` // 0.91, 1.0, 2.0 等
+	Title       string     `json:"title"`
+	Link        string     `json:"link,omitempty"` // 网站地址
+	Description string     `json:"description,omitempty"`
+	Language    string     `json:"language,omitempty"`
+	Image       string     `json:"image,omitempty"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+	Items       []FeedItem `json:"items"`
+}
+
+// FeedItem 订阅源条目
+type FeedItem struct {
+	// 稳定的外部 ID，可直接作为 Article.externalId（规则与 Node.js 端 fetchRSS 一致）
+	ExternalID  string          `json:"externalId"`
+	GUID        string          `json:"guid,omitempty"` // RSS guid、Atom id、JSON Feed id
+	Link        string          `json:"link,omitempty"` // 绝对地址
+	Title       string          `json:"title"`
+	Summary     string          `json:"summary,omitempty"` // 纯文本摘要
+	Content     string          `json:"content,omitempty"` // HTML 正文
+	PublishedAt *time.Time      `json:"publishedAt,omitempty"`
+	UpdatedAt   *time.Time      `json:"updatedAt,omitempty"`
+	Authors     []string        `json:"authors,omitempty"`
+	Categories  []string        `json:"categories,omitempty"`
+	Enclosures  []FeedEnclosure `json:"enclosures,omitempty"`
+	Thumbnail   string          `json:"thumbnail,omitempty"` // 封面图
+}
+
+// FeedEnclosure 附件（enclosure、media:content、JSON Feed attachments）
+type FeedEnclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type,omitempty"`
+	Length int64  `json:"length,omitempty"`
+}
+
+// ParseFeed 解析订阅源
+//
+// 支持 RSS 0.9x / 1.0 / 2.0、Atom 0.3 / 1.0 和 JSON Feed。fetcher 不做字符集转换，
+// 非 UTF-8 内容按 XML 声明或 Content-Type 中的编码解码（均缺失时按 GB18030）。
+// XML 按宽松模式解析，容忍国内站点常见的问题：
+//   - 未转义的 & 和 <、未知 HTML 实体、非法控制字符、声明前的空白和 BOM
+//   - 未声明或写错 URI 的命名空间前缀（content:encoded、dc:creator、media:thumbnail 等）
+//   - 标签大小写不一致、description 中未转义的 HTML、文档中途截断（保留已解析的条目）
+//
+// 内容不是订阅源时返回 ErrNotFeed。
+func (e *Extractor) ParseFeed(body, contentType, feedURL string) (*Feed, error) {
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return nil, err
+	}
+	body = strings.TrimSpace(decodeFeedBody(body, contentType))
+	if strings.HasPrefix(body, "{") {
+		return e.parseJSONFeed(body, base)
+	}
+
+	root := parseXMLTree(repairXML(body))
+	if root == nil {
+		return nil, ErrNotFeed
+	}
+	switch root.name() {
+	case "rss", "channel", "rdf:rdf", "rdf":
+		return e.parseRSS(root, base), nil
+	case "feed", "atom:feed":
+		return e.parseAtom(root, base), nil
+	}
+	return nil, ErrNotFeed
+}
+
+// xmlEncodingPattern XML 声明中的编码
+var xmlEncodingPattern = regexp.MustCompile(`^<\?xml[^>]*encoding\s*=\s*["']([A-Za-z0-9_.:-]+)["']`)
+
+// decodeFeedBody 将响应体转为 UTF-8
+//
+// 已是合法 UTF-8 时原样返回（部分站点声明 gbk 但实际输出 UTF-8）。
+func decodeFeedBody(body, contentType string) string {
+	body = strings.TrimPrefix(body, "\ufeff")
+	if utf8.ValidString(body) {
+		return body
+	}
+	label := ""
+	if m := xmlEncodingPattern.FindStringSubmatch(strings.TrimSpace(body)); m != nil {
+		label = m[1]
+	} else if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		label = params["charset"]
+	}
+	enc, _ := charset.Lookup(label)
+	if enc == nil {
+		enc, _ = charset.Lookup("gb18030")
+	}
+	decoded, err := enc.NewDecoder().String(body)
+	if err != nil {
+		return strings.ToValidUTF8(body, "\ufffd")
+	}
+	return decoded
+}
+
+// repairXML 修复常见的 XML 格式错误
+//
+// 去掉首个 '<' 之前的内容和非法控制字符，将不构成标签的 '<'（如「a < b」）转义。
+// CDATA 和注释中的内容原样保留。
+func repairXML(data string) string {
+	data = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, data)
+	if i := strings.IndexByte(data, '<'); i > 0 {
+		data = data[i:]
+	}
+
+	var b strings.Builder
+	b.Grow(len(data))
+	for i := 0; i < len(data); {
+		rest := data[i:]
+		if end, ok := skipXMLSection(rest, "<![CDATA[", "]]>"); ok {
+			b.WriteString(rest[:end])
+			i += end
+			continue
+		}
+		if end, ok := skipXMLSection(rest, "<!--", "-->"); ok {
+			b.WriteString(rest[:end])
+			i += end
+			continue
+		}
+		if data[i] == '<' && (len(rest) == 1 || !isXMLTagStart(rest[1])) {
+			b.WriteString("&lt;")
+		} else {
+			b.WriteByte(data[i])
+		}
+		i++
+	}
+	return b.String()
+}
+
+// skipXMLSection 返回以 open 开头、close 结尾的片段长度（缺少结尾时到文档末尾）
+func skipXMLSection(s, open, close string) (int, bool) {
+	if !strings.HasPrefix(s, open) {
+		return 0, false
+	}
+	end := strings.Index(s[len(open):], close)
+	if end < 0 {
+		return len(s), true
+	}
+	return len(open) + end + len(close), true
+}
+
+// isXMLTagStart 判断 '<' 后的字符能否构成标签
+func isXMLTagStart(c byte) bool {
+	return c == '/' || c == '!' || c == '?' || c == '_' || c == ':' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// feedNamespaces 已知命名空间 URI（去掉协议和末尾的 / #，小写）到规范前缀的映射
+//
+// RSS 1.0 / 0.90 和 UserLand RSS 的默认命名空间映射为空前缀。
+var feedNamespaces = map[string]string{
+	"purl.org/rss/1.0/modules/content":         "content",
+	"purl.org/dc/elements/1.1":                 "dc",
+	"purl.org/dc/terms":                        "dcterms",
+	"search.yahoo.com/mrss":                    "media",
+	"tools.search.yahoo.com/mrss":              "media",
+	"www.w3.org/2005/atom":                     "atom",
+	"purl.org/atom/ns":                         "atom",
+	"www.itunes.com/dtds/podcast-1.0.dtd":      "itunes",
+	"www.w3.org/1999/02/22-rdf-syntax-ns":      "rdf",
+	"www.google.com/schemas/sitemap-news/0.9":  "news",
+	"www.google.com/schemas/sitemap-image/1.1": "image",
+	"purl.org/rss/1.0":                         "",
+	"my.netscape.com/rdf/simple/0.9":           "",
+	"backend.userland.com/rss2":                "",
+	"backend.userland.com/rss":                 "",
+}
+
+// feedPrefixAliases 未声明或 URI 未知时按前缀识别的别名
+var feedPrefixAliases = map[string]string{
+	"atom10": "atom",
+	"a10":    "atom",
+	"dcterm": "dcterms",
+}
+
+// xmlNode 宽松解析得到的 XML 元素
+type xmlNode struct {
+	space    string            // 规范化后的命名空间前缀（默认命名空间为空）
+	local    string            // 小写的本地名
+	attrs    map[string]string // 键为小写的 "prefix:local" 或 "local"
+	children []*xmlNode
+	text     strings.Builder // 直接包含的文本（实体和 CDATA 已解码）
+	inner    string          // 原始内部标记（用于 description 中未转义的 HTML）
+	start    int64
+}
+
+// name 返回 "prefix:local" 或 "local"
+func (n *xmlNode) name() string {
+	if n.space == "" {
+		return n.local
+	}
+	return n.space + ":" + n.local
+}
+
+// child 按名称优先级返回第一个匹配的子元素
+func (n *xmlNode) child(names ...string) *xmlNode {
+	for _, name := range names {
+		for _, c := range n.children {
+			if c.name() == name {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// all 返回指定名称的全部子元素
+func (n *xmlNode) all(name string) []*xmlNode {
+	var result []*xmlNode
+	for _, c := range n.children {
+		if c.name() == name {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// value 元素的文本值（去除首尾空白）
+func (n *xmlNode) value() string {
+	if n == nil {
+		return ""
+	}
+	return strings.TrimSpace(n.text.String())
+}
+
+// markup 元素内容作为 HTML：包含未转义的子标签时返回原始内部标记
+func (n *xmlNode) markup() string {
+	if n == nil {
+		return ""
+	}
+	if len(n.children) > 0 {
+		return strings.TrimSpace(n.inner)
+	}
+	return n.value()
+}
+
+// attr 读取属性
+func (n *xmlNode) attr(key string) string {
+	if n == nil {
+		return ""
+	}
+	return strings.TrimSpace(n.attrs[key])
+}
+
+// childValue 返回第一个非空的子元素文本
+func (n *xmlNode) childValue(names ...string) string {
+	for _, name := range names {
+		for _, c := range n.all(name) {
+			if v := c.value(); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// parseXMLTree 宽松解析 XML，返回根元素（没有任何元素时返回 nil）
+//
+// 使用 RawToken 自行匹配结束标签：结束标签关闭最近的同名元素及其间未关闭的元素，
+// 没有对应开始标签的结束标签被忽略；语法错误时保留已解析的部分。
+func parseXMLTree(data string) *xmlNode {
+	d := xml.NewDecoder(strings.NewReader(data))
+	d.Strict = false
+	d.Entity = xml.HTMLEntity
+	// 内容已在 decodeFeedBody 中转为 UTF-8，忽略声明的编码
+	d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	document := &xmlNode{}
+	stack := []*xmlNode{document}
+	scopes := []map[string]string{nil}
+	closeTo := func(depth int, offset int64) {
+		for len(stack) > depth {
+			n := stack[len(stack)-1]
+			if offset >= n.start && offset <= int64(len(data)) {
+				n.inner = data[n.start:offset]
+			}
+			stack = stack[:len(stack)-1]
+			scopes = scopes[:len(scopes)-1]
+		}
+	}
+
+	for {
+		offset := d.InputOffset()
+		tok, err := d.RawToken()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var scope map[string]string
+			for _, a := range t.Attr {
+				if a.Name.Space == "" && a.Name.Local == "xmlns" {
+					scope = withNamespace(scope, "", a.Value)
+				} else if a.Name.Space == "xmlns" {
+					scope = withNamespace(scope, a.Name.Local, a.Value)
+				}
+			}
+			scopes = append(scopes, scope)
+			// 不带前缀的属性不属于默认命名空间
+			attrs := make(map[string]string, len(t.Attr))
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					continue
+				}
+				space := ""
+				if a.Name.Space != "" {
+					space = resolveNamespace(a.Name.Space, scopes)
+				}
+				attrs[xmlName(space, a.Name.Local)] = a.Value
+			}
+			n := &xmlNode{
+				space: resolveNamespace(t.Name.Space, scopes),
+				local: strings.ToLower(t.Name.Local),
+				attrs: attrs,
+				start: d.InputOffset(),
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			name := xmlName(resolveNamespace(t.Name.Space, scopes), t.Name.Local)
+			for depth := len(stack) - 1; depth > 0; depth-- {
+				if stack[depth].name() == name {
+					closeTo(depth, offset)
+					break
+				}
+			}
+		case xml.CharData:
+			stack[len(stack)-1].text.Write(t)
+		}
+	}
+	closeTo(1, int64(len(data)))
+
+	for _, c := range document.children {
+		return c
+	}
+	return nil
+}
+
+// withNamespace 记录命名空间声明
+func withNamespace(scope map[string]string, prefix, uri string) map[string]string {
+	if scope == nil {
+		scope = map[string]string{}
+	}
+	scope[prefix] = uri
+	return scope
+}
+
+// resolveNamespace 将前缀解析为规范前缀
+//
+// 声明了已知 URI 的前缀使用规范前缀；URI 未知、写错或未声明时按前缀本身识别，
+// 因此未声明命名空间的 content:encoded 同样可以匹配。未知的默认命名空间视为空。
+func resolveNamespace(prefix string, scopes []map[string]string) string {
+	for i := len(scopes) - 1; i >= 0; i-- {
+		uri, ok := scopes[i][prefix]
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(uri))
+		key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+		if canonical, ok := feedNamespaces[strings.TrimRight(key, "/#")]; ok {
+			return canonical
+		}
+		break
+	}
+	if prefix == "" {
+		return ""
+	}
+	prefix = strings.ToLower(prefix)
+	if alias, ok := feedPrefixAliases[prefix]; ok {
+		return alias
+	}
+	return prefix
+}
+
+// xmlName 组合小写的 "prefix:local"
+func xmlName(space, local string) string {
+	local = strings.ToLower(local)
+	if space == "" {
+		return local
+	}
+	return strings.ToLower(space) + ":" + local
+}
+
+// parseRSS 解析 RSS 0.9x / 1.0 / 2.0
+func (e *Extractor) parseRSS(root *xmlNode, base *url.URL) *Feed {
+	feed := &Feed{Format: FeedFormatRSS, Version: root.attr("version"), Items: []FeedItem{}}
+	channel := root
+	if root.local != "channel" {
+		if c := root.child("channel"); c != nil {
+			channel = c
+		}
+	}
+	if root.local == "rdf" {
+		feed.Version = "1.0"
+	} else if feed.Version == "" {
+		feed.Version = "2.0"
+	}
+
+	feed.Title = feedText(channel.child("title", "dc:title").markup())
+	feed.Link = resolveFeedURL(base, firstNonEmpty(channel.childValue("link"), alternateLink(channel.all("atom:link"))))
+	feed.Description = feedText(channel.child("description", "dc:description", "itunes:summary").markup())
+	feed.Language = firstNonEmpty(channel.childValue("language", "dc:language"), root.attr("xml:lang"))
+	feed.UpdatedAt = e.feedDate(channel.childValue("lastbuilddate", "pubdate", "dc:date", "atom:updated"))
+	image := channel.child("image")
+	if image == nil {
+		// RSS 1.0 的 image 与 channel 同级
+		image = root.child("image")
+	}
+	if image != nil {
+		feed.Image = resolveFeedURL(base, firstNonEmpty(image.childValue("url"), image.attr("rdf:resource")))
+	}
+	if feed.Image == "" {
+		feed.Image = resolveFeedURL(base, channel.child("itunes:image").attr("href"))
+	}
+
+	// 部分站点把 item 直接放在 rss 下，RSS 1.0 的 item 与 channel 同级
+	items := channel.all("item")
+	if channel != root {
+		items = append(items, root.all("item")...)
+	}
+	for _, n := range items {
+		if item, ok := e.rssItem(n, base); ok {
+			feed.Items = append(feed.Items, item)
+		}
+	}
+	return feed
+}
+
+// rssItem 解析 RSS 条目
+//
+// externalId 按 guid > link > title 取值（与 Node.js 端一致，保证与已入库文章去重），
+// 都为空时使用内容哈希。
+func (e *Extractor) rssItem(n *xmlNode, base *url.URL) (FeedItem, bool) {
+	var item FeedItem
+	guid := n.child("guid")
+	item.GUID = guid.value()
+
+	link := firstNonEmpty(
+		n.childValue("link"),
+		n.child("link").attr("href"),
+		alternateLink(n.all("atom:link")),
+		n.attr("rdf:about"),
+	)
+	if link == "" && looksLikeURL(item.GUID) && !strings.EqualFold(guid.attr("ispermalink"), "false") {
+		link = item.GUID
+	}
+	item.Link = resolveFeedURL(base, link)
+	item.Title = feedText(n.child("title", "dc:title").markup())
+
+	description := n.child("description", "dc:description").markup()
+	item.Content = firstNonEmpty(n.child("content:encoded").markup(), atomContent(n.child("atom:content")), description)
+	item.Summary = excerptOf(feedText(firstNonEmpty(description, n.childValue("itunes:summary"), item.Content)))
+
+	item.PublishedAt = e.feedDate(n.childValue("pubdate", "dc:date", "dcterms:issued", "dcterms:created", "atom:published", "atom:updated"))
+	item.UpdatedAt = e.feedDate(n.childValue("atom:updated", "dcterms:modified"))
+
+	var authors []string
+	for _, name := range []string{"author", "dc:creator", "itunes:author", "atom:author"} {
+		for _, a := range n.all(name) {
+			authors = append(authors, feedAuthor(a))
+		}
+	}
+	item.Authors = compactStrings(authors)
+
+	var categories []string
+	for _, name := range []string{"category", "dc:subject", "atom:category"} {
+		for _, c := range n.all(name) {
+			categories = append(categories, firstNonEmpty(feedText(c.markup()), c.attr("label"), c.attr("term")))
+		}
+	}
+	item.Categories = compactStrings(categories)
+
+	e.feedMedia(&item, n, n.all("atom:link"), base)
+	return finishFeedItem(item, item.GUID, link, item.Title)
+}
+
+// parseAtom 解析 Atom 0.3 / 1.0
+func (e *Extractor) parseAtom(root *xmlNode, base *url.URL) *Feed {
+	// Atom 文档中的 Atom 元素统一为空前缀（兼容缺少 xmlns 的文档）
+	var unprefix func(*xmlNode)
+	unprefix = func(n *xmlNode) {
+		if n.space == "atom" {
+			n.space = ""
+		}
+		for _, c := range n.children {
+			unprefix(c)
+		}
+	}
+	unprefix(root)
+
+	feed := &Feed{Format: FeedFormatAtom, Version: "1.0", Items: []FeedItem{}}
+	if v := root.attr("version"); v != "" {
+		feed.Version = v
+	}
+	feed.Title = feedText(atomContent(root.child("title")))
+	feed.Link = resolveFeedURL(base, alternateLink(root.all("link")))
+	feed.Description = feedText(atomContent(root.child("subtitle", "tagline")))
+	feed.Language = root.attr("xml:lang")
+	feed.UpdatedAt = e.feedDate(root.childValue("updated", "modified"))
+	feed.Image = resolveFeedURL(base, root.childValue("logo", "icon"))
+
+	for _, n := range root.all("entry") {
+		if item, ok := e.atomEntry(n, base); ok {
+			feed.Items = append(feed.Items, item)
+		}
+	}
+	return feed
+}
+
+// atomEntry 解析 Atom 条目
+//
+// externalId 按 link > id > title 取值：Node.js 端的 rss-parser 不为 Atom 条目设置 guid，
+// 已入库文章的 externalId 为链接。
+func (e *Extractor) atomEntry(n *xmlNode, base *url.URL) (FeedItem, bool) {
+	var item FeedItem
+	item.GUID = n.childValue("id")
+	link := alternateLink(n.all("link"))
+	item.Link = resolveFeedURL(base, link)
+	item.Title = feedText(atomContent(n.child("title")))
+
+	summary := atomContent(n.child("summary"))
+	item.Content = firstNonEmpty(atomContent(n.child("content")), n.child("content:encoded").markup(), summary)
+	item.Summary = excerptOf(feedText(firstNonEmpty(summary, item.Content)))
+
+	item.PublishedAt = e.feedDate(n.childValue("published", "issued", "created", "updated", "modified", "dc:date"))
+	item.UpdatedAt = e.feedDate(n.childValue("updated", "modified"))
+
+	var authors []string
+	for _, a := range n.all("author") {
+		authors = append(authors, feedAuthor(a))
+	}
+	for _, a := range n.all("dc:creator") {
+		authors = append(authors, a.value())
+	}
+	item.Authors = compactStrings(authors)
+
+	var categories []string
+	for _, c := range n.all("category") {
+		categories = append(categories, firstNonEmpty(c.attr("label"), c.attr("term"), c.value()))
+	}
+	for _, c := range n.all("dc:subject") {
+		categories = append(categories, c.value())
+	}
+	item.Categories = compactStrings(categories)
+
+	e.feedMedia(&item, n, n.all("link"), base)
+	return finishFeedItem(item, link, item.GUID, item.Title)
+}
+
+// alternateLink 返回 rel 为 alternate（或未指定）的链接，没有时返回第一个链接
+func alternateLink(links []*xmlNode) string {
+	for _, l := range links {
+		if rel := strings.ToLower(l.attr("rel")); (rel == "" || rel == "alternate") && l.attr("href") != "" {
+			return l.attr("href")
+		}
+	}
+	for _, l := range links {
+		if rel := strings.ToLower(l.attr("rel")); rel != "self" && rel != "enclosure" && l.attr("href") != "" {
+			return l.attr("href")
+		}
+	}
+	return ""
+}
+
+// atomContent Atom 文本构造的 HTML：type="text" 时转义，xhtml 时取内部标记，src 引用的外部内容忽略
+func atomContent(n *xmlNode) string {
+	if n == nil || n.attr("src") != "" {
+		return ""
+	}
+	switch strings.ToLower(n.attr("type")) {
+	case "text", "text/plain":
+		if len(n.children) == 0 {
+			return html.EscapeString(n.value())
+		}
+	case "xhtml":
+		if div := n.child("div"); div != nil {
+			return div.markup()
+		}
+	}
+	return n.markup()
+}
+
+// rssAuthorPattern RSS 2.0 author 的「邮箱 (姓名)」格式
+var rssAuthorPattern = regexp.MustCompile(`^\S+@\S+\s*\((.+)\)$`)
+
+// feedAuthor 作者名称（Atom author/name、RSS「邮箱 (姓名)」取姓名）
+func feedAuthor(n *xmlNode) string {
+	if name := n.childValue("name", "atom:name"); name != "" {
+		return name
+	}
+	value := feedText(n.markup())
+	if m := rssAuthorPattern.FindStringSubmatch(value); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return value
+}
+
+// imgSrcPattern 正文中第一张图片
+var imgSrcPattern = regexp.MustCompile(`(?i)<img[^>]+src\s*=\s*["']([^"']+)["']`)
+
+// feedMedia 提取附件和封面图
+//
+// 附件来自 enclosure、rel="enclosure" 的链接和 media:content（含 media:group 中的）；
+// 封面图依次取 media:thumbnail、图片类 media:content、图片附件、itunes:image 和正文第一张图片。
+func (e *Extractor) feedMedia(item *FeedItem, n *xmlNode, links []*xmlNode, base *url.URL) {
+	seen := map[string]bool{}
+	add := func(rawURL, mimeType, length string) {
+		u := resolveFeedURL(base, rawURL)
+		if u == "" || seen[u] {
+			return
+		}
+		seen[u] = true
+		size, _ := strconv.ParseInt(strings.TrimSpace(length), 10, 64)
+		item.Enclosures = append(item.Enclosures, FeedEnclosure{URL: u, Type: mimeType, Length: size})
+	}
+
+	for _, enc := range n.all("enclosure") {
+		add(firstNonEmpty(enc.attr("url"), enc.attr("href")), enc.attr("type"), enc.attr("length"))
+	}
+	for _, l := range links {
+		if strings.EqualFold(l.attr("rel"), "enclosure") {
+			add(l.attr("href"), l.attr("type"), l.attr("length"))
+		}
+	}
+
+	mediaParents := append([]*xmlNode{n}, n.all("media:group")...)
+	var thumbnail, imageContent string
+	for _, p := range mediaParents {
+		for _, t := range p.all("media:thumbnail") {
+			thumbnail = firstNonEmpty(thumbnail, t.attr("url"))
+		}
+		for _, c := range p.all("media:content") {
+			add(c.attr("url"), c.attr("type"), c.attr("filesize"))
+			for _, t := range c.all("media:thumbnail") {
+				thumbnail = firstNonEmpty(thumbnail, t.attr("url"))
+			}
+			if c.attr("medium") == "image" || strings.HasPrefix(c.attr("type"), "image/") {
+				imageContent = firstNonEmpty(imageContent, c.attr("url"))
+			}
+		}
+	}
+
+	var imageEnclosure string
+	for _, enc := range item.Enclosures {
+		if strings.HasPrefix(enc.Type, "image/") {
+			imageEnclosure = enc.URL
+			break
+		}
+	}
+	var contentImage string
+	if m := imgSrcPattern.FindStringSubmatch(item.Content); m != nil {
+		contentImage = html.UnescapeString(m[1])
+	}
+	item.Thumbnail = resolveFeedURL(base, firstNonEmpty(
+		thumbnail, imageContent, imageEnclosure, n.child("itunes:image").attr("href"), contentImage,
+	))
+}
+
+// jsonFeed JSON Feed 1.0 / 1.1（https://www.jsonfeed.org/version/1.1/）
+type jsonFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	Description string           `json:"description"`
+	Icon        string           `json:"icon"`
+	Favicon     string           `json:"favicon"`
+	Language    string           `json:"language"`
+	Items       []jsonFeedItem   `json:"items"`
+	Author      *jsonFeedAuthor  `json:"author"`
+	Authors     []jsonFeedAuthor `json:"authors"`
+}
+
+type jsonFeedItem struct {
+	ID            jsonFeedID           `json:"id"`
+	URL           string               `json:"url"`
+	ExternalURL   string               `json:"external_url"`
+	Title         string               `json:"title"`
+	ContentHTML   string               `json:"content_html"`
+	ContentText   string               `json:"content_text"`
+	Summary       string               `json:"summary"`
+	Image         string               `json:"image"`
+	BannerImage   string               `json:"banner_image"`
+	DatePublished string               `json:"date_published"`
+	DateModified  string               `json:"date_modified"`
+	Author        *jsonFeedAuthor      `json:"author"`
+	Authors       []jsonFeedAuthor     `json:"authors"`
+	Tags          []string             `json:"tags"`
+	Attachments   []jsonFeedAttachment `json:"attachments"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedAttachment struct {
+	URL         string  `json:"url"`
+	MimeType    string  `json:"mime_type"`
+	SizeInBytes float64 `json:"size_in_bytes"`
+}
+
+// jsonFeedID 条目 id（规范要求字符串，部分生成器输出数字）
+type jsonFeedID string
+
+// UnmarshalJSON 同时接受字符串和数字
+func (id *jsonFeedID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = jsonFeedID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*id = jsonFeedID(n.String())
+	return nil
+}
+
+// parseJSONFeed 解析 JSON Feed
+//
+// externalId 按 id > url > title 取值。
+func (e *Extractor) parseJSONFeed(body string, base *url.URL) (*Feed, error) {
+	var jf jsonFeed
+	if err := json.Unmarshal([]byte(body), &jf); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON feed: %v", ErrNotFeed, err)
+	}
+	if !strings.Contains(jf.Version, "jsonfeed.org") && jf.Items == nil {
+		return nil, ErrNotFeed
+	}
+
+	feed := &Feed{
+		Format:      FeedFormatJSON,
+		Version:     strings.TrimPrefix(jf.Version[strings.LastIndex(jf.Version, "/")+1:], "v"),
+		Title:       strings.TrimSpace(jf.Title),
+		Link:        resolveFeedURL(base, jf.HomePageURL),
+		Description: strings.TrimSpace(jf.Description),
+		Language:    jf.Language,
+		Image:       resolveFeedURL(base, firstNonEmpty(jf.Icon, jf.Favicon)),
+		Items:       []FeedItem{},
+	}
+	for _, it := range jf.Items {
+		item := FeedItem{
+			GUID:        strings.TrimSpace(string(it.ID)),
+			Link:        resolveFeedURL(base, firstNonEmpty(it.URL, it.ExternalURL)),
+			Title:       feedText(it.Title),
+			Content:     firstNonEmpty(it.ContentHTML, html.EscapeString(it.ContentText)),
+			PublishedAt: e.feedDate(it.DatePublished),
+			UpdatedAt:   e.feedDate(it.DateModified),
+			Thumbnail:   resolveFeedURL(base, firstNonEmpty(it.Image, it.BannerImage)),
+		}
+		item.Summary = excerptOf(firstNonEmpty(it.Summary, it.ContentText, feedText(it.ContentHTML)))
+
+		authors := it.Authors
+		if it.Author != nil {
+			authors = append(authors, *it.Author)
+		}
+		if len(authors) == 0 {
+			// 条目没有作者时继承订阅源作者
+			authors = jf.Authors
+			if jf.Author != nil {
+				authors = append(authors, *jf.Author)
+			}
+		}
+		names := make([]string, 0, len(authors))
+		for _, a := range authors {
+			names = append(names, a.Name)
+		}
+		item.Authors = compactStrings(names)
+		item.Categories = compactStrings(it.Tags)
+		for _, a := range it.Attachments {
+			if u := resolveFeedURL(base, a.URL); u != "" {
+				item.Enclosures = append(item.Enclosures, FeedEnclosure{URL: u, Type: a.MimeType, Length: int64(a.SizeInBytes)})
+			}
+		}
+
+		if item, ok := finishFeedItem(item, item.GUID, firstNonEmpty(it.URL, it.ExternalURL), item.Title); ok {
+			feed.Items = append(feed.Items, item)
+		}
+	}
+	return feed, nil
+}
+
+// finishFeedItem 按优先级确定 externalId
+//
+// 候选值都为空时使用正文哈希；没有正文的条目被丢弃。
+func finishFeedItem(item FeedItem, candidates ...string) (FeedItem, bool) {
+	item.ExternalID = firstNonEmpty(candidates...)
+	if item.ExternalID == "" {
+		content := firstNonEmpty(item.Content, item.Summary)
+		if content == "" {
+			return item, false
+		}
+		sum := sha256.Sum256([]byte(content))
+		item.ExternalID = "sha256:" + hex.EncodeToString(sum[:16])
+	}
+	return item, true
+}
+
+// feedDate 解析订阅源中的日期（RFC 822 / RFC 3339 及国内站点常见的本地格式）
+func (e *Extractor) feedDate(value string) *time.Time {
+	if d := e.dateExtractor.parseAs(value, DateSourceMeta); d != nil {
+		return &d.Time
+	}
+	return nil
+}
+
+// feedText 将可能包含 HTML 的字段转为单行纯文本（兼容重复转义的实体）
+func feedText(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	return strings.Join(strings.Fields(HTMLToText(s)), " ")
+}
+
+// resolveFeedURL 解析为绝对地址，只接受 http / https
+func resolveFeedURL(base *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	u, err := base.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
+
+// compactStrings 去除空值并去重
+func compactStrings(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return uniqueStrings(result)
+}
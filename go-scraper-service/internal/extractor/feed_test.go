@@ -0,0 +1,217 @@
+package extractor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestParseFeed(t *testing.T) {
+	e := New()
+	shanghai := time.FixedZone("CST", 8*3600)
+	e.SetDefaultTimezone(shanghai)
+
+	t.Run("RSS 2.0：未声明命名空间与未转义字符", func(t *testing.T) {
+		body := "\n\n<?xml version=\"1.0\" encoding=\"utf-8\"?>\n" + `<rss version="2.0"><channel>
+			<title>新闻 & 评论</title><link>https://news.example.com/</link>
+			<lastBuildDate>Tue, 3 Mar 2026 10:00:00 +0800</lastBuildDate>
+			<item>
+				<title>A &amp;quot;quoted&amp;quot; title</title>
+				<link>https://news.example.com/a/1.html</link>
+				<guid isPermaLink="false">news-1</guid>
+				<description><p>摘要 &nbsp;第一段</p><img src="/img/1.jpg"></description>
+				<content:encoded><![CDATA[<p>正文 a < b</p>]]></content:encoded>
+				<dc:creator>张三</dc:creator>
+				<author>editor@example.com (李四)</author>
+				<category>科技</category><category>科技</category>
+				<pubDate>2026-03-03 09:30:00</pubDate>
+				<media:thumbnail url="/thumb/1.jpg"/>
+				<enclosure url="https://cdn.example.com/1.mp3" type="audio/mpeg" length="1024"/>
+			</item>
+			<item><title>只有标题` + "\x0b" + `</title></item>
+		</channel></rss>`
+		feed, err := e.ParseFeed(body, "application/rss+xml", "https://news.example.com/rss.xml")
+		if err != nil {
+			t.Fatalf("ParseFeed() error = %v", err)
+		}
+		if feed.Format != FeedFormatRSS || feed.Version != "2.0" || feed.Title != "新闻 & 评论" || len(feed.Items) != 2 {
+			t.Fatalf("feed = %+v", feed)
+		}
+		if feed.UpdatedAt == nil || !feed.UpdatedAt.Equal(time.Date(2026, 3, 3, 10, 0, 0, 0, shanghai)) {
+			t.Errorf("UpdatedAt = %v", feed.UpdatedAt)
+		}
+
+		item := feed.Items[0]
+		if item.ExternalID != "news-1" || item.Link != "https://news.example.com/a/1.html" {
+			t.Errorf("ExternalID = %q, Link = %q", item.ExternalID, item.Link)
+		}
+		if item.Title != `A "quoted" title` {
+			t.Errorf("Title = %q", item.Title)
+		}
+		if item.Content != "<p>正文 a < b</p>" || item.Summary != "摘要 第一段" {
+			t.Errorf("Content = %q, Summary = %q", item.Content, item.Summary)
+		}
+		if !reflect.DeepEqual(item.Authors, []string{"李四", "张三"}) || !reflect.DeepEqual(item.Categories, []string{"科技"}) {
+			t.Errorf("Authors = %v, Categories = %v", item.Authors, item.Categories)
+		}
+		if item.PublishedAt == nil || !item.PublishedAt.Equal(time.Date(2026, 3, 3, 9, 30, 0, 0, shanghai)) {
+			t.Errorf("PublishedAt = %v", item.PublishedAt)
+		}
+		if item.Thumbnail != "https://news.example.com/thumb/1.jpg" {
+			t.Errorf("Thumbnail = %q", item.Thumbnail)
+		}
+		if want := []FeedEnclosure{{URL: "https://cdn.example.com/1.mp3", Type: "audio/mpeg", Length: 1024}}; !reflect.DeepEqual(item.Enclosures, want) {
+			t.Errorf("Enclosures = %+v", item.Enclosures)
+		}
+		if feed.Items[1].ExternalID != "只有标题" {
+			t.Errorf("ExternalID = %q, want title fallback", feed.Items[1].ExternalID)
+		}
+	})
+
+	t.Run("RSS 1.0（RDF）", func(t *testing.T) {
+		body := `<?xml version="1.0"?>
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+			<channel rdf:about="https://example.org/"><title>RDF</title><link>https://example.org/</link></channel>
+			<item rdf:about="https://example.org/post/1"><title>Post</title><dc:date>2026-03-01T08:00:00Z</dc:date></item>
+		</rdf:RDF>`
+		feed, err := e.ParseFeed(body, "", "https://example.org/index.rdf")
+		if err != nil {
+			t.Fatalf("ParseFeed() error = %v", err)
+		}
+		if feed.Version != "1.0" || len(feed.Items) != 1 {
+			t.Fatalf("feed = %+v", feed)
+		}
+		if item := feed.Items[0]; item.ExternalID != "https://example.org/post/1" || item.PublishedAt == nil {
+			t.Errorf("item = %+v", item)
+		}
+	})
+
+	t.Run("Atom 1.0", func(t *testing.T) {
+		body := `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+			<title type="text">Atom Feed</title><link rel="self" href="/atom.xml"/><link href="/"/>
+			<entry>
+				<id>tag:example.com,2026:1</id>
+				<title type="html">Hello &lt;em&gt;world&lt;/em&gt;</title>
+				<link rel="alternate" href="/posts/1"/><link rel="enclosure" href="/files/1.png" type="image/png"/>
+				<published>2026-03-02T08:00:00+08:00</published><updated>2026-03-02T09:00:00+08:00</updated>
+				<author><name>王五</name></author>
+				<category term="go" label="Go"/>
+				<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Body</p></div></content>
+			</entry>
+		</feed>`
+		feed, err := e.ParseFeed(body, "", "https://example.com/atom.xml")
+		if err != nil {
+			t.Fatalf("ParseFeed() error = %v", err)
+		}
+		if feed.Format != FeedFormatAtom || feed.Title != "Atom Feed" || feed.Link != "https://example.com/" || len(feed.Items) != 1 {
+			t.Fatalf("feed = %+v", feed)
+		}
+		item := feed.Items[0]
+		if item.ExternalID != "/posts/1" || item.GUID != "tag:example.com,2026:1" || item.Link != "https://example.com/posts/1" {
+			t.Errorf("ExternalID = %q, GUID = %q, Link = %q", item.ExternalID, item.GUID, item.Link)
+		}
+		if item.Title != "Hello world" || item.Content != "<p>Body</p>" {
+			t.Errorf("Title = %q, Content = %q", item.Title, item.Content)
+		}
+		if !reflect.DeepEqual(item.Authors, []string{"王五"}) || !reflect.DeepEqual(item.Categories, []string{"Go"}) {
+			t.Errorf("Authors = %v, Categories = %v", item.Authors, item.Categories)
+		}
+		if item.UpdatedAt == nil || item.PublishedAt == nil || !item.UpdatedAt.After(*item.PublishedAt) {
+			t.Errorf("PublishedAt = %v, UpdatedAt = %v", item.PublishedAt, item.UpdatedAt)
+		}
+		if item.Thumbnail != "https://example.com/files/1.png" {
+			t.Errorf("Thumbnail = %q", item.Thumbnail)
+		}
+	})
+
+	t.Run("JSON Feed", func(t *testing.T) {
+		body := `{"version":"https://jsonfeed.org/version/1.1","title":"JSON","home_page_url":"https://example.net/",
+			"authors":[{"name":"Feed Author"}],
+			"items":[
+				{"id":42,"url":"https://example.net/42","title":"Forty-two","content_text":"a < b","tags":["x"],
+				 "date_published":"2026-03-04T10:00:00Z","attachments":[{"url":"/a.mp3","mime_type":"audio/mpeg","size_in_bytes":2048}]},
+				{"url":"https://example.net/43","content_html":"<p>Hi</p>","image":"/43.jpg"}
+			]}`
+		feed, err := e.ParseFeed(body, "application/feed+json", "https://example.net/feed.json")
+		if err != nil {
+			t.Fatalf("ParseFeed() error = %v", err)
+		}
+		if feed.Format != FeedFormatJSON || feed.Version != "1.1" || len(feed.Items) != 2 {
+			t.Fatalf("feed = %+v", feed)
+		}
+		first, second := feed.Items[0], feed.Items[1]
+		if first.ExternalID != "42" || first.Content != "a &lt; b" || !reflect.DeepEqual(first.Authors, []string{"Feed Author"}) {
+			t.Errorf("first = %+v", first)
+		}
+		if len(first.Enclosures) != 1 || first.Enclosures[0].URL != "https://example.net/a.mp3" || first.Enclosures[0].Length != 2048 {
+			t.Errorf("Enclosures = %+v", first.Enclosures)
+		}
+		if second.ExternalID != "https://example.net/43" || second.Thumbnail != "https://example.net/43.jpg" {
+			t.Errorf("second = %+v", second)
+		}
+	})
+
+	t.Run("GBK 编码", func(t *testing.T) {
+		body, err := simplifiedchinese.GBK.NewEncoder().String(`<?xml version="1.0" encoding="gbk"?><rss><channel><title>中文频道</title>` +
+			`<item><title>标题</title><link>https://example.cn/1</link></item></channel></rss>`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		feed, err := e.ParseFeed(body, "text/xml", "https://example.cn/rss")
+		if err != nil {
+			t.Fatalf("ParseFeed() error = %v", err)
+		}
+		if feed.Title != "中文频道" || len(feed.Items) != 1 || feed.Items[0].Title != "标题" {
+			t.Errorf("feed = %+v", feed)
+		}
+	})
+
+	t.Run("声明 gbk 但内容为 UTF-8", func(t *testing.T) {
+		body := `<?xml version="1.0" encoding="gb2312"?><rss><channel><title>中文</title></channel></rss>`
+		feed, err := e.ParseFeed(body, "text/xml; charset=gbk", "https://example.cn/rss")
+		if err != nil || feed.Title != "中文" {
+			t.Errorf("feed = %+v, err = %v", feed, err)
+		}
+	})
+
+	t.Run("文档截断时保留已解析的条目", func(t *testing.T) {
+		body := `<rss><channel><title>T</title><item><title>One</title><link>https://example.com/1</link></item><item><title>Tw`
+		feed, err := e.ParseFeed(body, "", "https://example.com/rss")
+		if err != nil {
+			t.Fatalf("ParseFeed() error = %v", err)
+		}
+		if len(feed.Items) == 0 || feed.Items[0].ExternalID != "https://example.com/1" {
+			t.Errorf("Items = %+v", feed.Items)
+		}
+	})
+
+	t.Run("HTML 页面", func(t *testing.T) {
+		_, err := e.ParseFeed("<!DOCTYPE html><html><body>hi</body></html>", "text/html", "https://example.com/")
+		if !errors.Is(err, ErrNotFeed) {
+			t.Errorf("err = %v, want ErrNotFeed", err)
+		}
+	})
+}
+
+func TestRepairXML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"去除声明前的内容", "junk\n<rss/>", "<rss/>"},
+		{"转义不构成标签的 <", "<t>a < b</t>", "<t>a &lt; b</t>"},
+		{"保留 CDATA", "<t><![CDATA[a < b]]></t>", "<t><![CDATA[a < b]]></t>"},
+		{"去除控制字符", "<t>a\x01b</t>", "<t>ab</t>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repairXML(tt.in); got != tt.want {
+				t.Errorf("repairXML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现正文指纹（精确哈希 + SimHash）和近似重复检测
+
+package extractor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultSimilarityThreshold 默认的近似重复阈值（SimHash 相似度，0.9 即 64 位中最多 6 位不同）
+const DefaultSimilarityThreshold = 0.9
+
+// ErrInvalidSimHash SimHash 格式错误（应为 16 位十六进制）
+var ErrInvalidSimHash = errors.New("invalid simhash (16 hex digits)")
+
+// Fingerprint 正文指纹
+//
+// 基于规范化后的正文文本（NFKC、小写、去除标点、折叠空白）计算：
+//   - ContentHash：SHA-256，规范化文本完全相同（仅排版、标点、大小写不同）时相等
+//   - SimHash：64 位局部敏感哈希，转载稿、小幅修改的稿件汉明距离很小
+type Fingerprint struct {
+	// 规范化文本的 SHA-256（十六进制）
+	ContentHash string `json:"contentHash"`
+	// 64 位 SimHash（16 位十六进制）
+	SimHash string `json:"simhash"`
+	// 参与计算的特征（shingle）数
+	Shingles int `json:"shingles"`
+}
+
+// ComputeFingerprint 计算正文指纹，文本为空（去除标点后）时返回 nil
+//
+// 分词：连续的字母 / 数字为一个词，中日韩字符每个字为一个词；
+// 特征为相邻两个词组成的 shingle（中文即字 bigram），按出现次数加权。
+func ComputeFingerprint(text string) *Fingerprint {
+	tokens := fingerprintTokens(text)
+	if len(tokens) == 0 {
+		return nil
+	}
+	normalized := strings.Join(tokens, " ")
+	sum := sha256.Sum256([]byte(normalized))
+
+	var shingles []string
+	if len(tokens) == 1 {
+		shingles = tokens
+	} else {
+		shingles = make([]string, 0, len(tokens)-1)
+		for i := 0; i+1 < len(tokens); i++ {
+			shingles = append(shingles, tokens[i]+" "+tokens[i+1])
+		}
+	}
+
+	return &Fingerprint{
+		ContentHash: hex.EncodeToString(sum[:]),
+		SimHash:     FormatSimHash(simHash(shingles)),
+		Shingles:    len(shingles),
+	}
+}
+
+// fingerprintTokens 规范化并分词
+func fingerprintTokens(text string) []string {
+	text = strings.ToLower(norm.NFKC.String(text))
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case isCJK(r) && unicode.IsLetter(r):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// simHash 计算加权特征的 64 位 SimHash
+func simHash(features []string) uint64 {
+	counts := map[string]int{}
+	for _, f := range features {
+		counts[f]++
+	}
+	var v [64]int
+	for f, weight := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(f))
+		x := mix64(h.Sum64())
+		for i := 0; i < 64; i++ {
+			if x&(1<<uint(i)) != 0 {
+				v[i] += weight
+			} else {
+				v[i] -= weight
+			}
+		}
+	}
+	var result uint64
+	for i := 0; i < 64; i++ {
+		if v[i] > 0 {
+			result |= 1 << uint(i)
+		}
+	}
+	return result
+}
+
+// mix64 打散 FNV 哈希的位分布（splitmix64 终结函数），短 shingle 的 FNV 高位相关性较强
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// FormatSimHash 格式化 SimHash 为 16 位十六进制
+func FormatSimHash(h uint64) string {
+	return fmt.Sprintf("%016x", h)
+}
+
+// ParseSimHash 解析 16 位十六进制 SimHash
+func ParseSimHash(s string) (uint64, error) {
+	if len(s) != 16 {
+		return 0, ErrInvalidSimHash
+	}
+	h, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, ErrInvalidSimHash
+	}
+	return h, nil
+}
+
+// SimHashDistance 两个 SimHash 的汉明距离（0-64）
+func SimHashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// SimHashSimilarity 由汉明距离换算的相似度（0-1）
+func SimHashSimilarity(distance int) float64 {
+	return 1 - float64(distance)/64
+}
+
+// MaxSimilarItems 单次近似重复检测的最大项数
+const MaxSimilarItems = 1000
+
+// SimilarItem 待比较的内容：提供 Text 时计算指纹，否则使用已保存的 SimHash（可附带 ContentHash）
+type SimilarItem struct {
+	// 调用方的标识，为空时使用下标
+	ID          string `json:"id"`
+	Text        string `json:"text,omitempty"`
+	SimHash     string `json:"simhash,omitempty"`
+	ContentHash string `json:"contentHash,omitempty"`
+}
+
+// SimilarPair 一对近似重复的内容
+type SimilarPair struct {
+	A string `json:"a"`
+	B string `json:"b"`
+	// SimHash 汉明距离
+	Distance int `json:"distance"`
+	// 相似度（1 - distance/64）
+	Similarity float64 `json:"similarity"`
+	// 规范化文本完全相同（ContentHash 相等）
+	Exact bool `json:"exact"`
+}
+
+// SimilarResult 近似重复检测结果
+type SimilarResult struct {
+	// 各项的指纹（不含 Text；文本为空时 SimHash 为空且不参与比较）
+	Items []SimilarItem `json:"items"`
+	// 相似度不低于阈值的内容对
+	Pairs []SimilarPair `json:"pairs"`
+	// 近似重复聚类（两项以上，按输入顺序）
+	Clusters [][]string `json:"clusters"`
+}
+
+// FindSimilar 两两比较内容指纹，返回近似重复的内容对和聚类
+//
+// 聚类按近似重复关系传递合并（A≈B、B≈C 时 A、B、C 为一类）。
+// 只提供 SimHash 而没有 ContentHash 的项不判定 Exact。threshold 为 0 时使用 DefaultSimilarityThreshold。
+func FindSimilar(items []SimilarItem, threshold float64) (*SimilarResult, error) {
+	if len(items) == 0 {
+		return nil, errors.New("items is required")
+	}
+	if len(items) > MaxSimilarItems {
+		return nil, fmt.Errorf("maximum %d items", MaxSimilarItems)
+	}
+	if threshold == 0 {
+		threshold = DefaultSimilarityThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, errors.New("threshold must be between 0 and 1")
+	}
+
+	result := &SimilarResult{Items: make([]SimilarItem, len(items)), Pairs: []SimilarPair{}, Clusters: [][]string{}}
+	hashes := make([]uint64, len(items))
+	valid := make([]bool, len(items))
+	for i, item := range items {
+		out := SimilarItem{ID: item.ID, SimHash: item.SimHash, ContentHash: item.ContentHash}
+		if out.ID == "" {
+			out.ID = strconv.Itoa(i)
+		}
+		switch {
+		case item.Text != "":
+			out.SimHash, out.ContentHash = "", ""
+			if fp := ComputeFingerprint(item.Text); fp != nil {
+				out.SimHash, out.ContentHash = fp.SimHash, fp.ContentHash
+			}
+		case item.SimHash == "":
+			return nil, fmt.Errorf("item %d: text or simhash is required", i)
+		}
+		if out.SimHash != "" {
+			h, err := ParseSimHash(out.SimHash)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			hashes[i], valid[i] = h, true
+		}
+		result.Items[i] = out
+	}
+
+	parent := make([]int, len(items))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range items {
+		if !valid[i] {
+			continue
+		}
+		for j := i + 1; j < len(items); j++ {
+			if !valid[j] {
+				continue
+			}
+			distance := SimHashDistance(hashes[i], hashes[j])
+			similarity := SimHashSimilarity(distance)
+			if similarity < threshold {
+				continue
+			}
+			a, b := result.Items[i], result.Items[j]
+			result.Pairs = append(result.Pairs, SimilarPair{
+				A:          a.ID,
+				B:          b.ID,
+				Distance:   distance,
+				Similarity: similarity,
+				Exact:      a.ContentHash != "" && a.ContentHash == b.ContentHash,
+			})
+			if ri, rj := find(i), find(j); ri != rj {
+				parent[max(ri, rj)] = min(ri, rj)
+			}
+		}
+	}
+
+	// 根节点为类内最小下标，按根节点顺序输出即为输入顺序
+	groups := map[int][]string{}
+	var roots []int
+	for i := range items {
+		if !valid[i] {
+			continue
+		}
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], result.Items[i].ID)
+	}
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			result.Clusters = append(result.Clusters, groups[root])
+		}
+	}
+	return result, nil
+}
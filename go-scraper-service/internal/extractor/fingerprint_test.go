@@ -0,0 +1,138 @@
+package extractor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	fingerprintArticle = "国家统计局今天发布数据显示，今年前三季度国内生产总值同比增长百分之五点二，" +
+		"其中第三季度增长百分之四点九。分产业看，第一产业增加值增长百分之四，第二产业增长百分之四点四，" +
+		"第三产业增长百分之六。社会消费品零售总额同比增长百分之六点八，最终消费支出对经济增长的贡献率明显提升。"
+	fingerprintRepost = "【转载】国家统计局今天发布数据显示，今年前三季度国内生产总值同比增长百分之五点二，" +
+		"其中第三季度增长百分之四点九。分产业看，第一产业增加值增长百分之四，第二产业增长百分之四点四，" +
+		"第三产业增长百分之六。社会消费品零售总额同比增长百分之六点八，最终消费支出对经济增长的贡献率明显提升。"
+	fingerprintOther = "本市今日迎来入秋以来最强降雨，气象台发布暴雨蓝色预警，提醒市民减少外出，" +
+		"注意防范城市内涝和地质灾害。交通部门已启动应急预案，部分低洼路段实施临时交通管制，地铁运营时间延长一小时。"
+)
+
+func TestComputeFingerprint(t *testing.T) {
+	base := ComputeFingerprint(fingerprintArticle)
+	if base == nil || len(base.SimHash) != 16 || len(base.ContentHash) != 64 {
+		t.Fatalf("ComputeFingerprint() = %+v", base)
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		exact   bool
+		maxDist int
+		minDist int
+	}{
+		{"仅标点、空白和全角不同", strings.ReplaceAll(fingerprintArticle, "，", ", ") + "  ", true, 0, 0},
+		{"转载稿近似重复", fingerprintRepost, false, 6, 0},
+		{"无关文章", fingerprintOther, false, 64, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := ComputeFingerprint(tt.text)
+			if (fp.ContentHash == base.ContentHash) != tt.exact {
+				t.Errorf("ContentHash 相等 = %v, want %v", fp.ContentHash == base.ContentHash, tt.exact)
+			}
+			a, _ := ParseSimHash(base.SimHash)
+			b, _ := ParseSimHash(fp.SimHash)
+			if d := SimHashDistance(a, b); d > tt.maxDist || d < tt.minDist {
+				t.Errorf("SimHashDistance() = %d, want %d-%d", d, tt.minDist, tt.maxDist)
+			}
+		})
+	}
+
+	t.Run("大小写和 NFKC 规范化", func(t *testing.T) {
+		x, y := ComputeFingerprint("Hello, ＷＯＲＬＤ 2024!"), ComputeFingerprint("hello world 2024")
+		if x.ContentHash != y.ContentHash {
+			t.Errorf("ContentHash 不同: %s / %s", x.ContentHash, y.ContentHash)
+		}
+	})
+	t.Run("只有标点时为空", func(t *testing.T) {
+		if fp := ComputeFingerprint("——！？…"); fp != nil {
+			t.Errorf("ComputeFingerprint() = %+v, want nil", fp)
+		}
+	})
+}
+
+func TestParseSimHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{"合法", "00000000000000ff", 0xff, false},
+		{"大写", "FFFFFFFFFFFFFFFF", ^uint64(0), false},
+		{"长度不对", "ff", 0, true},
+		{"非十六进制", "zzzzzzzzzzzzzzzz", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSimHash(tt.input)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseSimHash(%q) = %x, %v", tt.input, got, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSimHash) {
+				t.Errorf("error = %v, want ErrInvalidSimHash", err)
+			}
+		})
+	}
+}
+
+func TestFindSimilar(t *testing.T) {
+	stored := ComputeFingerprint(fingerprintArticle)
+	result, err := FindSimilar([]SimilarItem{
+		{ID: "a", Text: fingerprintArticle},
+		{ID: "b", Text: fingerprintOther},
+		{ID: "c", Text: fingerprintRepost},
+		{ID: "d", SimHash: stored.SimHash, ContentHash: stored.ContentHash},
+		{Text: "。。。"},
+	}, 0)
+	if err != nil {
+		t.Fatalf("FindSimilar() error = %v", err)
+	}
+	if len(result.Clusters) != 1 || strings.Join(result.Clusters[0], ",") != "a,c,d" {
+		t.Errorf("Clusters = %v, want [[a c d]]", result.Clusters)
+	}
+	if result.Items[4].ID != "4" || result.Items[4].SimHash != "" || result.Items[0].Text != "" {
+		t.Errorf("Items = %+v", result.Items)
+	}
+	exact := 0
+	for _, p := range result.Pairs {
+		if p.Exact {
+			exact++
+			if p.A != "a" || p.B != "d" || p.Distance != 0 || p.Similarity != 1 {
+				t.Errorf("Exact pair = %+v", p)
+			}
+		}
+	}
+	if len(result.Pairs) != 3 || exact != 1 {
+		t.Errorf("Pairs = %+v", result.Pairs)
+	}
+
+	invalid := []struct {
+		name      string
+		items     []SimilarItem
+		threshold float64
+	}{
+		{"没有内容", nil, 0},
+		{"阈值超出范围", []SimilarItem{{Text: "x"}}, 1.5},
+		{"既无文本也无指纹", []SimilarItem{{ID: "x"}}, 0},
+		{"指纹格式错误", []SimilarItem{{SimHash: "xyz"}}, 0},
+		{"超过最大项数", make([]SimilarItem, MaxSimilarItems+1), 0},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FindSimilar(tt.items, tt.threshold); err == nil {
+				t.Error("FindSimilar() 应返回错误")
+			}
+		})
+	}
+}
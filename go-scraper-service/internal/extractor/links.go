@@ -0,0 +1,267 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现页面链接提取（供站点爬取发现新 URL）
+
+package extractor
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// 链接所在的页面区域
+const (
+	RegionNav    = "nav"
+	RegionHeader = "header"
+	RegionFooter = "footer"
+	RegionAside  = "aside"
+	RegionMain   = "main"
+	RegionBody   = "body" // 无法判断区域
+)
+
+// Link 页面中的链接
+type Link struct {
+	URL         string   `json:"url"`                   // 解析为绝对地址并规范化后的 URL
+	Text        string   `json:"text,omitempty"`        // 锚文本（无文本时取 title 或图片 alt）
+	Rel         []string `json:"rel,omitempty"`         // rel 属性（nofollow、sponsored 等）
+	Region      string   `json:"region"`                // 所在区域：nav, header, footer, aside, main, body
+	Internal    bool     `json:"internal"`              // 是否为本站链接（含子域名）
+	ArticleLike bool     `json:"articleLike,omitempty"` // URL 形态像文章（日期、长 slug、数字 ID 等）
+}
+
+// LinkOptions 链接过滤选项
+type LinkOptions struct {
+	// Include 规范化后的 URL 至少匹配其中一个正则才保留（为空时全部保留）
+	Include []string
+	// Exclude 规范化后的 URL 匹配任一正则即丢弃
+	Exclude []string
+}
+
+// linkFilter 编译后的过滤规则
+type linkFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// compile 编译过滤正则
+func (o LinkOptions) compile() (*linkFilter, error) {
+	f := &linkFilter{}
+	for _, pattern := range o.Include {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+		f.include = append(f.include, re)
+	}
+	for _, pattern := range o.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		f.exclude = append(f.exclude, re)
+	}
+	return f, nil
+}
+
+// Validate 校验过滤正则
+func (o LinkOptions) Validate() error {
+	_, err := o.compile()
+	return err
+}
+
+// allows 判断 URL 是否通过过滤
+func (f *linkFilter) allows(u string) bool {
+	for _, re := range f.exclude {
+		if re.MatchString(u) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(u) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractLinks 提取页面中的全部链接
+//
+// 链接按 <base href>（如有）解析为绝对地址并规范化（见 NormalizeURL），
+// 跳过 javascript:、mailto:、tel: 等非 HTTP 链接、页内锚点和指向页面自身的链接；同一 URL 只保留首次出现，
+// 首次出现没有锚文本时使用后续出现的文本。结果按页面中的出现顺序排列。
+func ExtractLinks(pageHTML, pageURL string, opts LinkOptions) ([]Link, error) {
+	filter, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+	page, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, err
+	}
+	self := NormalizeURL(page)
+	// <base> 只用于解析相对地址，站内外仍按页面自身的域名判断
+	base := page
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if b, err := page.Parse(strings.TrimSpace(href)); err == nil {
+			base = b
+		}
+	}
+
+	links := []Link{}
+	seen := map[string]int{}
+	doc.Find("a[href], area[href]").Each(func(_ int, a *goquery.Selection) {
+		href := strings.TrimSpace(a.AttrOr("href", ""))
+		if href == "" || strings.HasPrefix(href, "#") {
+			return
+		}
+		target, err := base.Parse(href)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+			return
+		}
+		normalized := NormalizeURL(target)
+		if normalized == self || !filter.allows(normalized) {
+			return
+		}
+
+		text := strings.Join(strings.Fields(a.Text()), " ")
+		if text == "" {
+			text = firstNonEmpty(a.AttrOr("title", ""), a.Find("img[alt]").AttrOr("alt", ""), a.AttrOr("aria-label", ""))
+		}
+		if i, ok := seen[normalized]; ok {
+			if links[i].Text == "" {
+				links[i].Text = text
+			}
+			return
+		}
+
+		seen[normalized] = len(links)
+		links = append(links, Link{
+			URL:         normalized,
+			Text:        text,
+			Rel:         strings.Fields(strings.ToLower(a.AttrOr("rel", ""))),
+			Region:      linkRegion(a.Get(0)),
+			Internal:    sameSite(page.Hostname(), target.Hostname()),
+			ArticleLike: IsArticleURL(target),
+		})
+	})
+	return links, nil
+}
+
+// trackingParams 规范化时移除的跟踪参数
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"mc_cid": true, "mc_eid": true, "igshid": true, "spm": true, "share_token": true,
+}
+
+// NormalizeURL 规范化 URL（用于去重）
+//
+// 小写协议和主机名、去除默认端口、片段和 utm_* 等跟踪参数，空路径补为 "/"。
+// 不改变路径大小写和其余查询参数的顺序。
+func NormalizeURL(u *url.URL) string {
+	n := *u
+	n.Scheme = strings.ToLower(n.Scheme)
+	n.Host = strings.ToLower(n.Host)
+	if port := n.Port(); (n.Scheme == "http" && port == "80") || (n.Scheme == "https" && port == "443") {
+		n.Host = n.Hostname()
+	}
+	n.Fragment, n.RawFragment = "", ""
+	n.User = nil
+	if n.Path == "" {
+		n.Path, n.RawPath = "/", ""
+	}
+
+	if n.RawQuery != "" {
+		var kept []string
+		for _, pair := range strings.Split(n.RawQuery, "&") {
+			key := pair
+			if i := strings.IndexByte(pair, '='); i >= 0 {
+				key = pair[:i]
+			}
+			key = strings.ToLower(key)
+			if pair == "" || strings.HasPrefix(key, "utm_") || trackingParams[key] {
+				continue
+			}
+			kept = append(kept, pair)
+		}
+		n.RawQuery = strings.Join(kept, "&")
+	}
+	n.ForceQuery = false
+	return n.String()
+}
+
+// IsArticleURL 判断 URL 形态是否像文章（日期路径、长 slug、数字 ID、/article/ 等前缀），
+// 列表页形态（tag、category、分页等）优先排除
+func IsArticleURL(u *url.URL) bool {
+	if hasArticleQuery(u) {
+		return true
+	}
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+	if path == "" || rootPathPattern.MatchString(path) || listingPathPattern.MatchString(path+"/") || hasListingQuery(u) {
+		return false
+	}
+	return articlePathPattern.MatchString(path)
+}
+
+// regionHintPattern class / id 中的区域提示
+var regionHintPattern = regexp.MustCompile(`(?i)(?:^|[\s_-])(nav|navbar|navigation|menu|breadcrumbs?|header|masthead|footer|sidebar|aside|widget)(?:$|[\s_-])`)
+
+// linkRegion 按祖先元素判断链接所在区域
+//
+// 优先使用语义标签和 ARIA role，其次使用 class / id 命名（nav、menu、footer、sidebar 等）；
+// 最近的祖先决定区域，位于 article / main 中的链接为 main。
+func linkRegion(n *html.Node) string {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type != html.ElementNode {
+			continue
+		}
+		switch p.Data {
+		case "nav":
+			return RegionNav
+		case "header":
+			return RegionHeader
+		case "footer":
+			return RegionFooter
+		case "aside":
+			return RegionAside
+		case "main", "article":
+			return RegionMain
+		}
+		switch attr(p, "role") {
+		case "navigation":
+			return RegionNav
+		case "banner":
+			return RegionHeader
+		case "contentinfo":
+			return RegionFooter
+		case "complementary":
+			return RegionAside
+		case "main", "article":
+			return RegionMain
+		}
+		if m := regionHintPattern.FindStringSubmatch(attr(p, "class") + " " + attr(p, "id")); m != nil {
+			switch strings.ToLower(m[1]) {
+			case "header", "masthead":
+				return RegionHeader
+			case "footer":
+				return RegionFooter
+			case "sidebar", "aside", "widget":
+				return RegionAside
+			default:
+				return RegionNav
+			}
+		}
+	}
+	return RegionBody
+}
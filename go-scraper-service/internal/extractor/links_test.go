@@ -0,0 +1,105 @@
+package extractor
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	page := `<html><head><base href="https://example.com/news/"></head><body>
+		<nav><a href="/">Home</a><a href="/tag/world">World</a></nav>
+		<div class="main-content">
+			<article>
+				<a href="2026/03/05/city-council-approves-transit-plan?utm_source=rss#comments">Council approves plan</a>
+				<a href="https://other.org/report" rel="nofollow noopener">Full report</a>
+				<a href="https://www.example.com/news/2026/03/05/city-council-approves-transit-plan"><img src="a.jpg" alt="dup"></a>
+				<a href="javascript:void(0)">Share</a>
+				<a href="#top">Top</a>
+			</article>
+		</div>
+		<div id="footer"><a href="https://cdn.example.com/about">About</a><a href="/privacy">Privacy</a></div>
+	</body></html>`
+
+	links, err := ExtractLinks(page, "https://example.com/news/index.html", LinkOptions{Exclude: []string{`/privacy$`}})
+	if err != nil {
+		t.Fatalf("ExtractLinks() error = %v", err)
+	}
+
+	want := []Link{
+		{URL: "https://example.com/", Text: "Home", Region: RegionNav, Internal: true},
+		{URL: "https://example.com/tag/world", Text: "World", Region: RegionNav, Internal: true},
+		{URL: "https://example.com/news/2026/03/05/city-council-approves-transit-plan", Text: "Council approves plan", Region: RegionMain, Internal: true, ArticleLike: true},
+		{URL: "https://other.org/report", Text: "Full report", Rel: []string{"nofollow", "noopener"}, Region: RegionMain},
+		{URL: "https://www.example.com/news/2026/03/05/city-council-approves-transit-plan", Text: "dup", Region: RegionMain, Internal: true, ArticleLike: true},
+		{URL: "https://cdn.example.com/about", Text: "About", Region: RegionFooter, Internal: true},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("len(links) = %d, want %d: %+v", len(links), len(want), links)
+	}
+	for i := range want {
+		got, w := links[i], want[i]
+		if got.URL != w.URL || got.Text != w.Text || got.Region != w.Region || got.Internal != w.Internal ||
+			got.ArticleLike != w.ArticleLike || len(got.Rel) != len(w.Rel) {
+			t.Errorf("links[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if _, err := ExtractLinks(page, "https://example.com/", LinkOptions{Include: []string{"("}}); err == nil {
+		t.Error("无效正则应返回错误")
+	}
+
+	t.Run("base 指向 CDN 时按页面域名判断站内", func(t *testing.T) {
+		cdnPage := `<html><head><base href="https://cdn.example.net/assets/"></head><body>
+			<a href="logo.png">Logo</a>
+			<a href="https://example.com/news/2026/03/05/city-council-approves-transit-plan">Council</a>
+		</body></html>`
+		links, err := ExtractLinks(cdnPage, "https://example.com/news/index.html", LinkOptions{})
+		if err != nil {
+			t.Fatalf("ExtractLinks() error = %v", err)
+		}
+		if len(links) != 2 || links[0].URL != "https://cdn.example.net/assets/logo.png" || links[0].Internal || !links[1].Internal {
+			t.Errorf("links = %+v", links)
+		}
+	})
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"HTTPS://Example.COM:443/A?b=1&utm_medium=x&fbclid=y#frag", "https://example.com/A?b=1"},
+		{"http://example.com", "http://example.com/"},
+		{"http://example.com:8080/x?", "http://example.com:8080/x"},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.in)
+		if got := NormalizeURL(u); got != tt.want {
+			t.Errorf("NormalizeURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsArticleURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{name: "日期路径", url: "https://example.com/news/2026/03/05/council-approves-plan", want: true},
+		{name: "WordPress ?p= 文章 ID", url: "https://blog.example.com/?p=123", want: true},
+		{name: "Drupal /node/ 文章", url: "https://example.org/node/4512", want: true},
+		{name: "首页", url: "https://example.com/", want: false},
+		{name: "分页参数", url: "https://example.com/news?page=2", want: false},
+		{name: "标签页", url: "https://example.com/tag/transit", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := IsArticleURL(u); got != tt.want {
+				t.Errorf("IsArticleURL(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
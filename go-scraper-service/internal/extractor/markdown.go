@@ -0,0 +1,752 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现 HTML → Markdown 转换
+
+package extractor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// 输出格式
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+	FormatText     = "text"
+)
+
+// ParseOutputFormat 解析输出格式（为空时默认 html，md 视为 markdown）
+func ParseOutputFormat(format string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FormatHTML:
+		return FormatHTML, true
+	case FormatMarkdown, "md":
+		return FormatMarkdown, true
+	case FormatText, "txt", "plain":
+		return FormatText, true
+	}
+	return "", false
+}
+
+// hardBreak 行内换行（<br>）占位符，空白折叠之后替换为 Markdown 硬换行
+const hardBreak = "\ue000"
+
+// HTMLToMarkdown 将净化后的正文 HTML 转换为 Markdown（GFM）
+//
+// 支持：
+//   - 标题、段落、粗体 / 斜体 / 删除线、行内代码、链接、图片（含 alt、title）
+//   - 有序 / 无序列表（可嵌套）、嵌套引用、分隔线
+//   - 代码块：按 class="language-xx" / "lang-xx" 或 data-lang 输出带语言的围栏
+//   - 表格：输出 GFM 表格，首行（或 thead）作为表头
+//   - 脚注：<sup><a href="#fn1">1</a></sup> 转为 [^1]，被引用的脚注内容输出到文末
+//   - 数学公式：<math> 按 TeX 注解输出为 $…$，独立显示的公式输出为 $$ 块
+//
+// 空白处理与浏览器一致地折叠，但两个 CJK 字符之间的换行直接去除，
+// 避免源码换行在中日韩文本中产生多余空格。
+func HTMLToMarkdown(contentHTML string) string {
+	root, err := html.Parse(strings.NewReader(contentHTML))
+	if err != nil {
+		return ""
+	}
+	c := &mdConverter{footnoteRefs: map[string]string{}}
+	c.collectFootnotes(root)
+
+	body := c.blocks(root)
+	if len(c.footnotes) > 0 {
+		body += "\n\n" + strings.Join(c.footnotes, "\n")
+	}
+	return strings.TrimSpace(body) + "\n"
+}
+
+// mdConverter Markdown 转换状态
+type mdConverter struct {
+	// 脚注引用：目标 id → 标签
+	footnoteRefs map[string]string
+	// 已输出的脚注定义
+	footnotes []string
+}
+
+// footnoteLabelPattern 脚注标签（去除 [] 后为数字或短字母）
+var footnoteLabelPattern = regexp.MustCompile(`^\[?([0-9A-Za-z]{1,8})\]?$`)
+
+// collectFootnotes 收集脚注引用（<sup> 中指向页内锚点的链接，或 href 为 #fn… 的链接）
+func (c *mdConverter) collectFootnotes(n *html.Node) {
+	if n.Type == html.ElementNode && n.DataAtom == atom.A {
+		if label, id, ok := c.footnoteRef(n); ok {
+			if _, exists := c.footnoteRefs[id]; !exists {
+				c.footnoteRefs[id] = label
+			}
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.collectFootnotes(child)
+	}
+}
+
+// footnoteRef 判断链接是否为脚注引用
+func (c *mdConverter) footnoteRef(a *html.Node) (label, id string, ok bool) {
+	href := attr(a, "href")
+	if !strings.HasPrefix(href, "#") || len(href) < 2 || isFootnoteBackref(a) {
+		return "", "", false
+	}
+	inSup := a.Parent != nil && a.Parent.DataAtom == atom.Sup
+	if !inSup && !strings.HasPrefix(anchorName(href), "fn") {
+		return "", "", false
+	}
+	m := footnoteLabelPattern.FindStringSubmatch(strings.TrimSpace(nodeText(a)))
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], href[1:], true
+}
+
+// isFootnoteBackref 脚注内容中返回正文的链接（↩、#fnref…）
+func isFootnoteBackref(a *html.Node) bool {
+	href := attr(a, "href")
+	text := strings.TrimSpace(nodeText(a))
+	return (strings.HasPrefix(href, "#") && strings.HasPrefix(anchorName(href), "fnref")) || text == "↩" || text == "↩︎" || text == "^"
+}
+
+// anchorName 页内链接的锚点名（小写，去除 # 和 anchorPrefix 命名空间）
+func anchorName(href string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimPrefix(href, "#")), anchorPrefix)
+}
+
+// blocks 渲染块级上下文中的子节点，块之间以空行分隔
+func (c *mdConverter) blocks(n *html.Node) string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		if text := finishInline(inline.String()); text != "" {
+			out = append(out, text)
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && (isBlockElement(child) || isDisplayMath(child)) {
+			flush()
+			if block := c.block(child); strings.TrimSpace(block) != "" {
+				out = append(out, block)
+			}
+			continue
+		}
+		inline.WriteString(c.inline(child))
+	}
+	flush()
+	return strings.Join(out, "\n\n")
+}
+
+// block 渲染单个块级元素
+func (c *mdConverter) block(n *html.Node) string {
+	if id := attr(n, "id"); id != "" {
+		if label, ok := c.footnoteRefs[id]; ok {
+			c.addFootnote(label, n)
+			return ""
+		}
+	}
+
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		text := finishInline(c.inlineChildren(n))
+		if text == "" {
+			return ""
+		}
+		return strings.Repeat("#", level) + " " + strings.ReplaceAll(text, "\n", " ")
+	case atom.P:
+		if m := onlyElementChild(n); m != nil && isDisplayMath(m) {
+			return displayMath(m)
+		}
+		return finishInline(c.inlineChildren(n))
+	case atom.Math:
+		return displayMath(n)
+	case atom.Hr:
+		return "---"
+	case atom.Pre:
+		return c.codeBlock(n)
+	case atom.Blockquote:
+		return prefixLines(c.blocks(n), "> ", ">")
+	case atom.Ul, atom.Ol:
+		return c.list(n)
+	case atom.Table:
+		return c.table(n)
+	case atom.Figure:
+		return c.figure(n)
+	case atom.Dl:
+		return c.definitionList(n)
+	case atom.Script, atom.Style, atom.Noscript, atom.Template:
+		return ""
+	}
+	return c.blocks(n)
+}
+
+// addFootnote 记录脚注定义
+func (c *mdConverter) addFootnote(label string, n *html.Node) {
+	content := indentLines(strings.TrimSpace(c.blocks(n)), "    ")
+	c.footnotes = append(c.footnotes, "[^"+label+"]: "+content)
+}
+
+// codeBlock 渲染代码块
+func (c *mdConverter) codeBlock(pre *html.Node) string {
+	lang := codeLanguage(pre)
+	code := pre
+	for child := pre.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == atom.Code {
+			code = child
+			if lang == "" {
+				lang = codeLanguage(child)
+			}
+			break
+		}
+	}
+	text := strings.TrimRight(rawText(code), "\n")
+	text = strings.TrimPrefix(text, "\n")
+
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fence + lang + "\n" + text + "\n" + fence
+}
+
+// codeLanguagePattern class 中的代码语言
+var codeLanguagePattern = regexp.MustCompile(`(?:^|\s)(?:language|lang)-([A-Za-z0-9_+#.-]+)`)
+
+// codeLanguage 识别代码语言
+func codeLanguage(n *html.Node) string {
+	if lang := attr(n, "data-lang"); lang != "" {
+		return strings.ToLower(lang)
+	}
+	if m := codeLanguagePattern.FindStringSubmatch(attr(n, "class")); m != nil {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+// isDisplayMath 判断是否为独立显示的公式（<math display="block">）
+func isDisplayMath(n *html.Node) bool {
+	return n.Type == html.ElementNode && n.DataAtom == atom.Math && attr(n, "display") == "block"
+}
+
+// displayMath 渲染独立显示的公式为 $$ 块
+func displayMath(n *html.Node) string {
+	tex := mathTeX(n)
+	if tex == "" {
+		return ""
+	}
+	return "$$\n" + tex + "\n$$"
+}
+
+// mathTeX 返回公式的 TeX 源码（annotation 中的 TeX，没有时为公式文本）
+func mathTeX(n *html.Node) string {
+	if tex := findTeXAnnotation(n); tex != "" {
+		return tex
+	}
+	return strings.Join(strings.Fields(nodeText(n)), " ")
+}
+
+// findTeXAnnotation 查找 <annotation encoding="application/x-tex">
+func findTeXAnnotation(n *html.Node) string {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		if child.Data == "annotation" && attr(child, "encoding") == "application/x-tex" {
+			return strings.TrimSpace(nodeText(child))
+		}
+		if tex := findTeXAnnotation(child); tex != "" {
+			return tex
+		}
+	}
+	return ""
+}
+
+// list 渲染列表（嵌套列表按标记宽度缩进）
+func (c *mdConverter) list(n *html.Node) string {
+	ordered := n.DataAtom == atom.Ol
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil && ordered {
+		index = start
+	}
+
+	var items []string
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode {
+			continue
+		}
+		if id := attr(li, "id"); id != "" {
+			if label, ok := c.footnoteRefs[id]; ok {
+				c.addFootnote(label, li)
+				continue
+			}
+		}
+
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+		content := strings.TrimSpace(c.listItem(li))
+		if content == "" {
+			continue
+		}
+		items = append(items, marker+indentLines(content, strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// listItem 渲染列表项内容
+//
+// 文本与紧随其后的子列表之间只换行不空行（紧凑列表），其余块之间空一行。
+func (c *mdConverter) listItem(li *html.Node) string {
+	var b strings.Builder
+	var inline strings.Builder
+	flush := func() {
+		if text := finishInline(inline.String()); text != "" {
+			if b.Len() > 0 {
+				b.WriteString("\n\n")
+			}
+			b.WriteString(text)
+		}
+		inline.Reset()
+	}
+	for child := li.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || !isBlockElement(child) {
+			inline.WriteString(c.inline(child))
+			continue
+		}
+		flush()
+		block := c.block(child)
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		switch {
+		case b.Len() == 0:
+		case child.DataAtom == atom.Ul || child.DataAtom == atom.Ol:
+			b.WriteString("\n")
+		default:
+			b.WriteString("\n\n")
+		}
+		b.WriteString(block)
+	}
+	flush()
+	return b.String()
+}
+
+// indentLines 为第一行之后的非空行添加缩进
+func indentLines(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// table 渲染 GFM 表格
+func (c *mdConverter) table(n *html.Node) string {
+	var rows [][]string
+	var caption string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.DataAtom {
+			case atom.Caption:
+				caption = finishInline(c.inlineChildren(child))
+			case atom.Tr:
+				var row []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Th || cell.DataAtom == atom.Td) {
+						text := finishInline(c.inlineChildren(cell))
+						text = strings.ReplaceAll(text, "  \n", " ")
+						text = strings.ReplaceAll(text, "\n", " ")
+						row = append(row, strings.ReplaceAll(text, "|", `\|`))
+						if span, err := strconv.Atoi(attr(cell, "colspan")); err == nil {
+							for i := 1; i < span && i < 20; i++ {
+								row = append(row, "")
+							}
+						}
+					}
+				}
+				rows = append(rows, row)
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	line := func(row []string) string {
+		cells := make([]string, cols)
+		copy(cells, row)
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	lines := []string{line(rows[0]), "|" + strings.Repeat(" --- |", cols)}
+	for _, row := range rows[1:] {
+		lines = append(lines, line(row))
+	}
+	table := strings.Join(lines, "\n")
+	if caption != "" {
+		table = "*" + caption + "*\n\n" + table
+	}
+	return table
+}
+
+// figure 渲染图片容器：图片 + 斜体说明
+func (c *mdConverter) figure(n *html.Node) string {
+	var parts []string
+	var caption string
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == atom.Figcaption {
+			caption = finishInline(c.inlineChildren(child))
+			continue
+		}
+		if child.Type == html.ElementNode && isBlockElement(child) {
+			parts = append(parts, c.block(child))
+		} else if text := finishInline(c.inline(child)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if caption != "" {
+		parts = append(parts, "*"+caption+"*")
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// definitionList 渲染定义列表（术语加粗，释义作为段落）
+func (c *mdConverter) definitionList(n *html.Node) string {
+	var parts []string
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		text := finishInline(c.inlineChildren(child))
+		if text == "" {
+			continue
+		}
+		if child.DataAtom == atom.Dt {
+			parts = append(parts, "**"+text+"**")
+		} else {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// inlineChildren 渲染子节点的行内内容（块级子元素视为行内）
+func (c *mdConverter) inlineChildren(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(c.inline(child))
+	}
+	return b.String()
+}
+
+// inline 渲染行内节点
+func (c *mdConverter) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return escapeMarkdown(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return hardBreak
+	case atom.Strong, atom.B:
+		return wrapInline(c.inlineChildren(n), "**")
+	case atom.Em, atom.I, atom.Cite:
+		return wrapInline(c.inlineChildren(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(c.inlineChildren(n), "~~")
+	case atom.Code, atom.Kbd, atom.Samp:
+		return codeSpan(nodeText(n))
+	case atom.A:
+		return c.link(n)
+	case atom.Img:
+		return image(n)
+	case atom.Math:
+		if tex := mathTeX(n); tex != "" {
+			return "$" + tex + "$"
+		}
+		return ""
+	case atom.Sup:
+		if a := onlyElementChild(n); a != nil && a.DataAtom == atom.A {
+			if label, _, ok := c.footnoteRef(a); ok {
+				return "[^" + label + "]"
+			}
+		}
+		return c.inlineChildren(n)
+	case atom.Script, atom.Style, atom.Noscript, atom.Template:
+		return ""
+	}
+	return c.inlineChildren(n)
+}
+
+// link 渲染链接
+func (c *mdConverter) link(a *html.Node) string {
+	if label, _, ok := c.footnoteRef(a); ok {
+		return "[^" + label + "]"
+	}
+	if isFootnoteBackref(a) && strings.HasPrefix(attr(a, "href"), "#") {
+		return ""
+	}
+	text := c.inlineChildren(a)
+	href := strings.TrimSpace(attr(a, "href"))
+	if strings.TrimSpace(text) == "" {
+		return text
+	}
+	if href == "" || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return text
+	}
+	lead, body, trail := splitSpace(text)
+	return lead + "[" + body + "](" + markdownURL(href) + titlePart(attr(a, "title")) + ")" + trail
+}
+
+// image 渲染图片
+func image(img *html.Node) string {
+	src := strings.TrimSpace(attr(img, "src"))
+	if src == "" {
+		return ""
+	}
+	alt := strings.ReplaceAll(escapeMarkdown(collapseSpace(attr(img, "alt"))), "\n", " ")
+	return "![" + alt + "](" + markdownURL(src) + titlePart(attr(img, "title")) + ")"
+}
+
+// titlePart 链接 / 图片的 title 部分
+func titlePart(title string) string {
+	title = collapseSpace(title)
+	if title == "" {
+		return ""
+	}
+	return ` "` + strings.ReplaceAll(title, `"`, `\"`) + `"`
+}
+
+// markdownURL 转义 URL 中会破坏 Markdown 语法的字符
+func markdownURL(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(u)
+}
+
+// codeSpan 渲染行内代码（内容含反引号时使用更长的定界符）
+func codeSpan(text string) string {
+	text = collapseSpace(text)
+	if text == "" {
+		return ""
+	}
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+// wrapInline 用强调标记包裹行内内容
+//
+// 首尾空白移到标记外侧（「** 文字 **」不是合法的强调）。
+func wrapInline(text, marker string) string {
+	lead, body, trail := splitSpace(text)
+	if body == "" {
+		return text
+	}
+	return lead + marker + body + marker + trail
+}
+
+// splitSpace 拆分首尾空白
+func splitSpace(text string) (lead, body, trail string) {
+	body = strings.TrimLeftFunc(text, unicode.IsSpace)
+	lead = text[:len(text)-len(body)]
+	trimmed := strings.TrimRightFunc(body, unicode.IsSpace)
+	trail = body[len(trimmed):]
+	return lead, trimmed, trail
+}
+
+// markdownEscaper 行内需要转义的 Markdown 字符
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`,
+)
+
+// lineStartPattern 行首会被解析为块标记的文本（标题、引用、列表）
+var lineStartPattern = regexp.MustCompile(`(?m)^(\s*)(#{1,6}\s|>|[-+]\s|(\d+)([.)])\s)`)
+
+// escapeMarkdown 转义文本中的 Markdown 特殊字符
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// finishInline 完成一段行内内容：折叠空白、转义行首标记、处理硬换行
+func finishInline(text string) string {
+	text = collapseSpace(text)
+	text = strings.ReplaceAll(text, " "+hardBreak, hardBreak)
+	text = strings.ReplaceAll(text, hardBreak+" ", hardBreak)
+	text = strings.Trim(text, hardBreak+" ")
+	text = strings.ReplaceAll(text, hardBreak, "  \n")
+	return lineStartPattern.ReplaceAllStringFunc(text, func(m string) string {
+		sub := lineStartPattern.FindStringSubmatch(m)
+		if sub[3] != "" {
+			return sub[1] + sub[3] + `\` + sub[4] + " "
+		}
+		return sub[1] + `\` + sub[2]
+	})
+}
+
+// collapseSpace 折叠空白
+//
+// 与浏览器渲染一致，连续空白折叠为一个空格；但包含换行且两侧都是 CJK 字符的空白直接去除
+// （中文 HTML 源码常在句中换行，浏览器对此同样不显示空格）。
+func collapseSpace(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	var prev rune
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !unicode.IsSpace(r) {
+			b.WriteRune(r)
+			prev = r
+			i += size
+			continue
+		}
+
+		j := i
+		hasNewline := false
+		for j < len(text) {
+			r2, s2 := utf8.DecodeRuneInString(text[j:])
+			if !unicode.IsSpace(r2) {
+				break
+			}
+			if r2 == '\n' || r2 == '\r' {
+				hasNewline = true
+			}
+			j += s2
+		}
+		next, _ := utf8.DecodeRuneInString(text[j:])
+		if !(hasNewline && isCJK(prev) && isCJK(next)) {
+			b.WriteByte(' ')
+			prev = ' '
+		}
+		i = j
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// isCJK 判断是否为中日韩文字或全角标点
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
+
+// prefixLines 为每行添加前缀（空行使用 emptyPrefix）
+func prefixLines(text, prefix, emptyPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// blockElements 块级元素
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true, atom.Header: true,
+	atom.Footer: true, atom.Aside: true, atom.Nav: true, atom.H1: true, atom.H2: true, atom.H3: true,
+	atom.H4: true, atom.H5: true, atom.H6: true, atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true,
+	atom.Dt: true, atom.Dd: true, atom.Blockquote: true, atom.Pre: true, atom.Table: true, atom.Figure: true,
+	atom.Figcaption: true, atom.Hr: true, atom.Address: true, atom.Details: true, atom.Summary: true,
+	atom.Html: true, atom.Body: true, atom.Head: true, atom.Script: true, atom.Style: true,
+}
+
+// isBlockElement 判断是否为块级元素
+func isBlockElement(n *html.Node) bool {
+	return blockElements[n.DataAtom]
+}
+
+// onlyElementChild 返回唯一的元素子节点（忽略空白文本）
+func onlyElementChild(n *html.Node) *html.Node {
+	var found *html.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case html.ElementNode:
+			if found != nil {
+				return nil
+			}
+			found = child
+		case html.TextNode:
+			if strings.TrimSpace(child.Data) != "" {
+				return nil
+			}
+		}
+	}
+	return found
+}
+
+// attr 读取属性值
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// nodeText 节点的文本内容
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// rawText 代码块的原始文本（<br> 视为换行）
+func rawText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			b.WriteString(node.Data)
+		case node.Type == html.ElementNode && node.DataAtom == atom.Br:
+			b.WriteString("\n")
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package extractor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "标题与段落",
+			html: `<h2>Section <em>One</em></h2><p>Hello <strong>bold </strong>and <a href="https://example.com/a b">link</a>.</p>`,
+			want: "## Section *One*\n\nHello **bold** and [link](https://example.com/a%20b).\n",
+		},
+		{
+			name: "中文换行不产生空格",
+			html: "<p>这是第一行\n    第二行，English\n  words 混排</p>",
+			want: "这是第一行第二行，English words 混排\n",
+		},
+		{
+			name: "嵌套列表",
+			html: `<ul><li>One<ul><li>Nested</li></ul></li><li>Two</li></ul><ol start="3"><li>Three</li><li>Four</li></ol>`,
+			want: "- One\n  - Nested\n- Two\n\n3. Three\n4. Four\n",
+		},
+		{
+			name: "嵌套引用",
+			html: `<blockquote><p>Outer</p><blockquote><p>Inner</p></blockquote></blockquote>`,
+			want: "> Outer\n>\n> > Inner\n",
+		},
+		{
+			name: "带语言的代码块",
+			html: "<pre><code class=\"language-go\">func main() {\n\tfmt.Println(\"```\")\n}\n</code></pre>",
+			want: "````go\nfunc main() {\n\tfmt.Println(\"```\")\n}\n````\n",
+		},
+		{
+			name: "表格",
+			html: `<table><thead><tr><th>Name</th><th>Value</th></tr></thead><tbody><tr><td>a|b</td><td><code>1</code></td></tr></tbody></table>`,
+			want: "| Name | Value |\n| --- | --- |\n| a\\|b | `1` |\n",
+		},
+		{
+			name: "图片与说明",
+			html: `<figure><img src="/img/a.png" alt="示意图" title="T"><figcaption>图 1 说明</figcaption></figure>`,
+			want: "![示意图](/img/a.png \"T\")\n\n*图 1 说明*\n",
+		},
+		{
+			name: "脚注",
+			html: `<p>Claim<sup><a href="#fn1">1</a></sup>.</p><ol><li id="fn1"><p>Source text. <a href="#fnref1">↩</a></p></li></ol>`,
+			want: "Claim[^1].\n\n[^1]: Source text.\n",
+		},
+		{
+			name: "转义与硬换行",
+			html: `<p># not heading<br>a*b_c</p>`,
+			want: "\\# not heading  \na\\*b\\_c\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToMarkdown(tt.html); got != tt.want {
+				t.Errorf("HTMLToMarkdown() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	for input, want := range map[string]string{"": FormatHTML, "MD": FormatMarkdown, "text": FormatText} {
+		if got, ok := ParseOutputFormat(input); !ok || got != want {
+			t.Errorf("ParseOutputFormat(%q) = %q, %v", input, got, ok)
+		}
+	}
+	if _, ok := ParseOutputFormat("pdf"); ok {
+		t.Error("ParseOutputFormat(pdf) 应返回 false")
+	}
+	if !strings.HasSuffix(HTMLToMarkdown("<p>x</p>"), "\n") {
+		t.Error("输出应以换行结尾")
+	}
+}
@@ -0,0 +1,440 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现结构化元数据提取（OpenGraph、Twitter Card、article:*、Dublin Core、JSON-LD）
+
+package extractor
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Metadata 规范化的文章元数据
+//
+// 同一字段存在多个来源时按可信度取值：JSON-LD > OpenGraph / article:* > Twitter Card > Dublin Core > 通用 meta。
+type Metadata struct {
+	CanonicalURL  string   `json:"canonicalUrl,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	PublishedTime string   `json:"publishedTime,omitempty"` // 带时区时为 RFC3339，否则为原始值
+	ModifiedTime  string   `json:"modifiedTime,omitempty"`
+	Authors       []string `json:"authors,omitempty"`
+	Section       string   `json:"section,omitempty"`
+	Keywords      []string `json:"keywords,omitempty"`
+	Image         string   `json:"image,omitempty"` // 头图（绝对 URL）
+	Language      string   `json:"language,omitempty"`
+	Publisher     string   `json:"publisher,omitempty"`
+	Type          string   `json:"type,omitempty"` // JSON-LD @type 或 og:type
+}
+
+// ExtractMetadata 从 HTML 中提取结构化元数据
+func ExtractMetadata(html, pageURL string) *Metadata {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil
+	}
+	base, _ := url.Parse(pageURL)
+	return extractMetadata(doc, base)
+}
+
+// extractMetadata 在已解析的文档中提取元数据
+func extractMetadata(doc *goquery.Document, base *url.URL) *Metadata {
+	meta := collectMetaTags(doc)
+	ld := findArticleLD(doc)
+
+	m := &Metadata{}
+	m.Title = firstNonEmpty(ld.str("headline"), ld.str("name"), meta.get("og:title"), meta.get("twitter:title"), meta.get("dc.title"))
+	m.Description = firstNonEmpty(ld.str("description"), meta.get("og:description"), meta.get("twitter:description"),
+		meta.get("dc.description"), meta.get("description"))
+	m.Type = firstNonEmpty(ld.typeName(), meta.get("og:type"))
+
+	canonical, _ := doc.Find(`link[rel~="canonical"]`).First().Attr("href")
+	m.CanonicalURL = resolveMetaURL(base, firstNonEmpty(canonical, meta.get("og:url"), ld.ref("mainEntityOfPage"), ld.str("url")))
+
+	m.PublishedTime = normalizeMetaTime(firstNonEmpty(ld.str("datePublished"), meta.get("article:published_time"),
+		meta.get("og:published_time"), meta.get("datepublished"), meta.get("dcterms.created"), meta.get("dc.date.issued"),
+		meta.get("dc.date"), meta.get("pubdate"), meta.get("publishdate"), meta.get("parsely-pub-date"), meta.get("sailthru.date")))
+	m.ModifiedTime = normalizeMetaTime(firstNonEmpty(ld.str("dateModified"), meta.get("article:modified_time"),
+		meta.get("og:updated_time"), meta.get("datemodified"), meta.get("dcterms.modified"), meta.get("lastmod")))
+
+	// 作者：article:author 常为 Facebook 个人主页链接，需要过滤
+	authors := ld.names("author")
+	if len(authors) == 0 {
+		authors = withoutURLs(meta.all("article:author"))
+	}
+	if len(authors) == 0 {
+		authors = withoutURLs(append(meta.all("author"), meta.all("parsely-author")...))
+	}
+	if len(authors) == 0 {
+		authors = withoutURLs(append(meta.all("dc.creator"), meta.all("dcterms.creator")...))
+	}
+	if len(authors) == 0 {
+		authors = meta.all("twitter:creator")
+	}
+	m.Authors = uniqueStrings(authors)
+
+	m.Section = firstNonEmpty(ld.first("articleSection"), meta.get("article:section"), meta.get("section"), meta.get("parsely-section"))
+
+	var keywords []string
+	for _, group := range [][]string{ld.list("keywords"), meta.all("article:tag"), meta.all("news_keywords"),
+		meta.all("keywords"), meta.all("dc.subject")} {
+		if len(group) > 0 {
+			keywords = splitKeywords(group)
+			break
+		}
+	}
+	m.Keywords = keywords
+
+	m.Image = resolveMetaURL(base, firstNonEmpty(ld.imageURL(), meta.get("og:image:secure_url"), meta.get("og:image"),
+		meta.get("og:image:url"), meta.get("twitter:image"), meta.get("twitter:image:src"), doc.Find(`link[rel="image_src"]`).AttrOr("href", "")))
+
+	lang, _ := doc.Find("html").Attr("lang")
+	m.Language = normalizeLanguage(firstNonEmpty(lang, meta.get("content-language"), ld.str("inLanguage"),
+		meta.get("og:locale"), meta.get("dc.language")))
+
+	m.Publisher = firstNonEmpty(ld.publisher(), meta.get("og:site_name"), meta.get("dc.publisher"), meta.get("application-name"))
+
+	return m
+}
+
+// metaTags 页面 meta 标签（键统一小写，值保持出现顺序）
+type metaTags map[string][]string
+
+// collectMetaTags 收集 meta 标签
+//
+// 依次识别 property、name、itemprop、http-equiv 属性作为键。
+func collectMetaTags(doc *goquery.Document) metaTags {
+	tags := metaTags{}
+	doc.Find("meta").Each(func(_ int, s *goquery.Selection) {
+		content := strings.TrimSpace(s.AttrOr("content", ""))
+		if content == "" {
+			return
+		}
+		for _, attr := range []string{"property", "name", "itemprop", "http-equiv"} {
+			if key := strings.ToLower(strings.TrimSpace(s.AttrOr(attr, ""))); key != "" {
+				tags[key] = append(tags[key], content)
+			}
+		}
+	})
+	return tags
+}
+
+// get 返回第一个值
+func (t metaTags) get(key string) string {
+	if values := t[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// all 返回全部值
+func (t metaTags) all(key string) []string {
+	return t[key]
+}
+
+// ldNode JSON-LD 节点
+type ldNode map[string]interface{}
+
+// articleLD 文章 JSON-LD 节点及同文档中可按 @id 引用的节点
+type articleLD struct {
+	node ldNode
+	ids  map[string]ldNode
+}
+
+// findArticleLD 查找文章类型的 JSON-LD 节点
+//
+// 支持顶层数组和 @graph 容器；author、publisher 为 {"@id": ...} 引用时从同一文档中解析。
+func findArticleLD(doc *goquery.Document) articleLD {
+	var nodes []ldNode
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, s *goquery.Selection) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(s.Text())), &data); err != nil {
+			return
+		}
+		nodes = flattenLD(data, nodes)
+	})
+
+	ld := articleLD{ids: map[string]ldNode{}}
+	for _, node := range nodes {
+		if id, ok := node["@id"].(string); ok && id != "" {
+			ld.ids[id] = node
+		}
+	}
+	for _, node := range nodes {
+		if isArticleType(node["@type"]) {
+			ld.node = node
+			break
+		}
+	}
+	return ld
+}
+
+// flattenLD 展开数组和 @graph
+func flattenLD(data interface{}, nodes []ldNode) []ldNode {
+	switch v := data.(type) {
+	case []interface{}:
+		for _, item := range v {
+			nodes = flattenLD(item, nodes)
+		}
+	case map[string]interface{}:
+		nodes = append(nodes, ldNode(v))
+		if graph, ok := v["@graph"]; ok {
+			nodes = flattenLD(graph, nodes)
+		}
+	}
+	return nodes
+}
+
+// isArticleType 判断 @type 是否为文章类型（Article、NewsArticle、BlogPosting 等）
+func isArticleType(t interface{}) bool {
+	for _, name := range ldStrings(t) {
+		if strings.HasSuffix(name, "Article") || strings.HasSuffix(name, "BlogPosting") {
+			return true
+		}
+	}
+	return false
+}
+
+// str 返回字符串字段
+func (ld articleLD) str(key string) string {
+	if ld.node == nil {
+		return ""
+	}
+	s, _ := ld.node[key].(string)
+	return strings.TrimSpace(s)
+}
+
+// first 返回字符串或字符串数组字段的第一个值
+func (ld articleLD) first(key string) string {
+	if values := ld.list(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// list 返回字符串或字符串数组字段
+func (ld articleLD) list(key string) []string {
+	if ld.node == nil {
+		return nil
+	}
+	return ldStrings(ld.node[key])
+}
+
+// typeName 返回 @type（数组时取第一个文章类型）
+func (ld articleLD) typeName() string {
+	if ld.node == nil {
+		return ""
+	}
+	for _, name := range ldStrings(ld.node["@type"]) {
+		if isArticleType(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// ref 返回字符串或 {"@id": ...} / {"url": ...} 形式的 URL 字段
+func (ld articleLD) ref(key string) string {
+	if ld.node == nil {
+		return ""
+	}
+	switch v := ld.node[key].(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		return firstNonEmpty(ldString(v["@id"]), ldString(v["url"]))
+	}
+	return ""
+}
+
+// resolve 解析 @id 引用
+func (ld articleLD) resolve(v interface{}) interface{} {
+	if obj, ok := v.(map[string]interface{}); ok {
+		if id := ldString(obj["@id"]); id != "" && len(obj) <= 2 {
+			if target, ok := ld.ids[id]; ok {
+				return map[string]interface{}(target)
+			}
+		}
+	}
+	return v
+}
+
+// names 返回人物或组织字段的名称列表（author 可以是字符串、对象、数组或 @id 引用）
+func (ld articleLD) names(key string) []string {
+	if ld.node == nil {
+		return nil
+	}
+	items, ok := ld.node[key].([]interface{})
+	if !ok {
+		items = []interface{}{ld.node[key]}
+	}
+	var names []string
+	for _, item := range items {
+		switch v := ld.resolve(item).(type) {
+		case string:
+			if v = strings.TrimSpace(v); v != "" && !looksLikeURL(v) {
+				names = append(names, v)
+			}
+		case map[string]interface{}:
+			if name := strings.TrimSpace(ldString(v["name"])); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// publisher 返回出版方名称
+func (ld articleLD) publisher() string {
+	if names := ld.names("publisher"); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+// imageURL 返回头图 URL（image 可以是字符串、ImageObject 或数组）
+func (ld articleLD) imageURL() string {
+	if ld.node == nil {
+		return ""
+	}
+	v := ld.node["image"]
+	if items, ok := v.([]interface{}); ok {
+		if len(items) == 0 {
+			return ""
+		}
+		v = items[0]
+	}
+	switch img := ld.resolve(v).(type) {
+	case string:
+		return img
+	case map[string]interface{}:
+		return firstNonEmpty(ldString(img["url"]), ldString(img["contentUrl"]))
+	}
+	return ""
+}
+
+// ldString 将 JSON 值转为字符串（非字符串返回空）
+func ldString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// ldStrings 将字符串或字符串数组转为切片
+func ldStrings(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		if val = strings.TrimSpace(val); val != "" {
+			return []string{val}
+		}
+	case []interface{}:
+		var result []string
+		for _, item := range val {
+			if s := strings.TrimSpace(ldString(item)); s != "" {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// metaTimeLayouts 元数据中常见的时间格式
+var metaTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// normalizeMetaTime 将带时区的时间统一为 RFC3339，无法识别或不带时区的时间保留原值
+func normalizeMetaTime(value string) string {
+	value = strings.TrimSpace(value)
+	for _, layout := range metaTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return value
+}
+
+// normalizeLanguage 规范化语言标签（zh_CN → zh-CN）
+func normalizeLanguage(lang string) string {
+	lang = strings.TrimSpace(strings.Split(lang, ",")[0])
+	return strings.ReplaceAll(lang, "_", "-")
+}
+
+// splitKeywords 拆分关键词（支持中英文逗号、分号、顿号），去重并保持顺序
+func splitKeywords(values []string) []string {
+	var keywords []string
+	for _, value := range values {
+		for _, kw := range strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == '，' || r == ';' || r == '；' || r == '、'
+		}) {
+			if kw = strings.TrimSpace(kw); kw != "" {
+				keywords = append(keywords, kw)
+			}
+		}
+	}
+	return uniqueStrings(keywords)
+}
+
+// uniqueStrings 去重（不区分大小写）并保持顺序
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, v := range values {
+		key := strings.ToLower(v)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// withoutURLs 过滤掉 URL 形式的值
+func withoutURLs(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if !looksLikeURL(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// looksLikeURL 判断字符串是否为 URL
+func looksLikeURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "//")
+}
+
+// resolveMetaURL 将元数据中的 URL 转为绝对 URL
+func resolveMetaURL(base *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || base == nil {
+		return raw
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package extractor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected Metadata
+	}{
+		{
+			name: "JSON-LD @graph 与 @id 引用",
+			html: `<html lang="en-US"><head>
+				<link rel="canonical" href="/news/launch">
+				<meta property="og:title" content="OG Title">
+				<script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+					{"@type":"Organization","@id":"#org","name":"Example News"},
+					{"@type":"Person","@id":"#alice","name":"Alice Smith"},
+					{"@type":["NewsArticle"],"headline":"Rocket Launch","datePublished":"2024-03-01T08:00:00+08:00",
+					 "dateModified":"2024-03-02T10:00:00Z","author":[{"@id":"#alice"},{"@type":"Person","name":"Bob"}],
+					 "publisher":{"@id":"#org"},"articleSection":["Science"],"keywords":"space, rockets",
+					 "image":{"@type":"ImageObject","url":"/img/rocket.jpg"}}
+				]}</script>
+			</head><body></body></html>`,
+			expected: Metadata{
+				CanonicalURL:  "https://example.com/news/launch",
+				Title:         "Rocket Launch",
+				PublishedTime: "2024-03-01T08:00:00+08:00",
+				ModifiedTime:  "2024-03-02T10:00:00Z",
+				Authors:       []string{"Alice Smith", "Bob"},
+				Section:       "Science",
+				Keywords:      []string{"space", "rockets"},
+				Image:         "https://example.com/img/rocket.jpg",
+				Language:      "en-US",
+				Publisher:     "Example News",
+				Type:          "NewsArticle",
+			},
+		},
+		{
+			name: "OpenGraph + article:* 回退",
+			html: `<html><head>
+				<meta property="og:url" content="https://example.com/a/1">
+				<meta property="og:type" content="article">
+				<meta property="og:site_name" content="示例网">
+				<meta property="og:locale" content="zh_CN">
+				<meta property="og:image" content="https://cdn.example.com/1.jpg">
+				<meta property="article:published_time" content="2024-05-06T07:08:09Z">
+				<meta property="article:author" content="https://facebook.com/someone">
+				<meta property="article:section" content="科技">
+				<meta property="article:tag" content="人工智能">
+				<meta property="article:tag" content="芯片">
+				<meta name="author" content="张三">
+				<meta name="keywords" content="忽略，不使用">
+			</head></html>`,
+			expected: Metadata{
+				CanonicalURL:  "https://example.com/a/1",
+				PublishedTime: "2024-05-06T07:08:09Z",
+				Authors:       []string{"张三"},
+				Section:       "科技",
+				Keywords:      []string{"人工智能", "芯片"},
+				Image:         "https://cdn.example.com/1.jpg",
+				Language:      "zh-CN",
+				Publisher:     "示例网",
+				Type:          "article",
+			},
+		},
+		{
+			name: "Twitter Card + Dublin Core",
+			html: `<html><head>
+				<meta name="twitter:title" content="Card Title">
+				<meta name="twitter:image" content="/card.png">
+				<meta name="twitter:creator" content="@writer">
+				<meta name="DC.date" content="2024-01-02">
+				<meta name="DC.language" content="fr">
+				<meta name="DC.publisher" content="Le Journal">
+				<meta name="news_keywords" content="a; b; a">
+			</head></html>`,
+			expected: Metadata{
+				Title:         "Card Title",
+				PublishedTime: "2024-01-02",
+				Authors:       []string{"@writer"},
+				Keywords:      []string{"a", "b"},
+				Image:         "https://example.com/card.png",
+				Language:      "fr",
+				Publisher:     "Le Journal",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractMetadata(tt.html, "https://example.com/news/launch?utm=1")
+			if got == nil {
+				t.Fatal("ExtractMetadata() = nil")
+			}
+			if !reflect.DeepEqual(*got, tt.expected) {
+				t.Errorf("ExtractMetadata() =\n%+v\nwant\n%+v", *got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现多页文章拼接功能
+
+package extractor
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/newsflow/go-scraper-service/internal/processor"
+)
+
+const (
+	// DefaultMaxPages 未指定页数上限时的默认值
+	DefaultMaxPages = 5
+	// MaxPagesLimit 页数上限的硬性封顶，防止无限翻页
+	MaxPagesLimit = 20
+)
+
+// PageFetcher 后续分页抓取函数
+//
+// 由调用方（HTTP/gRPC 处理器）注入，保证后续分页与首页使用同一抓取器和请求选项。
+// 返回页面 HTML 和重定向后的最终 URL。
+type PageFetcher func(ctx context.Context, pageURL string) (html string, finalURL string, err error)
+
+// 分页 URL 识别规则
+//
+// 支持的分页形式：
+//   - 查询参数: ?page=2, &pg=2, ?pn=2 ...（不含 WordPress 的 ?p=文章ID）
+//   - 文件名后缀: 12345_2.html, index_3.shtml（国内门户常见）
+//   - 路径段: /page/2/（WordPress 等博客常见）
+var (
+	pageQueryKeys     = []string{"page", "pg", "pn", "paged", "pageno", "page_no", "pagenum", "pageindex"}
+	pageSuffixPattern = regexp.MustCompile(`^(.+?)_(\d{1,3})(\.[a-zA-Z]{2,5})?$`)
+	pageDirPattern    = regexp.MustCompile(`^(.*?)/page/(\d{1,3})/?$`)
+	// 「下一页」链接文本
+	nextTextPattern = regexp.MustCompile(`(?i)^\s*(下一页|下页|后一页|next|next\s+page|continue|›|»|>|>>)\s*[>»›]*\s*$`)
+)
+
+// paginationKey 计算分页无关的 URL 标识
+//
+// 去除分页标记后，同一文章的不同分页会得到相同的标识。
+// 返回标识和识别出的页码（无分页标记时页码为 1）。
+//
+// 示例：
+//
+//	https://a.com/news/123_2.html  → a.com/news/123.html?, 2
+//	https://a.com/post?id=9&page=3 → a.com/post?id=9, 3
+func paginationKey(u *url.URL) (string, int) {
+	k := *u
+	k.Fragment = ""
+	page := 1
+
+	// 查询参数中的页码
+	query := k.Query()
+	for key := range query {
+		for _, pageKey := range pageQueryKeys {
+			if strings.EqualFold(key, pageKey) {
+				if n, err := strconv.Atoi(query.Get(key)); err == nil {
+					page = n
+				}
+				query.Del(key)
+			}
+		}
+	}
+	k.RawQuery = query.Encode()
+
+	// 路径中的页码
+	if m := pageDirPattern.FindStringSubmatch(k.Path); m != nil {
+		page, _ = strconv.Atoi(m[2])
+		k.Path = m[1] + "/"
+	} else {
+		dir, file := splitPath(k.Path)
+		if m := pageSuffixPattern.FindStringSubmatch(file); m != nil {
+			page, _ = strconv.Atoi(m[2])
+			k.Path = dir + m[1] + m[3]
+		}
+	}
+
+	return strings.ToLower(k.Host) + k.Path + "?" + k.RawQuery, page
+}
+
+// splitPath 拆分目录和文件名
+func splitPath(p string) (string, string) {
+	idx := strings.LastIndex(p, "/")
+	return p[:idx+1], p[idx+1:]
+}
+
+// normalizePageURL 规范化 URL（去除 fragment），用于已访问判断
+func normalizePageURL(u *url.URL) string {
+	k := *u
+	k.Fragment = ""
+	return k.String()
+}
+
+// paginationTarget 首页的分页特征
+type paginationTarget struct {
+	// 首页去除分页标记后的标识
+	key string
+	// 首页原样标识（首页 URL 本身可能被误判为带页码，如 /a/b_12.html）
+	raw string
+}
+
+// newPaginationTarget 根据首页 URL 创建分页特征
+func newPaginationTarget(u *url.URL) paginationTarget {
+	key, _ := paginationKey(u)
+	k := *u
+	k.Fragment = ""
+	return paginationTarget{
+		key: key,
+		raw: strings.ToLower(k.Host) + k.Path + "?" + k.Query().Encode(),
+	}
+}
+
+// matches 判断候选 URL 是否为同一文章的分页
+func (t paginationTarget) matches(candidate *url.URL) bool {
+	key, page := paginationKey(candidate)
+	return page > 1 && (key == t.key || key == t.raw)
+}
+
+// FindNextPageURL 在页面中查找文章的下一页链接
+//
+// 查找顺序：
+//  1. <link rel="next"> / <a rel="next">
+//  2. 文本为「下一页」「Next」「»」等的链接
+//  3. 文本为下一页页码的数字链接（如当前第 2 页时查找「3」）
+//
+// 所有候选都必须是同一文章的分页（去除分页标记后 URL 一致），
+// 以避免把「下一篇文章」误判为下一页。
+//
+// 参数：
+//   - html: 当前页 HTML
+//   - pageURL: 当前页 URL
+//   - firstURL: 文章首页 URL
+//   - visited: 已抓取过的页面（规范化 URL），可为 nil
+//
+// 返回：
+//   - 下一页的绝对 URL，未找到时返回空字符串
+func FindNextPageURL(html, pageURL, firstURL string, visited map[string]bool) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+	first, err := url.Parse(firstURL)
+	if err != nil {
+		return ""
+	}
+	return findNextPage(doc, base, newPaginationTarget(first), visited)
+}
+
+// findNextPage 在已解析的文档中查找下一页
+func findNextPage(doc *goquery.Document, base *url.URL, target paginationTarget, visited map[string]bool) string {
+	_, currentPage := paginationKey(base)
+	if !target.matches(base) {
+		currentPage = 1
+	}
+
+	accept := func(href string) (string, bool) {
+		href = strings.TrimSpace(href)
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return "", false
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			return "", false
+		}
+		abs := base.ResolveReference(ref)
+		if abs.Scheme != "http" && abs.Scheme != "https" {
+			return "", false
+		}
+		if !target.matches(abs) {
+			return "", false
+		}
+		normalized := normalizePageURL(abs)
+		if visited[normalized] || normalized == normalizePageURL(base) {
+			return "", false
+		}
+		return normalized, true
+	}
+
+	// 1. rel="next"
+	var found string
+	doc.Find(`link[rel~="next"], a[rel~="next"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if u, ok := accept(s.AttrOr("href", "")); ok {
+			found = u
+			return false
+		}
+		return true
+	})
+	if found != "" {
+		return found
+	}
+
+	// 2. 「下一页」文本链接
+	doc.Find("a[href]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			text = s.AttrOr("title", "")
+		}
+		if !nextTextPattern.MatchString(text) {
+			return true
+		}
+		if u, ok := accept(s.AttrOr("href", "")); ok {
+			found = u
+			return false
+		}
+		return true
+	})
+	if found != "" {
+		return found
+	}
+
+	// 3. 数字页码链接
+	nextNumber := strconv.Itoa(currentPage + 1)
+	doc.Find("a[href]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if strings.Trim(strings.TrimSpace(s.Text()), "[]") != nextNumber {
+			return true
+		}
+		if u, ok := accept(s.AttrOr("href", "")); ok {
+			found = u
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// dedupBlockSelector 参与重复段落判断的块级元素
+const dedupBlockSelector = "p, h1, h2, h3, h4, h5, h6, li, blockquote, pre, figcaption"
+
+// paragraphDeduper 跨页重复段落过滤器
+//
+// 分页文章的每一页通常会重复标题、导语、版权声明、「责任编辑」等段落，
+// 拼接时按规范化文本去重，同一图片也只保留一次。
+type paragraphDeduper struct {
+	seenText  map[string]bool
+	seenImage map[string]bool
+}
+
+func newParagraphDeduper() *paragraphDeduper {
+	return &paragraphDeduper{
+		seenText:  make(map[string]bool),
+		seenImage: make(map[string]bool),
+	}
+}
+
+// normalizeParagraph 规范化段落文本（合并空白）
+func normalizeParagraph(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// filter 过滤 HTML 中已出现过的段落和图片
+//
+// 返回过滤后的 HTML 和纯文本。首页调用时只记录不删除（没有已出现内容）。
+func (d *paragraphDeduper) filter(html string) (string, string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return html, ""
+	}
+
+	doc.Find(dedupBlockSelector).Each(func(i int, s *goquery.Selection) {
+		text := normalizeParagraph(s.Text())
+		if text == "" {
+			return
+		}
+		if d.seenText[text] {
+			s.Remove()
+			return
+		}
+		d.seenText[text] = true
+	})
+
+	doc.Find("img").Each(func(i int, s *goquery.Selection) {
+		src := s.AttrOr("src", "")
+		if src == "" {
+			return
+		}
+		if d.seenImage[src] {
+			s.Remove()
+			return
+		}
+		d.seenImage[src] = true
+	})
+
+	body := doc.Find("body")
+	result, _ := body.Html()
+	return result, strings.TrimSpace(body.Text())
+}
+
+// nextPage 后续分页的提取结果
+type nextPage struct {
+	// 已去重、已完成图片处理的 HTML（尚未净化）
+	content     string
+	textContent string
+	images      []processor.Image
+}
+
+// fetchNextPages 依次抓取并提取文章的后续分页
+//
+// 参数：
+//   - firstHTML: 首页预处理后的 HTML（用于查找下一页链接）
+//   - firstURL: 首页 URL
+//   - firstContent: 首页提取的正文（用于初始化段落去重）
+//
+// 每一页与首页一样经过多引擎择优（含站点规则的正文选择器和 strip），
+// 任何一页抓取或提取失败、被重定向到非本文分页的地址或去重后没有新内容时停止翻页，返回已成功的分页，
+// 保证首页内容不会因为分页失败而丢失。
+func (e *Extractor) fetchNextPages(ctx context.Context, firstHTML string, firstURL *url.URL, firstContent string, opts ExtractOptions) []nextPage {
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	if maxPages > MaxPagesLimit {
+		maxPages = MaxPagesLimit
+	}
+
+	target := newPaginationTarget(firstURL)
+	visited := map[string]bool{normalizePageURL(firstURL): true}
+
+	deduper := newParagraphDeduper()
+	deduper.filter(firstContent)
+
+	var pages []nextPage
+	currentHTML := firstHTML
+	currentURL := firstURL
+	for len(pages)+1 < maxPages && ctx.Err() == nil {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(currentHTML))
+		if err != nil {
+			break
+		}
+		nextURL := findNextPage(doc, currentURL, target, visited)
+		if nextURL == "" {
+			break
+		}
+		visited[nextURL] = true
+
+		html, finalURL, err := opts.PageFetcher(ctx, nextURL)
+		if err != nil {
+			log.Printf("[Pagination] 抓取分页失败 %s: %v", nextURL, err)
+			break
+		}
+		if finalURL == "" {
+			finalURL = nextURL
+		}
+		parsedFinal, err := url.Parse(finalURL)
+		if err != nil {
+			break
+		}
+		visited[normalizePageURL(parsedFinal)] = true
+		// 重定向到登录页、付费墙或首页等非本文分页的地址
+		if !target.matches(parsedFinal) {
+			log.Printf("[Pagination] 分页被重定向到其他页面 %s -> %s", nextURL, finalURL)
+			break
+		}
+
+		preprocessed := markEmbeds(e.preprocess(html), parsedFinal)
+		selection, err := e.runEngines(preprocessed, parsedFinal)
+		if err != nil {
+			log.Printf("[Pagination] 提取分页失败 %s: %v", finalURL, err)
+			break
+		}
+
+		pageHTML, pageText := deduper.filter(selection.best.Content)
+		// 去重后没有新的文字和图片（站点对超出范围的页码返回了已抓取的内容）
+		if strings.TrimSpace(pageText) == "" && !strings.Contains(pageHTML, "<img") {
+			break
+		}
+		processedHTML, images := e.imageProcessor.ProcessImages(pageHTML, parsedFinal)
+		pages = append(pages, nextPage{
+			content:     processedHTML,
+			textContent: pageText,
+			images:      images,
+		})
+
+		currentHTML = preprocessed
+		currentURL = parsedFinal
+	}
+
+	return pages
+}
+
+// appendUniqueImages 合并图片列表（按原始 URL 去重）
+func appendUniqueImages(images []processor.Image, more []processor.Image) []processor.Image {
+	seen := make(map[string]bool, len(images))
+	for _, img := range images {
+		seen[img.OriginalURL] = true
+	}
+	for _, img := range more {
+		if !seen[img.OriginalURL] {
+			seen[img.OriginalURL] = true
+			images = append(images, img)
+		}
+	}
+	return images
+}
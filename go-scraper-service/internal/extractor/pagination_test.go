@@ -0,0 +1,227 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFindNextPageURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		pageURL  string
+		firstURL string
+		expected string
+	}{
+		{
+			name:     "rel=next 链接",
+			html:     `<html><head><link rel="next" href="/news/123_2.html"></head><body></body></html>`,
+			pageURL:  "https://example.com/news/123.html",
+			firstURL: "https://example.com/news/123.html",
+			expected: "https://example.com/news/123_2.html",
+		},
+		{
+			name:     "下一页文本链接（查询参数）",
+			html:     `<div class="pages"><a href="?id=9&page=1">1</a><a href="?id=9&page=3">下一页</a></div>`,
+			pageURL:  "https://example.com/article?id=9&page=2",
+			firstURL: "https://example.com/article?id=9",
+			expected: "https://example.com/article?id=9&page=3",
+		},
+		{
+			name:     "数字页码链接",
+			html:     `<div class="pager"><span>1</span><a href="/2026/03/post/page/2/">2</a><a href="/2026/03/post/page/3/">3</a></div>`,
+			pageURL:  "https://example.com/2026/03/post/",
+			firstURL: "https://example.com/2026/03/post/",
+			expected: "https://example.com/2026/03/post/page/2/",
+		},
+		{
+			name:     "rel=next 指向下一篇文章时忽略",
+			html:     `<a rel="next" href="/news/124.html">下一篇：另一篇文章</a>`,
+			pageURL:  "https://example.com/news/123.html",
+			firstURL: "https://example.com/news/123.html",
+			expected: "",
+		},
+		{
+			name:     "WordPress ?p= 文章 ID 不视为分页",
+			html:     `<a href="/?p=124">Next</a>`,
+			pageURL:  "https://example.com/?p=123",
+			firstURL: "https://example.com/?p=123",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FindNextPageURL(tt.html, tt.pageURL, tt.firstURL, nil)
+			if result != tt.expected {
+				t.Errorf("FindNextPageURL() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// paginatedArticle 生成测试用的分页文章
+func paginatedArticle(page int, next string, paragraphs ...string) string {
+	var b strings.Builder
+	b.WriteString(`<html><head><title>分页测试文章</title></head><body>`)
+	b.WriteString(`<nav><a href="/">首页</a><a href="/news/">新闻</a></nav>`)
+	b.WriteString(`<article><h1>分页测试文章</h1>`)
+	b.WriteString(`<p>本文来源：测试通讯社。这是每一页都会重复出现的导语段落，用于验证跨页重复段落会被去除。</p>`)
+	for _, p := range paragraphs {
+		b.WriteString("<p>" + p + "</p>")
+	}
+	b.WriteString(`</article>`)
+	if next != "" {
+		b.WriteString(fmt.Sprintf(`<div class="pages"><span>%d</span><a href="%s">下一页</a></div>`, page, next))
+	}
+	b.WriteString(`</body></html>`)
+	return b.String()
+}
+
+func TestExtractWithPagination(t *testing.T) {
+	long := strings.Repeat("这是一段足够长的正文内容，用于让 Readability 识别文章主体。", 6)
+	pages := map[string]string{
+		"https://example.com/news/123_2.html": paginatedArticle(2, "/news/123_3.html", "第二页独有段落。"+long),
+		"https://example.com/news/123_3.html": paginatedArticle(3, "", "第三页独有段落。"+long),
+	}
+	first := paginatedArticle(1, "/news/123_2.html", "第一页独有段落。"+long)
+
+	var fetched []string
+	opts := ExtractOptions{
+		FollowPagination: true,
+		MaxPages:         5,
+		PageFetcher: func(ctx context.Context, pageURL string) (string, string, error) {
+			fetched = append(fetched, pageURL)
+			html, ok := pages[pageURL]
+			if !ok {
+				return "", "", fmt.Errorf("unexpected page %s", pageURL)
+			}
+			return html, pageURL, nil
+		},
+	}
+
+	result, err := New().ExtractWithOptions(context.Background(), first, "https://example.com/news/123.html", opts)
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+
+	if result.Pages != 3 {
+		t.Errorf("Pages = %d, want 3 (fetched %v)", result.Pages, fetched)
+	}
+	for _, want := range []string{"第一页独有段落", "第二页独有段落", "第三页独有段落"} {
+		if !strings.Contains(result.TextContent, want) {
+			t.Errorf("TextContent 缺少 %q", want)
+		}
+	}
+	if n := strings.Count(result.Content, "每一页都会重复出现的导语段落"); n != 1 {
+		t.Errorf("重复导语段落出现 %d 次, want 1", n)
+	}
+
+	// 页数上限
+	fetched = nil
+	opts.MaxPages = 2
+	result, err = New().ExtractWithOptions(context.Background(), first, "https://example.com/news/123.html", opts)
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	if result.Pages != 2 || len(fetched) != 1 {
+		t.Errorf("MaxPages=2: Pages = %d, fetched = %v", result.Pages, fetched)
+	}
+}
+
+func TestExtractWithPaginationSiteRule(t *testing.T) {
+	long := strings.Repeat("这是一段足够长的正文内容，用于让 Readability 识别文章主体。", 6)
+	promo := `<p class="promo">` + strings.Repeat("订阅会员即可畅读全部付费文章，首月仅需一元，立即开通享受更多权益。", 4) + `</p>`
+	withPromo := func(page int, next, paragraph string) string {
+		return strings.Replace(paginatedArticle(page, next, paragraph), "</article>", promo+"</article>", 1)
+	}
+	pages := map[string]string{
+		"https://example.com/news/123_2.html": withPromo(2, "", "第二页独有段落。"+long),
+	}
+	first := withPromo(1, "/news/123_2.html", "第一页独有段落。"+long)
+
+	rules, err := NewSiteRules([]*SiteRule{{
+		Name:         "example-news",
+		Domains:      []string{"example.com"},
+		PathPatterns: []string{`^/news/`},
+		Content:      "article",
+		Strip:        []string{".promo"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := New()
+	e.SetSiteRules(rules)
+
+	result, err := e.ExtractWithOptions(context.Background(), first, "https://example.com/news/123.html", ExtractOptions{
+		FollowPagination: true,
+		PageFetcher: func(ctx context.Context, pageURL string) (string, string, error) {
+			html, ok := pages[pageURL]
+			if !ok {
+				return "", "", fmt.Errorf("unexpected page %s", pageURL)
+			}
+			return html, pageURL, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	if result.Pages != 2 || !strings.Contains(result.TextContent, "第二页独有段落") {
+		t.Fatalf("Pages = %d, TextContent = %q", result.Pages, result.TextContent)
+	}
+	if strings.Contains(result.Content, "订阅会员") || strings.Contains(result.TextContent, "订阅会员") {
+		t.Errorf("分页内容未按站点规则去除 strip 元素:\n%s", result.Content)
+	}
+}
+
+func TestExtractWithPaginationStops(t *testing.T) {
+	long := strings.Repeat("这是一段足够长的正文内容，用于让 Readability 识别文章主体。", 6)
+	first := paginatedArticle(1, "/news/123_2.html", "第一页独有段落。"+long)
+	login := `<html><body><article><h1>用户登录</h1><p>` + strings.Repeat("请登录后继续阅读全文，登录即表示同意用户协议和隐私政策。", 6) + `</p></article></body></html>`
+
+	tests := []struct {
+		name  string
+		pages map[string][2]string // URL -> {HTML, 最终 URL}
+	}{
+		{
+			name: "分页被重定向到登录页",
+			pages: map[string][2]string{
+				"https://example.com/news/123_2.html": {login, "https://example.com/login?next=%2Fnews%2F123_2.html"},
+			},
+		},
+		{
+			name: "去重后没有新内容",
+			pages: map[string][2]string{
+				"https://example.com/news/123_2.html": {paginatedArticle(2, "/news/123_3.html", "第一页独有段落。"+long), "https://example.com/news/123_2.html"},
+				"https://example.com/news/123_3.html": {paginatedArticle(3, "", "第三页独有段落。"+long), "https://example.com/news/123_3.html"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			result, err := New().ExtractWithOptions(context.Background(), first, "https://example.com/news/123.html", ExtractOptions{
+				FollowPagination: true,
+				PageFetcher: func(ctx context.Context, pageURL string) (string, string, error) {
+					fetched = append(fetched, pageURL)
+					page, ok := tt.pages[pageURL]
+					if !ok {
+						return "", "", fmt.Errorf("unexpected page %s", pageURL)
+					}
+					return page[0], page[1], nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("ExtractWithOptions() error = %v", err)
+			}
+			if result.Pages != 1 || len(fetched) != 1 {
+				t.Errorf("Pages = %d, fetched = %v, want 1 page", result.Pages, fetched)
+			}
+			if strings.Contains(result.TextContent, "请登录") || strings.Contains(result.TextContent, "第三页独有段落") {
+				t.Errorf("TextContent = %q", result.TextContent)
+			}
+		})
+	}
+}
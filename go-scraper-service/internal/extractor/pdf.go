@@ -0,0 +1,197 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现 PDF 文档的内容类型识别和提取（纯 Go 解析，见 internal/pdf）
+
+package extractor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/pdf"
+)
+
+// DocumentFormatPDF PDF 文档
+const DocumentFormatPDF = "pdf"
+
+// PageTextSeparator 纯文本正文中的分页符（换页符，与 pdftotext 一致）
+const PageTextSeparator = "\n\f\n"
+
+// maxPDFSize 提取的 PDF 最大字节数
+const maxPDFSize = 64 << 20
+
+// ErrNoDocumentText 文档没有可提取的文本（扫描件、纯图片或字体缺少 Unicode 映射）
+var ErrNoDocumentText = errors.New("document has no extractable text")
+
+// DocumentInfo 文档（非网页）信息
+type DocumentInfo struct {
+	Format    string `json:"format"`    // pdf
+	PageCount int    `json:"pageCount"` // 文档总页数
+	Author    string `json:"author,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+	Creator   string `json:"creator,omitempty"`  // 创建文档的应用
+	Producer  string `json:"producer,omitempty"` // 生成 PDF 的应用
+}
+
+// IsPDF 判断响应是否为 PDF：Content-Type 为 application/pdf，或内容以 %PDF- 开头
+func IsPDF(contentType, body string) bool {
+	return isPDFContentType(contentType) || strings.HasPrefix(strings.TrimLeft(body[:min(len(body), 1024)], " \t\r\n\ufeff"), "%PDF-")
+}
+
+func isPDFContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(mediaType) {
+	case "application/pdf", "application/x-pdf", "application/acrobat":
+		return true
+	}
+	return false
+}
+
+// decodePDFBody 还原响应体中的 PDF 字节
+//
+// 标准客户端返回原始字节（服务端未按 Accept-Encoding 解压时为 gzip 压缩数据），
+// CycleTLS 对 application/pdf 响应体做了 base64 编码。
+func decodePDFBody(body string) ([]byte, bool) {
+	data := []byte(body)
+	if bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return data, true
+	}
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		if zr, err := gzip.NewReader(bytes.NewReader(data)); err == nil {
+			if raw, err := io.ReadAll(io.LimitReader(zr, maxPDFSize+1)); err == nil && len(raw) <= maxPDFSize {
+				return decodePDFBody(string(raw))
+			}
+		}
+		return nil, false
+	}
+	compact := strings.Join(strings.Fields(body), "")
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding} {
+		if raw, err := enc.DecodeString(compact); err == nil && bytes.HasPrefix(raw, []byte("%PDF-")) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// extractPDF 提取 PDF 文档：标题、分页纯文本、简单 HTML（标题和段落，页之间以 <hr> 分隔）和页数
+func (e *Extractor) extractPDF(body, pageURL string, opts ExtractOptions) (*ExtractResult, error) {
+	if len(body) > maxPDFSize {
+		return nil, fmt.Errorf("pdf extraction failed: document is larger than %d MB", maxPDFSize>>20)
+	}
+	data, ok := decodePDFBody(body)
+	if !ok {
+		return nil, fmt.Errorf("pdf extraction failed: %w", pdf.ErrNotPDF)
+	}
+	doc, err := pdf.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("pdf extraction failed: %w", err)
+	}
+
+	var content strings.Builder
+	pageTexts := make([]string, 0, len(doc.Pages))
+	for _, page := range doc.Pages {
+		text := page.Text()
+		if text == "" {
+			continue
+		}
+		if content.Len() > 0 {
+			content.WriteString("<hr/>\n")
+		}
+		for _, block := range page.Blocks {
+			tag := "p"
+			if block.Heading {
+				tag = "h2"
+			}
+			fmt.Fprintf(&content, "<%s>%s</%s>\n", tag, html.EscapeString(block.Text), tag)
+		}
+		pageTexts = append(pageTexts, text)
+	}
+	if len(pageTexts) == 0 {
+		return nil, ErrNoDocumentText
+	}
+	textContent := strings.Join(pageTexts, PageTextSeparator)
+
+	profile, ok := e.SanitizeProfile(opts.SanitizeProfile)
+	if !ok {
+		profile, _ = e.SanitizeProfile(ProfileReader)
+	}
+
+	title := doc.Title()
+	if title == "" {
+		title = documentFileName(pageURL)
+	}
+	metadata := &Metadata{Title: doc.Info.Title, Type: DocumentFormatPDF}
+	if doc.Info.Author != "" {
+		metadata.Authors = []string{doc.Info.Author}
+	}
+	for _, kw := range strings.FieldsFunc(doc.Info.Keywords, func(r rune) bool { return r == ',' || r == ';' || r == '，' || r == '；' }) {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			metadata.Keywords = append(metadata.Keywords, kw)
+		}
+	}
+	signal := "magic:%PDF-"
+	if isPDFContentType(opts.ContentType) {
+		signal = "contentType:application/pdf"
+	}
+	var published *DateResult
+	if !doc.Info.Created.IsZero() {
+		metadata.PublishedTime = doc.Info.Created.Format(time.RFC3339)
+		published = &DateResult{Time: doc.Info.Created, Confidence: dateSourceConfidence[DateSourcePDF], Source: DateSourcePDF}
+	}
+	if !doc.Info.Modified.IsZero() {
+		metadata.ModifiedTime = doc.Info.Modified.Format(time.RFC3339)
+	}
+
+	return &ExtractResult{
+		Content:       profile.Sanitize(content.String()),
+		TextContent:   textContent,
+		Title:         title,
+		Excerpt:       excerptOf(strings.ReplaceAll(textContent, "\f", "")),
+		Byline:        doc.Info.Author,
+		ReadingTime:   calculateReadingTime(textContent),
+		Pages:         doc.PageCount,
+		Variant:       VariantCanonical,
+		Metadata:      metadata,
+		PublishedDate: published,
+		Engine:        EnginePDF,
+		PageType:      &PageClass{Type: PageTypeDocument, Confidence: 1, Signals: []string{signal}},
+		Fingerprint:   ComputeFingerprint(textContent),
+		Document: &DocumentInfo{
+			Format:    DocumentFormatPDF,
+			PageCount: doc.PageCount,
+			Author:    doc.Info.Author,
+			Subject:   doc.Info.Subject,
+			Creator:   doc.Info.Creator,
+			Producer:  doc.Info.Producer,
+		},
+	}, nil
+}
+
+// documentFileName 以 URL 中的文件名（去掉扩展名）作为标题的兜底
+func documentFileName(pageURL string) string {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return ""
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.TrimSpace(strings.TrimSuffix(name, path.Ext(name)))
+}
@@ -0,0 +1,160 @@
+package extractor
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildTestPDF 构造两页的 PDF（对象通过扫描定位，不需要 xref 表）
+func buildTestPDF(title string) string {
+	page1 := "BT /F1 20 Tf 72 720 Td (Quarterly Outlook) Tj ET\n" +
+		"BT /F1 11 Tf 72 690 Td 14 TL (Demand recovered across all regions during the quarter.) Tj T* T*\n" +
+		"(Costs <fell> as supply chains normalised.) Tj ET"
+	page2 := "BT /F1 11 Tf 72 700 Td (Outlook for next year remains stable.) Tj ET"
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(page1), page1),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(page2), page2),
+		fmt.Sprintf("<< /Title (%s) /Author (Research Desk) /Keywords (macro, outlook) /CreationDate (D:20240102) >>", title),
+	}
+	var sb strings.Builder
+	sb.WriteString("%PDF-1.4\n")
+	for i, obj := range objs {
+		fmt.Fprintf(&sb, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	sb.WriteString("trailer\n<< /Root 1 0 R /Info 8 0 R >>\n%%EOF\n")
+	return sb.String()
+}
+
+func TestExtractPDF(t *testing.T) {
+	e := New()
+	raw := buildTestPDF("Q3 Outlook Report")
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		pageURL     string
+		format      string
+		title       string
+		wantContent []string
+	}{
+		{
+			name:        "按 Content-Type 识别",
+			body:        raw,
+			contentType: "application/pdf",
+			title:       "Q3 Outlook Report",
+			wantContent: []string{"<h2>Quarterly Outlook</h2>", "<p>Costs &lt;fell&gt; as supply chains normalised.</p>", "<hr/>"},
+		},
+		{
+			name:        "CycleTLS 返回的 base64 响应体",
+			body:        base64.StdEncoding.EncodeToString([]byte(raw)),
+			contentType: "application/pdf; qs=0.001",
+			title:       "Q3 Outlook Report",
+		},
+		{
+			name:        "按内容识别",
+			body:        raw,
+			contentType: "application/octet-stream",
+			title:       "Q3 Outlook Report",
+		},
+		{
+			name:        "元数据标题无效时使用首个标题块",
+			body:        buildTestPDF("untitled"),
+			contentType: "application/pdf",
+			title:       "Quarterly Outlook",
+		},
+		{
+			name:        "Markdown 输出",
+			body:        raw,
+			contentType: "application/pdf",
+			format:      FormatMarkdown,
+			title:       "Q3 Outlook Report",
+			wantContent: []string{"## Quarterly Outlook", "Demand recovered across all regions during the quarter."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pageURL := tt.pageURL
+			if pageURL == "" {
+				pageURL = "https://example.com/reports/q3.pdf"
+			}
+			result, err := e.ExtractWithOptions(context.Background(), tt.body, pageURL, ExtractOptions{
+				ContentType:  tt.contentType,
+				OutputFormat: tt.format,
+				// 文档不按文章判定
+				RequireArticle: true,
+			})
+			if err != nil {
+				t.Fatalf("ExtractWithOptions() error = %v", err)
+			}
+			if result.Title != tt.title {
+				t.Errorf("Title = %q, want %q", result.Title, tt.title)
+			}
+			if result.PageType == nil || result.PageType.Type != PageTypeDocument || result.Engine != EnginePDF {
+				t.Errorf("PageType = %+v, Engine = %q", result.PageType, result.Engine)
+			}
+			if result.Pages != 2 || result.Document == nil || result.Document.PageCount != 2 || result.Document.Format != DocumentFormatPDF {
+				t.Errorf("Pages = %d, Document = %+v", result.Pages, result.Document)
+			}
+			pages := strings.Split(result.TextContent, PageTextSeparator)
+			if len(pages) != 2 || pages[1] != "Outlook for next year remains stable." {
+				t.Errorf("TextContent = %q", result.TextContent)
+			}
+			for _, want := range tt.wantContent {
+				if !strings.Contains(result.Content, want) {
+					t.Errorf("Content 缺少 %q:\n%s", want, result.Content)
+				}
+			}
+			if result.Byline != "Research Desk" || result.PublishedDate == nil || result.PublishedDate.Source != DateSourcePDF ||
+				result.Metadata == nil || strings.Join(result.Metadata.Keywords, "|") != "macro|outlook" {
+				t.Errorf("Byline = %q, PublishedDate = %+v, Metadata = %+v", result.Byline, result.PublishedDate, result.Metadata)
+			}
+		})
+	}
+
+	t.Run("不是 PDF 的响应体", func(t *testing.T) {
+		_, err := e.ExtractWithOptions(context.Background(), "<html><body>Access denied</body></html>",
+			"https://example.com/a.pdf", ExtractOptions{ContentType: "application/pdf"})
+		if err == nil || !strings.Contains(err.Error(), "pdf extraction failed") {
+			t.Errorf("error = %v", err)
+		}
+	})
+
+	t.Run("没有文本的文档", func(t *testing.T) {
+		body := strings.Replace(raw, "/BaseFont /Helvetica", "/BaseFont /Helvetica /Encoding << /Differences [0 /g1] >> /Subtype /Type0", 1)
+		_, err := e.ExtractWithOptions(context.Background(), body, "https://example.com/a.pdf", ExtractOptions{})
+		if !errors.Is(err, ErrNoDocumentText) {
+			t.Errorf("error = %v, want ErrNoDocumentText", err)
+		}
+	})
+}
+
+func TestIsPDF(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        bool
+	}{
+		{"application/pdf", "application/pdf", "", true},
+		{"大小写和参数", "Application/PDF; charset=binary", "", true},
+		{"内容以 %PDF- 开头", "", "%PDF-1.7\n", true},
+		{"HTML", "text/html; charset=utf-8", "<!DOCTYPE html><html>%PDF-</html>", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPDF(tt.contentType, tt.body); got != tt.want {
+				t.Errorf("IsPDF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
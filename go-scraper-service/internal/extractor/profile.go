@@ -0,0 +1,360 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现命名净化配置（sanitize profile），按使用场景选择不同严格程度的净化策略
+
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/microcosm-cc/bluemonday"
+	"gopkg.in/yaml.v3"
+)
+
+// 内置净化配置
+const (
+	// ProfileReader 阅读视图（默认）：保留图片、嵌入媒体和代码语言标记
+	ProfileReader = "reader"
+	// ProfileEmailSafe 邮件摘要：不允许 data: URI 和 iframe / 音视频，
+	// 图片只保留绝对 http(s) 地址的 src、alt、width、height，嵌入媒体渲染为占位图
+	ProfileEmailSafe = "email-safe"
+	// ProfileStrictText AI 处理：只保留文本结构、链接和表格，移除图片和媒体
+	ProfileStrictText = "strict-text"
+	// ProfileArchive 归档：在 reader 的基础上保留所有元素的 id 和 class
+	ProfileArchive = "archive"
+)
+
+// builtinProfiles 内置净化配置的构造函数（按名称）
+var builtinProfiles = map[string]func() *Sanitizer{
+	ProfileReader:     NewSanitizer,
+	ProfileEmailSafe:  newEmailSafeSanitizer,
+	ProfileStrictText: newStrictTextSanitizer,
+	ProfileArchive:    newArchiveSanitizer,
+}
+
+// builtinProfileNames 内置净化配置名称（按展示顺序）
+var builtinProfileNames = []string{ProfileReader, ProfileEmailSafe, ProfileStrictText, ProfileArchive}
+
+// builtinEmbedModes 内置净化配置强制的嵌入媒体渲染方式（不支持 iframe 的配置渲染为占位图）
+var builtinEmbedModes = map[string]string{
+	ProfileEmailSafe:  EmbedModePlaceholder,
+	ProfileStrictText: EmbedModePlaceholder,
+}
+
+// profileNamePattern 自定义配置名称
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// unsafeProfileElements 自定义配置不允许放开的元素
+//
+// 脚本和样式由 bluemonday 始终拒绝；iframe 等嵌入元素只能通过嵌入媒体流程（embed.go）生成。
+var unsafeProfileElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "base": true, "meta": true, "link": true,
+	"form": true, "input": true, "button": true, "textarea": true, "select": true,
+	"svg": true, "math": true, "noscript": true, "template": true,
+}
+
+// unsafeProfileAttrs 自定义配置不允许放开的属性（另外拒绝所有 on* 事件属性）
+var unsafeProfileAttrs = map[string]bool{
+	"style": true, "srcdoc": true, "formaction": true, "action": true, "xmlns": true,
+}
+
+// SanitizeProfile 命名净化配置
+type SanitizeProfile struct {
+	// Name 配置名称
+	Name string
+	// EmbedMode 强制的嵌入媒体渲染方式，为空时由请求决定
+	EmbedMode string
+
+	sanitizer *Sanitizer
+}
+
+// Sanitize 按配置净化 HTML
+func (p *SanitizeProfile) Sanitize(html string) string {
+	return p.sanitizer.Sanitize(html)
+}
+
+// SanitizeProfileConfig 自定义净化配置
+//
+// 在内置配置（base，默认 reader）的基础上放开额外的元素和属性，
+// 并可在净化前连同内容移除指定元素。
+//
+// 示例（YAML）：
+//
+//	profiles:
+//	  - name: ai-images
+//	    base: strict-text
+//	    allowElements: [img]
+//	    allowAttrs:
+//	      - attrs: [src, alt]
+//	        elements: [img]
+//	    strip: [table, .footnotes]
+//	    embedMode: placeholder
+type SanitizeProfileConfig struct {
+	// 配置名称（小写字母、数字、- 和 _，不能与内置配置重名）
+	Name string `json:"name" yaml:"name"`
+	// 基础配置（内置配置名称），为空时为 reader
+	Base string `json:"base,omitempty" yaml:"base,omitempty"`
+	// 额外允许的元素
+	AllowElements []string `json:"allowElements,omitempty" yaml:"allowElements,omitempty"`
+	// 额外允许的属性
+	AllowAttrs []SanitizeAttrRule `json:"allowAttrs,omitempty" yaml:"allowAttrs,omitempty"`
+	// 净化前连同内容移除的元素（CSS 选择器）
+	Strip []string `json:"strip,omitempty" yaml:"strip,omitempty"`
+	// 强制的嵌入媒体渲染方式（iframe / placeholder），为空时沿用基础配置
+	EmbedMode string `json:"embedMode,omitempty" yaml:"embedMode,omitempty"`
+}
+
+// SanitizeAttrRule 属性白名单规则
+type SanitizeAttrRule struct {
+	// 属性名
+	Attrs []string `json:"attrs" yaml:"attrs"`
+	// 适用的元素，为空时适用于所有元素
+	Elements []string `json:"elements,omitempty" yaml:"elements,omitempty"`
+	// 属性值正则，为空时不限制
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+}
+
+// sanitizeProfilesFile 配置文件结构
+type sanitizeProfilesFile struct {
+	Profiles []*SanitizeProfileConfig `json:"profiles" yaml:"profiles"`
+}
+
+// builtinSanitizeProfiles 创建全部内置净化配置
+func builtinSanitizeProfiles() map[string]*SanitizeProfile {
+	profiles := make(map[string]*SanitizeProfile, len(builtinProfiles))
+	for name, build := range builtinProfiles {
+		profiles[name] = &SanitizeProfile{Name: name, EmbedMode: builtinEmbedModes[name], sanitizer: build()}
+	}
+	return profiles
+}
+
+// LoadSanitizeProfiles 加载自定义净化配置文件（YAML 或 JSON，按扩展名 .json 区分）
+func LoadSanitizeProfiles(path string) ([]*SanitizeProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file sanitizeProfilesFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse sanitize profiles %s: %w", path, err)
+	}
+
+	profiles, err := NewSanitizeProfiles(file.Profiles)
+	if err != nil {
+		return nil, fmt.Errorf("sanitize profiles %s: %w", path, err)
+	}
+	log.Printf("[Sanitizer] 已加载 %d 个自定义净化配置: %s", len(profiles), path)
+	return profiles, nil
+}
+
+// NewSanitizeProfiles 由配置列表创建自定义净化配置
+func NewSanitizeProfiles(configs []*SanitizeProfileConfig) ([]*SanitizeProfile, error) {
+	profiles := make([]*SanitizeProfile, 0, len(configs))
+	names := map[string]bool{}
+	for i, cfg := range configs {
+		profile, err := cfg.build()
+		if err != nil {
+			return nil, fmt.Errorf("profile %d (%s): %w", i, cfg.Name, err)
+		}
+		if names[profile.Name] {
+			return nil, fmt.Errorf("profile %d (%s): duplicate name", i, cfg.Name)
+		}
+		names[profile.Name] = true
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// build 校验配置并构建净化器
+func (c *SanitizeProfileConfig) build() (*SanitizeProfile, error) {
+	if !profileNamePattern.MatchString(c.Name) {
+		return nil, fmt.Errorf("invalid name %q", c.Name)
+	}
+	if _, ok := builtinProfiles[c.Name]; ok {
+		return nil, fmt.Errorf("name %q conflicts with built-in profile", c.Name)
+	}
+
+	base := c.Base
+	if base == "" {
+		base = ProfileReader
+	}
+	build, ok := builtinProfiles[base]
+	if !ok {
+		return nil, fmt.Errorf("unknown base profile %q", c.Base)
+	}
+
+	embedMode := builtinEmbedModes[base]
+	if c.EmbedMode != "" {
+		mode, ok := ParseEmbedMode(c.EmbedMode)
+		if !ok {
+			return nil, fmt.Errorf("invalid embedMode %q", c.EmbedMode)
+		}
+		embedMode = mode
+	}
+
+	s := build()
+	for _, el := range c.AllowElements {
+		el = strings.ToLower(strings.TrimSpace(el))
+		if el == "" || unsafeProfileElements[el] {
+			return nil, fmt.Errorf("element %q is not allowed", el)
+		}
+		s.policy.AllowElements(el)
+		s.policy.AllowNoAttrs().OnElements(el)
+	}
+
+	for _, rule := range c.AllowAttrs {
+		if len(rule.Attrs) == 0 {
+			return nil, fmt.Errorf("allowAttrs: attrs is required")
+		}
+		for _, attr := range rule.Attrs {
+			attr = strings.ToLower(attr)
+			if attr == "" || strings.HasPrefix(attr, "on") || unsafeProfileAttrs[attr] {
+				return nil, fmt.Errorf("attribute %q is not allowed", attr)
+			}
+		}
+		for _, el := range rule.Elements {
+			if unsafeProfileElements[strings.ToLower(el)] {
+				return nil, fmt.Errorf("element %q is not allowed", el)
+			}
+		}
+		attrs := s.policy.AllowAttrs(rule.Attrs...)
+		if rule.Pattern != "" {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid attribute pattern %q: %w", rule.Pattern, err)
+			}
+			attrs = attrs.Matching(re)
+		}
+		if len(rule.Elements) > 0 {
+			attrs.OnElements(rule.Elements...)
+		} else {
+			attrs.Globally()
+		}
+	}
+
+	for _, selector := range c.Strip {
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return nil, fmt.Errorf("invalid strip selector %q: %w", selector, err)
+		}
+	}
+	s.strip = c.Strip
+
+	return &SanitizeProfile{Name: c.Name, EmbedMode: embedMode, sanitizer: s}, nil
+}
+
+// newEmailSafeSanitizer 创建 email-safe 净化器
+//
+// 邮件客户端不执行脚本也不加载 iframe，且普遍拦截 data: 图片：
+//   - 只允许 http、https、mailto 协议，禁止相对 URL（邮件中无法解析）
+//   - 图片只保留绝对 http(s) 地址的 src 和 alt、width、height（不含 srcset / loading 等）
+//   - 不允许 picture、source、iframe、video、audio
+func newEmailSafeSanitizer() *Sanitizer {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements(textElements...)
+	policy.AllowElements("a", "img", "figure", "figcaption")
+	allowSafeLinks(policy, false)
+
+	policy.AllowAttrs("src").Matching(httpURLPattern).OnElements("img")
+	policy.AllowAttrs("alt").OnElements("img")
+	policy.AllowAttrs("width", "height").Matching(regexp.MustCompile(`^[0-9]{1,4}$`)).OnElements("img")
+
+	allowTextAttrs(policy)
+	return &Sanitizer{policy: policy}
+}
+
+// newStrictTextSanitizer 创建 strict-text 净化器
+//
+// 只保留文本结构、链接和表格，供 AI 处理等只关心文字的场景使用；
+// 图片和媒体元素被移除（figcaption 等容器的文字保留）。
+func newStrictTextSanitizer() *Sanitizer {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements(textElements...)
+	policy.AllowElements("a")
+	allowSafeLinks(policy, true)
+	allowTextAttrs(policy)
+	return &Sanitizer{policy: policy}
+}
+
+// newArchiveSanitizer 创建 archive 净化器（reader 基础上保留所有元素的 id 和 class）
+func newArchiveSanitizer() *Sanitizer {
+	s := NewSanitizer()
+	s.policy.AllowAttrs("id", "class").Globally()
+	return s
+}
+
+// allowSafeLinks 链接安全配置（与 reader 一致，但不允许 data: 协议）
+func allowSafeLinks(policy *bluemonday.Policy, relative bool) {
+	policy.AllowAttrs("href").OnElements("a")
+	// AllowStandardURLs 会重新允许相对 URL，须在 AllowRelativeURLs 之前调用
+	policy.AllowStandardURLs()
+	policy.AllowRelativeURLs(relative)
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	policy.RequireNoFollowOnLinks(true)
+	policy.RequireNoReferrerOnFullyQualifiedLinks(true)
+}
+
+// allowTextAttrs 文本结构的属性（表格合并、时间、代码语言、锚点）和数学公式
+func allowTextAttrs(policy *bluemonday.Policy) {
+	policy.AllowAttrs("colspan", "rowspan", "scope").OnElements("th", "td")
+	policy.AllowAttrs("datetime").OnElements("time")
+	policy.AllowAttrs("class").Matching(codeLanguageClass).OnElements("pre", "code")
+	allowAnchors(policy)
+	allowMath(policy)
+}
+
+// stripElements 移除匹配选择器的元素（连同内容）
+func stripElements(html string, selectors []string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return html
+	}
+	for _, selector := range selectors {
+		doc.Find(selector).Remove()
+	}
+	result, err := doc.Find("body").Html()
+	if err != nil {
+		return html
+	}
+	return result
+}
+
+// SetSanitizeProfiles 注册自定义净化配置（在服务启动时调用，与内置配置一起按名称选择）
+func (e *Extractor) SetSanitizeProfiles(profiles []*SanitizeProfile) {
+	all := builtinSanitizeProfiles()
+	names := append([]string(nil), builtinProfileNames...)
+	for _, p := range profiles {
+		all[p.Name] = p
+		names = append(names, p.Name)
+	}
+	e.profiles, e.profileNames = all, names
+}
+
+// SanitizeProfile 按名称查找净化配置，空字符串为默认的 reader
+func (e *Extractor) SanitizeProfile(name string) (*SanitizeProfile, bool) {
+	if name == "" {
+		name = ProfileReader
+	}
+	p, ok := e.profiles[name]
+	return p, ok
+}
+
+// SanitizeProfileNames 返回可选的净化配置名称（内置配置在前）
+func (e *Extractor) SanitizeProfileNames() []string {
+	return e.profileNames
+}
@@ -0,0 +1,174 @@
+package extractor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profileInput = `<h2 id="intro" class="lead">Intro</h2>` +
+	`<p class="note">Text <a href="/rel">rel</a> <a href="https://example.com/x">abs</a></p>` +
+	`<img src="https://cdn.example.com/a.jpg" srcset="https://cdn.example.com/a2.jpg 2x" alt="A" width="640" loading="lazy">` +
+	`<img src="data:image/png;base64,AAAA" alt="inline">` +
+	`<pre><code class="language-go">x := 1</code></pre>`
+
+func TestSanitizeProfiles(t *testing.T) {
+	e := New()
+	tests := []struct {
+		profile  string
+		want     []string
+		unwanted []string
+	}{
+		{
+			ProfileReader,
+			[]string{`<h2>Intro</h2>`, `srcset=`, `src="data:image/png`, `<a href="/rel" rel="nofollow">`, `class="language-go"`},
+			[]string{`id=`, `class="lead"`},
+		},
+		{
+			ProfileEmailSafe,
+			[]string{`<img src="https://cdn.example.com/a.jpg" alt="A" width="640">`, `<img alt="inline">`, `class="language-go"`},
+			[]string{`data:`, `srcset`, `loading`, `href="/rel"`},
+		},
+		{
+			ProfileStrictText,
+			[]string{`<h2>Intro</h2>`, `<a href="/rel" rel="nofollow">rel</a>`, `class="language-go"`},
+			[]string{`<img`, `data:`},
+		},
+		{
+			ProfileArchive,
+			[]string{`<h2 id="intro" class="lead">`, `<p class="note">`, `srcset=`, `class="language-go"`},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			p, ok := e.SanitizeProfile(tt.profile)
+			if !ok {
+				t.Fatalf("SanitizeProfile(%q) 不存在", tt.profile)
+			}
+			got := p.Sanitize(profileInput)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("缺少 %s:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("不应包含 %s:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+
+	t.Run("空名称为 reader，未知名称不存在", func(t *testing.T) {
+		if p, ok := e.SanitizeProfile(""); !ok || p.Name != ProfileReader {
+			t.Errorf("SanitizeProfile(\"\") = %+v, %v", p, ok)
+		}
+		if _, ok := e.SanitizeProfile("unknown"); ok {
+			t.Error("SanitizeProfile(\"unknown\") 应不存在")
+		}
+	})
+}
+
+const sanitizeProfilesYAML = `profiles:
+  - name: ai-images
+    base: strict-text
+    allowElements: [img]
+    allowAttrs:
+      - attrs: [src, alt]
+        elements: [img]
+    strip: [pre, .note]
+`
+
+func TestCustomSanitizeProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	if err := os.WriteFile(path, []byte(sanitizeProfilesYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := LoadSanitizeProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadSanitizeProfiles() error = %v", err)
+	}
+	e := New()
+	e.SetSanitizeProfiles(profiles)
+
+	if got := strings.Join(e.SanitizeProfileNames(), ","); got != "reader,email-safe,strict-text,archive,ai-images" {
+		t.Errorf("SanitizeProfileNames() = %s", got)
+	}
+
+	p, ok := e.SanitizeProfile("ai-images")
+	if !ok {
+		t.Fatal("自定义配置不存在")
+	}
+	if p.EmbedMode != EmbedModePlaceholder {
+		t.Errorf("EmbedMode = %q, 应沿用 strict-text 的 placeholder", p.EmbedMode)
+	}
+	want := `<h2>Intro</h2><img src="https://cdn.example.com/a.jpg" alt="A"/><img alt="inline"/>`
+	if got := p.Sanitize(profileInput); got != want {
+		t.Errorf("Sanitize() = %q, want %q", got, want)
+	}
+
+	invalid := []struct {
+		name string
+		cfg  SanitizeProfileConfig
+	}{
+		{"与内置配置重名", SanitizeProfileConfig{Name: ProfileReader}},
+		{"非法名称", SanitizeProfileConfig{Name: "Bad Name"}},
+		{"未知基础配置", SanitizeProfileConfig{Name: "x", Base: "x"}},
+		{"放开脚本", SanitizeProfileConfig{Name: "x", AllowElements: []string{"script"}}},
+		{"放开 iframe", SanitizeProfileConfig{Name: "x", AllowElements: []string{"IFRAME"}}},
+		{"放开事件属性", SanitizeProfileConfig{Name: "x", AllowAttrs: []SanitizeAttrRule{{Attrs: []string{"onerror"}}}}},
+		{"放开 style", SanitizeProfileConfig{Name: "x", AllowAttrs: []SanitizeAttrRule{{Attrs: []string{"style"}, Elements: []string{"p"}}}}},
+		{"非法属性正则", SanitizeProfileConfig{Name: "x", AllowAttrs: []SanitizeAttrRule{{Attrs: []string{"title"}, Pattern: "("}}}},
+		{"非法选择器", SanitizeProfileConfig{Name: "x", Strip: []string{"[["}}},
+		{"非法嵌入方式", SanitizeProfileConfig{Name: "x", EmbedMode: "video"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSanitizeProfiles([]*SanitizeProfileConfig{&tt.cfg}); err == nil {
+				t.Error("NewSanitizeProfiles() 应返回错误")
+			}
+		})
+	}
+	t.Run("重复名称", func(t *testing.T) {
+		cfg := &SanitizeProfileConfig{Name: "dup"}
+		if _, err := NewSanitizeProfiles([]*SanitizeProfileConfig{cfg, cfg}); err == nil {
+			t.Error("NewSanitizeProfiles() 应拒绝重复名称")
+		}
+	})
+	t.Run("放开的元素没有属性时保留", func(t *testing.T) {
+		profiles, err := NewSanitizeProfiles([]*SanitizeProfileConfig{{Name: "data", Base: ProfileStrictText, AllowElements: []string{"data"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `<p>Price <data>42</data></p>`
+		if got := profiles[0].Sanitize(want); got != want {
+			t.Errorf("Sanitize() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestExtractWithSanitizeProfile(t *testing.T) {
+	para := "<p>" + strings.Repeat("这是一段足够长的正文内容，用于让提取引擎识别为文章。", 8) + "</p>"
+	page := `<html><head><title>视频文章</title></head><body><article><h1>视频文章</h1>` + para +
+		`<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" title="Demo"></iframe>` + para +
+		`</article></body></html>`
+
+	e := New()
+	result, err := e.ExtractWithOptions(context.Background(), page, "https://news.example.com/a/1.html",
+		ExtractOptions{SanitizeProfile: ProfileEmailSafe, EmbedMode: EmbedModeIframe})
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	if strings.Contains(result.Content, "<iframe") {
+		t.Errorf("email-safe 不应包含 iframe:\n%s", result.Content)
+	}
+	if !strings.Contains(result.Content, `<img src="https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg" alt="Demo"`) {
+		t.Errorf("email-safe 应渲染为缩略图占位:\n%s", result.Content)
+	}
+	if len(result.Media) != 1 {
+		t.Errorf("Media = %+v", result.Media)
+	}
+}
@@ -0,0 +1,118 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现提取结果的质量评估
+
+package extractor
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// QualityScore 提取结果的质量评分
+type QualityScore struct {
+	Engine       string  `json:"engine,omitempty"`
+	Score        float64 `json:"score"`
+	TextLength   int     `json:"textLength"`   // 正文字符数（不含空白）
+	LinkDensity  float64 `json:"linkDensity"`  // 链接文字占比
+	Paragraphs   int     `json:"paragraphs"`   // 不少于 20 字的段落数
+	TitleOverlap float64 `json:"titleOverlap"` // 页面标题与提取标题、导语的重合度（0-1）
+	Error        string  `json:"error,omitempty"`
+}
+
+// scoreExtraction 评估提取结果的质量
+func scoreExtraction(r *ExtractResult) float64 {
+	if r == nil {
+		return 0
+	}
+	return measureQuality(r.Content, r.TextContent, "", "").Score
+}
+
+// measureQuality 评估提取结果的质量
+//
+// 评分依据：
+//   - 正文长度：按字符数计，越长越好（超过 5000 字后不再加分，避免整页噪声胜出）
+//   - 链接密度：链接文字占比越高，越可能混入导航、推荐列表，按比例扣减
+//   - 段落数量：段落结构完整的正文每段加分
+//   - 标题重合度：页面标题（refTitle）在提取标题和正文开头中出现的比例，
+//     用于识别误选侧边栏、推荐列表的结果（refTitle 为空时不计）
+//
+// 返回：
+//   - 质量分及各项指标，分数仅用于同一篇文章不同提取结果之间的比较
+func measureQuality(contentHTML, textContent, title, refTitle string) QualityScore {
+	q := QualityScore{}
+
+	q.TextLength = utf8.RuneCountInString(strings.Join(strings.Fields(textContent), ""))
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(contentHTML)); err == nil {
+		totalLen := utf8.RuneCountInString(strings.Join(strings.Fields(doc.Text()), ""))
+		linkLen := 0
+		doc.Find("a").Each(func(i int, s *goquery.Selection) {
+			linkLen += utf8.RuneCountInString(strings.Join(strings.Fields(s.Text()), ""))
+		})
+		if totalLen > 0 {
+			q.LinkDensity = float64(linkLen) / float64(totalLen)
+		}
+		doc.Find("p").Each(func(i int, s *goquery.Selection) {
+			if utf8.RuneCountInString(strings.TrimSpace(s.Text())) >= 20 {
+				q.Paragraphs++
+			}
+		})
+	}
+
+	if refTitle != "" {
+		lead := []rune(strings.Join(strings.Fields(textContent), " "))
+		if len(lead) > 300 {
+			lead = lead[:300]
+		}
+		q.TitleOverlap = titleOverlap(refTitle, title+" "+string(lead))
+	}
+
+	textLen := min(q.TextLength, 5000)
+	q.Score = float64(textLen)*(1-q.LinkDensity) + float64(q.Paragraphs)*30 + q.TitleOverlap*200
+	return q
+}
+
+// titleSeparators 页面标题中站点名的分隔符（「文章标题 - 站点名」）
+var titleSeparators = []string{" | ", " - ", " – ", " — ", "_", "｜", " :: "}
+
+// titleOverlap 计算页面标题的字符二元组在候选文本中出现的比例
+//
+// 先去掉标题中的站点名后缀（取分隔后最长的一段），按字符二元组比较以同时适用于中英文。
+func titleOverlap(refTitle, candidate string) float64 {
+	for _, sep := range titleSeparators {
+		if parts := strings.Split(refTitle, sep); len(parts) > 1 {
+			longest := ""
+			for _, part := range parts {
+				if utf8.RuneCountInString(part) > utf8.RuneCountInString(longest) {
+					longest = part
+				}
+			}
+			refTitle = longest
+		}
+	}
+
+	ref := bigrams(refTitle)
+	if len(ref) == 0 {
+		return 0
+	}
+	cand := bigrams(candidate)
+	hit := 0
+	for gram := range ref {
+		if cand[gram] {
+			hit++
+		}
+	}
+	return float64(hit) / float64(len(ref))
+}
+
+// bigrams 文本的字符二元组集合（忽略空白和大小写）
+func bigrams(text string) map[string]bool {
+	runes := []rune(strings.ToLower(strings.Join(strings.Fields(text), "")))
+	set := make(map[string]bool, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		set[string(runes[i:i+2])] = true
+	}
+	return set
+}
@@ -0,0 +1,271 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现文章修订检测和段落级文本差异
+
+package extractor
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// 差异块类型
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+)
+
+// ModifiedSimilarity 删除段落与新增段落视为同一段落的修改（而非删除 + 新增）的最低相似度
+const ModifiedSimilarity = 0.5
+
+// maxDiffCells 差异比较表的最大单元数（去除首尾相同段落后的旧段落数 × 新段落数），
+// 超过时不再计算最长公共子序列，中间部分全部视为删除 + 新增
+const maxDiffCells = 4 << 20
+
+// ErrInvalidContentHash 内容哈希格式错误（应为 Fingerprint.ContentHash，64 位十六进制）
+var ErrInvalidContentHash = errors.New("invalid previous content hash (64 hex digits)")
+
+// contentHashPattern 内容哈希格式
+var contentHashPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// DiffBlock 一个变化的段落
+type DiffBlock struct {
+	// added, removed, modified
+	Op string `json:"op"`
+	// 在新文本中的段落下标（removed 为 -1）
+	Index int `json:"index"`
+	// 在旧文本中的段落下标（added 为 -1）
+	OldIndex int    `json:"oldIndex"`
+	Text     string `json:"text,omitempty"`
+	OldText  string `json:"oldText,omitempty"`
+	// 修改前后的相似度（仅 modified）
+	Similarity float64 `json:"similarity,omitempty"`
+}
+
+// TextDiff 段落级文本差异
+type TextDiff struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Modified  int `json:"modified"`
+	Unchanged int `json:"unchanged"`
+	// 变化的段落（按在文章中的位置排序，不含未变化的段落）
+	Blocks []DiffBlock `json:"blocks"`
+}
+
+// Changed 是否有变化
+func (d *TextDiff) Changed() bool {
+	return d.Added+d.Removed+d.Modified > 0
+}
+
+// Revision 文章修订检测结果
+type Revision struct {
+	// 正文是否变化（规范化文本的内容哈希不同）
+	Changed bool `json:"changed"`
+	// 比较所用的旧内容哈希（提供旧文本时由旧文本计算）
+	PreviousHash string `json:"previousHash"`
+	// 当前正文的内容哈希
+	ContentHash string `json:"contentHash"`
+	// 段落级差异（仅提供旧文本时）
+	Diff *TextDiff `json:"diff,omitempty"`
+}
+
+// ValidContentHash 检查内容哈希格式（Fingerprint.ContentHash）
+func ValidContentHash(hash string) bool {
+	return contentHashPattern.MatchString(hash)
+}
+
+// CheckRevision 将当前正文文本与旧版本比较
+//
+// 提供 previousText（之前保存的 TextContent）时按段落比较并返回差异，
+// 否则只比较 previousHash（之前保存的 Fingerprint.ContentHash）。
+// 是否变化以规范化文本的内容哈希为准，仅排版、标点、大小写的变化不算修订。
+func CheckRevision(previousHash, previousText, text string) (*Revision, error) {
+	if previousText == "" {
+		if previousHash == "" {
+			return nil, errors.New("previous hash or text is required")
+		}
+		if !ValidContentHash(previousHash) {
+			return nil, ErrInvalidContentHash
+		}
+	}
+
+	rev := &Revision{PreviousHash: strings.ToLower(previousHash)}
+	if fp := ComputeFingerprint(text); fp != nil {
+		rev.ContentHash = fp.ContentHash
+	}
+	if previousText != "" {
+		rev.PreviousHash = ""
+		if fp := ComputeFingerprint(previousText); fp != nil {
+			rev.PreviousHash = fp.ContentHash
+		}
+		rev.Diff = DiffText(previousText, text)
+	}
+	rev.Changed = rev.ContentHash != rev.PreviousHash
+	return rev, nil
+}
+
+// diffParagraph 参与比较的段落
+type diffParagraph struct {
+	text string
+	// 规范化后的比较键（见 fingerprintTokens），纯标点段落为原文
+	key string
+}
+
+// splitParagraphs 按行拆分段落（忽略空行），TextContent 的段落、列表项、表格行各占一行
+func splitParagraphs(text string) []diffParagraph {
+	var paras []diffParagraph
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key := strings.Join(fingerprintTokens(line), " ")
+		if key == "" {
+			key = line
+		}
+		paras = append(paras, diffParagraph{text: line, key: key})
+	}
+	return paras
+}
+
+// DiffText 比较两个版本的正文文本，返回段落级差异
+//
+// 段落按规范化文本（见 ComputeFingerprint）比较，仅排版、标点、大小写不同的段落视为未变化。
+// 以最长公共子序列对齐段落，两个对齐段落之间被删除和新增的段落按顺序配对，
+// 相似度不低于 ModifiedSimilarity 的配对为修改。
+func DiffText(oldText, newText string) *TextDiff {
+	olds, news := splitParagraphs(oldText), splitParagraphs(newText)
+	diff := &TextDiff{Blocks: []DiffBlock{}}
+
+	// 去除首尾相同的段落，缩小比较表
+	prefix := 0
+	for prefix < len(olds) && prefix < len(news) && olds[prefix].key == news[prefix].key {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(olds)-prefix && suffix < len(news)-prefix &&
+		olds[len(olds)-1-suffix].key == news[len(news)-1-suffix].key {
+		suffix++
+	}
+	diff.Unchanged = prefix + suffix
+
+	oldMid, newMid := olds[prefix:len(olds)-suffix], news[prefix:len(news)-suffix]
+	matches := lcsParagraphs(oldMid, newMid)
+	diff.Unchanged += len(matches)
+
+	// 逐段处理两个对齐段落之间的删除和新增
+	i, j := 0, 0
+	for _, m := range append(matches, [2]int{len(oldMid), len(newMid)}) {
+		diff.diffHunk(oldMid[i:m[0]], newMid[j:m[1]], prefix+i, prefix+j)
+		i, j = m[0]+1, m[1]+1
+	}
+	return diff
+}
+
+// lcsParagraphs 返回最长公共子序列中各对齐段落的下标（旧, 新），按顺序排列
+func lcsParagraphs(olds, news []diffParagraph) [][2]int {
+	n, m := len(olds), len(news)
+	if n == 0 || m == 0 || n*m > maxDiffCells {
+		return nil
+	}
+	// dp[i][j]：olds[i:] 与 news[j:] 的最长公共子序列长度
+	dp := make([][]int32, n+1)
+	for i := range dp {
+		dp[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if olds[i].key == news[j].key {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+	var matches [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case olds[i].key == news[j].key:
+			matches = append(matches, [2]int{i, j})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matches
+}
+
+// diffHunk 处理两个对齐段落之间被删除和新增的段落，按顺序配对为修改
+func (d *TextDiff) diffHunk(removed, added []diffParagraph, oldStart, newStart int) {
+	next := 0 // 下一个未输出的新增段落
+	for i, r := range removed {
+		paired, similarity := -1, 0.0
+		for j := next; j < len(added); j++ {
+			if s := paragraphSimilarity(r.key, added[j].key); s >= ModifiedSimilarity {
+				paired, similarity = j, s
+				break
+			}
+		}
+		if paired < 0 {
+			d.Removed++
+			d.Blocks = append(d.Blocks, DiffBlock{Op: DiffRemoved, Index: -1, OldIndex: oldStart + i, OldText: r.text})
+			continue
+		}
+		for ; next < paired; next++ {
+			d.Added++
+			d.Blocks = append(d.Blocks, DiffBlock{Op: DiffAdded, Index: newStart + next, OldIndex: -1, Text: added[next].text})
+		}
+		d.Modified++
+		d.Blocks = append(d.Blocks, DiffBlock{
+			Op:         DiffModified,
+			Index:      newStart + paired,
+			OldIndex:   oldStart + i,
+			Text:       added[paired].text,
+			OldText:    r.text,
+			Similarity: similarity,
+		})
+		next = paired + 1
+	}
+	for ; next < len(added); next++ {
+		d.Added++
+		d.Blocks = append(d.Blocks, DiffBlock{Op: DiffAdded, Index: newStart + next, OldIndex: -1, Text: added[next].text})
+	}
+}
+
+// paragraphSimilarity 两个段落比较键的相似度（相邻词 bigram 的 Dice 系数，0-1）
+func paragraphSimilarity(a, b string) float64 {
+	ba, bb := keyBigrams(a), keyBigrams(b)
+	if len(ba) == 0 || len(bb) == 0 {
+		return 0
+	}
+	counts := map[string]int{}
+	for _, g := range ba {
+		counts[g]++
+	}
+	common := 0
+	for _, g := range bb {
+		if counts[g] > 0 {
+			counts[g]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(ba)+len(bb))
+}
+
+// keyBigrams 比较键的相邻词 bigram（只有一个词时为该词）
+func keyBigrams(key string) []string {
+	tokens := strings.Fields(key)
+	if len(tokens) <= 1 {
+		return tokens
+	}
+	grams := make([]string, 0, len(tokens)-1)
+	for i := 0; i+1 < len(tokens); i++ {
+		grams = append(grams, tokens[i]+" "+tokens[i+1])
+	}
+	return grams
+}
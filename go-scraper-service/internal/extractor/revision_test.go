@@ -0,0 +1,111 @@
+package extractor
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDiffText(t *testing.T) {
+	const oldText = "# 标题\n\n" +
+		"第一段：本市今日发布新的交通管理措施，自下月起实施。\n\n" +
+		"第二段：据介绍，新措施涉及早晚高峰时段的限行范围调整，涉及全市主要干道。\n\n" +
+		"第三段：市民可通过官方网站查询详细信息。\n\n" +
+		"• 列表项一"
+
+	tests := []struct {
+		name    string
+		newText string
+		want    string // 各块的 op:index:oldIndex
+		counts  [4]int // added, removed, modified, unchanged
+	}{
+		{"完全相同", oldText, "", [4]int{0, 0, 0, 5}},
+		{"仅标点和空白不同", strings.ReplaceAll(oldText, "，", ", ") + "\n\n\n", "", [4]int{0, 0, 0, 5}},
+		{
+			"修改一段",
+			strings.Replace(oldText, "涉及全市主要干道", "涉及全市十二条主要干道", 1),
+			"modified:2:2", [4]int{0, 0, 1, 4},
+		},
+		{
+			"新增和删除",
+			strings.Replace(oldText, "第三段：市民可通过官方网站查询详细信息。", "更正：此前报道中的实施日期有误，特此更正。", 1) + "\n\n• 列表项二",
+			"removed:-1:3,added:3:-1,added:5:-1", [4]int{2, 1, 0, 4},
+		},
+		{
+			"删除段落后修改下一段",
+			strings.Replace(strings.Replace(oldText, "第一段：本市今日发布新的交通管理措施，自下月起实施。\n\n", "", 1), "官方网站", "官方网站或热线电话", 1),
+			"removed:-1:1,modified:2:3", [4]int{0, 1, 1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DiffText(oldText, tt.newText)
+			var got []string
+			for _, b := range d.Blocks {
+				got = append(got, b.Op+":"+strconv.Itoa(b.Index)+":"+strconv.Itoa(b.OldIndex))
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("Blocks = %s, want %s", strings.Join(got, ","), tt.want)
+			}
+			if counts := [4]int{d.Added, d.Removed, d.Modified, d.Unchanged}; counts != tt.counts {
+				t.Errorf("counts = %v, want %v", counts, tt.counts)
+			}
+			if d.Changed() != (tt.want != "") {
+				t.Errorf("Changed() = %v", d.Changed())
+			}
+		})
+	}
+
+	t.Run("修改块包含前后文本", func(t *testing.T) {
+		d := DiffText("Breaking: the mayor resigned on Monday after a long investigation into city contracts.",
+			"Breaking: the mayor resigned on Tuesday after a long investigation into city contracts.")
+		if len(d.Blocks) != 1 || d.Blocks[0].Op != DiffModified || !strings.Contains(d.Blocks[0].OldText, "Monday") ||
+			!strings.Contains(d.Blocks[0].Text, "Tuesday") || d.Blocks[0].Similarity < ModifiedSimilarity {
+			t.Errorf("Blocks = %+v", d.Blocks)
+		}
+	})
+}
+
+func TestCheckRevision(t *testing.T) {
+	const text = "第一段正文。\n\n第二段正文。"
+	hash := ComputeFingerprint(text).ContentHash
+
+	tests := []struct {
+		name         string
+		previousHash string
+		previousText string
+		current      string
+		changed      bool
+		hasDiff      bool
+		wantErr      error
+	}{
+		{"哈希相同", hash, "", text, false, false, nil},
+		{"哈希大小写不敏感", strings.ToUpper(hash), "", text + "。", false, false, nil},
+		{"哈希不同", hash, "", text + "\n\n第三段。", true, false, nil},
+		{"提供旧文本时返回差异", "", text, "第一段正文。\n\n第二段正文（已更新）。", true, true, nil},
+		{"旧文本优先于哈希", "0000000000000000000000000000000000000000000000000000000000000000", text, text, false, true, nil},
+		{"哈希格式错误", "abc", "", text, false, false, ErrInvalidContentHash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rev, err := CheckRevision(tt.previousHash, tt.previousText, tt.current)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckRevision() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if rev.Changed != tt.changed || (rev.Diff != nil) != tt.hasDiff {
+				t.Errorf("CheckRevision() = %+v", rev)
+			}
+			if rev.Diff != nil && rev.Diff.Changed() != rev.Changed {
+				t.Errorf("Diff.Changed() = %v, Changed = %v", rev.Diff.Changed(), rev.Changed)
+			}
+		})
+	}
+
+	if _, err := CheckRevision("", "", text); err == nil {
+		t.Error("缺少旧版本时应返回错误")
+	}
+}
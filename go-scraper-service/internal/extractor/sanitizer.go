@@ -5,9 +5,60 @@
 package extractor
 
 import (
+	"regexp"
+
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// codeLanguageClass 允许保留的代码语言 class
+var codeLanguageClass = regexp.MustCompile(`^(language|lang)-[A-Za-z0-9_+#.-]+$`)
+
+var (
+	// embedClassPattern 嵌入媒体容器的 class 和 data-provider
+	embedClassPattern = regexp.MustCompile(`^(embed embed-)?(youtube|bilibili|vimeo|twitter|native)$`)
+	// embedAllowPattern 嵌入 iframe 的 allow 属性（权限策略）
+	embedAllowPattern = regexp.MustCompile(`^(fullscreen|picture-in-picture|encrypted-media)(; (fullscreen|picture-in-picture|encrypted-media))*$`)
+	// httpURLPattern 绝对 http(s) URL
+	httpURLPattern = regexp.MustCompile(`^https?://[^\s"'<>]+$`)
+	// anchorIDAttrPattern 加了命名空间的锚点 id（由 normalizeTechnical 生成）
+	anchorIDAttrPattern = regexp.MustCompile(`^nf-[\p{L}\p{N}_.:-]{1,64}$`)
+	// mathAttrPattern MathML 展示属性的取值
+	mathAttrPattern = regexp.MustCompile(`^[A-Za-z0-9 .%+#-]{1,64}$`)
+)
+
+// mathElements MathML 展示标记（不含 annotation-xml、mglyph、maction 等可携带 HTML 或交互的元素）
+var mathElements = []string{
+	"math", "semantics", "annotation", "mrow", "mi", "mn", "mo", "ms", "mtext", "mspace",
+	"msup", "msub", "msubsup", "mfrac", "msqrt", "mroot", "mover", "munder", "munderover",
+	"mtable", "mtr", "mtd", "mstyle", "mpadded", "mphantom", "menclose", "merror", "mmultiscripts", "mprescripts", "none",
+}
+
+// mathAttrs MathML 展示属性
+var mathAttrs = []string{
+	"display", "mathvariant", "displaystyle", "scriptlevel", "stretchy", "fence", "separator",
+	"accent", "accentunder", "lspace", "rspace", "linethickness", "minsize", "maxsize", "movablelimits",
+	"columnalign", "rowalign", "columnspan", "rowspan", "notation", "width", "height", "depth",
+}
+
+// textElements 文本结构标签（各净化配置共用，不含链接和媒体）
+var textElements = []string{
+	// 文本结构 - 段落和分隔
+	"p", "br", "hr", "div", "span",
+	// 标题 - 文章层级结构
+	"h1", "h2", "h3", "h4", "h5", "h6",
+	// 列表 - 有序/无序/定义列表
+	"ul", "ol", "li", "dl", "dt", "dd",
+	// 文本格式 - 强调、删除线、上下标等
+	"b", "i", "strong", "em", "u", "s", "strike", "del", "ins",
+	"sub", "sup", "small", "mark", "abbr",
+	// 引用和代码 - 代码块、引用块
+	"blockquote", "pre", "code", "kbd", "samp", "var",
+	// 表格 - 完整表格支持
+	"table", "caption", "thead", "tbody", "tfoot", "tr", "th", "td",
+	// 其他语义化标签
+	"address", "cite", "q", "time", "details", "summary",
+}
+
 // Sanitizer HTML 净化器
 //
 // 使用 bluemonday 库实现 HTML 净化，移除潜在的 XSS 攻击向量，
@@ -25,9 +76,11 @@ import (
 //   - Go: AddTargetBlankToFullyQualifiedLinks <-> Node.js: afterSanitizeAttributes hook
 type Sanitizer struct {
 	policy *bluemonday.Policy
+	// strip 净化前连同内容移除的元素（自定义配置的 strip 选择器）
+	strip []string
 }
 
-// NewSanitizer 创建并配置 HTML 净化器
+// NewSanitizer 创建并配置 HTML 净化器（即 reader 净化配置，其他配置见 profile.go）
 //
 // 返回一个配置完整的 Sanitizer 实例，包含以下安全策略：
 //
@@ -40,6 +93,8 @@ type Sanitizer struct {
 //   - 媒体：a, img, figure, figcaption, picture, source
 //   - 表格：table, caption, thead, tbody, tfoot, tr, th, td
 //   - 其他：address, cite, q, time, details, summary
+//   - 数学公式：MathML 展示标记（math, mrow, mi, mo, mfrac 等，TeX 源码保留在 annotation 中）
+//   - 嵌入媒体：iframe（仅白名单平台播放器，强制 sandbox）、video、audio（仅 http(s) 来源）
 //
 // 2. 链接安全配置：
 //   - 允许 href, target, rel 属性
@@ -47,7 +102,9 @@ type Sanitizer struct {
 //   - 外部链接自动添加 rel="noopener noreferrer nofollow"
 //   - 禁止相对 URL（防止路径遍历）
 //
-// 3. 图片属性：
+// 3. 锚点：只保留 nf- 命名空间的 id（脚注、目录锚点），避免与宿主页面冲突
+//
+// 4. 图片属性：
 //   - 允许 src, srcset, sizes, alt, width, height, loading, decoding
 //   - loading 和 decoding 属性支持懒加载优化（由 image.go 设置）
 //
@@ -61,25 +118,9 @@ func NewSanitizer() *Sanitizer {
 	// ============================================================
 	// 允许的标签（白名单模式，保留文章排版所需的语义化标签）
 	// ============================================================
-	policy.AllowElements(
-		// 文本结构 - 段落和分隔
-		"p", "br", "hr", "div", "span",
-		// 标题 - 文章层级结构
-		"h1", "h2", "h3", "h4", "h5", "h6",
-		// 列表 - 有序/无序/定义列表
-		"ul", "ol", "li", "dl", "dt", "dd",
-		// 文本格式 - 强调、删除线、上下标等
-		"b", "i", "strong", "em", "u", "s", "strike", "del", "ins",
-		"sub", "sup", "small", "mark", "abbr",
-		// 引用和代码 - 代码块、引用块
-		"blockquote", "pre", "code", "kbd", "samp", "var",
-		// 媒体 - 链接、图片、图片容器
-		"a", "img", "figure", "figcaption", "picture", "source",
-		// 表格 - 完整表格支持
-		"table", "caption", "thead", "tbody", "tfoot", "tr", "th", "td",
-		// 其他语义化标签
-		"address", "cite", "q", "time", "details", "summary",
-	)
+	policy.AllowElements(textElements...)
+	// 媒体 - 链接、图片、图片容器
+	policy.AllowElements("a", "img", "figure", "figcaption", "picture", "source")
 
 	// ============================================================
 	// 链接安全配置
@@ -151,9 +192,60 @@ func NewSanitizer() *Sanitizer {
 	// 时间标签的 datetime 属性（机器可读的日期时间）
 	policy.AllowAttrs("datetime").OnElements("time")
 
+	// 代码块的语言标记（class="language-go" / "lang-go"），Markdown 输出时用于围栏语言
+	policy.AllowAttrs("class").Matching(codeLanguageClass).OnElements("pre", "code")
+
+	// 脚注、目录等页内锚点（带 nf- 命名空间）和 MathML 公式（见 technical.go）
+	allowAnchors(policy)
+	allowMath(policy)
+
+	// ============================================================
+	// 嵌入媒体配置（由 renderEmbeds 生成，见 embed.go）
+	// ============================================================
+
+	// iframe 只允许白名单平台的规范播放器地址，并强制沙箱
+	policy.AllowElements("iframe", "video", "audio")
+	policy.AllowAttrs("src").Matching(embedIframePattern).OnElements("iframe")
+	policy.AllowAttrs("title", "allowfullscreen", "width", "height", "loading").OnElements("iframe")
+	policy.AllowAttrs("allow").Matching(embedAllowPattern).OnElements("iframe")
+	policy.AllowAttrs("referrerpolicy").Matching(regexp.MustCompile(`^strict-origin-when-cross-origin$`)).OnElements("iframe")
+	policy.AllowAttrs("sandbox").OnElements("iframe")
+	policy.RequireSandboxOnIFrame(
+		bluemonday.SandboxAllowScripts,
+		bluemonday.SandboxAllowSameOrigin,
+		bluemonday.SandboxAllowPresentation,
+		bluemonday.SandboxAllowPopups,
+	)
+
+	// 原生音视频只允许 http(s) 来源
+	policy.AllowAttrs("src").Matching(httpURLPattern).OnElements("video", "audio", "source")
+	policy.AllowAttrs("poster").Matching(httpURLPattern).OnElements("video")
+	policy.AllowAttrs("controls", "preload", "width", "height").OnElements("video", "audio")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^(video|audio)/[a-z0-9.+-]+$`)).OnElements("source")
+
+	// 嵌入媒体容器的平台和 ID 标记
+	policy.AllowAttrs("class").Matching(embedClassPattern).OnElements("figure")
+	policy.AllowAttrs("data-provider").Matching(embedClassPattern).OnElements("figure")
+	policy.AllowAttrs("data-id").Matching(regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)).OnElements("figure")
+
 	return &Sanitizer{policy: policy}
 }
 
+// allowAnchors 允许带命名空间的锚点 id
+func allowAnchors(policy *bluemonday.Policy) {
+	policy.AllowAttrs("id").Matching(anchorIDAttrPattern).Globally()
+}
+
+// allowMath 允许 MathML 展示标记（TeX 注解只允许 encoding 属性）
+func allowMath(policy *bluemonday.Policy) {
+	policy.AllowElements(mathElements...)
+	policy.AllowNoAttrs().OnElements(mathElements...)
+	policy.AllowAttrs(mathAttrs...).Matching(mathAttrPattern).OnElements(mathElements...)
+	policy.AllowAttrs("encoding").Matching(regexp.MustCompile(`^application/x-tex$`)).OnElements("annotation")
+	// annotation-xml 可嵌入任意 HTML，连同内容移除
+	policy.SkipElementsContent("annotation-xml")
+}
+
 // Sanitize 净化 HTML 内容
 //
 // 对输入的 HTML 字符串进行安全净化处理，移除所有不在白名单中的标签和属性，
@@ -177,6 +269,9 @@ func NewSanitizer() *Sanitizer {
 //	output := sanitizer.Sanitize(input)
 //	// output: "<p>Hello World</p>"
 func (s *Sanitizer) Sanitize(html string) string {
+	if len(s.strip) > 0 {
+		html = stripElements(html, s.strip)
+	}
 	return s.policy.Sanitize(html)
 }
 
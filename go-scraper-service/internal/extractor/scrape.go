@@ -0,0 +1,318 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现按 CSS 选择器抓取列表页条目
+
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// ErrListSelectorNoMatch 列表选择器在首页没有匹配到任何元素
+var ErrListSelectorNoMatch = errors.New("listSelector matched no elements")
+
+// ScrapeConfig 列表页抓取配置
+//
+// 字段与 Node.js 端 ScrapeConfig（src/lib/fetchers/types.ts）一致，可直接传入 Source.config.scrape；
+// 在此基础上支持为每个字段指定读取的属性（*Attr）以及翻页。
+// 字段选择器都在列表项内部查找。
+type ScrapeConfig struct {
+	// 列表项选择器（必填）
+	ListSelector string `json:"listSelector"`
+
+	// 标题，为空时使用链接元素的文本
+	TitleSelector string `json:"titleSelector,omitempty"`
+	TitleAttr     string `json:"titleAttr,omitempty"`
+	// 链接，为空时使用列表项内第一个 a[href]（列表项本身是链接时使用列表项）；属性默认 href
+	LinkSelector string `json:"linkSelector,omitempty"`
+	LinkAttr     string `json:"linkAttr,omitempty"`
+	// 摘要（contentSelector 为 Node.js 端的字段名，两者等价）
+	SummarySelector string `json:"summarySelector,omitempty"`
+	ContentSelector string `json:"contentSelector,omitempty"`
+	SummaryAttr     string `json:"summaryAttr,omitempty"`
+	// 封面图，属性默认 src（懒加载图片已在预处理中还原）
+	ImageSelector string `json:"imageSelector,omitempty"`
+	ImageAttr     string `json:"imageAttr,omitempty"`
+	// 作者
+	AuthorSelector string `json:"authorSelector,omitempty"`
+	AuthorAttr     string `json:"authorAttr,omitempty"`
+	// 发布时间，属性为空时依次尝试 datetime、content 属性和文本
+	DateSelector string `json:"dateSelector,omitempty"`
+	DateAttr     string `json:"dateAttr,omitempty"`
+
+	// 翻页：下一页链接选择器，为空时自动识别（rel="next"、「下一页」、页码链接）
+	NextSelector string `json:"nextSelector,omitempty"`
+	// 最多抓取的列表页数（含首页），默认 1，上限 MaxPagesLimit
+	MaxPages int `json:"maxPages,omitempty"`
+}
+
+// ScrapeItem 列表条目
+type ScrapeItem struct {
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Summary     string     `json:"summary,omitempty"`
+	ImageURL    string     `json:"imageUrl,omitempty"`
+	Author      string     `json:"author,omitempty"`
+	Date        string     `json:"date,omitempty"`        // 日期原始文本
+	PublishedAt *time.Time `json:"publishedAt,omitempty"` // 解析后的发布时间（无法解析时为空）
+}
+
+// SelectorError 选择器校验结果：已配置的字段选择器在所有列表项中都没有匹配
+type SelectorError struct {
+	Field    string `json:"field"`
+	Selector string `json:"selector"`
+	Message  string `json:"message"`
+}
+
+// ScrapeResult 列表页抓取结果
+type ScrapeResult struct {
+	Items    []ScrapeItem `json:"items"`
+	Pages    int          `json:"pages"`    // 实际抓取的列表页数
+	PageURLs []string     `json:"pageUrls"` // 抓取的列表页 URL
+	// 未匹配的字段选择器（基于首页判断）
+	SelectorErrors []SelectorError `json:"selectorErrors,omitempty"`
+}
+
+// scrapeField 字段选择器及读取的属性
+type scrapeField struct {
+	name     string
+	selector string
+	attr     string
+}
+
+// fields 返回已配置的字段
+func (c ScrapeConfig) fields() []scrapeField {
+	fields := []scrapeField{
+		{"title", c.TitleSelector, c.TitleAttr},
+		{"link", c.LinkSelector, c.LinkAttr},
+		{"summary", firstNonEmpty(c.SummarySelector, c.ContentSelector), c.SummaryAttr},
+		{"image", c.ImageSelector, c.ImageAttr},
+		{"author", c.AuthorSelector, c.AuthorAttr},
+		{"date", c.DateSelector, c.DateAttr},
+	}
+	configured := fields[:0]
+	for _, f := range fields {
+		if f.selector != "" {
+			configured = append(configured, f)
+		}
+	}
+	return configured
+}
+
+// Validate 校验配置（必填项和选择器语法）
+func (c ScrapeConfig) Validate() error {
+	if strings.TrimSpace(c.ListSelector) == "" {
+		return errors.New("listSelector is required")
+	}
+	selectors := map[string]string{"listSelector": c.ListSelector, "nextSelector": c.NextSelector}
+	for _, f := range c.fields() {
+		selectors[f.name+"Selector"] = f.selector
+	}
+	for name, selector := range selectors {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", name, selector, err)
+		}
+	}
+	return nil
+}
+
+// Scrape 按选择器抓取列表页条目
+//
+// 首页 HTML 由调用方抓取后传入，后续列表页通过 fetch 抓取（fetch 为 nil 或 MaxPages <= 1 时不翻页）。
+// 条目 URL 和图片 URL 解析为绝对地址，按 URL 去重；缺少标题或链接的条目被跳过。
+// 列表选择器在首页没有匹配时返回 ErrListSelectorNoMatch；字段选择器在首页所有条目中
+// 都没有匹配时记录到 SelectorErrors，不影响其他字段。
+func (e *Extractor) Scrape(ctx context.Context, html, pageURL string, cfg ScrapeConfig, fetch PageFetcher) (*ScrapeResult, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPages := cfg.MaxPages
+	if maxPages <= 0 || fetch == nil {
+		maxPages = 1
+	}
+	maxPages = min(maxPages, MaxPagesLimit)
+
+	result := &ScrapeResult{Items: []ScrapeItem{}}
+	seen := map[string]bool{}
+	target := newPaginationTarget(base)
+	visited := map[string]bool{normalizePageURL(base): true}
+
+	for {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(e.preprocess(html)))
+		if err != nil {
+			return nil, err
+		}
+		items := doc.Find(cfg.ListSelector)
+		if result.Pages == 0 {
+			if items.Length() == 0 {
+				return nil, fmt.Errorf("%w: %q", ErrListSelectorNoMatch, cfg.ListSelector)
+			}
+			result.SelectorErrors = validateScrapeFields(items, cfg)
+		}
+		result.Pages++
+		result.PageURLs = append(result.PageURLs, base.String())
+
+		items.Each(func(_ int, item *goquery.Selection) {
+			if it, ok := e.scrapeItem(item, base, cfg); ok && !seen[it.URL] {
+				seen[it.URL] = true
+				result.Items = append(result.Items, it)
+			}
+		})
+
+		if result.Pages >= maxPages || ctx.Err() != nil {
+			break
+		}
+		nextURL := nextListPage(doc, base, cfg.NextSelector, target, visited)
+		if nextURL == "" {
+			break
+		}
+		visited[nextURL] = true
+
+		nextHTML, finalURL, err := fetch(ctx, nextURL)
+		if err != nil {
+			log.Printf("[Scrape] 抓取列表分页失败 %s: %v", nextURL, err)
+			break
+		}
+		if finalURL == "" {
+			finalURL = nextURL
+		}
+		if base, err = url.Parse(finalURL); err != nil {
+			break
+		}
+		visited[normalizePageURL(base)] = true
+		html = nextHTML
+	}
+	return result, nil
+}
+
+// nextListPage 查找下一个列表页
+func nextListPage(doc *goquery.Document, base *url.URL, nextSelector string, target paginationTarget, visited map[string]bool) string {
+	if nextSelector == "" {
+		return findNextPage(doc, base, target, visited)
+	}
+	href := strings.TrimSpace(doc.Find(nextSelector).First().AttrOr("href", ""))
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	next, err := base.Parse(href)
+	if err != nil || (next.Scheme != "http" && next.Scheme != "https") {
+		return ""
+	}
+	normalized := normalizePageURL(next)
+	if visited[normalized] {
+		return ""
+	}
+	return normalized
+}
+
+// validateScrapeFields 找出在所有列表项中都没有匹配的字段选择器
+func validateScrapeFields(items *goquery.Selection, cfg ScrapeConfig) []SelectorError {
+	var errs []SelectorError
+	for _, f := range cfg.fields() {
+		matched := false
+		items.EachWithBreak(func(_ int, item *goquery.Selection) bool {
+			matched = item.Find(f.selector).Length() > 0
+			return !matched
+		})
+		if !matched {
+			errs = append(errs, SelectorError{
+				Field:    f.name,
+				Selector: f.selector,
+				Message:  fmt.Sprintf("%sSelector matched nothing in %d items", f.name, items.Length()),
+			})
+		}
+	}
+	return errs
+}
+
+// scrapeItem 提取单个列表项
+func (e *Extractor) scrapeItem(item *goquery.Selection, base *url.URL, cfg ScrapeConfig) (ScrapeItem, bool) {
+	var it ScrapeItem
+
+	// 链接
+	link := item.Find("a[href]").First()
+	if cfg.LinkSelector != "" {
+		link = item.Find(cfg.LinkSelector).First()
+	} else if goquery.NodeName(item) == "a" {
+		link = item
+	}
+	href := strings.TrimSpace(link.AttrOr(firstNonEmpty(cfg.LinkAttr, "href"), ""))
+	if href == "" || strings.HasPrefix(href, "#") {
+		return it, false
+	}
+	u, err := base.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return it, false
+	}
+	it.URL = NormalizeURL(u)
+
+	// 标题
+	if cfg.TitleSelector != "" {
+		it.Title = selectorValue(item.Find(cfg.TitleSelector).First(), cfg.TitleAttr)
+	} else {
+		it.Title = firstNonEmpty(selectorValue(link, ""), link.AttrOr("title", ""))
+	}
+	if it.Title == "" {
+		return it, false
+	}
+
+	if selector := firstNonEmpty(cfg.SummarySelector, cfg.ContentSelector); selector != "" {
+		it.Summary = selectorValue(item.Find(selector).First(), cfg.SummaryAttr)
+	}
+	if cfg.ImageSelector != "" {
+		img := item.Find(cfg.ImageSelector).First()
+		src := strings.TrimSpace(img.AttrOr(firstNonEmpty(cfg.ImageAttr, "src"), ""))
+		if src == "" && cfg.ImageAttr == "" {
+			// 选择器命中的是 <source> / <picture> 时取 srcset 的第一项
+			if fields := strings.Fields(img.AttrOr("srcset", "")); len(fields) > 0 {
+				src = fields[0]
+			}
+		}
+		if imgURL, err := base.Parse(src); src != "" && err == nil {
+			it.ImageURL = imgURL.String()
+		}
+	}
+	if cfg.AuthorSelector != "" {
+		it.Author = selectorValue(item.Find(cfg.AuthorSelector).First(), cfg.AuthorAttr)
+	}
+	if cfg.DateSelector != "" {
+		s := item.Find(cfg.DateSelector).First()
+		if cfg.DateAttr != "" {
+			it.Date = strings.TrimSpace(s.AttrOr(cfg.DateAttr, ""))
+		} else {
+			it.Date = firstNonEmpty(s.AttrOr("datetime", ""), s.AttrOr("content", ""), selectorValue(s, ""))
+		}
+		if d := e.dateExtractor.parseAs(it.Date, DateSourceText); d != nil {
+			it.PublishedAt = &d.Time
+		}
+	}
+	return it, true
+}
+
+// selectorValue 读取元素的属性值（attrName 为空时读取折叠空白后的文本）
+func selectorValue(s *goquery.Selection, attrName string) string {
+	if s.Length() == 0 {
+		return ""
+	}
+	if attrName != "" {
+		return strings.TrimSpace(s.AttrOr(attrName, ""))
+	}
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
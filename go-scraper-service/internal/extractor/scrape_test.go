@@ -0,0 +1,87 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScrape(t *testing.T) {
+	listPage := func(page int, next string) string {
+		var b strings.Builder
+		b.WriteString(`<html><body><ul class="news">`)
+		for i := 1; i <= 3; i++ {
+			id := (page-1)*3 + i
+			fmt.Fprintf(&b, `<li class="item"><h3><a href="/news/%d.html?utm_source=list">Story %d</a></h3>
+				<p class="desc">Summary %d</p><img data-src="/img/%d.jpg"><span class="time">2026-03-0%d 08:00</span></li>`, id, id, id, id, i)
+		}
+		// 与第一页重复的条目
+		b.WriteString(`<li class="item"><h3><a href="/news/1.html">Story 1</a></h3></li></ul>`)
+		if next != "" {
+			b.WriteString(`<a class="next" href="` + next + `">下一页</a>`)
+		}
+		b.WriteString(`</body></html>`)
+		return b.String()
+	}
+
+	pages := map[string]string{"https://example.com/list?page=2": listPage(2, "")}
+	fetch := func(_ context.Context, pageURL string) (string, string, error) {
+		if html, ok := pages[pageURL]; ok {
+			return html, pageURL, nil
+		}
+		return "", "", errors.New("not found")
+	}
+
+	e := New()
+	e.SetDefaultTimezone(time.FixedZone("CST", 8*3600))
+	cfg := ScrapeConfig{
+		ListSelector:    "li.item",
+		TitleSelector:   "h3",
+		LinkSelector:    "h3 a",
+		ContentSelector: ".desc",
+		ImageSelector:   "img",
+		DateSelector:    ".time",
+		AuthorSelector:  ".author",
+		MaxPages:        3,
+	}
+
+	t.Run("翻页与去重", func(t *testing.T) {
+		result, err := e.Scrape(context.Background(), listPage(1, "/list?page=2"), "https://example.com/list", cfg, fetch)
+		if err != nil {
+			t.Fatalf("Scrape() error = %v", err)
+		}
+		if result.Pages != 2 || len(result.Items) != 6 {
+			t.Fatalf("Pages = %d, Items = %d", result.Pages, len(result.Items))
+		}
+		first := result.Items[0]
+		if first.URL != "https://example.com/news/1.html" || first.Title != "Story 1" || first.Summary != "Summary 1" ||
+			first.ImageURL != "https://example.com/img/1.jpg" {
+			t.Errorf("Items[0] = %+v", first)
+		}
+		if first.PublishedAt == nil || !first.PublishedAt.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("PublishedAt = %v", first.PublishedAt)
+		}
+		if len(result.SelectorErrors) != 1 || result.SelectorErrors[0].Field != "author" {
+			t.Errorf("SelectorErrors = %+v", result.SelectorErrors)
+		}
+	})
+
+	t.Run("列表选择器无匹配", func(t *testing.T) {
+		bad := cfg
+		bad.ListSelector = ".missing"
+		if _, err := e.Scrape(context.Background(), listPage(1, ""), "https://example.com/list", bad, nil); !errors.Is(err, ErrListSelectorNoMatch) {
+			t.Errorf("error = %v, want ErrListSelectorNoMatch", err)
+		}
+	})
+
+	t.Run("无效选择器", func(t *testing.T) {
+		bad := cfg
+		bad.TitleSelector = "h3["
+		if err := bad.Validate(); err == nil {
+			t.Error("Validate() 应返回错误")
+		}
+	})
+}
@@ -0,0 +1,233 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现离线分词（中文词典最大匹配 + 文档内新词发现，英文按单词）和停用词
+
+package extractor
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxWordLen 中文词典词和新词的最大长度（字）
+const maxWordLen = 6
+
+// Token 分词结果
+type Token struct {
+	// 原文形式（英文保留大小写，用于展示关键词）
+	Text string
+	// 比较键（NFKC、小写）
+	Key string
+	// 是否为中日韩词
+	CJK bool
+}
+
+// Segmenter 分词器
+//
+// 中文按词典（内置常用词 + 文档内发现的新词）做动态规划切分，优先较长的词，
+// 不在词典中的字单独成词；字母 / 数字连续部分为一个词。标点和空白不产生词。
+type Segmenter struct {
+	words map[string]bool
+}
+
+// NewSegmenter 创建分词器，并从 text 中发现重复出现的新词（人名、机构名、术语等）加入词典
+func NewSegmenter(text string) *Segmenter {
+	s := &Segmenter{words: map[string]bool{}}
+	s.words = s.discoverWords(text)
+	return s
+}
+
+// Segment 分词
+func (s *Segmenter) Segment(text string) []Token {
+	var tokens []Token
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case isCJK(r) && unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && isCJK(runes[j]) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			for _, w := range s.segmentCJK(runes[i:j]) {
+				tokens = append(tokens, Token{Text: w, Key: w, CJK: true})
+			}
+			i = j
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			j := i
+			for j < len(runes) && isWordRune(runes, j) {
+				j++
+			}
+			word := string(runes[i:j])
+			tokens = append(tokens, Token{Text: word, Key: strings.ReplaceAll(strings.ToLower(norm.NFKC.String(word)), "’", "'")})
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// isWordRune 判断字母 / 数字词的组成字符（词内的 ' - . 连接符也算，如 don't、e-mail、3.5）
+func isWordRune(runes []rune, i int) bool {
+	r := runes[i]
+	if isCJK(r) {
+		return false
+	}
+	if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r) {
+		return true
+	}
+	if (r == '\'' || r == '’' || r == '-' || r == '.') && i > 0 && i+1 < len(runes) {
+		prev, next := runes[i-1], runes[i+1]
+		return (unicode.IsLetter(prev) || unicode.IsNumber(prev)) && (unicode.IsLetter(next) || unicode.IsNumber(next)) && !isCJK(next)
+	}
+	return false
+}
+
+// segmentCJK 对连续的中日韩字符做动态规划切分：每个词得分为长度的平方，取总分最高的切分
+func (s *Segmenter) segmentCJK(runes []rune) []string {
+	n := len(runes)
+	score := make([]int, n+1)
+	prev := make([]int, n+1)
+	for j := 1; j <= n; j++ {
+		score[j], prev[j] = score[j-1]+1, j-1
+		for i := max(0, j-maxWordLen); i < j-1; i++ {
+			if w := string(runes[i:j]); s.isWord(w) {
+				if sc := score[i] + (j-i)*(j-i); sc > score[j] {
+					score[j], prev[j] = sc, i
+				}
+			}
+		}
+	}
+	var words []string
+	for j := n; j > 0; j = prev[j] {
+		words = append(words, string(runes[prev[j]:j]))
+	}
+	for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+		words[i], words[j] = words[j], words[i]
+	}
+	return words
+}
+
+// isWord 是否为词典词（内置词、停用词或文档内的新词）
+func (s *Segmenter) isWord(w string) bool {
+	return zhWords[w] || zhStopwords[w] || s.words[w]
+}
+
+// discoverWords 发现文档内重复出现的中文新词
+//
+// 先按词典切分，词典外的字会被切为单字；连续的非停用词单字中出现至少两次的 2-6 字片段为候选，
+// 排除总是作为某个长一字的候选的一部分出现的片段（如只出现在"张小明"中的"小明"），
+// 即只保留重复出现的最长片段。新词不会跨越词典词的边界。
+func (s *Segmenter) discoverWords(text string) map[string]bool {
+	counts := map[string]int{}
+	var run []string
+	flush := func() {
+		for i := range run {
+			for n := 2; n <= maxWordLen && i+n <= len(run); n++ {
+				counts[strings.Join(run[i:i+n], "")]++
+			}
+		}
+		run = run[:0]
+	}
+	for _, t := range s.Segment(norm.NFKC.String(text)) {
+		if t.CJK && utf8.RuneCountInString(t.Key) == 1 && !zhStopwords[t.Key] {
+			run = append(run, t.Key)
+		} else {
+			flush()
+		}
+	}
+	flush()
+
+	candidates := map[string]int{}
+	for w, c := range counts {
+		if c >= 2 {
+			candidates[w] = c
+		}
+	}
+	covered := map[string]bool{}
+	for w, c := range candidates {
+		runes := []rune(w)
+		if len(runes) < 3 {
+			continue
+		}
+		for _, part := range []string{string(runes[:len(runes)-1]), string(runes[1:])} {
+			if candidates[part] == c {
+				covered[part] = true
+			}
+		}
+	}
+	words := map[string]bool{}
+	for w := range candidates {
+		if !covered[w] {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// IsStopword 是否为停用词（中文虚词、常见无实义词，英文停用词）
+func IsStopword(key string) bool {
+	return zhStopwords[key] || enStopwords[key]
+}
+
+// wordSet 将空白分隔的词表转为集合
+func wordSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// zhStopwords 中文停用词（虚词、代词、常见无实义的动词和副词）
+var zhStopwords = wordSet(`
+的 了 是 在 和 与 及 或 也 都 就 而 被 把 对 从 为 以 于 将 等 这 那 其 之 着 过 吗 呢 吧 啊 呀 哦 嗯
+并 但 又 还 已 很 所 由 让 给 向 到 说 个 一 不 有 没 要 会 能 可 去 来 上 下 中 里 后 前 再 更 最 太 只
+我 你 他 她 它 我们 你们 他们 她们 它们 自己 大家 咱们 人家 别人 什么 怎么 怎样 为什么 哪 哪里 哪些 谁
+这个 那个 这些 那些 这样 那样 这里 那里 这种 那种 这么 那么 此 此外 其中 其他 其它 其实 另外 另一方面
+因为 所以 因此 但是 可是 然而 而且 并且 或者 还是 如果 假如 即使 虽然 尽管 不过 于是 然后 接着 同时
+以及 以来 以后 以前 之后 之前 之间 之中 当中 通过 根据 按照 关于 对于 由于 为了 除了 随着 作为 成为
+已经 正在 曾经 一直 一些 一个 一种 一样 一起 一定 一般 一切 一下 有些 有的 所有 每个 各种 各个 任何
+可以 可能 能够 应该 需要 必须 不能 不会 不要 没有 还有 只有 只是 就是 也是 都是 不是 还是 即是
+进行 表示 认为 指出 称 据 据悉 据了解 据介绍 介绍 报道 记者 消息 目前 现在 今天 昨天 明天 今年 去年
+非常 十分 特别 比较 相当 更加 越 最近 近日 日前 当时 此时 同样 其次 首先 最后 总之 例如 比如
+这是 那是 不仅 而是 就是说 也就是 甚至 以上 以下 方面 情况 问题 工作 时候 时间 地方 东西 部分
+`)
+
+// zhWords 内置中文常用词（辅助切分边界，领域术语和专名主要依靠新词发现）
+var zhWords = wordSet(`
+中国 美国 日本 韩国 英国 法国 德国 俄罗斯 印度 欧洲 亚洲 非洲 全球 世界 国际 国家 国内 国外 地区 城市 农村
+北京 上海 广州 深圳 香港 澳门 台湾 天津 重庆 杭州 南京 武汉 成都 西安 全国 全市 全省 本市 各地 当地
+政府 部门 机构 企业 公司 集团 银行 学校 大学 医院 市场 行业 产业 社会 经济 政治 文化 科技 教育 医疗 健康
+发展 建设 改革 创新 管理 服务 生产 销售 投资 贸易 合作 竞争 增长 下降 提高 降低 增加 减少 扩大 推动 促进
+数据 信息 技术 产品 项目 计划 政策 措施 规定 法律 制度 标准 系统 平台 网络 互联网 人工智能 模型 算法 软件
+硬件 芯片 手机 电脑 汽车 电动车 新能源 能源 电力 石油 天然气 环境 环保 气候 天气 气温 降雨 暴雨 台风 地震
+交通 道路 铁路 高铁 地铁 航空 机场 航班 旅游 消费 价格 收入 工资 就业 失业 人口 家庭 儿童 老人 学生 教师
+患者 医生 疫情 病毒 疫苗 药品 研究 研发 科学 科学家 专家 学者 调查 分析 报告 结果 影响 原因 目标 任务
+总统 主席 总理 部长 市长 官员 领导 代表 会议 会谈 访问 外交 军事 安全 战争 冲突 和平 协议 谈判 选举
+公布 发布 宣布 召开 举行 启动 实施 执行 完成 开始 结束 继续 加强 支持 反对 要求 提出 回应 确认 发现
+股市 股票 基金 债券 利率 汇率 美元 人民币 通胀 金融 资本 资金 融资 上市 营收 利润 亏损 财报 季度 年度
+生产总值 国内生产总值 增加值 零售总额 失业率 贡献率 百分点 发言人 新闻发言人 第一产业 第二产业 第三产业
+同比 环比 百分之 亿元 万元 千米 公里 小时 分钟 上午 下午 晚上 凌晨 周一 周二 周三 周四 周五 周六 周日
+时期 阶段 过程 方式 方法 能力 水平 质量 效率 规模 速度 范围 领域 内容 用户 客户 员工 团队 公众 市民 居民
+网友 观众 读者 作者 媒体 新闻 文章 视频 图片 照片 直播 应用 游戏 电影 音乐 体育 比赛 冠军 球队
+`)
+
+// enStopwords 英文停用词
+var enStopwords = wordSet(`
+a about above after again against all also am an and any are aren't as at be because been before being below
+between both but by can can't cannot could couldn't did didn't do does doesn't doing don't down during each
+few for from further had hadn't has hasn't have haven't having he he'd he'll he's her here here's hers herself
+him himself his how how's i i'd i'll i'm i've if in into is isn't it it's its itself just let's me more most
+mustn't my myself no nor not now of off on once only or other ought our ours ourselves out over own same shan't
+she she'd she'll she's should shouldn't so some such than that that's the their theirs them themselves then
+there there's these they they'd they'll they're they've this those through to too under until up very was
+wasn't we we'd we'll we're we've were weren't what what's when when's where where's which while who who's whom
+why why's will with won't would wouldn't you you'd you'll you're you've your yours yourself yourselves
+said says say according also however may might must new one two three first last year years many much
+like get got make made well even still yet us via per mr mrs ms dr
+`)
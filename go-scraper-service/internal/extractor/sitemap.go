@@ -0,0 +1,357 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现 sitemap 解析和遍历
+
+package extractor
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotSitemap 内容不是可识别的 sitemap
+var ErrNotSitemap = errors.New("not a sitemap")
+
+// sitemap 遍历限制
+const (
+	DefaultMaxSitemaps = 50  // 默认最多抓取的 sitemap 文件数
+	MaxSitemapsLimit   = 500 // 最多抓取的 sitemap 文件数上限
+	// maxSitemapSize 解压后的 sitemap 大小上限（协议规定单个文件不超过 50MB）
+	maxSitemapSize = 50 << 20
+)
+
+// SitemapEntry sitemap 条目（sitemapindex 中为子 sitemap）
+type SitemapEntry struct {
+	URL        string         `json:"url"`
+	LastMod    *time.Time     `json:"lastmod,omitempty"`
+	ChangeFreq string         `json:"changefreq,omitempty"`
+	Priority   float64        `json:"priority,omitempty"`
+	News       *SitemapNews   `json:"news,omitempty"`   // Google News 扩展（news:news）
+	Images     []SitemapImage `json:"images,omitempty"` // 图片扩展（image:image）
+}
+
+// SitemapNews Google News sitemap 扩展
+type SitemapNews struct {
+	Title           string     `json:"title,omitempty"`
+	PublicationName string     `json:"publicationName,omitempty"`
+	Language        string     `json:"language,omitempty"`
+	PublishedAt     *time.Time `json:"publishedAt,omitempty"`
+	Keywords        []string   `json:"keywords,omitempty"`
+}
+
+// SitemapImage 图片 sitemap 扩展
+type SitemapImage struct {
+	URL     string `json:"url"`
+	Title   string `json:"title,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// Sitemap 解析后的 sitemap
+type Sitemap struct {
+	Index   bool           `json:"index"` // sitemapindex：条目为子 sitemap
+	Entries []SitemapEntry `json:"entries"`
+}
+
+// LastModified 条目中最新的 lastmod
+func (s *Sitemap) LastModified() *time.Time {
+	var latest *time.Time
+	for _, entry := range s.Entries {
+		if entry.LastMod != nil && (latest == nil || entry.LastMod.After(*latest)) {
+			latest = entry.LastMod
+		}
+	}
+	return latest
+}
+
+// ParseSitemap 解析 sitemap
+//
+// 支持 urlset / sitemapindex、gzip 压缩的 sitemap（.xml.gz）和每行一个 URL 的文本 sitemap，
+// 以及 news:、image: 扩展。XML 与订阅源共用宽松解析（见 ParseFeed）。
+// 条目 URL 解析为绝对地址，只保留 http / https 链接。内容不是 sitemap 时返回 ErrNotSitemap。
+func (e *Extractor) ParseSitemap(body, sitemapURL string) (*Sitemap, error) {
+	base, err := url.Parse(sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(body, "\x1f\x8b") {
+		if body, err = gunzipString(body); err != nil {
+			return nil, err
+		}
+	}
+	body = strings.TrimSpace(decodeFeedBody(body, ""))
+	if !strings.HasPrefix(body, "<") {
+		return textSitemap(body, base)
+	}
+
+	root := parseXMLTree(repairXML(body))
+	if root == nil {
+		return nil, ErrNotSitemap
+	}
+	sitemap := &Sitemap{Entries: []SitemapEntry{}}
+	entryName := "url"
+	switch root.local {
+	case "urlset":
+	case "sitemapindex":
+		sitemap.Index = true
+		entryName = "sitemap"
+	default:
+		return nil, ErrNotSitemap
+	}
+	for _, n := range root.children {
+		if n.local != entryName {
+			continue
+		}
+		if entry, ok := e.sitemapEntry(n, base); ok {
+			sitemap.Entries = append(sitemap.Entries, entry)
+		}
+	}
+	return sitemap, nil
+}
+
+// sitemapEntry 解析 <url> / <sitemap> 条目
+func (e *Extractor) sitemapEntry(n *xmlNode, base *url.URL) (SitemapEntry, bool) {
+	entry := SitemapEntry{
+		URL:        resolveFeedURL(base, n.childValue("loc")),
+		LastMod:    e.feedDate(n.childValue("lastmod")),
+		ChangeFreq: strings.ToLower(n.childValue("changefreq")),
+	}
+	if entry.URL == "" {
+		return entry, false
+	}
+	if p, err := strconv.ParseFloat(n.childValue("priority"), 64); err == nil {
+		entry.Priority = p
+	}
+
+	if news := n.child("news:news"); news != nil {
+		publication := news.child("news:publication")
+		entry.News = &SitemapNews{
+			Title:       feedText(news.child("news:title").markup()),
+			PublishedAt: e.feedDate(news.childValue("news:publication_date")),
+		}
+		if publication != nil {
+			entry.News.PublicationName = publication.childValue("news:name")
+			entry.News.Language = publication.childValue("news:language")
+		}
+		keywords := strings.FieldsFunc(news.childValue("news:keywords"), func(r rune) bool { return r == ',' || r == '，' })
+		entry.News.Keywords = compactStrings(keywords)
+	}
+
+	for _, img := range n.all("image:image") {
+		if u := resolveFeedURL(base, img.childValue("image:loc")); u != "" {
+			entry.Images = append(entry.Images, SitemapImage{
+				URL:     u,
+				Title:   feedText(img.child("image:title").markup()),
+				Caption: feedText(img.child("image:caption").markup()),
+			})
+		}
+	}
+	return entry, true
+}
+
+// textSitemap 解析文本 sitemap（每行一个 URL）
+func textSitemap(body string, base *url.URL) (*Sitemap, error) {
+	sitemap := &Sitemap{Entries: []SitemapEntry{}}
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if !looksLikeURL(line) {
+			continue
+		}
+		if u := resolveFeedURL(base, line); u != "" {
+			sitemap.Entries = append(sitemap.Entries, SitemapEntry{URL: u})
+		}
+	}
+	if len(sitemap.Entries) == 0 {
+		return nil, ErrNotSitemap
+	}
+	return sitemap, nil
+}
+
+// gunzipString 解压 gzip 内容（超过 maxSitemapSize 的部分被截断）
+func gunzipString(body string) (string, error) {
+	r, err := gzip.NewReader(strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, maxSitemapSize))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RobotsSitemaps 返回 robots.txt 中声明的 sitemap 地址
+func RobotsSitemaps(robots string, base *url.URL) []string {
+	var sitemaps []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(robots))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "sitemap") {
+			continue
+		}
+		if i := strings.IndexByte(value, '#'); i >= 0 {
+			value = value[:i]
+		}
+		if u := resolveFeedURL(base, value); u != "" && !seen[u] {
+			seen[u] = true
+			sitemaps = append(sitemaps, u)
+		}
+	}
+	return sitemaps
+}
+
+// sitemapRoots 站点的入口 sitemap：robots.txt 中声明的地址，未声明时为 /sitemap.xml
+func sitemapRoots(ctx context.Context, site *url.URL, fetch PageFetcher) []string {
+	root := &url.URL{Scheme: site.Scheme, Host: site.Host, Path: "/"}
+	if fetch != nil {
+		if robots, _, err := fetch(ctx, root.ResolveReference(&url.URL{Path: "/robots.txt"}).String()); err == nil {
+			if declared := RobotsSitemaps(robots, root); len(declared) > 0 {
+				return declared
+			}
+		}
+	}
+	return []string{root.ResolveReference(&url.URL{Path: "/sitemap.xml"}).String()}
+}
+
+// SitemapOptions sitemap 遍历选项
+type SitemapOptions struct {
+	// Since 只返回 lastmod（或新闻发布时间）不早于该时间的 URL；没有日期的 URL 保留。
+	// sitemapindex 中 lastmod 早于该时间的子 sitemap 不再抓取。
+	Since *time.Time
+	// Limit 最多返回的 URL 数，<= 0 时不限制
+	Limit int
+	// MaxSitemaps 最多抓取的 sitemap 文件数，<= 0 时使用 DefaultMaxSitemaps，上限 MaxSitemapsLimit
+	MaxSitemaps int
+}
+
+// SitemapURL 遍历得到的 URL
+type SitemapURL struct {
+	SitemapEntry
+	Sitemap string `json:"sitemap"` // 所在的 sitemap
+}
+
+// SitemapError 单个 sitemap 的抓取或解析错误
+type SitemapError struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// SitemapSummary 遍历汇总
+type SitemapSummary struct {
+	Sitemaps  int            `json:"sitemaps"`            // 抓取的 sitemap 文件数
+	URLs      int            `json:"urls"`                // 返回的 URL 数
+	Skipped   int            `json:"skipped"`             // 按 lastmod 过滤掉的 URL 数
+	Truncated bool           `json:"truncated,omitempty"` // 达到 Limit 或 MaxSitemaps 后提前结束
+	Errors    []SitemapError `json:"errors,omitempty"`
+}
+
+// WalkSitemaps 遍历 sitemap，逐个回调发现的 URL
+//
+// sitemapURL 为站点根地址（路径为空或 "/"）时从 robots.txt 声明的 sitemap 开始（见 sitemapRoots）。
+// sitemapindex 按广度优先递归展开，已抓取的 sitemap 不会重复抓取；同一 URL 只回调一次。
+// 子 sitemap 位于其他站点（且不是 robots.txt 声明的主机）时跳过并记录到 Errors。
+// 单个 sitemap 失败时记录到 Errors 并继续；emit 返回错误（如客户端断开）或 ctx 取消时立即返回。
+func (e *Extractor) WalkSitemaps(ctx context.Context, sitemapURL string, fetch PageFetcher, opts SitemapOptions, emit func(SitemapURL) error) (*SitemapSummary, error) {
+	start, err := url.Parse(sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	maxSitemaps := opts.MaxSitemaps
+	if maxSitemaps <= 0 {
+		maxSitemaps = DefaultMaxSitemaps
+	}
+	maxSitemaps = min(maxSitemaps, MaxSitemapsLimit)
+
+	queue := []string{sitemapURL}
+	if start.Path == "" || start.Path == "/" {
+		queue = sitemapRoots(ctx, start, fetch)
+	}
+	// 子 sitemap 只允许同站点，或 robots.txt 声明的入口 sitemap 所在主机（如 CDN）
+	allowedHosts := map[string]bool{}
+	for _, root := range queue {
+		if u, err := url.Parse(root); err == nil {
+			allowedHosts[strings.ToLower(u.Hostname())] = true
+		}
+	}
+	summary := &SitemapSummary{}
+	visited := map[string]bool{}
+	seen := map[string]bool{}
+
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		if summary.Sitemaps >= maxSitemaps {
+			summary.Truncated = true
+			break
+		}
+		visited[current] = true
+		summary.Sitemaps++
+
+		body, finalURL, err := fetch(ctx, current)
+		if err != nil {
+			summary.Errors = append(summary.Errors, SitemapError{URL: current, Error: err.Error()})
+			continue
+		}
+		if finalURL == "" {
+			finalURL = current
+		}
+		sitemap, err := e.ParseSitemap(body, finalURL)
+		if err != nil {
+			summary.Errors = append(summary.Errors, SitemapError{URL: current, Error: err.Error()})
+			continue
+		}
+
+		if sitemap.Index {
+			for _, entry := range sitemap.Entries {
+				if opts.Since != nil && entry.LastMod != nil && entry.LastMod.Before(*opts.Since) {
+					continue
+				}
+				if u, err := url.Parse(entry.URL); err != nil || !(sameSite(u.Hostname(), start.Hostname()) || allowedHosts[strings.ToLower(u.Hostname())]) {
+					summary.Errors = append(summary.Errors, SitemapError{URL: entry.URL, Error: "off-site sitemap skipped"})
+					continue
+				}
+				queue = append(queue, entry.URL)
+			}
+			continue
+		}
+		for _, entry := range sitemap.Entries {
+			if seen[entry.URL] {
+				continue
+			}
+			seen[entry.URL] = true
+			if opts.Since != nil {
+				date := entry.LastMod
+				if date == nil && entry.News != nil {
+					date = entry.News.PublishedAt
+				}
+				if date != nil && date.Before(*opts.Since) {
+					summary.Skipped++
+					continue
+				}
+			}
+			if err := emit(SitemapURL{SitemapEntry: entry, Sitemap: finalURL}); err != nil {
+				return summary, err
+			}
+			summary.URLs++
+			if opts.Limit > 0 && summary.URLs >= opts.Limit {
+				summary.Truncated = true
+				return summary, nil
+			}
+		}
+	}
+	return summary, nil
+}
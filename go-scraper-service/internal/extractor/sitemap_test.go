@@ -0,0 +1,189 @@
+package extractor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSitemap(t *testing.T) {
+	e := New()
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(`<urlset><url><loc>https://example.com/gz</loc></url></urlset>`))
+	w.Close()
+
+	tests := []struct {
+		name    string
+		body    string
+		index   bool
+		urls    []string
+		wantErr error
+	}{
+		{"urlset", `<urlset><url><loc>/a</loc><lastmod>2026-03-01T08:00:00Z</lastmod></url><url><loc>javascript:void(0)</loc></url></urlset>`, false, []string{"https://example.com/a"}, nil},
+		{"sitemapindex", `<sitemapindex><sitemap><loc>https://example.com/s1.xml</loc></sitemap></sitemapindex>`, true, []string{"https://example.com/s1.xml"}, nil},
+		{"gzip", gz.String(), false, []string{"https://example.com/gz"}, nil},
+		{"文本 sitemap", "https://example.com/1\n\n# comment\nhttps://example.com/2\r\n", false, []string{"https://example.com/1", "https://example.com/2"}, nil},
+		{"非 sitemap", `<rss><channel/></rss>`, false, nil, ErrNotSitemap},
+		{"空文本", "hello", false, nil, ErrNotSitemap},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sitemap, err := e.ParseSitemap(tt.body, "https://example.com/sitemap.xml")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var urls []string
+			for _, entry := range sitemap.Entries {
+				urls = append(urls, entry.URL)
+			}
+			if sitemap.Index != tt.index || !reflect.DeepEqual(urls, tt.urls) {
+				t.Errorf("Index = %v, URLs = %v", sitemap.Index, urls)
+			}
+		})
+	}
+
+	t.Run("news 与 image 扩展", func(t *testing.T) {
+		body := `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+			<url><loc>https://example.com/news/1</loc><changefreq>Daily</changefreq><priority>0.8</priority>
+				<news:news><news:publication><news:name>示例日报</news:name><news:language>zh-cn</news:language></news:publication>
+					<news:publication_date>2026-03-05T08:00:00+08:00</news:publication_date>
+					<news:title>标题</news:title><news:keywords>经济，政策, 市场</news:keywords></news:news>
+				<image:image><image:loc>/img/1.jpg</image:loc><image:caption>图片说明</image:caption></image:image>
+			</url></urlset>`
+		sitemap, err := e.ParseSitemap(body, "https://example.com/news-sitemap.xml")
+		if err != nil || len(sitemap.Entries) != 1 {
+			t.Fatalf("sitemap = %+v, err = %v", sitemap, err)
+		}
+		entry := sitemap.Entries[0]
+		if entry.ChangeFreq != "daily" || entry.Priority != 0.8 {
+			t.Errorf("ChangeFreq = %q, Priority = %v", entry.ChangeFreq, entry.Priority)
+		}
+		news := entry.News
+		if news == nil || news.PublicationName != "示例日报" || news.Title != "标题" || news.PublishedAt == nil ||
+			!reflect.DeepEqual(news.Keywords, []string{"经济", "政策", "市场"}) {
+			t.Errorf("News = %+v", news)
+		}
+		if want := []SitemapImage{{URL: "https://example.com/img/1.jpg", Caption: "图片说明"}}; !reflect.DeepEqual(entry.Images, want) {
+			t.Errorf("Images = %+v", entry.Images)
+		}
+	})
+}
+
+func TestWalkSitemaps(t *testing.T) {
+	pages := map[string]string{
+		"https://example.com/robots.txt": "Sitemap: https://example.com/index.xml",
+		"https://example.com/index.xml": `<sitemapindex>
+			<sitemap><loc>/posts-2026.xml</loc><lastmod>2026-03-01</lastmod></sitemap>
+			<sitemap><loc>/posts-2020.xml</loc><lastmod>2020-01-01</lastmod></sitemap>
+			<sitemap><loc>/index.xml</loc></sitemap>
+			<sitemap><loc>/missing.xml</loc></sitemap></sitemapindex>`,
+		"https://example.com/posts-2026.xml": `<urlset>
+			<url><loc>/p/1</loc><lastmod>2026-03-01</lastmod></url>
+			<url><loc>/p/2</loc><lastmod>2025-01-01</lastmod></url>
+			<url><loc>/p/3</loc></url>
+			<url><loc>/p/1</loc></url></urlset>`,
+		"https://example.com/posts-2020.xml": `<urlset><url><loc>/old</loc></url></urlset>`,
+	}
+	fetch := func(_ context.Context, pageURL string) (string, string, error) {
+		if body, ok := pages[pageURL]; ok {
+			return body, pageURL, nil
+		}
+		return "", "", errors.New("404")
+	}
+
+	e := New()
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	collect := func(opts SitemapOptions) ([]string, *SitemapSummary) {
+		var urls []string
+		summary, err := e.WalkSitemaps(context.Background(), "https://example.com/", fetch, opts, func(u SitemapURL) error {
+			urls = append(urls, u.URL)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkSitemaps() error = %v", err)
+		}
+		return urls, summary
+	}
+
+	t.Run("按 lastmod 过滤", func(t *testing.T) {
+		urls, summary := collect(SitemapOptions{Since: &since})
+		if want := []string{"https://example.com/p/1", "https://example.com/p/3"}; !reflect.DeepEqual(urls, want) {
+			t.Errorf("urls = %v, want %v", urls, want)
+		}
+		if summary.Sitemaps != 3 || summary.Skipped != 1 || len(summary.Errors) != 1 || summary.Errors[0].URL != "https://example.com/missing.xml" {
+			t.Errorf("summary = %+v", summary)
+		}
+	})
+
+	t.Run("跳过站外子 sitemap", func(t *testing.T) {
+		sites := map[string]string{
+			"https://example.com/robots.txt": "Sitemap: https://cdn.example.net/index.xml",
+			"https://cdn.example.net/index.xml": `<sitemapindex>
+				<sitemap><loc>https://cdn.example.net/posts.xml</loc></sitemap>
+				<sitemap><loc>https://attacker.org/evil.xml</loc></sitemap></sitemapindex>`,
+			"https://cdn.example.net/posts.xml": `<urlset><url><loc>https://example.com/p/1</loc></url></urlset>`,
+			"https://attacker.org/evil.xml":     `<urlset><url><loc>https://attacker.org/p/1</loc></url></urlset>`,
+		}
+		var fetched []string
+		fetchSite := func(_ context.Context, pageURL string) (string, string, error) {
+			fetched = append(fetched, pageURL)
+			if body, ok := sites[pageURL]; ok {
+				return body, pageURL, nil
+			}
+			return "", "", errors.New("404")
+		}
+		var urls []string
+		summary, err := e.WalkSitemaps(context.Background(), "https://example.com/", fetchSite, SitemapOptions{}, func(u SitemapURL) error {
+			urls = append(urls, u.URL)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkSitemaps() error = %v", err)
+		}
+		if want := []string{"https://example.com/p/1"}; !reflect.DeepEqual(urls, want) {
+			t.Errorf("urls = %v, want %v", urls, want)
+		}
+		for _, u := range fetched {
+			if strings.Contains(u, "attacker.org") {
+				t.Errorf("不应抓取站外子 sitemap: %s", u)
+			}
+		}
+		if len(summary.Errors) != 1 || summary.Errors[0].URL != "https://attacker.org/evil.xml" {
+			t.Errorf("summary = %+v", summary)
+		}
+	})
+
+	t.Run("数量限制", func(t *testing.T) {
+		urls, summary := collect(SitemapOptions{Limit: 2})
+		if len(urls) != 2 || !summary.Truncated {
+			t.Errorf("urls = %v, summary = %+v", urls, summary)
+		}
+	})
+
+	t.Run("sitemap 文件数限制", func(t *testing.T) {
+		_, summary := collect(SitemapOptions{MaxSitemaps: 1})
+		if summary.Sitemaps != 1 || summary.URLs != 0 || !summary.Truncated {
+			t.Errorf("summary = %+v", summary)
+		}
+	})
+}
+
+func TestRobotsSitemaps(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+	robots := "User-agent: *\nDisallow: /admin\nsitemap: /sitemap.xml # 主 sitemap\nSitemap: https://cdn.example.com/news.xml\nSitemap: /sitemap.xml\n"
+	want := []string{"https://example.com/sitemap.xml", "https://cdn.example.com/news.xml"}
+	if got := RobotsSitemaps(robots, base); !reflect.DeepEqual(got, want) {
+		t.Errorf("RobotsSitemaps() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,341 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现按站点配置的声明式提取规则
+
+package extractor
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v3"
+)
+
+// siteRulesReloadInterval 规则文件变更检查的最小间隔
+const siteRulesReloadInterval = 5 * time.Second
+
+// SiteRule 单个站点的提取规则
+//
+// 示例（YAML）：
+//
+//	rules:
+//	  - name: example-news
+//	    domains: [example.com]
+//	    pathPatterns: ['^/news/\d+']
+//	    title: h1.article-title
+//	    content: div.article-body
+//	    author: .byline .name
+//	    date: .byline time
+//	    strip: [.share-bar, .related]
+//	    readability: false
+type SiteRule struct {
+	// 规则名称（响应中的 rule 字段）
+	Name string `json:"name" yaml:"name"`
+	// 适用的域名（含子域名，忽略 www.）
+	Domains []string `json:"domains" yaml:"domains"`
+	// URL 路径正则，为空时适用于站点所有页面；配置多个时任一匹配即可
+	PathPatterns []string `json:"pathPatterns,omitempty" yaml:"pathPatterns,omitempty"`
+
+	// 字段选择器（为空时使用 Readability 的结果）
+	Title   string `json:"title,omitempty" yaml:"title,omitempty"`
+	Content string `json:"content,omitempty" yaml:"content,omitempty"`
+	Author  string `json:"author,omitempty" yaml:"author,omitempty"`
+	Date    string `json:"date,omitempty" yaml:"date,omitempty"`
+
+	// 提取前移除的元素（分享栏、相关推荐等）
+	Strip []string `json:"strip,omitempty" yaml:"strip,omitempty"`
+	// 是否在 content 选中的区域上继续运行 Readability（区域内仍有噪声时开启）
+	Readability bool `json:"readability,omitempty" yaml:"readability,omitempty"`
+
+	pathPatterns []*regexp.Regexp
+}
+
+// siteRulesFile 规则文件结构
+type siteRulesFile struct {
+	Rules []*SiteRule `json:"rules" yaml:"rules"`
+}
+
+// SiteRules 站点规则集（支持热加载）
+//
+// 规则文件为 YAML 或 JSON（按扩展名 .json 区分）。匹配时检查文件修改时间
+// （间隔至少 siteRulesReloadInterval），变更后自动重新加载；新文件解析失败时
+// 保留旧规则并记录日志，不影响正在运行的服务。
+type SiteRules struct {
+	path string
+
+	mu        sync.RWMutex
+	rules     []*SiteRule
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// LoadSiteRules 加载规则文件
+func LoadSiteRules(path string) (*SiteRules, error) {
+	s := &SiteRules{path: path}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := parseSiteRules(path)
+	if err != nil {
+		return nil, err
+	}
+	s.rules, s.modTime, s.checkedAt = rules, info.ModTime(), time.Now()
+	log.Printf("[SiteRules] 已加载 %d 条站点规则: %s", len(rules), path)
+	return s, nil
+}
+
+// NewSiteRules 由规则列表创建规则集（不关联文件，不热加载）
+func NewSiteRules(rules []*SiteRule) (*SiteRules, error) {
+	for i, rule := range rules {
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i, rule.Name, err)
+		}
+	}
+	return &SiteRules{rules: rules}, nil
+}
+
+// parseSiteRules 解析并校验规则文件
+func parseSiteRules(path string) ([]*SiteRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file siteRulesFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse site rules %s: %w", path, err)
+	}
+
+	for i, rule := range file.Rules {
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("site rules %s: rule %d (%s): %w", path, i, rule.Name, err)
+		}
+	}
+	return file.Rules, nil
+}
+
+// compile 校验规则并编译路径正则
+func (r *SiteRule) compile() error {
+	if len(r.Domains) == 0 {
+		return fmt.Errorf("domains is required")
+	}
+	if r.Name == "" {
+		r.Name = r.Domains[0]
+	}
+	r.pathPatterns = r.pathPatterns[:0]
+	for _, pattern := range r.PathPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pathPattern %q: %w", pattern, err)
+		}
+		r.pathPatterns = append(r.pathPatterns, re)
+	}
+	for _, selector := range append([]string{r.Title, r.Content, r.Author, r.Date}, r.Strip...) {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.ParseGroup(selector); err != nil {
+			return fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+	}
+	return nil
+}
+
+// matches 判断规则是否适用于 URL
+func (r *SiteRule) matches(u *url.URL) bool {
+	host := u.Hostname()
+	matched := false
+	for _, domain := range r.Domains {
+		domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+		h := strings.TrimPrefix(strings.ToLower(host), "www.")
+		if h == domain || strings.HasSuffix(h, "."+domain) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	if len(r.pathPatterns) == 0 {
+		return true
+	}
+	for _, re := range r.pathPatterns {
+		if re.MatchString(u.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match 查找适用于 URL 的第一条规则
+func (s *SiteRules) Match(u *url.URL) *SiteRule {
+	if s == nil || u == nil {
+		return nil
+	}
+	s.reloadIfChanged()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rule := range s.rules {
+		if rule.matches(u) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Len 返回规则数量
+func (s *SiteRules) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.rules)
+}
+
+// reloadIfChanged 规则文件修改后重新加载
+func (s *SiteRules) reloadIfChanged() {
+	if s.path == "" {
+		return
+	}
+
+	s.mu.Lock()
+	if time.Since(s.checkedAt) < siteRulesReloadInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.checkedAt = time.Now()
+	lastMod := s.modTime
+	s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil || info.ModTime().Equal(lastMod) {
+		return
+	}
+	rules, err := parseSiteRules(s.path)
+	if err != nil {
+		log.Printf("[SiteRules] 重新加载失败，继续使用旧规则: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.rules, s.modTime = rules, info.ModTime()
+	s.mu.Unlock()
+	log.Printf("[SiteRules] 已重新加载 %d 条站点规则: %s", len(rules), s.path)
+}
+
+// siteRuleResult 站点规则提取结果
+type siteRuleResult struct {
+	article  *ReadabilityResult
+	dateText string // date 选择器选中的原始值
+}
+
+// applySiteRule 按站点规则提取
+//
+// 流程：移除 strip 元素 → 按 content 选择器截取正文区域（可选再运行 Readability）
+// → title / author / date 选择器覆盖对应字段。content 选择器未选中任何元素时
+// 在移除 strip 元素后的完整页面上运行 Readability。
+func applySiteRule(pageHTML, pageURL string, rule *SiteRule) (*siteRuleResult, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return nil, err
+	}
+	for _, selector := range rule.Strip {
+		doc.Find(selector).Remove()
+	}
+
+	var article *ReadabilityResult
+	content := doc.Selection.Slice(0, 0)
+	if rule.Content != "" {
+		content = doc.Find(rule.Content)
+	}
+	if content.Length() > 0 {
+		var parts []string
+		content.Each(func(_ int, s *goquery.Selection) {
+			if h, err := goquery.OuterHtml(s); err == nil {
+				parts = append(parts, h)
+			}
+		})
+		contentHTML := strings.Join(parts, "\n")
+
+		if rule.Readability {
+			article, err = ExtractWithReadability("<html><head><title>"+html.EscapeString(doc.Find("title").First().Text())+
+				"</title></head><body>"+contentHTML+"</body></html>", pageURL)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			article = &ReadabilityResult{
+				Title:       strings.TrimSpace(doc.Find("title").First().Text()),
+				Content:     contentHTML,
+				TextContent: strings.TrimSpace(content.Text()),
+			}
+			article.Length = len([]rune(article.TextContent))
+		}
+	} else {
+		if rule.Content != "" {
+			log.Printf("[SiteRules] 规则 %s 的 content 选择器未匹配，回退到 Readability: %s", rule.Name, pageURL)
+		}
+		stripped, err := doc.Html()
+		if err != nil {
+			return nil, err
+		}
+		if article, err = ExtractWithReadability(stripped, pageURL); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &siteRuleResult{article: article}
+	if rule.Title != "" {
+		if title := strings.TrimSpace(doc.Find(rule.Title).First().Text()); title != "" {
+			article.Title = title
+		}
+	}
+	if rule.Author != "" {
+		var authors []string
+		doc.Find(rule.Author).Each(func(_ int, s *goquery.Selection) {
+			if name := strings.TrimSpace(s.Text()); name != "" {
+				authors = append(authors, name)
+			}
+		})
+		if len(authors) > 0 {
+			article.Byline = strings.Join(uniqueStrings(authors), ", ")
+		}
+	}
+	if rule.Date != "" {
+		s := doc.Find(rule.Date).First()
+		result.dateText = firstNonEmpty(s.AttrOr("datetime", ""), s.AttrOr("content", ""), s.Text())
+	}
+	if article.Excerpt == "" {
+		article.Excerpt = excerptOf(article.TextContent)
+	}
+	return result, nil
+}
+
+// excerptOf 取正文前 200 个字符作为摘要
+func excerptOf(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) > 200 {
+		return string(runes[:200]) + "…"
+	}
+	return text
+}
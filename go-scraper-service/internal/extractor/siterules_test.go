@@ -0,0 +1,98 @@
+package extractor
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const siteRulesYAML = `rules:
+  - name: example-news
+    domains: [example.com]
+    pathPatterns: ['^/news/']
+    title: h1.headline
+    content: div.story
+    author: .byline .name
+    date: .byline time
+    strip: [.share]
+`
+
+const siteRulesPage = `<html><head><title>Site | Headline</title></head><body>
+	<aside class="sidebar"><p>Trending: a very long sidebar list that Readability might pick up as content.</p></aside>
+	<h1 class="headline">Real Headline</h1>
+	<div class="byline"><span class="name">Alice</span><time datetime="2026-03-05T06:30:00Z">Mar 5</time></div>
+	<div class="story">
+		<p>Lead paragraph that must be kept.</p>
+		<div class="share">Share on Twitter</div>
+		<p>Second paragraph.</p>
+	</div>
+</body></html>`
+
+func TestSiteRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(siteRulesYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadSiteRules(path)
+	if err != nil {
+		t.Fatalf("LoadSiteRules() error = %v", err)
+	}
+
+	e := New()
+	e.SetSiteRules(rules)
+
+	t.Run("匹配规则", func(t *testing.T) {
+		result, err := e.Extract(siteRulesPage, "https://www.example.com/news/1")
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if result.Rule != "example-news" {
+			t.Errorf("Rule = %q", result.Rule)
+		}
+		if result.Title != "Real Headline" || result.Byline != "Alice" {
+			t.Errorf("Title = %q, Byline = %q", result.Title, result.Byline)
+		}
+		if !strings.Contains(result.Content, "Lead paragraph") || strings.Contains(result.Content, "Share on Twitter") ||
+			strings.Contains(result.Content, "Trending") {
+			t.Errorf("Content = %q", result.Content)
+		}
+		if result.PublishedDate == nil || result.PublishedDate.Source != DateSourceRule ||
+			!result.PublishedDate.Time.Equal(time.Date(2026, 3, 5, 6, 30, 0, 0, time.UTC)) {
+			t.Errorf("PublishedDate = %+v", result.PublishedDate)
+		}
+	})
+
+	t.Run("路径不匹配", func(t *testing.T) {
+		u, _ := url.Parse("https://example.com/about")
+		if rule := rules.Match(u); rule != nil {
+			t.Errorf("Match() = %s, want nil", rule.Name)
+		}
+	})
+
+	t.Run("热加载", func(t *testing.T) {
+		updated := strings.Replace(siteRulesYAML, "example-news", "example-news-v2", 1)
+		if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		future := time.Now().Add(time.Minute)
+		os.Chtimes(path, future, future)
+		rules.checkedAt = time.Time{}
+
+		u, _ := url.Parse("https://example.com/news/2")
+		if rule := rules.Match(u); rule == nil || rule.Name != "example-news-v2" {
+			t.Errorf("Match() after reload = %v", rule)
+		}
+
+		// 无效文件不覆盖已加载的规则
+		os.WriteFile(path, []byte("rules:\n  - name: broken\n"), 0o644)
+		later := future.Add(time.Minute)
+		os.Chtimes(path, later, later)
+		rules.checkedAt = time.Time{}
+		if rule := rules.Match(u); rule == nil || rule.Name != "example-news-v2" {
+			t.Errorf("Match() after invalid reload = %v", rule)
+		}
+	})
+}
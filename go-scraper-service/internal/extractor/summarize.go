@@ -0,0 +1,439 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现离线的抽取式摘要和关键词提取（TextRank）
+
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 摘要默认值
+const (
+	// DefaultSummarySentences 默认摘要句数
+	DefaultSummarySentences = 3
+	// DefaultSummaryKeywords 默认关键词数
+	DefaultSummaryKeywords = 8
+	// MaxSummarySentences 最多摘要句数
+	MaxSummarySentences = 20
+	// MaxSummaryKeywords 最多关键词数
+	MaxSummaryKeywords = 50
+	// MaxSummarizeLength 单独摘要时文本 / HTML 的最大长度（字节）
+	MaxSummarizeLength = 1 << 20
+)
+
+const (
+	// textRankDamping PageRank 阻尼系数
+	textRankDamping = 0.85
+	// maxRankedSentences 参与句子排序的最大句数（按文章顺序取前面的句子，相似度计算为平方复杂度）
+	maxRankedSentences = 300
+	// keywordWindow 关键词共现窗口（过滤停用词后的相邻词数）
+	keywordWindow = 5
+	// minSentenceWords 摘要句的最少实词数
+	minSentenceWords = 3
+)
+
+// sentenceTerminators 句末标点
+const sentenceTerminators = "。！？!?；;…"
+
+// sentenceClosers 句末标点之后仍属于本句的引号和括号
+const sentenceClosers = `”’"'」』）)]`
+
+// enAbbreviations 后面跟句点但不表示句末的英文缩写
+var enAbbreviations = wordSet(`mr mrs ms dr prof st jr sr vs etc inc ltd co corp no fig gen gov sen rep jan feb mar apr jun jul aug sep sept oct nov dec`)
+
+// SummaryOptions 摘要选项
+type SummaryOptions struct {
+	// Sentences 摘要句数，<=0 时使用 DefaultSummarySentences
+	Sentences int
+	// Keywords 关键词数，<=0 时使用 DefaultSummaryKeywords
+	Keywords int
+	// Title 文章标题（可选），与标题相近的句子和标题中的词权重更高
+	Title string
+}
+
+// Keyword 关键词
+type Keyword struct {
+	Word string `json:"word"`
+	// TextRank 得分，以最高分为 1 归一化
+	Score float64 `json:"score"`
+}
+
+// Summary 抽取式摘要
+type Summary struct {
+	// 摘要句按原文顺序拼接
+	Summary string `json:"summary"`
+	// 摘要句（原文顺序）
+	Sentences []string `json:"sentences"`
+	// 关键词（得分从高到低），可作为标签
+	Keywords []Keyword `json:"keywords"`
+}
+
+// rankedSentence 参与排序的句子
+type rankedSentence struct {
+	text  string
+	words map[string]bool
+}
+
+// Summarize 生成抽取式摘要和关键词（不依赖外部模型，可作为 AI 摘要的兜底）
+//
+// 文本按行（TextContent 的段落、列表项）和句末标点分句，跳过 Markdown 风格的标题行。
+// 摘要：句子为节点、实词重合度为边权做 TextRank，随机跳转偏向文章开头和与标题相近的句子
+// （新闻导语），取得分最高的句子按原文顺序输出。
+// 关键词：过滤停用词后的词为节点、窗口内共现为边做 TextRank，标题中的词随机跳转权重更高。
+func Summarize(text string, opts SummaryOptions) *Summary {
+	if opts.Sentences <= 0 {
+		opts.Sentences = DefaultSummarySentences
+	}
+	opts.Sentences = min(opts.Sentences, MaxSummarySentences)
+	if opts.Keywords <= 0 {
+		opts.Keywords = DefaultSummaryKeywords
+	}
+	opts.Keywords = min(opts.Keywords, MaxSummaryKeywords)
+
+	seg := NewSegmenter(opts.Title + "\n" + text)
+	sentences := splitSentences(text)
+	tokens := make([][]Token, len(sentences))
+	for i, s := range sentences {
+		tokens[i] = keywordTokens(seg.Segment(s))
+	}
+	titleTokens := keywordTokens(seg.Segment(opts.Title))
+
+	result := &Summary{
+		Sentences: summarySentences(sentences, tokens, titleTokens, opts.Sentences),
+		Keywords:  extractKeywords(tokens, titleTokens, opts.Keywords),
+	}
+	result.Summary = joinSentences(result.Sentences)
+	return result
+}
+
+// SummarizeContent 为纯文本或正文 HTML（净化后转为结构化纯文本）生成摘要，text 优先
+func SummarizeContent(text, contentHTML string, opts SummaryOptions) (*Summary, error) {
+	if text == "" && contentHTML == "" {
+		return nil, errors.New("text or html is required")
+	}
+	if len(text) > MaxSummarizeLength || len(contentHTML) > MaxSummarizeLength {
+		return nil, fmt.Errorf("content is too long (max %d bytes)", MaxSummarizeLength)
+	}
+	if text == "" {
+		text = HTMLToText(SanitizeHTML(contentHTML))
+	}
+	return Summarize(text, opts), nil
+}
+
+// summarySentences 对句子做 TextRank，返回得分最高的 n 句（原文顺序）
+func summarySentences(sentences []string, tokens [][]Token, titleTokens []Token, n int) []string {
+	var ranked []rankedSentence
+	for i, s := range sentences {
+		if len(ranked) >= maxRankedSentences {
+			break
+		}
+		words := map[string]bool{}
+		for _, t := range tokens[i] {
+			words[t.Key] = true
+		}
+		if len(words) < minSentenceWords {
+			continue
+		}
+		ranked = append(ranked, rankedSentence{text: s, words: words})
+	}
+	if len(ranked) == 0 {
+		return []string{}
+	}
+
+	title := map[string]bool{}
+	for _, t := range titleTokens {
+		title[t.Key] = true
+	}
+	edges := make([][]rankEdge, len(ranked))
+	prior := make([]float64, len(ranked))
+	for i := range ranked {
+		prior[i] = 1 / math.Sqrt(float64(i+1))
+		if len(title) > 0 {
+			prior[i] += 2 * float64(overlap(ranked[i].words, title)) / float64(len(title))
+		}
+		for j := i + 1; j < len(ranked); j++ {
+			if w := sentenceSimilarity(ranked[i].words, ranked[j].words); w > 0 {
+				edges[i] = append(edges[i], rankEdge{to: j, weight: w})
+				edges[j] = append(edges[j], rankEdge{to: i, weight: w})
+			}
+		}
+	}
+	scores := pageRank(edges, prior)
+
+	order := make([]int, len(ranked))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	order = order[:min(n, len(order))]
+	sort.Ints(order)
+
+	result := make([]string, len(order))
+	for i, idx := range order {
+		result[i] = ranked[idx].text
+	}
+	return result
+}
+
+// sentenceSimilarity 句子相似度（TextRank：共有词数 / (log(1+|A|) + log(1+|B|))）
+func sentenceSimilarity(a, b map[string]bool) float64 {
+	common := overlap(a, b)
+	if common == 0 {
+		return 0
+	}
+	return float64(common) / (math.Log(float64(1+len(a))) + math.Log(float64(1+len(b))))
+}
+
+// overlap 两个词集合的共有词数
+func overlap(a, b map[string]bool) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	n := 0
+	for w := range a {
+		if b[w] {
+			n++
+		}
+	}
+	return n
+}
+
+// extractKeywords 对词共现图做 TextRank，返回得分最高的 n 个关键词
+func extractKeywords(tokens [][]Token, titleTokens []Token, n int) []Keyword {
+	index := map[string]int{}
+	var keys []string
+	surfaces := []map[string]int{}
+	node := func(t Token) int {
+		i, ok := index[t.Key]
+		if !ok {
+			i = len(keys)
+			index[t.Key] = i
+			keys = append(keys, t.Key)
+			surfaces = append(surfaces, map[string]int{})
+		}
+		surfaces[i][t.Text]++
+		return i
+	}
+
+	weights := map[[2]int]float64{}
+	for _, sentence := range tokens {
+		ids := make([]int, len(sentence))
+		for i, t := range sentence {
+			ids[i] = node(t)
+		}
+		for i := range ids {
+			for j := i + 1; j < len(ids) && j < i+keywordWindow; j++ {
+				if ids[i] != ids[j] {
+					weights[[2]int{min(ids[i], ids[j]), max(ids[i], ids[j])}]++
+				}
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return []Keyword{}
+	}
+
+	// 边按节点顺序排列，保证浮点累加顺序（以及结果）稳定
+	pairs := make([][2]int, 0, len(weights))
+	for p := range weights {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(a, b int) bool {
+		if pairs[a][0] != pairs[b][0] {
+			return pairs[a][0] < pairs[b][0]
+		}
+		return pairs[a][1] < pairs[b][1]
+	})
+	edges := make([][]rankEdge, len(keys))
+	for _, p := range pairs {
+		edges[p[0]] = append(edges[p[0]], rankEdge{to: p[1], weight: weights[p]})
+		edges[p[1]] = append(edges[p[1]], rankEdge{to: p[0], weight: weights[p]})
+	}
+
+	prior := make([]float64, len(keys))
+	for i := range prior {
+		prior[i] = 1
+	}
+	for _, t := range titleTokens {
+		if i, ok := index[t.Key]; ok {
+			prior[i] = 3
+		}
+	}
+	scores := pageRank(edges, prior)
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	order = order[:min(n, len(order))]
+
+	result := make([]Keyword, len(order))
+	for i, idx := range order {
+		result[i] = Keyword{Word: mostFrequent(surfaces[idx]), Score: math.Round(scores[idx]/scores[order[0]]*1000) / 1000}
+	}
+	return result
+}
+
+// keywordTokens 过滤出可作为关键词的词：非停用词，中文至少两个字，其他至少两个字符且包含字母
+func keywordTokens(tokens []Token) []Token {
+	var result []Token
+	for _, t := range tokens {
+		if IsStopword(t.Key) || utf8.RuneCountInString(t.Key) < 2 {
+			continue
+		}
+		if !t.CJK && strings.IndexFunc(t.Key, unicode.IsLetter) < 0 {
+			continue
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// mostFrequent 出现次数最多的原文形式（次数相同时取字典序最小的，保证结果稳定）
+func mostFrequent(surfaces map[string]int) string {
+	best, bestCount := "", 0
+	for s, c := range surfaces {
+		if c > bestCount || (c == bestCount && s < best) {
+			best, bestCount = s, c
+		}
+	}
+	return best
+}
+
+// rankEdge 带权边
+type rankEdge struct {
+	to     int
+	weight float64
+}
+
+// pageRank 带权、带个性化随机跳转的 PageRank（迭代至收敛），prior 无需归一化
+func pageRank(edges [][]rankEdge, prior []float64) []float64 {
+	n := len(edges)
+	total := 0.0
+	for _, p := range prior {
+		total += p
+	}
+	p := make([]float64, n)
+	for i := range p {
+		p[i] = prior[i] / total
+	}
+	out := make([]float64, n)
+	for i, es := range edges {
+		for _, e := range es {
+			out[i] += e.weight
+		}
+	}
+
+	scores := append([]float64(nil), p...)
+	next := make([]float64, n)
+	for iter := 0; iter < 100; iter++ {
+		dangling := 0.0
+		for i := range next {
+			next[i] = (1 - textRankDamping) * p[i]
+			if out[i] == 0 {
+				dangling += scores[i]
+			}
+		}
+		for i, es := range edges {
+			for _, e := range es {
+				next[e.to] += textRankDamping * scores[i] * e.weight / out[i]
+			}
+		}
+		delta := 0.0
+		for i := range next {
+			next[i] += textRankDamping * dangling * p[i]
+			delta += math.Abs(next[i] - scores[i])
+		}
+		scores, next = next, scores
+		if delta < 1e-6 {
+			break
+		}
+	}
+	return scores
+}
+
+// splitSentences 分句：按行拆分（跳过标题行，去除列表、引用标记），行内按句末标点拆分
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimPrefix(line, "• "), "> "))
+		if i := strings.Index(line, ". "); i > 0 && i <= 3 && strings.IndexFunc(line[:i], func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+			line = strings.TrimSpace(line[i+2:])
+		}
+		sentences = append(sentences, splitLineSentences(line)...)
+	}
+	return sentences
+}
+
+// splitLineSentences 按句末标点拆分一行文本
+//
+// 中文句末标点总是断句；英文句点、问号、叹号之后须为空白或行尾，且句点前不是缩写或单个字母（U.S.、J. K.）。
+func splitLineSentences(line string) []string {
+	var sentences []string
+	runes := []rune(line)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if !strings.ContainsRune(sentenceTerminators, r) && r != '.' {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && (strings.ContainsRune(sentenceTerminators, runes[end]) || strings.ContainsRune(sentenceClosers, runes[end])) {
+			end++
+		}
+		if r < utf8.RuneSelf {
+			if end < len(runes) && !unicode.IsSpace(runes[end]) {
+				continue
+			}
+			if r == '.' && isAbbreviation(runes[start:i]) {
+				continue
+			}
+		}
+		if s := strings.TrimSpace(string(runes[start:end])); s != "" {
+			sentences = append(sentences, s)
+		}
+		start, i = end, end-1
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+// isAbbreviation 句点前的词是否为缩写（常见缩写或单个字母）
+func isAbbreviation(before []rune) bool {
+	i := len(before)
+	for i > 0 && unicode.IsLetter(before[i-1]) {
+		i--
+	}
+	word := strings.ToLower(string(before[i:]))
+	return utf8.RuneCountInString(word) == 1 || enAbbreviations[word]
+}
+
+// joinSentences 拼接摘要句：中文句子之间不加空格
+func joinSentences(sentences []string) string {
+	var b strings.Builder
+	for i, s := range sentences {
+		if i > 0 {
+			last, _ := utf8.DecodeLastRuneInString(sentences[i-1])
+			first, _ := utf8.DecodeRuneInString(s)
+			if !isCJK(last) || !isCJK(first) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
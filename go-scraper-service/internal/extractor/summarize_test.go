@@ -0,0 +1,194 @@
+package extractor
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const summaryArticleZH = `国家统计局10月18日发布数据显示，前三季度国内生产总值同比增长5.2%。
+
+国家统计局新闻发言人表示，前三季度国民经济持续恢复向好，高质量发展稳步推进。分产业看，第一产业增加值增长4.0%，第二产业增加值增长4.4%，第三产业增加值增长6.0%。
+
+• 社会消费品零售总额同比增长6.8%。
+• 全国城镇调查失业率平均值为5.3%。
+
+## 消费
+
+消费对经济增长的贡献率明显提升，最终消费支出拉动国内生产总值增长4.4个百分点。国家统计局认为，新能源汽车、服务消费成为新的增长点。
+
+天气晴朗，街头行人不多。`
+
+const summaryArticleEN = `OpenAI released a new reasoning model on Tuesday, the company said. The model, called o5, outperforms earlier systems on math benchmarks.
+
+Dr. Smith of the U.S. National Science Foundation said the reasoning model could change how researchers approach proofs. "It is a remarkable step," she said.
+
+Critics warned that benchmarks do not capture real-world reliability. OpenAI said the model will be available to developers next month.`
+
+func TestSegmenter(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		input    string
+		want     string
+	}{
+		{"词典词", "", "国家发布新能源汽车政策", "国家/发布/新能源/汽车/政策"},
+		{"重复出现的新词", "张小明说。张小明表示。", "记者采访了张小明", "记者/采/访/了/张小明"},
+		{"新词不跨越词典词", summaryArticleZH, "国家统计局新闻发言人", "国家/统计局/新闻发言人"},
+		{"英文和数字", "", "OpenAI's o5 model scored 92.5% in U.S. tests", "OpenAI's/o5/model/scored/92.5/in/U.S/tests"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range NewSegmenter(tt.document).Segment(tt.input) {
+				got = append(got, tok.Text)
+			}
+			if strings.Join(got, "/") != tt.want {
+				t.Errorf("Segment() = %s, want %s", strings.Join(got, "/"), tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"中文标点和引号", "他说：“明天出发。”随后离开了！真的吗？", []string{"他说：“明天出发。”", "随后离开了！", "真的吗？"}},
+		{"英文缩写不断句", "Dr. Smith met Mr. J. K. Lee in the U.S. on Monday. They talked.", []string{"Dr. Smith met Mr. J. K. Lee in the U.S. on Monday.", "They talked."}},
+		{"小数和网址不断句", "Growth was 5.2 percent. See example.com for details", []string{"Growth was 5.2 percent.", "See example.com for details"}},
+		{"跳过标题，去除列表标记", "## 小标题\n• 第一项。\n2. 第二项\n> 引用", []string{"第一项。", "第二项", "引用"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitSentences(tt.input)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("splitSentences() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		opts         SummaryOptions
+		wantSentence []string
+		wantKeywords []string
+		// 不应出现在摘要中的内容和不应成为关键词的词
+		unwantedSummary  []string
+		unwantedKeywords []string
+	}{
+		{
+			"中文新闻",
+			summaryArticleZH,
+			SummaryOptions{Title: "国家统计局：前三季度国内生产总值同比增长5.2%"},
+			[]string{"前三季度国内生产总值同比增长5.2%。"},
+			[]string{"统计局", "国内生产总值", "消费", "增长"},
+			[]string{"天气晴朗"},
+			[]string{"发言人", "表示"},
+		},
+		{
+			"英文新闻",
+			summaryArticleEN,
+			SummaryOptions{Sentences: 2, Keywords: 5},
+			[]string{"OpenAI released a new reasoning model on Tuesday, the company said."},
+			[]string{"model", "reasoning", "OpenAI"},
+			[]string{"Critics"},
+			[]string{"said", "the"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Summarize(tt.text, tt.opts)
+			want := tt.opts.Sentences
+			if want == 0 {
+				want = DefaultSummarySentences
+			}
+			if len(s.Sentences) != want {
+				t.Errorf("Sentences = %q, want %d 句", s.Sentences, want)
+			}
+			for _, w := range tt.wantSentence {
+				if !strings.Contains(s.Summary, w) {
+					t.Errorf("Summary 缺少 %q:\n%s", w, s.Summary)
+				}
+			}
+			words := map[string]bool{}
+			for _, k := range s.Keywords {
+				words[k.Word] = true
+			}
+			for _, w := range tt.wantKeywords {
+				if !words[w] {
+					t.Errorf("Keywords 缺少 %q: %+v", w, s.Keywords)
+				}
+			}
+			for _, w := range tt.unwantedSummary {
+				if strings.Contains(s.Summary, w) {
+					t.Errorf("Summary 不应包含 %q:\n%s", w, s.Summary)
+				}
+			}
+			for _, w := range tt.unwantedKeywords {
+				if words[w] {
+					t.Errorf("Keywords 不应包含 %q: %+v", w, s.Keywords)
+				}
+			}
+			if len(s.Keywords) == 0 || s.Keywords[0].Score != 1 {
+				t.Errorf("Keywords = %+v, 最高分应为 1", s.Keywords)
+			}
+		})
+	}
+
+	t.Run("摘要句保持原文顺序", func(t *testing.T) {
+		s := Summarize(summaryArticleZH, SummaryOptions{Sentences: 20})
+		last := -1
+		for _, sentence := range s.Sentences {
+			i := strings.Index(summaryArticleZH, sentence)
+			if i <= last {
+				t.Errorf("摘要句顺序错误: %q", s.Sentences)
+			}
+			last = i
+		}
+	})
+	t.Run("空文本", func(t *testing.T) {
+		s := Summarize("", SummaryOptions{})
+		if s.Summary != "" || len(s.Sentences) != 0 || len(s.Keywords) != 0 {
+			t.Errorf("Summarize(\"\") = %+v", s)
+		}
+	})
+}
+
+func TestSummarizeContent(t *testing.T) {
+	s, err := SummarizeContent("", `<h1>标题</h1><p>`+strings.ReplaceAll(summaryArticleZH, "\n\n", "</p><p>")+`</p><script>alert(1)</script>`, SummaryOptions{})
+	if err != nil {
+		t.Fatalf("SummarizeContent() error = %v", err)
+	}
+	if strings.Contains(s.Summary, "alert") || !strings.Contains(s.Summary, "国家统计局") {
+		t.Errorf("Summary = %s", s.Summary)
+	}
+	if _, err := SummarizeContent("", "", SummaryOptions{}); err == nil {
+		t.Error("SummarizeContent() 缺少内容时应返回错误")
+	}
+	if _, err := SummarizeContent(strings.Repeat("a", MaxSummarizeLength+1), "", SummaryOptions{}); err == nil {
+		t.Error("SummarizeContent() 内容过长时应返回错误")
+	}
+}
+
+func TestExtractWithSummarize(t *testing.T) {
+	page := `<html><head><title>统计数据</title></head><body><article><h1>前三季度经济数据发布</h1><p>` +
+		strings.ReplaceAll(summaryArticleZH, "\n\n", "</p><p>") + `</p></article></body></html>`
+	e := New()
+	result, err := e.ExtractWithOptions(context.Background(), page, "https://news.example.com/a/1.html", ExtractOptions{Summarize: true})
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	if result.Summary == nil || result.Summary.Summary == "" || len(result.Summary.Keywords) == 0 {
+		t.Errorf("Summary = %+v", result.Summary)
+	}
+	result, _ = e.ExtractWithOptions(context.Background(), page, "https://news.example.com/a/1.html", ExtractOptions{})
+	if result.Summary != nil {
+		t.Error("未开启 Summarize 时不应生成摘要")
+	}
+}
@@ -0,0 +1,291 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件处理技术文章的代码块语言、脚注锚点和数学公式
+
+package extractor
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html/atom"
+)
+
+// anchorPrefix 正文锚点 id 的命名空间前缀，避免与宿主页面的 id 冲突（以及 DOM clobbering）
+const anchorPrefix = "nf-"
+
+var (
+	// anchorIDPattern 可保留的锚点 id
+	anchorIDPattern = regexp.MustCompile(`^[\p{L}\p{N}_.:-]{1,64}$`)
+	// codeWrapperPattern 代码高亮器包裹 <pre> 的容器 class（GitHub、Hugo、Rouge、Pandoc、WordPress 等）
+	codeWrapperPattern = regexp.MustCompile(`(?i)(^|\s)(highlight(-source-[\w+#.-]+)?|highlighter-rouge|sourceCode|codehilite|code-block|code-toolbar|wp-block-code|language-[\w+#.-]+)(\s|$)`)
+	// footnotesPattern 脚注容器的 class / id
+	footnotesPattern = regexp.MustCompile(`(?i)(^|[\s_-])(foot|end)notes?($|[\s_-])`)
+	// mathJaxRendered MathJax v2 渲染结果（保留 <script type="math/tex"> 源码时移除）
+	mathJaxRendered = ".MathJax_Preview, .MathJax, .MathJax_Display, .MathJax_SVG, .MathJax_SVG_Display, .MathJax_CHTML"
+)
+
+// codeLanguageAliases 代码语言别名
+var codeLanguageAliases = map[string]string{
+	"golang": "go", "js": "javascript", "ts": "typescript", "py": "python", "rb": "ruby",
+	"yml": "yaml", "sh": "bash", "shell": "bash", "zsh": "bash", "c++": "cpp", "c#": "csharp",
+	"objective-c": "objectivec", "md": "markdown",
+}
+
+// codeLanguageNone 表示无语言的标记
+var codeLanguageNone = map[string]bool{
+	"none": true, "text": true, "txt": true, "plain": true, "plaintext": true, "nohighlight": true,
+}
+
+// technicalMarkers markTechnical 需要处理的内容的特征字符串（均不含时跳过解析）
+//
+// 脚注容器的 role="doc-endnotes" 也包含 "endnote"。
+var technicalMarkers = []string{
+	"<pre", "footnote", "Footnote", "endnote", "Endnote", "katex", "mjx-container", "math/tex",
+}
+
+// hasTechnicalMarkers 判断页面是否可能包含代码块、脚注或数学公式
+func hasTechnicalMarkers(pageHTML string) bool {
+	for _, marker := range technicalMarkers {
+		if strings.Contains(pageHTML, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// markTechnical 提取前规范化技术内容，使其能通过 Readability 等引擎保留下来
+//
+//   - 代码块：从 <pre> / <code> 及高亮器容器的 class、data-lang 识别语言，记录到 <pre data-lang>，
+//     并移除只包裹一个 <pre> 的高亮器容器（Readability 会清除 class 并可能整体移除短代码块的容器）
+//   - 脚注：<div class="footnotes"> 等容器改为 <section>，避免被 Readability 按链接密度清除
+//   - 数学公式：KaTeX / MathJax 渲染结果替换为其中的 MathML，MathJax v2 的 TeX 源码转为带 TeX 注解的 <math>
+func markTechnical(pageHTML string) string {
+	if !hasTechnicalMarkers(pageHTML) {
+		return pageHTML
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pageHTML))
+	if err != nil {
+		return pageHTML
+	}
+
+	doc.Find("pre").Each(func(_ int, pre *goquery.Selection) {
+		lang := codeBlockLanguage(pre)
+		for parent := pre.Parent(); goquery.NodeName(parent) == "div" && parent.Children().Length() == 1 &&
+			codeWrapperPattern.MatchString(parent.AttrOr("class", "")); parent = pre.Parent() {
+			parent.ReplaceWithSelection(pre)
+		}
+		if lang != "" {
+			pre.SetAttr("data-lang", lang)
+		}
+	})
+
+	doc.Find("div").Each(func(_ int, s *goquery.Selection) {
+		if s.AttrOr("role", "") == "doc-endnotes" || footnotesPattern.MatchString(s.AttrOr("class", "")+" "+s.AttrOr("id", "")) {
+			s.Nodes[0].Data, s.Nodes[0].DataAtom = "section", atom.Section
+		}
+	})
+
+	markMath(doc)
+
+	result, err := doc.Html()
+	if err != nil {
+		return pageHTML
+	}
+	return result
+}
+
+// codeBlockLanguage 识别代码块语言（依次检查 <pre>、其中的 <code> 和两层容器）
+func codeBlockLanguage(pre *goquery.Selection) string {
+	candidates := []*goquery.Selection{pre, pre.ChildrenFiltered("code").First(), pre.Parent(), pre.Parent().Parent()}
+	for _, s := range candidates {
+		if s.Length() == 0 {
+			continue
+		}
+		for _, attr := range []string{"data-lang", "data-language"} {
+			if lang, ok := normalizeCodeLanguage(s.AttrOr(attr, "")); ok {
+				return lang
+			}
+		}
+		if lang := classCodeLanguage(s.AttrOr("class", "")); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// classCodeLanguage 从 class 识别代码语言
+//
+// 支持 language-go / lang-go（Prism、highlight.js、GFM）、highlight-source-go（GitHub）、
+// brush: go（SyntaxHighlighter）和 sourceCode go（Pandoc）。
+func classCodeLanguage(class string) string {
+	tokens := strings.Fields(class)
+	for i, token := range tokens {
+		var raw string
+		switch {
+		case strings.HasPrefix(token, "language-"):
+			raw = strings.TrimPrefix(token, "language-")
+		case strings.HasPrefix(token, "lang-"):
+			raw = strings.TrimPrefix(token, "lang-")
+		case strings.HasPrefix(token, "highlight-source-"):
+			raw = strings.TrimPrefix(token, "highlight-source-")
+		case token == "brush:" && i+1 < len(tokens):
+			raw = tokens[i+1]
+		case strings.HasPrefix(token, "brush:"):
+			raw = strings.TrimPrefix(token, "brush:")
+		case token == "sourceCode" && i+1 < len(tokens):
+			raw = tokens[i+1]
+		}
+		if lang, ok := normalizeCodeLanguage(raw); ok {
+			return lang
+		}
+	}
+	return ""
+}
+
+// normalizeCodeLanguage 规范化语言名（小写、别名），非语言标记返回 false
+func normalizeCodeLanguage(raw string) (string, bool) {
+	lang := strings.ToLower(strings.TrimRight(strings.TrimSpace(raw), ";"))
+	if alias, ok := codeLanguageAliases[lang]; ok {
+		lang = alias
+	}
+	if lang == "" || codeLanguageNone[lang] || !codeLanguageClass.MatchString("language-"+lang) {
+		return "", false
+	}
+	return lang, true
+}
+
+// markMath 将 KaTeX / MathJax 渲染结果替换为 MathML
+func markMath(doc *goquery.Document) {
+	doc.Find(".katex-display").Each(func(_ int, s *goquery.Selection) {
+		if math := s.Find(".katex-mathml math").First(); math.Length() > 0 {
+			math.SetAttr("display", "block")
+			s.ReplaceWithSelection(math)
+		}
+	})
+	doc.Find(".katex").Each(func(_ int, s *goquery.Selection) {
+		if math := s.Find(".katex-mathml math").First(); math.Length() > 0 {
+			s.ReplaceWithSelection(math)
+		}
+	})
+
+	// MathJax v3（开启辅助 MathML 时包含 <mjx-assistive-mml>）
+	doc.Find("mjx-container").Each(func(_ int, s *goquery.Selection) {
+		if math := s.Find("mjx-assistive-mml math").First(); math.Length() > 0 {
+			if s.AttrOr("display", "") == "true" {
+				math.SetAttr("display", "block")
+			}
+			s.ReplaceWithSelection(math)
+		}
+	})
+
+	// MathJax v2：TeX 源码保存在 <script type="math/tex">
+	scripts := doc.Find(`script[type^="math/tex"]`)
+	if scripts.Length() == 0 {
+		return
+	}
+	doc.Find(mathJaxRendered).Remove()
+	scripts.Each(func(_ int, s *goquery.Selection) {
+		tex := strings.TrimSpace(s.Text())
+		if tex == "" {
+			s.Remove()
+			return
+		}
+		display := ""
+		if strings.Contains(s.AttrOr("type", ""), "mode=display") {
+			display = ` display="block"`
+		}
+		escaped := html.EscapeString(tex)
+		s.ReplaceWithHtml(`<math` + display + `><semantics><mrow><mtext>` + escaped +
+			`</mtext></mrow><annotation encoding="application/x-tex">` + escaped + `</annotation></semantics></math>`)
+	})
+}
+
+// normalizeTechnical 提取后、净化前规范化正文中的技术内容
+//
+//   - 代码块：<pre data-lang> 转为 <pre><code class="language-xx">（净化器允许的唯一语言标记）
+//   - 锚点：被正文中页内链接引用的 id 和标题的 id 加上 anchorPrefix 命名空间，
+//     指向它们的链接（#fn1，或指向本页的绝对 URL）改写为 #nf-fn1；<a name> 锚点转为 id
+func normalizeTechnical(content string, base *url.URL) string {
+	if !strings.Contains(content, "data-lang") && !strings.Contains(content, "#") && !strings.Contains(content, " id=") {
+		return content
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+
+	doc.Find("pre[data-lang]").Each(func(_ int, pre *goquery.Selection) {
+		lang := pre.AttrOr("data-lang", "")
+		pre.RemoveAttr("data-lang")
+		code := pre.ChildrenFiltered("code").First()
+		if code.Length() == 0 || pre.Children().Length() > 1 {
+			inner, _ := pre.Html()
+			pre.SetHtml("<code>" + inner + "</code>")
+			code = pre.ChildrenFiltered("code").First()
+		}
+		code.SetAttr("class", "language-"+lang)
+	})
+
+	refs := map[string]bool{}
+	links := doc.Find("a[href]")
+	links.Each(func(_ int, a *goquery.Selection) {
+		if frag := fragmentRef(a.AttrOr("href", ""), base); frag != "" {
+			refs[frag] = true
+		}
+	})
+	doc.Find("a[name]").Each(func(_ int, a *goquery.Selection) {
+		if name := a.AttrOr("name", ""); refs[name] {
+			if _, ok := a.Attr("id"); !ok {
+				a.SetAttr("id", name)
+			}
+			a.RemoveAttr("name")
+		}
+	})
+
+	kept := map[string]bool{}
+	doc.Find("[id]").Each(func(_ int, s *goquery.Selection) {
+		id := s.AttrOr("id", "")
+		heading := len(s.Nodes[0].Data) == 2 && s.Nodes[0].Data[0] == 'h' && s.Nodes[0].Data[1] >= '1' && s.Nodes[0].Data[1] <= '6'
+		if (refs[id] || heading) && anchorIDPattern.MatchString(id) && !kept[id] {
+			kept[id] = true
+			s.SetAttr("id", anchorPrefix+id)
+		}
+	})
+	links.Each(func(_ int, a *goquery.Selection) {
+		if frag := fragmentRef(a.AttrOr("href", ""), base); kept[frag] {
+			a.SetAttr("href", "#"+anchorPrefix+frag)
+		}
+	})
+
+	result, err := doc.Find("body").Html()
+	if err != nil {
+		return content
+	}
+	return result
+}
+
+// fragmentRef 返回指向本页的链接的锚点（#fn1，或与 base 仅锚点不同的绝对 URL），其他链接返回空
+func fragmentRef(href string, base *url.URL) string {
+	href = strings.TrimSpace(href)
+	if strings.HasPrefix(href, "#") {
+		if frag, err := url.PathUnescape(href[1:]); err == nil {
+			return frag
+		}
+		return href[1:]
+	}
+	if base == nil || !strings.Contains(href, "#") {
+		return ""
+	}
+	u, err := base.Parse(href)
+	if err != nil || u.Fragment == "" {
+		return ""
+	}
+	if !strings.EqualFold(u.Host, base.Host) || u.Path != base.Path || u.RawQuery != base.RawQuery {
+		return ""
+	}
+	return u.Fragment
+}
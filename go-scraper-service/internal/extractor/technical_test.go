@@ -0,0 +1,192 @@
+package extractor
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestClassCodeLanguage(t *testing.T) {
+	tests := []struct {
+		class string
+		want  string
+	}{
+		{"language-go hljs", "go"},
+		{"hljs lang-JS", "javascript"},
+		{"highlight highlight-source-golang", "go"},
+		{"brush: python; gutter: false", "python"},
+		{"sourceCode rb", "ruby"},
+		{"language-plaintext", ""},
+		{"chroma", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			if got := classCodeLanguage(tt.class); got != tt.want {
+				t.Errorf("classCodeLanguage(%q) = %q, want %q", tt.class, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTechnical(t *testing.T) {
+	base, _ := url.Parse("https://blog.example.com/post/1?lang=zh")
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"代码语言转为 code 的 class",
+			`<pre data-lang="go"><code class="chroma">x := 1</code></pre>`,
+			`<pre><code class="language-go">x := 1</code></pre>`,
+		},
+		{
+			"没有 code 时补充",
+			`<pre data-lang="bash">ls -l</pre>`,
+			`<pre><code class="language-bash">ls -l</code></pre>`,
+		},
+		{
+			"脚注引用和返回链接加命名空间",
+			`<p>正文<sup id="fnref1"><a href="#fn1">1</a></sup></p><ol><li id="fn1">注释 <a href="#fnref1">↩</a></li></ol>`,
+			`<p>正文<sup id="nf-fnref1"><a href="#nf-fn1">1</a></sup></p><ol><li id="nf-fn1">注释 <a href="#nf-fnref1">↩</a></li></ol>`,
+		},
+		{
+			"标题 id 保留，指向本页的绝对链接改为页内锚点",
+			`<h2 id="安装">安装</h2><p><a href="https://blog.example.com/post/1?lang=zh#%E5%AE%89%E8%A3%85">见上</a> <a href="https://blog.example.com/post/2#安装">他页</a></p>`,
+			`<h2 id="nf-安装">安装</h2><p><a href="#nf-安装">见上</a> <a href="https://blog.example.com/post/2#安装">他页</a></p>`,
+		},
+		{
+			"a name 锚点转为 id，未被引用的 id 不变",
+			`<p><a name="note">注</a><span id="other">x</span><a href="#note">跳转</a></p>`,
+			`<p><a id="nf-note">注</a><span id="other">x</span><a href="#nf-note">跳转</a></p>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTechnical(tt.input, base); got != tt.want {
+				t.Errorf("normalizeTechnical() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkTechnical(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     []string
+		unwanted []string
+	}{
+		{
+			"移除高亮器容器并记录语言",
+			`<div class="highlight-source-go notranslate"><pre><code>x := 1</code></pre></div>`,
+			[]string{`<pre data-lang="go"><code>x := 1</code></pre>`},
+			[]string{`highlight-source-go`},
+		},
+		{
+			"脚注容器改为 section",
+			`<div class="footnotes" role="doc-endnotes"><ol><li id="fn1">x</li></ol></div>`,
+			[]string{`<section class="footnotes" role="doc-endnotes">`},
+			nil,
+		},
+		{
+			"大写 id 的尾注容器",
+			`<div id="Endnotes"><ol><li id="en1">x</li></ol></div>`,
+			[]string{`<section id="Endnotes">`},
+			nil,
+		},
+		{
+			"KaTeX 替换为 MathML",
+			`<span class="katex-display"><span class="katex"><span class="katex-mathml"><math><mi>y</mi></math></span><span class="katex-html">y</span></span></span>`,
+			[]string{`<math display="block"><mi>y</mi></math>`},
+			[]string{`katex`},
+		},
+		{
+			"MathJax v2 源码转为 TeX 注解",
+			`<span class="MathJax_Preview">a&lt;b</span><span class="MathJax">渲染</span><script type="math/tex">a < b</script>`,
+			[]string{`<math><semantics><mrow><mtext>a &lt; b</mtext></mrow><annotation encoding="application/x-tex">a &lt; b</annotation></semantics></math>`},
+			[]string{`MathJax`, `渲染`, `<script`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := markTechnical(`<html><body>` + tt.input + `</body></html>`)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("缺少 %s:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.unwanted {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("不应包含 %s:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+
+	t.Run("没有技术内容时原样返回", func(t *testing.T) {
+		page := `<html><body><p>Readers' notes, votes and "quotes".</p></body></html>`
+		if got := markTechnical(page); got != page {
+			t.Errorf("markTechnical() = %q, want unchanged", got)
+		}
+	})
+}
+
+func TestTechnicalArticle(t *testing.T) {
+	para := "<p>" + strings.Repeat("This paragraph explains the technical details of the example in depth. ", 6) + "</p>"
+	page := `<html><head><title>Tech</title></head><body><article><h1>Tech</h1>` + para +
+		`<p>See note<sup id="fnref1"><a href="#fn1">1</a></sup>.</p>` +
+		`<div class="highlight"><pre class="chroma"><code class="language-golang" data-lang="golang"><span class="k">func</span> main() {}</code></pre></div>` + para +
+		`<p>Area <span class="katex"><span class="katex-mathml"><math><semantics><mrow><msup><mi>r</mi><mn>2</mn></msup></mrow><annotation encoding="application/x-tex">r^2</annotation></semantics></math></span><span class="katex-html" aria-hidden="true">r2</span></span>.</p>` + para +
+		`<div class="footnotes"><ol><li id="fn1"><p>Footnote body. <a href="#fnref1">↩</a></p></li></ol></div>` +
+		`</article></body></html>`
+
+	e := New()
+	result, err := e.ExtractWithOptions(context.Background(), page, "https://blog.example.com/post/1", ExtractOptions{})
+	if err != nil {
+		t.Fatalf("ExtractWithOptions() error = %v", err)
+	}
+	for _, want := range []string{
+		`<pre><code class="language-go"><span>func</span> main() {}</code></pre>`,
+		`<sup id="nf-fnref1"><a href="#nf-fn1" rel="nofollow">1</a></sup>`,
+		`<li id="nf-fn1">`,
+		`<math><semantics><mrow><msup><mi>r</mi><mn>2</mn></msup></mrow><annotation encoding="application/x-tex">r^2</annotation></semantics></math>`,
+	} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("Content 缺少 %s:\n%s", want, result.Content)
+		}
+	}
+	if strings.Contains(result.Content, "katex") {
+		t.Errorf("Content 不应包含 KaTeX 渲染结果:\n%s", result.Content)
+	}
+	if !strings.Contains(result.TextContent, "Area r^2.") {
+		t.Errorf("TextContent 应使用 TeX 源码:\n%s", result.TextContent)
+	}
+
+	md, _ := e.ExtractWithOptions(context.Background(), page, "https://blog.example.com/post/1", ExtractOptions{OutputFormat: FormatMarkdown})
+	for _, want := range []string{"See note[^1].", "```go\nfunc main() {}\n```", "Area $r^2$.", "[^1]: Footnote body."} {
+		if !strings.Contains(md.Content, want) {
+			t.Errorf("Markdown 缺少 %q:\n%s", want, md.Content)
+		}
+	}
+}
+
+func TestSanitizeTechnical(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"只保留命名空间 id", `<h2 id="nf-intro">a</h2><p id="location">b</p>`, `<h2 id="nf-intro">a</h2><p>b</p>`},
+		{"MathML 展示属性", `<math display="block" onclick="x()"><mi mathvariant="bold" href="javascript:x()">x</mi></math>`, `<math display="block"><mi mathvariant="bold">x</mi></math>`},
+		{"不允许 annotation-xml", `<math><semantics><mi>x</mi><annotation-xml encoding="text/html"><img src=x onerror=alert(1)></annotation-xml></semantics></math>`, `<math><semantics><mi>x</mi></semantics></math>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
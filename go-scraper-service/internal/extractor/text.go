@@ -0,0 +1,227 @@
+// Package extractor 提供 HTML 内容提取和净化功能
+//
+// 本文件实现结构化纯文本渲染
+
+package extractor
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HTMLToText 将净化后的正文 HTML 渲染为保留结构的纯文本
+//
+// 与 Readability 的 TextContent（DOM textContent，段落、列表、标题粘连在一起）不同：
+//   - 块级元素之间以空行分隔，<br> 保留为换行
+//   - 标题以 # 标记层级（"## 小标题"），便于摘要和检索识别章节
+//   - 无序列表项以 "• " 开头，有序列表项以序号开头，嵌套列表按标记宽度缩进
+//   - 引用以 "> " 开头，代码块保留原始换行和缩进
+//   - 表格每行一行，单元格以 " | " 分隔
+//   - 跳过图片、图片说明（figcaption）、脚本等非正文内容
+//
+// 空白折叠规则与 Markdown 输出一致（见 collapseSpace），CJK 字符之间的源码换行不产生空格。
+func HTMLToText(contentHTML string) string {
+	root, err := html.Parse(strings.NewReader(contentHTML))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(textBlocks(root))
+}
+
+// textBlocks 渲染块级上下文中的子节点，块之间以空行分隔
+func textBlocks(n *html.Node) string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		if text := finishText(inline.String()); text != "" {
+			out = append(out, text)
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && isBlockElement(child) {
+			flush()
+			if block := textBlock(child); block != "" {
+				out = append(out, block)
+			}
+			continue
+		}
+		inline.WriteString(textInline(child))
+	}
+	flush()
+	return strings.Join(out, "\n\n")
+}
+
+// textBlock 渲染单个块级元素
+func textBlock(n *html.Node) string {
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := strings.ReplaceAll(finishText(textInlineChildren(n)), "\n", " ")
+		if text == "" {
+			return ""
+		}
+		return strings.Repeat("#", int(n.Data[1]-'0')) + " " + text
+	case atom.P, atom.Dt, atom.Dd, atom.Summary:
+		return finishText(textInlineChildren(n))
+	case atom.Pre:
+		return strings.Trim(rawText(n), "\n")
+	case atom.Blockquote:
+		return prefixLines(textBlocks(n), "> ", ">")
+	case atom.Ul, atom.Ol:
+		return textList(n)
+	case atom.Table:
+		return textTable(n)
+	case atom.Figure:
+		return textFigure(n)
+	case atom.Figcaption, atom.Hr, atom.Script, atom.Style, atom.Noscript, atom.Template:
+		return ""
+	}
+	return textBlocks(n)
+}
+
+// textFigure 渲染图片容器
+//
+// figure 中通常只有图片、说明和图片来源等零散文字，只保留其中的表格、引用和代码块。
+func textFigure(n *html.Node) string {
+	var parts []string
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		switch child.DataAtom {
+		case atom.Table, atom.Blockquote, atom.Pre:
+			if block := textBlock(child); block != "" {
+				parts = append(parts, block)
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// textList 渲染列表（嵌套列表按标记宽度缩进）
+func textList(n *html.Node) string {
+	ordered := n.DataAtom == atom.Ol
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil && ordered {
+		index = start
+	}
+
+	var items []string
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode {
+			continue
+		}
+		marker := "• "
+		if ordered {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+		content := textListItem(li)
+		if content == "" {
+			continue
+		}
+		items = append(items, marker+indentLines(content, strings.Repeat(" ", len([]rune(marker)))))
+	}
+	return strings.Join(items, "\n")
+}
+
+// textListItem 渲染列表项（文本与子列表之间只换行）
+func textListItem(li *html.Node) string {
+	var parts []string
+	var inline strings.Builder
+	flush := func() {
+		if text := finishText(inline.String()); text != "" {
+			parts = append(parts, text)
+		}
+		inline.Reset()
+	}
+	for child := li.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && isBlockElement(child) {
+			flush()
+			if block := textBlock(child); block != "" {
+				parts = append(parts, block)
+			}
+			continue
+		}
+		inline.WriteString(textInline(child))
+	}
+	flush()
+	return strings.Join(parts, "\n")
+}
+
+// textTable 渲染表格（每行一行，单元格以 " | " 分隔，表格标题单独一行）
+func textTable(n *html.Node) string {
+	var lines []string
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.DataAtom {
+			case atom.Caption:
+				if caption := finishText(textInlineChildren(child)); caption != "" {
+					lines = append(lines, caption)
+				}
+			case atom.Tr:
+				var cells []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Th || cell.DataAtom == atom.Td) {
+						if text := finishText(textInlineChildren(cell)); text != "" {
+							cells = append(cells, strings.ReplaceAll(text, "\n", " "))
+						}
+					}
+				}
+				if len(cells) > 0 {
+					lines = append(lines, strings.Join(cells, " | "))
+				}
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	return strings.Join(lines, "\n")
+}
+
+// textInlineChildren 渲染子节点的行内文本
+func textInlineChildren(n *html.Node) string {
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textInline(child))
+	}
+	return b.String()
+}
+
+// textInline 渲染行内节点（图片等非文本元素输出为空）
+func textInline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return n.Data
+	case html.ElementNode:
+	default:
+		return ""
+	}
+	switch n.DataAtom {
+	case atom.Br:
+		return hardBreak
+	case atom.Img, atom.Picture, atom.Source, atom.Figcaption, atom.Script, atom.Style, atom.Noscript, atom.Template:
+		return ""
+	case atom.Math:
+		return mathTeX(n)
+	}
+	return textInlineChildren(n)
+}
+
+// finishText 完成一段行内文本：折叠空白，<br> 转为换行
+func finishText(text string) string {
+	text = collapseSpace(text)
+	text = strings.ReplaceAll(text, " "+hardBreak, hardBreak)
+	text = strings.ReplaceAll(text, hardBreak+" ", hardBreak)
+	text = strings.Trim(text, hardBreak+" ")
+	return strings.ReplaceAll(text, hardBreak, "\n")
+}
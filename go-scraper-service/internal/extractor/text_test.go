@@ -0,0 +1,45 @@
+package extractor
+
+import "testing"
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "段落与标题",
+			html: `<h2>Background</h2><p>First <b>paragraph</b>.</p><p>Second<br>line</p>`,
+			want: "## Background\n\nFirst paragraph.\n\nSecond\nline",
+		},
+		{
+			name: "列表",
+			html: `<ul><li>Apples<ul><li>Green</li></ul></li><li>Pears</li></ul><ol><li>One</li><li>Two</li></ol>`,
+			want: "• Apples\n  • Green\n• Pears\n\n1. One\n2. Two",
+		},
+		{
+			name: "跳过图片说明",
+			html: `<p>Before</p><figure><img src="a.jpg" alt="x"><figcaption>Photo: Getty Images</figcaption></figure><p>After</p>`,
+			want: "Before\n\nAfter",
+		},
+		{
+			name: "中文换行与中英混排",
+			html: "<div><p>北京时间今天上午，\n  火箭成功发射。</p>\n<p>SpaceX\n发射了 Starship</p></div>",
+			want: "北京时间今天上午，火箭成功发射。\n\nSpaceX 发射了 Starship",
+		},
+		{
+			name: "引用、代码与表格",
+			html: "<blockquote><p>Quote</p></blockquote><pre><code>a := 1\n  b := 2\n</code></pre><table><tr><th>K</th><th>V</th></tr><tr><td>x</td><td>1</td></tr></table>",
+			want: "> Quote\n\na := 1\n  b := 2\n\nK | V\nx | 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTMLToText(tt.html); got != tt.want {
+				t.Errorf("HTMLToText() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
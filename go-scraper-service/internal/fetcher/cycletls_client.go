@@ -2,6 +2,8 @@ package fetcher
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"time"
 
 	cycletls "github.com/Danny-Dasilva/CycleTLS/cycletls"
@@ -182,6 +184,54 @@ func (c *CycleTLSClient) FetchWithHeaders(ctx context.Context, url string, custo
 	return result
 }
 
+// Do 执行单次请求（不跟随重定向，Cookie 由调用方通过 Headers 管理）
+func (c *CycleTLSClient) Do(ctx context.Context, req *Request) (*Response, error) {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	headers := map[string]string{
+		"Accept":          "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language": "zh-CN,zh;q=0.9,en;q=0.8",
+		"Accept-Encoding": "gzip, deflate, br",
+	}
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+
+	options := cycletls.Options{
+		Body:            req.Body,
+		Ja3:             c.ja3,
+		UserAgent:       c.userAgent,
+		Headers:         headers,
+		Timeout:         c.timeout,
+		DisableRedirect: true,
+	}
+
+	resp, err := c.client.Do(req.URL, options, method)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header, len(resp.Headers))
+	for name, value := range resp.Headers {
+		// CycleTLS 用 "/,/" 拼接多个 Set-Cookie
+		for _, v := range strings.Split(value, "/,/") {
+			header.Add(name, v)
+		}
+	}
+
+	return &Response{
+		StatusCode: resp.Status,
+		Header:     header,
+		Body:       resp.Body,
+		Cookies:    resp.Cookies,
+		URL:        req.URL,
+		Strategy:   "cycletls",
+	}, nil
+}
+
 // Close 关闭客户端
 func (c *CycleTLSClient) Close() {
 	c.client.Close()
@@ -2,6 +2,9 @@ package fetcher
 
 import (
 	"context"
+	"net/http"
+	neturl "net/url"
+	"strings"
 	"time"
 
 	"github.com/newsflow/go-scraper-service/internal/config"
@@ -112,6 +115,154 @@ func (f *Fetcher) FetchWithHeaders(ctx context.Context, url string, headers map[
 	return f.standard.FetchWithHeaders(ctx, url, headers)
 }
 
+// Options 抓取选项
+//
+// 汇总单次请求的抓取参数，供多页拼接等需要"沿用同一请求选项"
+// 发起后续抓取的场景复用。
+type Options struct {
+	Headers  map[string]string
+	Strategy string // cycletls, standard, auto
+	Referer  string
+
+	// 凭证 Headers（解密后的 Cookie / Authorization），
+	// 只发送到 CredentialDomain 及其子域名，防止后续抓取跳转到第三方站点时泄露
+	CredentialHeaders map[string]string
+	CredentialDomain  string
+
+	// Origin 原始请求的 URL：设置后 Headers 中的 Cookie / Authorization 只发送到该站点（含子域名），
+	// 分页、备用版本、站点地图子索引、爬取等后续抓取指向其他站点时不携带
+	Origin string
+}
+
+// HeadersFor 返回抓取指定 URL 时应使用的 Headers
+//
+// URL 不属于 Origin 站点时去除调用方传入的 Cookie / Authorization；
+// URL 属于凭证域名时合并凭证 Headers（Cookie 追加到已有 Cookie 之后，其余同名 Header 覆盖）。
+func (o Options) HeadersFor(rawURL string) map[string]string {
+	base := o.Headers
+	if o.Origin != "" && !sameOrigin(o.Origin, rawURL) {
+		base = make(map[string]string, len(o.Headers))
+		for k, v := range o.Headers {
+			if !IsSensitiveHeader(k) {
+				base[k] = v
+			}
+		}
+	}
+	if len(o.CredentialHeaders) == 0 || !MatchesDomain(o.CredentialDomain, rawURL) {
+		return base
+	}
+
+	headers := make(map[string]string, len(base)+len(o.CredentialHeaders))
+	for k, v := range base {
+		headers[k] = v
+	}
+	for name, value := range o.CredentialHeaders {
+		for k, v := range headers {
+			if strings.EqualFold(k, name) {
+				delete(headers, k)
+				if strings.EqualFold(name, "Cookie") && v != "" {
+					value = v + "; " + value
+				}
+			}
+		}
+		headers[name] = value
+	}
+	return headers
+}
+
+// IsSensitiveHeader 判断是否为携带身份的 Header（不随请求发往其他站点）
+func IsSensitiveHeader(name string) bool {
+	return strings.EqualFold(name, "Cookie") || strings.EqualFold(name, "Authorization")
+}
+
+// sameOrigin 判断 URL 是否与原始请求属于同一站点（含子域名）
+func sameOrigin(origin, rawURL string) bool {
+	u, err := neturl.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return MatchesDomain(u.Hostname(), rawURL)
+}
+
+// MatchesDomain 判断 URL 是否属于指定域名（忽略 www. 前缀，含子域名）
+//
+// 域名为空时视为不匹配。
+func MatchesDomain(domain, rawURL string) bool {
+	if domain == "" {
+		return false
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// FetchWithOptions 按选项抓取
+//
+// 选择优先级与 HTTP/gRPC 入口保持一致：
+// Headers（支持 Cookie 认证） > Strategy > Referer > 默认抓取
+func (f *Fetcher) FetchWithOptions(ctx context.Context, url string, opts Options) *FetchResult {
+	if headers := opts.HeadersFor(url); len(headers) > 0 {
+		return f.FetchWithHeaders(ctx, url, headers)
+	}
+	if opts.Strategy != "" {
+		return f.FetchWithStrategy(ctx, url, opts.Strategy)
+	}
+	if opts.Referer != "" {
+		return f.FetchWithReferer(ctx, url, opts.Referer)
+	}
+	return f.Fetch(ctx, url)
+}
+
+// PageFetcher 返回按 opts 抓取后续页面的函数（返回 HTML 和最终 URL）
+//
+// origin 为原始请求的 URL，调用方的 Cookie / Authorization 只发往该站点（见 Options.Origin）。
+func (f *Fetcher) PageFetcher(origin string, opts Options) func(ctx context.Context, pageURL string) (string, string, error) {
+	opts.Origin = origin
+	return func(ctx context.Context, pageURL string) (string, string, error) {
+		result := f.FetchWithOptions(ctx, pageURL, opts)
+		if result.Error != nil {
+			return "", "", result.Error
+		}
+		return result.HTML, result.FinalURL, nil
+	}
+}
+
+// Request 底层 HTTP 请求
+//
+// 与 Fetch 系列方法不同，Do 不会自动跟随重定向，也不会把非 200 状态视为错误，
+// 用于表单登录等需要自行管理 Cookie 和重定向的场景。
+type Request struct {
+	Method   string
+	URL      string
+	Headers  map[string]string
+	Body     string
+	Strategy string // cycletls, standard；为空时优先使用 CycleTLS
+}
+
+// Response 底层 HTTP 响应
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+	Cookies    []*http.Cookie // 本次响应 Set-Cookie 设置的 Cookie
+	URL        string         // 请求的 URL（不跟随重定向）
+	Strategy   string
+}
+
+// Do 执行单次 HTTP 请求（不跟随重定向）
+//
+// 优先使用 CycleTLS（TLS 指纹伪造），CycleTLS 不可用或指定 standard 策略时使用标准客户端。
+func (f *Fetcher) Do(ctx context.Context, req *Request) (*Response, error) {
+	if f.cycleTLS != nil && req.Strategy != "standard" {
+		return f.cycleTLS.Do(ctx, req)
+	}
+	return f.standard.Do(ctx, req)
+}
+
 // Close 关闭抓取器
 func (f *Fetcher) Close() {
 	if f.cycleTLS != nil {
@@ -0,0 +1,136 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/newsflow/go-scraper-service/internal/config"
+	"github.com/newsflow/go-scraper-service/internal/crawler"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+func TestOptionsHeadersFor(t *testing.T) {
+	opts := Options{
+		Headers:           map[string]string{"Cookie": "lang=zh", "Authorization": "Bearer caller", "X-Client": "newsflow"},
+		Origin:            "https://www.example.com/news/1",
+		CredentialHeaders: map[string]string{"Cookie": "sid=secret"},
+		CredentialDomain:  "example.com",
+	}
+	tests := []struct {
+		name string
+		url  string
+		want map[string]string
+	}{
+		{"同一站点合并凭证", "https://example.com/news/1?page=2", map[string]string{"Cookie": "lang=zh; sid=secret", "Authorization": "Bearer caller", "X-Client": "newsflow"}},
+		{"子域名", "https://m.example.com/news/1", map[string]string{"Cookie": "lang=zh; sid=secret", "Authorization": "Bearer caller", "X-Client": "newsflow"}},
+		{"其他站点", "https://cdn.example.net/amp/1", map[string]string{"X-Client": "newsflow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := opts.HeadersFor(tt.url)
+			if len(got) != len(tt.want) {
+				t.Fatalf("HeadersFor() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("HeadersFor()[%s] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+
+	t.Run("未设置 Origin 时不过滤", func(t *testing.T) {
+		if got := (Options{Headers: opts.Headers}).HeadersFor("https://other.org/"); got["Cookie"] != "lang=zh" {
+			t.Errorf("HeadersFor() = %v", got)
+		}
+	})
+}
+
+func TestPageFetcher(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]http.Header{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.Host] = r.Header.Clone()
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body><p>page</p></body></html>"))
+	})
+	site := httptest.NewServer(handler)
+	defer site.Close()
+	other := httptest.NewServer(handler)
+	defer other.Close()
+	// 以 localhost 访问第二个服务，模拟其他站点
+	otherURL := strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+
+	cfg := config.DefaultConfig()
+	f := &Fetcher{standard: NewStandardClient(cfg), config: cfg}
+	fetch := f.PageFetcher(site.URL+"/news/1", Options{Headers: map[string]string{"Cookie": "session=caller", "Authorization": "Bearer caller"}})
+
+	for _, pageURL := range []string{site.URL + "/news/1?page=2", otherURL + "/news/1?page=3"} {
+		if _, _, err := fetch(context.Background(), pageURL); err != nil {
+			t.Fatalf("fetch(%s) error = %v", pageURL, err)
+		}
+	}
+
+	same := received[strings.TrimPrefix(site.URL, "http://")]
+	if same.Get("Cookie") != "session=caller" || same.Get("Authorization") != "Bearer caller" {
+		t.Errorf("同一站点的分页 Cookie = %q, Authorization = %q", same.Get("Cookie"), same.Get("Authorization"))
+	}
+	cross := received[strings.TrimPrefix(otherURL, "http://")]
+	if cross == nil {
+		t.Fatal("未请求其他站点")
+	}
+	if cross.Get("Cookie") != "" || cross.Get("Authorization") != "" {
+		t.Errorf("其他站点的分页收到 Cookie = %q, Authorization = %q", cross.Get("Cookie"), cross.Get("Authorization"))
+	}
+}
+
+func TestPageFetcherCrawlAnyScope(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]http.Header{}
+	var otherURL string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.Host] = r.Header.Clone()
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if r.URL.Path == "/" {
+			w.Write([]byte(`<html><body><a href="` + otherURL + `/news/1">other</a></body></html>`))
+			return
+		}
+		w.Write([]byte("<html><body><p>page</p></body></html>"))
+	})
+	site := httptest.NewServer(handler)
+	defer site.Close()
+	other := httptest.NewServer(handler)
+	defer other.Close()
+	otherURL = strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+
+	cfg := config.DefaultConfig()
+	f := &Fetcher{standard: NewStandardClient(cfg), config: cfg}
+	seed := site.URL + "/"
+	m := crawler.NewManager(extractor.New(), 0, nil)
+	c, err := m.Create(crawler.Options{Seed: seed, Scope: crawler.ScopeAny, MaxDepth: 1}, f.PageFetcher(seed, Options{Headers: map[string]string{"Cookie": "session=caller"}}))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := c.Run(context.Background(), func(crawler.Event) error { return nil }); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if got := received[strings.TrimPrefix(site.URL, "http://")].Get("Cookie"); got != "session=caller" {
+		t.Errorf("种子站点 Cookie = %q", got)
+	}
+	cross := received[strings.TrimPrefix(otherURL, "http://")]
+	if cross == nil {
+		t.Fatal("未抓取其他主机")
+	}
+	if got := cross.Get("Cookie"); got != "" {
+		t.Errorf("其他主机收到 Cookie = %q", got)
+	}
+}
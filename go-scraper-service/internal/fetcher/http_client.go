@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/newsflow/go-scraper-service/internal/config"
@@ -154,3 +155,53 @@ func (c *StandardClient) FetchWithHeaders(ctx context.Context, url string, heade
 	result.Duration = time.Since(start)
 	return result
 }
+
+// Do 执行单次请求（不跟随重定向，Cookie 由调用方通过 Headers 管理）
+func (c *StandardClient) Do(ctx context.Context, req *Request) (*Response, error) {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if req.Body != "" {
+		body = strings.NewReader(req.Body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, req.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	httpReq.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	httpReq.Header.Set("Accept-Language", "zh-CN,zh;q=0.9,en;q=0.8")
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	// 复用连接池，但不跟随重定向
+	client := *c.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       string(respBody),
+		Cookies:    resp.Cookies(),
+		URL:        req.URL,
+		Strategy:   "standard",
+	}, nil
+}
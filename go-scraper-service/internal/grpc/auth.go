@@ -0,0 +1,224 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// Login 原生表单登录
+func (s *ScraperServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	if req.LoginUrl == "" {
+		return &pb.LoginResponse{Error: "login_url is required"}, nil
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.LoginResponse{Error: "context cancelled"}, nil
+	default:
+		return &pb.LoginResponse{Error: "server is busy"}, nil
+	}
+
+	// 设置超时（登录涉及多次往返请求，默认放宽到单次请求超时的 3 倍）
+	timeout := 3 * s.config.RequestTimeout
+	if req.TimeoutMs > 0 {
+		timeout = time.Duration(req.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	username, password := req.Username, req.Password
+	if req.Credential != nil && username == "" && password == "" {
+		var err error
+		username, password, err = s.decryptLogin(credentialRef(req.Credential), req.LoginUrl)
+		if err != nil {
+			return &pb.LoginResponse{Error: err.Error(), DurationMs: time.Since(start).Milliseconds()}, nil
+		}
+	}
+	loginReq := &auth.LoginRequest{
+		LoginURL:    req.LoginUrl,
+		Username:    username,
+		Password:    password,
+		ExtraFields: req.ExtraFields,
+		Headers:     req.Headers,
+		Strategy:    req.Strategy,
+	}
+	if req.Selectors != nil {
+		loginReq.Selectors = auth.LoginSelectors{
+			Username:         req.Selectors.Username,
+			Password:         req.Selectors.Password,
+			Submit:           req.Selectors.Submit,
+			SuccessIndicator: req.Selectors.SuccessIndicator,
+		}
+	}
+
+	result, err := s.loginExecutor.Login(ctx, loginReq)
+	resp := &pb.LoginResponse{DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+
+	resp.Success = result.Success
+	resp.Cookies = result.CookieString
+	resp.CookieList = convertCookies(result.Cookies)
+	resp.FinalUrl = result.FinalURL
+	resp.Error = result.Error
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = result.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+// convertCookies 转换 Cookie 元信息
+func convertCookies(cookies []auth.Cookie) []*pb.CookieInfo {
+	result := make([]*pb.CookieInfo, len(cookies))
+	for i, c := range cookies {
+		result[i] = &pb.CookieInfo{
+			Name:     c.Name,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			Expires:  c.Expires,
+			HttpOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+		}
+	}
+	return result
+}
+
+// CheckCredential 凭证有效性检测
+func (s *ScraperServer) CheckCredential(ctx context.Context, req *pb.CredentialCheckRequest) (*pb.CredentialCheckResponse, error) {
+	if req.Domain == "" && req.Url == "" {
+		return &pb.CredentialCheckResponse{Status: auth.CredentialUnknown, Error: "domain or url is required"}, nil
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.CredentialCheckResponse{Domain: req.Domain, Status: auth.CredentialUnknown, Error: "context cancelled"}, nil
+	default:
+		return &pb.CredentialCheckResponse{Domain: req.Domain, Status: auth.CredentialUnknown, Error: "server is busy"}, nil
+	}
+
+	// 设置超时
+	timeout := s.config.RequestTimeout
+	if req.TimeoutMs > 0 {
+		timeout = time.Duration(req.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	checkURL := req.Url
+	if checkURL == "" {
+		checkURL = "https://" + req.Domain + "/"
+	}
+	headers := make(map[string]string, len(req.Headers)+1)
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+	if req.Cookies != "" {
+		headers["Cookie"] = req.Cookies
+	}
+	var credentialHeaders map[string]string
+	var credentialDomain string
+	if req.Credential != nil {
+		ref := credentialRef(req.Credential)
+		if ref.Domain == "" {
+			ref.Domain = req.Domain
+		}
+		opts, err := s.withCredential(ref, checkURL, fetcher.Options{Headers: headers})
+		if err != nil {
+			return &pb.CredentialCheckResponse{
+				Domain:     req.Domain,
+				Status:     auth.CredentialUnknown,
+				DurationMs: time.Since(start).Milliseconds(),
+				Error:      err.Error(),
+			}, nil
+		}
+		credentialHeaders, credentialDomain = opts.CredentialHeaders, opts.CredentialDomain
+	}
+	checkReq := &auth.CheckRequest{
+		URL:               checkURL,
+		Headers:           headers,
+		Strategy:          req.Strategy,
+		CredentialHeaders: credentialHeaders,
+		CredentialDomain:  credentialDomain,
+	}
+	if req.Rule != nil {
+		checkReq.Rule = auth.CheckRule{
+			LoggedInSelector: req.Rule.LoggedInSelector,
+			LoginURLPattern:  req.Rule.LoginUrlPattern,
+			ExpectedText:     req.Rule.ExpectedText,
+		}
+	}
+
+	result, err := s.credentialChecker.Check(ctx, checkReq)
+	resp := &pb.CredentialCheckResponse{
+		Domain:     req.Domain,
+		Status:     auth.CredentialUnknown,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+
+	resp.Status = result.Status
+	resp.Evidence = result.Evidence
+	resp.FinalUrl = result.FinalURL
+	resp.StatusCode = int32(result.StatusCode)
+	return resp, nil
+}
+
+// credentialRef 转换加密凭证引用
+func credentialRef(ref *pb.CredentialRef) *auth.CredentialRef {
+	if ref == nil {
+		return nil
+	}
+	return &auth.CredentialRef{
+		Domain:            ref.Domain,
+		AuthType:          ref.AuthType,
+		EncryptedCookie:   ref.EncryptedCookie,
+		EncryptedToken:    ref.EncryptedToken,
+		EncryptedUsername: ref.EncryptedUsername,
+		EncryptedPassword: ref.EncryptedPassword,
+	}
+}
+
+// withCredential 解密凭证引用并写入抓取选项
+//
+// 凭证 Headers 只作用于凭证域名，分页、备用版本等后续抓取离开该域名时不会携带。
+// 返回的错误不包含任何凭证内容。
+func (s *ScraperServer) withCredential(ref *auth.CredentialRef, targetURL string, opts fetcher.Options) (fetcher.Options, error) {
+	if ref == nil {
+		return opts, nil
+	}
+	headers, domain, err := s.credentialCipher.Resolve(ref, targetURL)
+	if err != nil {
+		return opts, err
+	}
+	opts.CredentialHeaders = headers
+	opts.CredentialDomain = domain
+	return opts, nil
+}
+
+// decryptLogin 解密凭证引用中的登录账号和密码
+func (s *ScraperServer) decryptLogin(ref *auth.CredentialRef, loginURL string) (string, string, error) {
+	if s.credentialCipher == nil {
+		return "", "", auth.ErrCredentialNotConfigured
+	}
+	if ref.Domain != "" && !fetcher.MatchesDomain(ref.Domain, loginURL) {
+		return "", "", auth.ErrCredentialDomainMismatch
+	}
+	return s.credentialCipher.DecryptLogin(ref)
+}
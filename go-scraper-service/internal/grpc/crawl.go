@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/crawler"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// StartCrawl 创建并运行站点爬取，流式返回爬取事件
+//
+// 每个事件都携带 crawl_id；客户端断开时爬取自动暂停，可通过 ResumeCrawl 按 ID 恢复。
+func (s *ScraperServer) StartCrawl(req *pb.CrawlRequest, stream pb.ScraperService_StartCrawlServer) error {
+	if req.Seed == "" {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: "seed is required"})
+	}
+	if !crawler.ValidScope(req.Scope) {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: "invalid scope (host, subdomains, any)"})
+	}
+	if _, ok := extractor.ParseOutputFormat(req.Options.GetOutputFormat()); !ok {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: "invalid output_format (html, markdown, text)"})
+	}
+	if _, ok := extractor.ParseEmbedMode(req.Options.GetEmbedMode()); !ok {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: "invalid embed_mode (iframe, placeholder)"})
+	}
+	if _, ok := s.extractor.SanitizeProfile(req.Options.GetSanitizeProfile()); !ok {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: s.invalidProfileError()})
+	}
+
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Seed, fetchOptions(req.Options))
+	if err != nil {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: err.Error()})
+	}
+
+	// 单页超时
+	timeout := s.config.RequestTimeout
+	if req.Options != nil && req.Options.TimeoutMs > 0 {
+		timeout = time.Duration(req.Options.TimeoutMs) * time.Millisecond
+	}
+
+	c, err := s.crawls.Create(crawler.Options{
+		Seed:              req.Seed,
+		MaxDepth:          int(req.MaxDepth),
+		MaxPages:          int(req.MaxPages),
+		Include:           req.Include,
+		Exclude:           req.Exclude,
+		Scope:             req.Scope,
+		AllowedSubdomains: req.AllowedSubdomains,
+		SeedPathOnly:      req.SeedPathOnly,
+		ExtractArticles:   req.ExtractArticles,
+		OutputFormat:      req.Options.GetOutputFormat(),
+		EmbedMode:         req.Options.GetEmbedMode(),
+		SanitizeProfile:   req.Options.GetSanitizeProfile(),
+		Summarize:         req.Options.GetSummarize(),
+		Concurrency:       int(req.Concurrency),
+		Delay:             time.Duration(req.DelayMs) * time.Millisecond,
+		PageTimeout:       timeout,
+	}, s.pageFetcher(req.Seed, fetchOpts))
+	if err != nil {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, Error: err.Error()})
+	}
+	return s.runCrawl(c, stream)
+}
+
+// ResumeCrawl 恢复已暂停的爬取
+func (s *ScraperServer) ResumeCrawl(req *pb.CrawlControlRequest, stream pb.ScraperService_ResumeCrawlServer) error {
+	c, err := s.crawls.Get(req.Id)
+	if err != nil {
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, CrawlId: req.Id, Error: err.Error()})
+	}
+	return s.runCrawl(c, stream)
+}
+
+// runCrawl 运行爬取并转发事件
+//
+// 爬取本身不占用信号量：每次页面抓取各占用一个槽位（见 crawler.NewManager），
+// 服务繁忙时爬取放慢而不是失败。
+func (s *ScraperServer) runCrawl(c *crawler.Crawl, stream pb.ScraperService_StartCrawlServer) error {
+	ctx := stream.Context()
+	err := c.Run(ctx, func(ev crawler.Event) error {
+		return stream.Send(convertCrawlEvent(c.ID, ev))
+	})
+	switch err {
+	case nil:
+		return nil
+	case crawler.ErrCrawlRunning, crawler.ErrCrawlFinished:
+		snapshot := c.Snapshot()
+		return stream.Send(&pb.CrawlEvent{Type: crawler.EventDone, CrawlId: c.ID, Status: convertCrawlStatus(snapshot), Error: err.Error()})
+	default:
+		return err
+	}
+}
+
+// PauseCrawl 暂停爬取
+func (s *ScraperServer) PauseCrawl(ctx context.Context, req *pb.CrawlControlRequest) (*pb.CrawlStatus, error) {
+	return s.controlCrawl(req.Id, (*crawler.Crawl).Pause), nil
+}
+
+// CancelCrawl 取消爬取
+func (s *ScraperServer) CancelCrawl(ctx context.Context, req *pb.CrawlControlRequest) (*pb.CrawlStatus, error) {
+	return s.controlCrawl(req.Id, (*crawler.Crawl).Cancel), nil
+}
+
+// GetCrawl 查询爬取状态
+func (s *ScraperServer) GetCrawl(ctx context.Context, req *pb.CrawlControlRequest) (*pb.CrawlStatus, error) {
+	return s.controlCrawl(req.Id, nil), nil
+}
+
+// controlCrawl 按 ID 查找爬取任务并执行操作，返回操作后的状态
+func (s *ScraperServer) controlCrawl(id string, action func(*crawler.Crawl) error) *pb.CrawlStatus {
+	c, err := s.crawls.Get(id)
+	if err != nil {
+		return &pb.CrawlStatus{Id: id, Error: err.Error()}
+	}
+	var actionErr error
+	if action != nil {
+		actionErr = action(c)
+	}
+	status := convertCrawlStatus(c.Snapshot())
+	if actionErr != nil {
+		status.Error = actionErr.Error()
+	}
+	return status
+}
+
+// convertCrawlEvent 转换爬取事件
+func convertCrawlEvent(id string, ev crawler.Event) *pb.CrawlEvent {
+	event := &pb.CrawlEvent{
+		Type:      ev.Type,
+		CrawlId:   id,
+		Url:       ev.URL,
+		FinalUrl:  ev.FinalURL,
+		Depth:     int32(ev.Depth),
+		ParentUrl: ev.Parent,
+		Title:     ev.Title,
+		Links:     int32(ev.Links),
+		Error:     ev.Error,
+	}
+	if ev.Article != nil {
+		event.Article = &pb.FetchResponse{Url: ev.URL, FinalUrl: ev.FinalURL}
+		fillExtractResult(event.Article, ev.Article)
+	}
+	if ev.Status != nil {
+		event.Status = convertCrawlStatus(*ev.Status)
+	}
+	return event
+}
+
+// convertCrawlStatus 转换爬取状态
+func convertCrawlStatus(s crawler.Snapshot) *pb.CrawlStatus {
+	return &pb.CrawlStatus{
+		Id:         s.ID,
+		Seed:       s.Seed,
+		Status:     s.Status,
+		Discovered: int32(s.Stats.Discovered),
+		Crawled:    int32(s.Stats.Crawled),
+		Failed:     int32(s.Stats.Failed),
+		Articles:   int32(s.Stats.Articles),
+		Pending:    int32(s.Stats.Pending),
+		Truncated:  s.Stats.Truncated,
+		CreatedAt:  formatTime(&s.CreatedAt),
+		UpdatedAt:  formatTime(&s.UpdatedAt),
+	}
+}
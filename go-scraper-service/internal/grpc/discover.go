@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// DiscoverFeeds 发现站点的订阅源和 sitemap
+func (s *ScraperServer) DiscoverFeeds(ctx context.Context, req *pb.DiscoverRequest) (*pb.DiscoverResponse, error) {
+	if req.Url == "" {
+		return &pb.DiscoverResponse{Error: "url is required"}, nil
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.DiscoverResponse{Url: req.Url, Error: "context cancelled"}, nil
+	default:
+		return &pb.DiscoverResponse{Url: req.Url, Error: "server is busy"}, nil
+	}
+
+	// 设置超时（需要验证多个候选地址，默认放宽为两倍）
+	timeout := s.config.RequestTimeout * 2
+	if req.Options != nil && req.Options.TimeoutMs > 0 {
+		timeout = time.Duration(req.Options.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp := &pb.DiscoverResponse{Url: req.Url}
+
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+
+	fetchResult := s.fetcher.FetchWithOptions(ctx, req.Url, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+	resp.FinalUrl = fetchResult.FinalURL
+
+	result, err := s.extractor.Discover(ctx, fetchResult.HTML, fetchResult.FinalURL, s.pageFetcher(req.Url, fetchOpts), int(req.MaxCandidates))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+
+	resp.SiteTitle = result.SiteTitle
+	resp.Candidates = convertDiscoveredSources(result.Candidates)
+	resp.Checked = int32(result.Checked)
+	resp.DurationMs = time.Since(start).Milliseconds()
+	return resp, nil
+}
+
+// convertDiscoveredSources 转换候选来源
+func convertDiscoveredSources(sources []extractor.DiscoveredSource) []*pb.DiscoveredSource {
+	result := make([]*pb.DiscoveredSource, len(sources))
+	for i, s := range sources {
+		result[i] = &pb.DiscoveredSource{
+			Url:       s.URL,
+			Type:      s.Type,
+			Format:    s.Format,
+			Title:     s.Title,
+			ItemCount: int32(s.ItemCount),
+			UpdatedAt: formatTime(s.UpdatedAt),
+			Found:     s.Found,
+			Score:     s.Score,
+		}
+	}
+	return result
+}
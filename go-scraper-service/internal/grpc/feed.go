@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// FetchFeed 抓取并解析 RSS / Atom / JSON Feed
+func (s *ScraperServer) FetchFeed(ctx context.Context, req *pb.FeedRequest) (*pb.FeedResponse, error) {
+	if req.Url == "" {
+		return &pb.FeedResponse{Error: "url is required"}, nil
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.FeedResponse{Url: req.Url, Error: "context cancelled"}, nil
+	default:
+		return &pb.FeedResponse{Url: req.Url, Error: "server is busy"}, nil
+	}
+
+	// 设置超时
+	timeout := s.config.RequestTimeout
+	if req.Options != nil && req.Options.TimeoutMs > 0 {
+		timeout = time.Duration(req.Options.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp := &pb.FeedResponse{Url: req.Url}
+
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+
+	fetchResult := s.fetcher.FetchWithOptions(ctx, req.Url, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+	resp.FinalUrl = fetchResult.FinalURL
+
+	feed, err := s.extractor.ParseFeed(fetchResult.HTML, fetchResult.ContentType, fetchResult.FinalURL)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Feed = convertFeed(feed)
+	}
+	resp.DurationMs = time.Since(start).Milliseconds()
+	return resp, nil
+}
+
+// convertFeed 转换订阅源
+func convertFeed(f *extractor.Feed) *pb.Feed {
+	feed := &pb.Feed{
+		Format:      f.Format,
+		Version:     f.Version,
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Language:    f.Language,
+		Image:       f.Image,
+		UpdatedAt:   formatTime(f.UpdatedAt),
+		Items:       make([]*pb.FeedItem, len(f.Items)),
+	}
+	for i, it := range f.Items {
+		item := &pb.FeedItem{
+			ExternalId:  it.ExternalID,
+			Guid:        it.GUID,
+			Link:        it.Link,
+			Title:       it.Title,
+			Summary:     it.Summary,
+			Content:     it.Content,
+			PublishedAt: formatTime(it.PublishedAt),
+			UpdatedAt:   formatTime(it.UpdatedAt),
+			Authors:     it.Authors,
+			Categories:  it.Categories,
+			Thumbnail:   it.Thumbnail,
+		}
+		for _, enc := range it.Enclosures {
+			item.Enclosures = append(item.Enclosures, &pb.FeedEnclosure{Url: enc.URL, Type: enc.Type, Length: enc.Length})
+		}
+		feed.Items[i] = item
+	}
+	return feed
+}
+
+// formatTime 格式化为 RFC3339（为空时返回空字符串）
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// ExtractLinks 抓取页面并提取全部链接
+func (s *ScraperServer) ExtractLinks(ctx context.Context, req *pb.LinksRequest) (*pb.LinksResponse, error) {
+	if req.Url == "" {
+		return &pb.LinksResponse{Error: "url is required"}, nil
+	}
+	linkOpts := extractor.LinkOptions{Include: req.Include, Exclude: req.Exclude}
+	if err := linkOpts.Validate(); err != nil {
+		return &pb.LinksResponse{Url: req.Url, Error: err.Error()}, nil
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.LinksResponse{Url: req.Url, Error: "context cancelled"}, nil
+	default:
+		return &pb.LinksResponse{Url: req.Url, Error: "server is busy"}, nil
+	}
+
+	// 设置超时
+	timeout := s.config.RequestTimeout
+	if req.Options != nil && req.Options.TimeoutMs > 0 {
+		timeout = time.Duration(req.Options.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp := &pb.LinksResponse{Url: req.Url}
+
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+
+	fetchResult := s.fetcher.FetchWithOptions(ctx, req.Url, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+	resp.FinalUrl = fetchResult.FinalURL
+
+	links, err := extractor.ExtractLinks(fetchResult.HTML, fetchResult.FinalURL, linkOpts)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	resp.Links = convertLinks(links)
+	resp.DurationMs = time.Since(start).Milliseconds()
+	return resp, nil
+}
+
+// convertLinks 转换链接列表
+func convertLinks(links []extractor.Link) []*pb.Link {
+	result := make([]*pb.Link, len(links))
+	for i, l := range links {
+		result[i] = &pb.Link{
+			Url:         l.URL,
+			Text:        l.Text,
+			Rel:         l.Rel,
+			Region:      l.Region,
+			Internal:    l.Internal,
+			ArticleLike: l.ArticleLike,
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// CheckRevision 重新抓取并提取文章，与之前保存的内容哈希或文本比较
+func (s *ScraperServer) CheckRevision(ctx context.Context, req *pb.RevisionRequest) (*pb.RevisionResponse, error) {
+	if req.Url == "" {
+		return &pb.RevisionResponse{Error: "url is required"}, nil
+	}
+	if req.PreviousText == "" {
+		if req.PreviousHash == "" {
+			return &pb.RevisionResponse{Error: "previous_hash or previous_text is required"}, nil
+		}
+		if !extractor.ValidContentHash(req.PreviousHash) {
+			return &pb.RevisionResponse{Error: extractor.ErrInvalidContentHash.Error()}, nil
+		}
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.RevisionResponse{Error: "context cancelled"}, nil
+	default:
+		return &pb.RevisionResponse{Error: "server is busy"}, nil
+	}
+
+	article := s.fetchAndExtract(ctx, &pb.FetchRequest{Url: req.Url, Options: req.Options})
+	resp := &pb.RevisionResponse{Article: article}
+	if article.Error != "" {
+		resp.Error = article.Error
+		return resp, nil
+	}
+
+	rev, err := extractor.CheckRevision(req.PreviousHash, req.PreviousText, article.TextContent)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	resp.Changed = rev.Changed
+	resp.PreviousHash = rev.PreviousHash
+	resp.Diff = convertTextDiff(rev.Diff)
+	if !rev.Changed {
+		article.Content, article.TextContent = "", ""
+	}
+	return resp, nil
+}
+
+// convertTextDiff 转换段落级差异
+func convertTextDiff(d *extractor.TextDiff) *pb.TextDiff {
+	if d == nil {
+		return nil
+	}
+	result := &pb.TextDiff{
+		Added:     int32(d.Added),
+		Removed:   int32(d.Removed),
+		Modified:  int32(d.Modified),
+		Unchanged: int32(d.Unchanged),
+	}
+	for _, b := range d.Blocks {
+		result.Blocks = append(result.Blocks, &pb.DiffBlock{
+			Op:         b.Op,
+			Index:      int32(b.Index),
+			OldIndex:   int32(b.OldIndex),
+			Text:       b.Text,
+			OldText:    b.OldText,
+			Similarity: b.Similarity,
+		})
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// Scrape 按选择器抓取列表页条目
+func (s *ScraperServer) Scrape(ctx context.Context, req *pb.ScrapeRequest) (*pb.ScrapeResponse, error) {
+	if req.Url == "" {
+		return &pb.ScrapeResponse{Error: "url is required"}, nil
+	}
+	cfg := scrapeConfig(req.Config)
+	if err := cfg.Validate(); err != nil {
+		return &pb.ScrapeResponse{Url: req.Url, Error: err.Error()}, nil
+	}
+
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.ScrapeResponse{Url: req.Url, Error: "context cancelled"}, nil
+	default:
+		return &pb.ScrapeResponse{Url: req.Url, Error: "server is busy"}, nil
+	}
+
+	// 设置超时（翻页时按页数放宽）
+	timeout := s.config.RequestTimeout * time.Duration(min(max(cfg.MaxPages, 1), extractor.MaxPagesLimit))
+	if req.Options != nil && req.Options.TimeoutMs > 0 {
+		timeout = time.Duration(req.Options.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp := &pb.ScrapeResponse{Url: req.Url}
+
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+
+	fetchResult := s.fetcher.FetchWithOptions(ctx, req.Url, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+	resp.FinalUrl = fetchResult.FinalURL
+
+	result, err := s.extractor.Scrape(ctx, fetchResult.HTML, fetchResult.FinalURL, cfg, s.pageFetcher(req.Url, fetchOpts))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp, nil
+	}
+
+	resp.Items = convertScrapeItems(result.Items)
+	resp.Pages = int32(result.Pages)
+	resp.PageUrls = result.PageURLs
+	for _, e := range result.SelectorErrors {
+		resp.SelectorErrors = append(resp.SelectorErrors, &pb.SelectorError{Field: e.Field, Selector: e.Selector, Message: e.Message})
+	}
+	resp.DurationMs = time.Since(start).Milliseconds()
+	return resp, nil
+}
+
+// scrapeConfig 转换选择器配置
+func scrapeConfig(c *pb.ScrapeConfig) extractor.ScrapeConfig {
+	if c == nil {
+		return extractor.ScrapeConfig{}
+	}
+	return extractor.ScrapeConfig{
+		ListSelector:    c.ListSelector,
+		TitleSelector:   c.TitleSelector,
+		TitleAttr:       c.TitleAttr,
+		LinkSelector:    c.LinkSelector,
+		LinkAttr:        c.LinkAttr,
+		SummarySelector: c.SummarySelector,
+		SummaryAttr:     c.SummaryAttr,
+		ImageSelector:   c.ImageSelector,
+		ImageAttr:       c.ImageAttr,
+		AuthorSelector:  c.AuthorSelector,
+		AuthorAttr:      c.AuthorAttr,
+		DateSelector:    c.DateSelector,
+		DateAttr:        c.DateAttr,
+		NextSelector:    c.NextSelector,
+		MaxPages:        int(c.MaxPages),
+	}
+}
+
+// convertScrapeItems 转换列表条目
+func convertScrapeItems(items []extractor.ScrapeItem) []*pb.ScrapeItem {
+	result := make([]*pb.ScrapeItem, len(items))
+	for i, it := range items {
+		result[i] = &pb.ScrapeItem{
+			Title:    it.Title,
+			Url:      it.URL,
+			Summary:  it.Summary,
+			ImageUrl: it.ImageURL,
+			Author:   it.Author,
+			Date:     it.Date,
+		}
+		if it.PublishedAt != nil {
+			result[i].PublishedAt = it.PublishedAt.Format(time.RFC3339)
+		}
+	}
+	return result
+}
@@ -2,11 +2,15 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"io"
+	"strings"
 	"time"
 
 	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/auth"
 	"github.com/newsflow/go-scraper-service/internal/config"
+	"github.com/newsflow/go-scraper-service/internal/crawler"
 	"github.com/newsflow/go-scraper-service/internal/extractor"
 	"github.com/newsflow/go-scraper-service/internal/fetcher"
 	"github.com/newsflow/go-scraper-service/internal/processor"
@@ -15,10 +19,14 @@ import (
 // ScraperServer gRPC 服务实现
 type ScraperServer struct {
 	pb.UnimplementedScraperServiceServer
-	fetcher   *fetcher.Fetcher
-	extractor *extractor.Extractor
-	semaphore chan struct{}
-	config    *config.Config
+	fetcher           *fetcher.Fetcher
+	extractor         *extractor.Extractor
+	loginExecutor     *auth.LoginExecutor
+	credentialChecker *auth.CredentialChecker
+	credentialCipher  *auth.CredentialCipher // 未配置 CREDENTIAL_SECRET 时为 nil
+	crawls            *crawler.Manager
+	semaphore         chan struct{}
+	config            *config.Config
 }
 
 // NewScraperServer 创建 gRPC 服务
@@ -27,12 +35,37 @@ func NewScraperServer(cfg *config.Config) (*ScraperServer, error) {
 	if err != nil {
 		return nil, err
 	}
+	ext := extractor.New()
+	ext.SetDefaultTimezone(cfg.DefaultTimezone)
+	if cfg.SiteRulesPath != "" {
+		rules, err := extractor.LoadSiteRules(cfg.SiteRulesPath)
+		if err != nil {
+			return nil, err
+		}
+		ext.SetSiteRules(rules)
+	}
+	if cfg.SanitizeProfilesPath != "" {
+		profiles, err := extractor.LoadSanitizeProfiles(cfg.SanitizeProfilesPath)
+		if err != nil {
+			return nil, err
+		}
+		ext.SetSanitizeProfiles(profiles)
+	}
+	cipher, err := auth.NewCredentialCipher(cfg.CredentialSecret)
+	if err != nil && !errors.Is(err, auth.ErrCredentialNotConfigured) {
+		return nil, err
+	}
 
+	semaphore := make(chan struct{}, cfg.MaxConcurrent)
 	return &ScraperServer{
-		fetcher:   f,
-		extractor: extractor.New(),
-		semaphore: make(chan struct{}, cfg.MaxConcurrent),
-		config:    cfg,
+		fetcher:           f,
+		extractor:         ext,
+		loginExecutor:     auth.NewLoginExecutor(f),
+		credentialChecker: auth.NewCredentialChecker(f),
+		credentialCipher:  cipher,
+		crawls:            crawler.NewManager(ext, 0, semaphore),
+		semaphore:         semaphore,
+		config:            cfg,
 	}, nil
 }
 
@@ -129,23 +162,15 @@ func (s *ScraperServer) fetchRawContent(ctx context.Context, req *pb.FetchReques
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// 根据策略抓取
-	var fetchResult *fetcher.FetchResult
-	strategy := ""
-	if req.Options != nil {
-		strategy = req.Options.Strategy
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp
 	}
 
-	// 优先使用 Headers（支持 Cookie 认证）
-	if req.Options != nil && len(req.Options.Headers) > 0 {
-		fetchResult = s.fetcher.FetchWithHeaders(ctx, req.Url, req.Options.Headers)
-	} else if strategy != "" {
-		fetchResult = s.fetcher.FetchWithStrategy(ctx, req.Url, strategy)
-	} else if req.Options != nil && req.Options.Referer != "" {
-		fetchResult = s.fetcher.FetchWithReferer(ctx, req.Url, req.Options.Referer)
-	} else {
-		fetchResult = s.fetcher.Fetch(ctx, req.Url)
-	}
+	// 根据策略抓取（优先使用 Headers，支持 Cookie 认证）
+	fetchResult := s.fetcher.FetchWithOptions(ctx, req.Url, fetchOpts)
 
 	resp.Strategy = fetchResult.Strategy
 	resp.DurationMs = time.Since(start).Milliseconds()
@@ -180,24 +205,32 @@ func (s *ScraperServer) fetchAndExtract(ctx context.Context, req *pb.FetchReques
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// 根据策略抓取
-	var fetchResult *fetcher.FetchResult
-	strategy := ""
-	if req.Options != nil {
-		strategy = req.Options.Strategy
+	if _, ok := extractor.ParseOutputFormat(req.Options.GetOutputFormat()); !ok {
+		resp.Error = "invalid output_format (html, markdown, text)"
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp
+	}
+	if _, ok := extractor.ParseEmbedMode(req.Options.GetEmbedMode()); !ok {
+		resp.Error = "invalid embed_mode (iframe, placeholder)"
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp
+	}
+	if _, ok := s.extractor.SanitizeProfile(req.Options.GetSanitizeProfile()); !ok {
+		resp.Error = s.invalidProfileError()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp
 	}
 
-	// 优先使用 Headers（支持 Cookie 认证）
-	if req.Options != nil && len(req.Options.Headers) > 0 {
-		fetchResult = s.fetcher.FetchWithHeaders(ctx, req.Url, req.Options.Headers)
-	} else if strategy != "" {
-		fetchResult = s.fetcher.FetchWithStrategy(ctx, req.Url, strategy)
-	} else if req.Options != nil && req.Options.Referer != "" {
-		fetchResult = s.fetcher.FetchWithReferer(ctx, req.Url, req.Options.Referer)
-	} else {
-		fetchResult = s.fetcher.Fetch(ctx, req.Url)
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.DurationMs = time.Since(start).Milliseconds()
+		return resp
 	}
 
+	// 根据策略抓取（优先使用 Headers，支持 Cookie 认证）
+	fetchResult := s.fetcher.FetchWithOptions(ctx, req.Url, fetchOpts)
+
 	resp.Strategy = fetchResult.Strategy
 
 	if fetchResult.Error != nil {
@@ -209,26 +242,162 @@ func (s *ScraperServer) fetchAndExtract(ctx context.Context, req *pb.FetchReques
 	resp.FinalUrl = fetchResult.FinalURL
 
 	// 提取内容
-	extractResult, err := s.extractor.Extract(fetchResult.HTML, fetchResult.FinalURL)
+	extractOpts := extractor.ExtractOptions{PageFetcher: s.pageFetcher(req.Url, fetchOpts)}
+	if req.Options != nil {
+		extractOpts.FollowPagination = req.Options.FollowPagination
+		extractOpts.MaxPages = int(req.Options.MaxPages)
+		extractOpts.DiscoverAlternates = req.Options.DiscoverAlternates
+		extractOpts.OutputFormat = req.Options.OutputFormat
+		extractOpts.RequireArticle = req.Options.RequireArticle
+		extractOpts.EmbedMode = req.Options.EmbedMode
+		extractOpts.SanitizeProfile = req.Options.SanitizeProfile
+		extractOpts.Summarize = req.Options.Summarize
+	}
+	extractOpts.ContentType = fetchResult.ContentType
+	if extractOpts.MaxPages <= 0 {
+		extractOpts.MaxPages = s.config.PaginationMaxPages
+	}
+	extractResult, err := s.extractor.ExtractWithOptions(ctx, fetchResult.HTML, fetchResult.FinalURL, extractOpts)
 	if err != nil {
+		var notArticle *extractor.NotArticleError
+		if errors.As(err, &notArticle) {
+			resp.PageType = convertPageClass(notArticle.Class)
+		}
 		resp.Error = err.Error()
 		resp.DurationMs = time.Since(start).Milliseconds()
 		return resp
 	}
 
+	fillExtractResult(resp, extractResult)
+	resp.DurationMs = time.Since(start).Milliseconds()
+
+	return resp
+}
+
+// fillExtractResult 填充提取结果
+func fillExtractResult(resp *pb.FetchResponse, extractResult *extractor.ExtractResult) {
 	resp.Title = extractResult.Title
 	resp.Content = extractResult.Content
+	resp.Format = extractResult.Format
 	resp.TextContent = extractResult.TextContent
 	resp.Excerpt = extractResult.Excerpt
 	resp.Byline = extractResult.Byline
 	resp.SiteName = extractResult.SiteName
 	resp.ReadingTime = int32(extractResult.ReadingTime)
-	resp.DurationMs = time.Since(start).Milliseconds()
-
-	// 转换图片
+	resp.Pages = int32(extractResult.Pages)
+	resp.Variant = extractResult.Variant
+	resp.VariantUrl = extractResult.VariantURL
+	resp.Metadata = convertMetadata(extractResult.Metadata)
+	resp.PublishedDate = convertPublishedDate(extractResult.PublishedDate)
+	resp.Rule = extractResult.Rule
+	resp.Engine = extractResult.Engine
+	resp.EngineScores = convertEngineScores(extractResult.EngineScores)
+	resp.PageType = convertPageClass(extractResult.PageType)
+
+	// 转换图片和嵌入媒体
 	resp.Images = convertImages(extractResult.Images)
+	resp.Media = convertMedia(extractResult.Media)
+	resp.Fingerprint = convertFingerprint(extractResult.Fingerprint)
+	resp.Summary = convertSummary(extractResult.Summary)
+	resp.Document = convertDocumentInfo(extractResult.Document)
+}
 
-	return resp
+// fetchOptions 转换为抓取器选项
+func fetchOptions(opts *pb.FetchOptions) fetcher.Options {
+	if opts == nil {
+		return fetcher.Options{}
+	}
+	return fetcher.Options{
+		Headers:  opts.Headers,
+		Strategy: opts.Strategy,
+		Referer:  opts.Referer,
+	}
+}
+
+// pageFetcher 创建沿用同一抓取选项的页面抓取函数（用于后续分页、备用版本、站点地图和爬取）
+//
+// origin 为原始请求的 URL，调用方的 Cookie / Authorization 不会发往其他站点。
+func (s *ScraperServer) pageFetcher(origin string, opts fetcher.Options) extractor.PageFetcher {
+	return s.fetcher.PageFetcher(origin, opts)
+}
+
+// invalidProfileError 未知净化配置的错误信息（列出可选配置）
+func (s *ScraperServer) invalidProfileError() string {
+	return "invalid sanitize_profile (" + strings.Join(s.extractor.SanitizeProfileNames(), ", ") + ")"
+}
+
+// convertMetadata 转换结构化元数据
+func convertMetadata(m *extractor.Metadata) *pb.ArticleMetadata {
+	if m == nil {
+		return nil
+	}
+	return &pb.ArticleMetadata{
+		CanonicalUrl:  m.CanonicalURL,
+		Title:         m.Title,
+		Description:   m.Description,
+		PublishedTime: m.PublishedTime,
+		ModifiedTime:  m.ModifiedTime,
+		Authors:       m.Authors,
+		Section:       m.Section,
+		Keywords:      m.Keywords,
+		Image:         m.Image,
+		Language:      m.Language,
+		Publisher:     m.Publisher,
+		Type:          m.Type,
+	}
+}
+
+// convertPublishedDate 转换发布时间
+func convertPublishedDate(d *extractor.DateResult) *pb.PublishedDate {
+	if d == nil {
+		return nil
+	}
+	return &pb.PublishedDate{
+		Time:       d.Time.Format(time.RFC3339),
+		Confidence: d.Confidence,
+		Source:     d.Source,
+		Raw:        d.Raw,
+	}
+}
+
+// convertEngineScores 转换提取引擎评分
+func convertEngineScores(scores []extractor.QualityScore) []*pb.EngineScore {
+	result := make([]*pb.EngineScore, len(scores))
+	for i, q := range scores {
+		result[i] = &pb.EngineScore{
+			Engine:       q.Engine,
+			Score:        q.Score,
+			TextLength:   int32(q.TextLength),
+			LinkDensity:  q.LinkDensity,
+			Paragraphs:   int32(q.Paragraphs),
+			TitleOverlap: q.TitleOverlap,
+			Error:        q.Error,
+		}
+	}
+	return result
+}
+
+// convertPageClass 转换页面类型
+func convertPageClass(c *extractor.PageClass) *pb.PageClass {
+	if c == nil {
+		return nil
+	}
+	return &pb.PageClass{Type: c.Type, Confidence: c.Confidence, Signals: c.Signals}
+}
+
+// convertDocumentInfo 转换文档信息
+func convertDocumentInfo(d *extractor.DocumentInfo) *pb.DocumentInfo {
+	if d == nil {
+		return nil
+	}
+	return &pb.DocumentInfo{
+		Format:    d.Format,
+		PageCount: int32(d.PageCount),
+		Author:    d.Author,
+		Subject:   d.Subject,
+		Creator:   d.Creator,
+		Producer:  d.Producer,
+	}
 }
 
 // convertImages 转换图片格式
@@ -245,6 +414,24 @@ func convertImages(images []processor.Image) []*pb.Image {
 	return result
 }
 
+// convertMedia 转换嵌入媒体
+func convertMedia(media []extractor.EmbeddedMedia) []*pb.EmbeddedMedia {
+	result := make([]*pb.EmbeddedMedia, len(media))
+	for i, m := range media {
+		result[i] = &pb.EmbeddedMedia{
+			Type:      m.Type,
+			Provider:  m.Provider,
+			Id:        m.ID,
+			Url:       m.URL,
+			EmbedUrl:  m.EmbedURL,
+			Thumbnail: m.Thumbnail,
+			Title:     m.Title,
+			MimeType:  m.MimeType,
+		}
+	}
+	return result
+}
+
 // Close 关闭服务
 func (s *ScraperServer) Close() {
 	s.fetcher.Close()
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// FindSimilar 比较正文指纹或文本，返回近似重复的内容对和聚类
+func (s *ScraperServer) FindSimilar(ctx context.Context, req *pb.SimilarRequest) (*pb.SimilarResponse, error) {
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.SimilarResponse{Error: "context cancelled"}, nil
+	default:
+		return &pb.SimilarResponse{Error: "server is busy"}, nil
+	}
+
+	items := make([]extractor.SimilarItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = extractor.SimilarItem{ID: item.Id, Text: item.Text, SimHash: item.Simhash, ContentHash: item.ContentHash}
+	}
+	result, err := extractor.FindSimilar(items, req.Threshold)
+	if err != nil {
+		return &pb.SimilarResponse{Error: err.Error()}, nil
+	}
+
+	resp := &pb.SimilarResponse{}
+	for _, item := range result.Items {
+		resp.Items = append(resp.Items, &pb.SimilarItem{Id: item.ID, Simhash: item.SimHash, ContentHash: item.ContentHash})
+	}
+	for _, p := range result.Pairs {
+		resp.Pairs = append(resp.Pairs, &pb.SimilarPair{
+			A:          p.A,
+			B:          p.B,
+			Distance:   int32(p.Distance),
+			Similarity: p.Similarity,
+			Exact:      p.Exact,
+		})
+	}
+	for _, ids := range result.Clusters {
+		resp.Clusters = append(resp.Clusters, &pb.SimilarCluster{Ids: ids})
+	}
+	return resp, nil
+}
+
+// convertFingerprint 转换正文指纹
+func convertFingerprint(fp *extractor.Fingerprint) *pb.Fingerprint {
+	if fp == nil {
+		return nil
+	}
+	return &pb.Fingerprint{ContentHash: fp.ContentHash, Simhash: fp.SimHash, Shingles: int32(fp.Shingles)}
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// StreamSitemap 遍历 sitemap，逐条发送发现的 URL，最后发送汇总
+func (s *ScraperServer) StreamSitemap(req *pb.SitemapRequest, stream pb.ScraperService_StreamSitemapServer) error {
+	if req.Url == "" {
+		return stream.Send(&pb.SitemapEvent{Type: "done", Error: "url is required"})
+	}
+	opts := extractor.SitemapOptions{Limit: int(req.Limit), MaxSitemaps: int(req.MaxSitemaps)}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return stream.Send(&pb.SitemapEvent{Type: "done", Error: "invalid since (RFC3339)"})
+		}
+		opts.Since = &since
+	}
+
+	// 获取信号量
+	ctx := stream.Context()
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return stream.Send(&pb.SitemapEvent{Type: "done", Error: "server is busy"})
+	}
+
+	// 设置超时（需要抓取多个 sitemap 文件，默认放宽为十倍）
+	timeout := s.config.RequestTimeout * 10
+	if req.Options != nil && req.Options.TimeoutMs > 0 {
+		timeout = time.Duration(req.Options.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := &pb.SitemapEvent{Type: "done"}
+
+	fetchOpts, err := s.withCredential(credentialRef(req.Options.GetCredential()), req.Url, fetchOptions(req.Options))
+	if err != nil {
+		done.Error = err.Error()
+		done.DurationMs = time.Since(start).Milliseconds()
+		return stream.Send(done)
+	}
+
+	summary, err := s.extractor.WalkSitemaps(ctx, req.Url, s.pageFetcher(req.Url, fetchOpts), opts, func(u extractor.SitemapURL) error {
+		return stream.Send(&pb.SitemapEvent{Type: "url", Entry: convertSitemapURL(u)})
+	})
+	if err != nil {
+		if stream.Context().Err() != nil {
+			return err
+		}
+		done.Error = err.Error()
+	}
+	done.Summary = convertSitemapSummary(summary)
+	done.DurationMs = time.Since(start).Milliseconds()
+	return stream.Send(done)
+}
+
+// convertSitemapURL 转换 sitemap URL
+func convertSitemapURL(u extractor.SitemapURL) *pb.SitemapUrl {
+	result := &pb.SitemapUrl{
+		Url:        u.URL,
+		Lastmod:    formatTime(u.LastMod),
+		Changefreq: u.ChangeFreq,
+		Priority:   u.Priority,
+		Sitemap:    u.Sitemap,
+	}
+	if u.News != nil {
+		result.News = &pb.SitemapNews{
+			Title:           u.News.Title,
+			PublicationName: u.News.PublicationName,
+			Language:        u.News.Language,
+			PublishedAt:     formatTime(u.News.PublishedAt),
+			Keywords:        u.News.Keywords,
+		}
+	}
+	for _, img := range u.Images {
+		result.Images = append(result.Images, &pb.SitemapImage{Url: img.URL, Title: img.Title, Caption: img.Caption})
+	}
+	return result
+}
+
+// convertSitemapSummary 转换遍历汇总
+func convertSitemapSummary(s *extractor.SitemapSummary) *pb.SitemapSummary {
+	if s == nil {
+		return nil
+	}
+	result := &pb.SitemapSummary{
+		Sitemaps:  int32(s.Sitemaps),
+		Urls:      int32(s.URLs),
+		Skipped:   int32(s.Skipped),
+		Truncated: s.Truncated,
+	}
+	for _, e := range s.Errors {
+		result.Errors = append(result.Errors, &pb.SitemapError{Url: e.URL, Error: e.Error})
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	pb "github.com/newsflow/go-scraper-service/api/proto/gen"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// Summarize 离线生成抽取式摘要和关键词
+func (s *ScraperServer) Summarize(ctx context.Context, req *pb.SummarizeRequest) (*pb.SummarizeResponse, error) {
+	// 获取信号量
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	case <-ctx.Done():
+		return &pb.SummarizeResponse{Error: "context cancelled"}, nil
+	default:
+		return &pb.SummarizeResponse{Error: "server is busy"}, nil
+	}
+
+	summary, err := extractor.SummarizeContent(req.Text, req.Html, extractor.SummaryOptions{
+		Sentences: int(req.Sentences),
+		Keywords:  int(req.Keywords),
+		Title:     req.Title,
+	})
+	if err != nil {
+		return &pb.SummarizeResponse{Error: err.Error()}, nil
+	}
+	return &pb.SummarizeResponse{Summary: convertSummary(summary)}, nil
+}
+
+// convertSummary 转换抽取式摘要
+func convertSummary(summary *extractor.Summary) *pb.Summary {
+	if summary == nil {
+		return nil
+	}
+	result := &pb.Summary{Summary: summary.Summary, Sentences: summary.Sentences}
+	for _, k := range summary.Keywords {
+		result.Keywords = append(result.Keywords, &pb.Keyword{Word: k.Word, Score: k.Score})
+	}
+	return result
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// LoginRequest 表单登录请求
+//
+// selectors 与 SiteCredential.loginSelectors 的 JSON 结构一致。
+type LoginRequest struct {
+	LoginURL    string              `json:"loginUrl"`
+	Selectors   auth.LoginSelectors `json:"selectors"`
+	Username    string              `json:"username"`
+	Password    string              `json:"password"`
+	ExtraFields map[string]string   `json:"extraFields,omitempty"` // 额外提交的表单字段
+	Headers     map[string]string   `json:"headers,omitempty"`
+	Strategy    string              `json:"strategy,omitempty"` // cycletls, standard
+	Timeout     int                 `json:"timeout,omitempty"`
+	// 加密凭证引用，username/password 为空时从 encryptedUsername/encryptedPassword 解密
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+}
+
+// LoginResponse 表单登录响应
+type LoginResponse struct {
+	Success    bool          `json:"success"`
+	Cookies    string        `json:"cookies,omitempty"`    // Cookie 字符串，与 Node.js 端 LoginResult.cookies 一致
+	CookieList []auth.Cookie `json:"cookieList,omitempty"` // Cookie 元信息（不含值）
+	ExpiresAt  string        `json:"expiresAt,omitempty"`  // RFC3339，会话 Cookie 时为空
+	FinalURL   string        `json:"finalUrl,omitempty"`
+	Duration   int64         `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// handleLogin 原生表单登录
+func (h *Handler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.LoginURL == "" {
+		h.writeError(w, http.StatusBadRequest, "loginUrl is required")
+		return
+	}
+	if req.Selectors.Username == "" || req.Selectors.Password == "" {
+		h.writeError(w, http.StatusBadRequest, "selectors.username and selectors.password are required")
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时（登录涉及多次往返请求，默认放宽到单次请求超时的 3 倍）
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 3 * h.config.RequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	resp := h.login(ctx, req)
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// login 执行表单登录
+func (h *Handler) login(ctx context.Context, req LoginRequest) LoginResponse {
+	start := time.Now()
+	resp := LoginResponse{}
+
+	username, password := req.Username, req.Password
+	if req.Credential != nil && username == "" && password == "" {
+		var err error
+		username, password, err = h.decryptLogin(req.Credential, req.LoginURL)
+		if err != nil {
+			resp.Error = err.Error()
+			resp.Duration = time.Since(start).Milliseconds()
+			return resp
+		}
+	}
+
+	result, err := h.loginExecutor.Login(ctx, &auth.LoginRequest{
+		LoginURL:    req.LoginURL,
+		Selectors:   req.Selectors,
+		Username:    username,
+		Password:    password,
+		ExtraFields: req.ExtraFields,
+		Headers:     req.Headers,
+		Strategy:    req.Strategy,
+	})
+	resp.Duration = time.Since(start).Milliseconds()
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.Success = result.Success
+	resp.Cookies = result.CookieString
+	resp.CookieList = result.Cookies
+	resp.FinalURL = result.FinalURL
+	resp.Error = result.Error
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = result.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
+
+// CredentialCheckRequest 凭证有效性检测请求
+type CredentialCheckRequest struct {
+	Domain   string            `json:"domain"`
+	URL      string            `json:"url,omitempty"` // 检测 URL，默认 https://{domain}/
+	Headers  map[string]string `json:"headers,omitempty"`
+	Cookies  string            `json:"cookies,omitempty"` // Cookie 字符串，等价于 headers.Cookie
+	Rule     auth.CheckRule    `json:"rule"`
+	Strategy string            `json:"strategy,omitempty"`
+	Timeout  int               `json:"timeout,omitempty"`
+	// 加密凭证引用，由服务端解密后附加到检测请求
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+}
+
+// CredentialCheckResponse 凭证有效性检测响应
+type CredentialCheckResponse struct {
+	Domain     string   `json:"domain"`
+	Status     string   `json:"status"` // valid, expired, unknown
+	Evidence   []string `json:"evidence,omitempty"`
+	FinalURL   string   `json:"finalUrl,omitempty"`
+	StatusCode int      `json:"statusCode,omitempty"`
+	Duration   int64    `json:"duration"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// handleCredentialCheck 凭证有效性检测
+func (h *Handler) handleCredentialCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req CredentialCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Domain == "" && req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "domain or url is required")
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	resp := h.checkCredential(ctx, req)
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// checkCredential 执行凭证有效性检测
+func (h *Handler) checkCredential(ctx context.Context, req CredentialCheckRequest) CredentialCheckResponse {
+	start := time.Now()
+	resp := CredentialCheckResponse{Domain: req.Domain, Status: auth.CredentialUnknown}
+
+	checkURL := req.URL
+	if checkURL == "" {
+		checkURL = "https://" + req.Domain + "/"
+	}
+	headers := make(map[string]string, len(req.Headers)+1)
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+	if req.Cookies != "" {
+		headers["Cookie"] = req.Cookies
+	}
+	var credentialHeaders map[string]string
+	var credentialDomain string
+	if req.Credential != nil {
+		if req.Credential.Domain == "" {
+			req.Credential.Domain = req.Domain
+		}
+		opts, err := h.withCredential(req.Credential, checkURL, fetcher.Options{Headers: headers})
+		if err != nil {
+			resp.Error = err.Error()
+			resp.Duration = time.Since(start).Milliseconds()
+			return resp
+		}
+		credentialHeaders, credentialDomain = opts.CredentialHeaders, opts.CredentialDomain
+	}
+
+	result, err := h.credentialChecker.Check(ctx, &auth.CheckRequest{
+		URL:               checkURL,
+		Headers:           headers,
+		Rule:              req.Rule,
+		Strategy:          req.Strategy,
+		CredentialHeaders: credentialHeaders,
+		CredentialDomain:  credentialDomain,
+	})
+	resp.Duration = time.Since(start).Milliseconds()
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	resp.Status = result.Status
+	resp.Evidence = result.Evidence
+	resp.FinalURL = result.FinalURL
+	resp.StatusCode = result.StatusCode
+	return resp
+}
+
+// withCredential 解密请求中的凭证引用并写入抓取选项
+//
+// 凭证 Headers 只作用于凭证域名，分页、备用版本等后续抓取离开该域名时不会携带。
+// 返回的错误不包含任何凭证内容。
+func (h *Handler) withCredential(ref *auth.CredentialRef, targetURL string, opts fetcher.Options) (fetcher.Options, error) {
+	if ref == nil {
+		return opts, nil
+	}
+	headers, domain, err := h.credentialCipher.Resolve(ref, targetURL)
+	if err != nil {
+		return opts, err
+	}
+	opts.CredentialHeaders = headers
+	opts.CredentialDomain = domain
+	return opts, nil
+}
+
+// decryptLogin 解密凭证引用中的登录账号和密码
+func (h *Handler) decryptLogin(ref *auth.CredentialRef, loginURL string) (string, string, error) {
+	if h.credentialCipher == nil {
+		return "", "", auth.ErrCredentialNotConfigured
+	}
+	if ref.Domain != "" && !fetcher.MatchesDomain(ref.Domain, loginURL) {
+		return "", "", auth.ErrCredentialDomainMismatch
+	}
+	return h.credentialCipher.DecryptLogin(ref)
+}
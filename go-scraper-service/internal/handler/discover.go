@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// DiscoverRequest 订阅源自动发现请求
+type DiscoverRequest struct {
+	URL        string              `json:"url"` // 站点首页或任意页面
+	Referer    string              `json:"referer,omitempty"`
+	Headers    map[string]string   `json:"headers,omitempty"`
+	Timeout    int                 `json:"timeout,omitempty"`
+	Strategy   string              `json:"strategy,omitempty"` // cycletls, standard, auto
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+
+	// 最多验证的候选地址数，默认 20
+	MaxCandidates int `json:"maxCandidates,omitempty"`
+}
+
+// DiscoverResponse 订阅源自动发现响应
+type DiscoverResponse struct {
+	URL        string                       `json:"url"`
+	FinalURL   string                       `json:"finalUrl"`
+	SiteTitle  string                       `json:"siteTitle,omitempty"`
+	Candidates []extractor.DiscoveredSource `json:"candidates"` // 按得分降序
+	Checked    int                          `json:"checked"`
+	Strategy   string                       `json:"strategy"`
+	Duration   int64                        `json:"duration"`
+	Error      string                       `json:"error,omitempty"`
+}
+
+// handleDiscover 发现站点的订阅源和 sitemap（供创建来源时一键配置）
+func (h *Handler) handleDiscover(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req DiscoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "URL is required")
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时（需要验证多个候选地址，默认放宽为两倍）
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout * 2
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	resp := h.discover(ctx, req)
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// discover 抓取页面后发现并验证候选地址，候选地址沿用同一抓取选项
+func (h *Handler) discover(ctx context.Context, req DiscoverRequest) DiscoverResponse {
+	start := time.Now()
+	resp := DiscoverResponse{URL: req.URL, Candidates: []extractor.DiscoveredSource{}}
+
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, fetcher.Options{
+		Headers:  req.Headers,
+		Strategy: req.Strategy,
+		Referer:  req.Referer,
+	})
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+
+	fetchResult := h.fetcher.FetchWithOptions(ctx, req.URL, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+	resp.FinalURL = fetchResult.FinalURL
+
+	result, err := h.extractor.Discover(ctx, fetchResult.HTML, fetchResult.FinalURL, h.pageFetcher(req.URL, fetchOpts), req.MaxCandidates)
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+
+	resp.SiteTitle = result.SiteTitle
+	resp.Candidates = result.Candidates
+	resp.Checked = result.Checked
+	resp.Duration = time.Since(start).Milliseconds()
+	return resp
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// FeedRequest 订阅源解析请求
+type FeedRequest struct {
+	URL        string              `json:"url"`
+	Referer    string              `json:"referer,omitempty"`
+	Headers    map[string]string   `json:"headers,omitempty"`
+	Timeout    int                 `json:"timeout,omitempty"`
+	Strategy   string              `json:"strategy,omitempty"` // cycletls, standard, auto
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+}
+
+// FeedResponse 订阅源解析响应
+type FeedResponse struct {
+	URL      string          `json:"url"`
+	FinalURL string          `json:"finalUrl"`
+	Feed     *extractor.Feed `json:"feed,omitempty"`
+	Strategy string          `json:"strategy"`
+	Duration int64           `json:"duration"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// handleFeed 抓取并解析 RSS / Atom / JSON Feed
+func (h *Handler) handleFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req FeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "URL is required")
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	resp := h.fetchFeed(ctx, req)
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// fetchFeed 抓取并解析订阅源（条目中的相对链接按跳转后的最终 URL 解析）
+func (h *Handler) fetchFeed(ctx context.Context, req FeedRequest) FeedResponse {
+	start := time.Now()
+	resp := FeedResponse{URL: req.URL}
+
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, fetcher.Options{
+		Headers:  req.Headers,
+		Strategy: req.Strategy,
+		Referer:  req.Referer,
+	})
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+
+	fetchResult := h.fetcher.FetchWithOptions(ctx, req.URL, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+	resp.FinalURL = fetchResult.FinalURL
+
+	feed, err := h.extractor.ParseFeed(fetchResult.HTML, fetchResult.ContentType, fetchResult.FinalURL)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Feed = feed
+	}
+	resp.Duration = time.Since(start).Milliseconds()
+	return resp
+}
@@ -3,10 +3,13 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/newsflow/go-scraper-service/internal/auth"
 	"github.com/newsflow/go-scraper-service/internal/config"
 	"github.com/newsflow/go-scraper-service/internal/extractor"
 	"github.com/newsflow/go-scraper-service/internal/fetcher"
@@ -15,10 +18,13 @@ import (
 
 // Handler HTTP 处理器
 type Handler struct {
-	fetcher   *fetcher.Fetcher
-	extractor *extractor.Extractor
-	semaphore chan struct{}
-	config    *config.Config
+	fetcher           *fetcher.Fetcher
+	extractor         *extractor.Extractor
+	loginExecutor     *auth.LoginExecutor
+	credentialChecker *auth.CredentialChecker
+	credentialCipher  *auth.CredentialCipher // 未配置 CREDENTIAL_SECRET 时为 nil
+	semaphore         chan struct{}
+	config            *config.Config
 }
 
 // FetchRequest 抓取请求
@@ -28,23 +34,62 @@ type FetchRequest struct {
 	Headers  map[string]string `json:"headers,omitempty"`
 	Timeout  int               `json:"timeout,omitempty"`
 	Strategy string            `json:"strategy,omitempty"` // cycletls, standard, auto
+	// 加密凭证引用，由服务端解密后作为 Cookie / Bearer Token 使用
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+
+	// 多页文章拼接
+	FollowPagination bool `json:"followPagination,omitempty"`
+	MaxPages         int  `json:"maxPages,omitempty"` // 最多拼接页数（含首页），默认使用服务配置
+	// 发现 AMP / 打印版本并择优提取
+	DiscoverAlternates bool `json:"discoverAlternates,omitempty"`
+	// 正文输出格式：html（默认）, markdown, text
+	OutputFormat string `json:"outputFormat,omitempty"`
+	// 拒绝提取非文章页面（列表页、首页、错误页等）
+	RequireArticle bool `json:"requireArticle,omitempty"`
+	// 嵌入媒体渲染方式：iframe（默认，沙箱 iframe）, placeholder（缩略图占位）
+	EmbedMode string `json:"embedMode,omitempty"`
+	// 净化配置：reader（默认）, email-safe, strict-text, archive 或配置文件中的自定义配置
+	SanitizeProfile string `json:"sanitizeProfile,omitempty"`
+	// 生成抽取式摘要和关键词（不依赖 AI 服务）
+	Summarize bool `json:"summarize,omitempty"`
 }
 
 // FetchResponse 抓取响应
 type FetchResponse struct {
-	URL         string            `json:"url"`
-	FinalURL    string            `json:"finalUrl"`
-	Title       string            `json:"title,omitempty"`
-	Content     string            `json:"content,omitempty"`
-	TextContent string            `json:"textContent,omitempty"`
-	Excerpt     string            `json:"excerpt,omitempty"`
-	Byline      string            `json:"byline,omitempty"`
-	SiteName    string            `json:"siteName,omitempty"`
-	Images      []processor.Image `json:"images,omitempty"`
-	ReadingTime int               `json:"readingTime,omitempty"`
-	Strategy    string            `json:"strategy"`
-	Duration    int64             `json:"duration"`
-	Error       string            `json:"error,omitempty"`
+	URL         string              `json:"url"`
+	FinalURL    string              `json:"finalUrl"`
+	Title       string              `json:"title,omitempty"`
+	Content     string              `json:"content,omitempty"`
+	Format      string              `json:"format,omitempty"` // content 的格式：html, markdown, text
+	TextContent string              `json:"textContent,omitempty"`
+	Excerpt     string              `json:"excerpt,omitempty"`
+	Byline      string              `json:"byline,omitempty"`
+	SiteName    string              `json:"siteName,omitempty"`
+	Images      []processor.Image   `json:"images,omitempty"`
+	ReadingTime int                 `json:"readingTime,omitempty"`
+	Pages       int                 `json:"pages,omitempty"`
+	Variant     string              `json:"variant,omitempty"`    // 提取所用的页面版本：canonical, amp, print
+	VariantURL  string              `json:"variantUrl,omitempty"` // 备用版本 URL（finalUrl 仍为原始页面）
+	Metadata    *extractor.Metadata `json:"metadata,omitempty"`   // 结构化元数据
+	// 发布时间（含置信度和来源）
+	PublishedDate *extractor.DateResult `json:"publishedDate,omitempty"`
+	Rule          string                `json:"rule,omitempty"` // 匹配的站点规则名称
+	// 胜出的提取引擎及全部引擎的质量评分
+	Engine       string                   `json:"engine,omitempty"`
+	EngineScores []extractor.QualityScore `json:"engineScores,omitempty"`
+	// 页面类型及置信度（拒绝提取非文章页面时同样返回）
+	PageType *extractor.PageClass `json:"pageType,omitempty"`
+	// 正文中保留的嵌入媒体（视频平台、推文、原生音视频）
+	Media []extractor.EmbeddedMedia `json:"media,omitempty"`
+	// 正文指纹（精确哈希和 SimHash，可用 /similar 比较）
+	Fingerprint *extractor.Fingerprint `json:"fingerprint,omitempty"`
+	// 抽取式摘要和关键词（summarize 为 true 时）
+	Summary *extractor.Summary `json:"summary,omitempty"`
+	// 文档信息（PDF 等非网页内容，此时 pageType 为 document，pages 为文档页数）
+	Document *extractor.DocumentInfo `json:"document,omitempty"`
+	Strategy string                  `json:"strategy"`
+	Duration int64                   `json:"duration"`
+	Error    string                  `json:"error,omitempty"`
 }
 
 // RawFetchResponse 原始抓取响应（不经过 Readability 处理）
@@ -64,6 +109,16 @@ type BatchRequest struct {
 	URLs        []string `json:"urls"`
 	Concurrency int      `json:"concurrency,omitempty"`
 	Timeout     int      `json:"timeout,omitempty"`
+
+	// 提取选项（应用于批次内所有 URL）
+	FollowPagination   bool   `json:"followPagination,omitempty"`
+	MaxPages           int    `json:"maxPages,omitempty"`
+	DiscoverAlternates bool   `json:"discoverAlternates,omitempty"`
+	OutputFormat       string `json:"outputFormat,omitempty"`
+	RequireArticle     bool   `json:"requireArticle,omitempty"`
+	EmbedMode          string `json:"embedMode,omitempty"`
+	SanitizeProfile    string `json:"sanitizeProfile,omitempty"`
+	Summarize          bool   `json:"summarize,omitempty"`
 }
 
 // BatchResponse 批量抓取响应
@@ -86,12 +141,35 @@ func New(cfg *config.Config) (*Handler, error) {
 	if err != nil {
 		return nil, err
 	}
+	ext := extractor.New()
+	ext.SetDefaultTimezone(cfg.DefaultTimezone)
+	if cfg.SiteRulesPath != "" {
+		rules, err := extractor.LoadSiteRules(cfg.SiteRulesPath)
+		if err != nil {
+			return nil, err
+		}
+		ext.SetSiteRules(rules)
+	}
+	if cfg.SanitizeProfilesPath != "" {
+		profiles, err := extractor.LoadSanitizeProfiles(cfg.SanitizeProfilesPath)
+		if err != nil {
+			return nil, err
+		}
+		ext.SetSanitizeProfiles(profiles)
+	}
+	cipher, err := auth.NewCredentialCipher(cfg.CredentialSecret)
+	if err != nil && !errors.Is(err, auth.ErrCredentialNotConfigured) {
+		return nil, err
+	}
 
 	return &Handler{
-		fetcher:   f,
-		extractor: extractor.New(),
-		semaphore: make(chan struct{}, cfg.MaxConcurrent),
-		config:    cfg,
+		fetcher:           f,
+		extractor:         ext,
+		loginExecutor:     auth.NewLoginExecutor(f),
+		credentialChecker: auth.NewCredentialChecker(f),
+		credentialCipher:  cipher,
+		semaphore:         make(chan struct{}, cfg.MaxConcurrent),
+		config:            cfg,
 	}, nil
 }
 
@@ -101,6 +179,16 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/fetch", h.handleFetch)
 	mux.HandleFunc("/fetch-raw", h.handleFetchRaw)
 	mux.HandleFunc("/batch", h.handleBatch)
+	mux.HandleFunc("/links", h.handleLinks)
+	mux.HandleFunc("/scrape", h.handleScrape)
+	mux.HandleFunc("/feed", h.handleFeed)
+	mux.HandleFunc("/discover", h.handleDiscover)
+	mux.HandleFunc("/sitemap", h.handleSitemap)
+	mux.HandleFunc("/similar", h.handleSimilar)
+	mux.HandleFunc("/revision", h.handleRevision)
+	mux.HandleFunc("/summarize", h.handleSummarize)
+	mux.HandleFunc("/login", h.handleLogin)
+	mux.HandleFunc("/credentials/check", h.handleCredentialCheck)
 }
 
 // handleHealth 健康检查
@@ -133,6 +221,21 @@ func (h *Handler) handleFetch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := extractor.ParseOutputFormat(req.OutputFormat); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid outputFormat (html, markdown, text)")
+		return
+	}
+
+	if _, ok := extractor.ParseEmbedMode(req.EmbedMode); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid embedMode (iframe, placeholder)")
+		return
+	}
+
+	if _, ok := h.extractor.SanitizeProfile(req.SanitizeProfile); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid sanitizeProfile ("+strings.Join(h.extractor.SanitizeProfileNames(), ", ")+")")
+		return
+	}
+
 	// 获取信号量
 	select {
 	case h.semaphore <- struct{}{}:
@@ -201,18 +304,17 @@ func (h *Handler) fetchRaw(ctx context.Context, req FetchRequest) RawFetchRespon
 	start := time.Now()
 	resp := RawFetchResponse{URL: req.URL, StatusCode: 200}
 
-	// 根据策略和参数选择抓取方式
-	var fetchResult *fetcher.FetchResult
-	if len(req.Headers) > 0 {
-		fetchResult = h.fetcher.FetchWithHeaders(ctx, req.URL, req.Headers)
-	} else if req.Strategy != "" {
-		fetchResult = h.fetcher.FetchWithStrategy(ctx, req.URL, req.Strategy)
-	} else if req.Referer != "" {
-		fetchResult = h.fetcher.FetchWithReferer(ctx, req.URL, req.Referer)
-	} else {
-		fetchResult = h.fetcher.Fetch(ctx, req.URL)
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, req.fetchOptions())
+	if err != nil {
+		resp.Error = err.Error()
+		resp.StatusCode = 0
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
 	}
 
+	// 根据策略和参数选择抓取方式
+	fetchResult := h.fetcher.FetchWithOptions(ctx, req.URL, fetchOpts)
+
 	resp.Strategy = fetchResult.Strategy
 
 	if fetchResult.Error != nil {
@@ -254,6 +356,21 @@ func (h *Handler) handleBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := extractor.ParseOutputFormat(req.OutputFormat); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid outputFormat (html, markdown, text)")
+		return
+	}
+
+	if _, ok := extractor.ParseEmbedMode(req.EmbedMode); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid embedMode (iframe, placeholder)")
+		return
+	}
+
+	if _, ok := h.extractor.SanitizeProfile(req.SanitizeProfile); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid sanitizeProfile ("+strings.Join(h.extractor.SanitizeProfileNames(), ", ")+")")
+		return
+	}
+
 	start := time.Now()
 	concurrency := req.Concurrency
 	if concurrency <= 0 || concurrency > 10 {
@@ -268,7 +385,7 @@ func (h *Handler) handleBatch(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), timeout)
 	defer cancel()
 
-	results := h.batchFetch(ctx, req.URLs, concurrency)
+	results := h.batchFetch(ctx, req, concurrency)
 
 	resp := BatchResponse{
 		Results:  results,
@@ -282,19 +399,17 @@ func (h *Handler) fetchAndExtract(ctx context.Context, req FetchRequest) FetchRe
 	start := time.Now()
 	resp := FetchResponse{URL: req.URL}
 
-	// 根据策略和参数选择抓取方式
-	var fetchResult *fetcher.FetchResult
-	if len(req.Headers) > 0 {
-		// 有自定义 Headers（包括 Cookie），使用带 Headers 的方法
-		fetchResult = h.fetcher.FetchWithHeaders(ctx, req.URL, req.Headers)
-	} else if req.Strategy != "" {
-		fetchResult = h.fetcher.FetchWithStrategy(ctx, req.URL, req.Strategy)
-	} else if req.Referer != "" {
-		fetchResult = h.fetcher.FetchWithReferer(ctx, req.URL, req.Referer)
-	} else {
-		fetchResult = h.fetcher.Fetch(ctx, req.URL)
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, req.fetchOptions())
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
 	}
 
+	// 根据策略和参数选择抓取方式
+	// 有自定义 Headers（包括 Cookie）时优先使用带 Headers 的方法
+	fetchResult := h.fetcher.FetchWithOptions(ctx, req.URL, fetchOpts)
+
 	resp.Strategy = fetchResult.Strategy
 
 	if fetchResult.Error != nil {
@@ -306,8 +421,27 @@ func (h *Handler) fetchAndExtract(ctx context.Context, req FetchRequest) FetchRe
 	resp.FinalURL = fetchResult.FinalURL
 
 	// 提取内容
-	extractResult, err := h.extractor.Extract(fetchResult.HTML, fetchResult.FinalURL)
+	extractOpts := extractor.ExtractOptions{
+		FollowPagination:   req.FollowPagination,
+		MaxPages:           req.MaxPages,
+		DiscoverAlternates: req.DiscoverAlternates,
+		PageFetcher:        h.pageFetcher(req.URL, fetchOpts),
+		OutputFormat:       req.OutputFormat,
+		RequireArticle:     req.RequireArticle,
+		EmbedMode:          req.EmbedMode,
+		SanitizeProfile:    req.SanitizeProfile,
+		Summarize:          req.Summarize,
+		ContentType:        fetchResult.ContentType,
+	}
+	if extractOpts.MaxPages <= 0 {
+		extractOpts.MaxPages = h.config.PaginationMaxPages
+	}
+	extractResult, err := h.extractor.ExtractWithOptions(ctx, fetchResult.HTML, fetchResult.FinalURL, extractOpts)
 	if err != nil {
+		var notArticle *extractor.NotArticleError
+		if errors.As(err, &notArticle) {
+			resp.PageType = notArticle.Class
+		}
 		resp.Error = err.Error()
 		resp.Duration = time.Since(start).Milliseconds()
 		return resp
@@ -315,24 +449,54 @@ func (h *Handler) fetchAndExtract(ctx context.Context, req FetchRequest) FetchRe
 
 	resp.Title = extractResult.Title
 	resp.Content = extractResult.Content
+	resp.Format = extractResult.Format
 	resp.TextContent = extractResult.TextContent
 	resp.Excerpt = extractResult.Excerpt
 	resp.Byline = extractResult.Byline
 	resp.SiteName = extractResult.SiteName
 	resp.Images = extractResult.Images
 	resp.ReadingTime = extractResult.ReadingTime
+	resp.Pages = extractResult.Pages
+	resp.Variant = extractResult.Variant
+	resp.VariantURL = extractResult.VariantURL
+	resp.Metadata = extractResult.Metadata
+	resp.PublishedDate = extractResult.PublishedDate
+	resp.Rule = extractResult.Rule
+	resp.Engine = extractResult.Engine
+	resp.EngineScores = extractResult.EngineScores
+	resp.PageType = extractResult.PageType
+	resp.Media = extractResult.Media
+	resp.Fingerprint = extractResult.Fingerprint
+	resp.Summary = extractResult.Summary
+	resp.Document = extractResult.Document
 	resp.Duration = time.Since(start).Milliseconds()
 
 	return resp
 }
 
+// fetchOptions 转换为抓取器选项
+func (req FetchRequest) fetchOptions() fetcher.Options {
+	return fetcher.Options{
+		Headers:  req.Headers,
+		Strategy: req.Strategy,
+		Referer:  req.Referer,
+	}
+}
+
+// pageFetcher 创建沿用同一抓取选项的页面抓取函数（用于后续分页、备用版本、站点地图和爬取）
+//
+// origin 为原始请求的 URL，调用方的 Cookie / Authorization 不会发往其他站点。
+func (h *Handler) pageFetcher(origin string, opts fetcher.Options) extractor.PageFetcher {
+	return h.fetcher.PageFetcher(origin, opts)
+}
+
 // batchFetch 批量抓取
-func (h *Handler) batchFetch(ctx context.Context, urls []string, concurrency int) []FetchResponse {
-	results := make([]FetchResponse, len(urls))
+func (h *Handler) batchFetch(ctx context.Context, req BatchRequest, concurrency int) []FetchResponse {
+	results := make([]FetchResponse, len(req.URLs))
 	sem := make(chan struct{}, concurrency)
 	var wg sync.WaitGroup
 
-	for i, url := range urls {
+	for i, url := range req.URLs {
 		wg.Add(1)
 		go func(idx int, u string) {
 			defer wg.Done()
@@ -353,7 +517,17 @@ func (h *Handler) batchFetch(ctx context.Context, urls []string, concurrency int
 				return
 			}
 
-			results[idx] = h.fetchAndExtract(ctx, FetchRequest{URL: u})
+			results[idx] = h.fetchAndExtract(ctx, FetchRequest{
+				URL:                u,
+				FollowPagination:   req.FollowPagination,
+				MaxPages:           req.MaxPages,
+				DiscoverAlternates: req.DiscoverAlternates,
+				OutputFormat:       req.OutputFormat,
+				RequireArticle:     req.RequireArticle,
+				EmbedMode:          req.EmbedMode,
+				SanitizeProfile:    req.SanitizeProfile,
+				Summarize:          req.Summarize,
+			})
 		}(i, url)
 	}
 
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// LinksRequest 链接提取请求
+type LinksRequest struct {
+	URL        string              `json:"url"`
+	Referer    string              `json:"referer,omitempty"`
+	Headers    map[string]string   `json:"headers,omitempty"`
+	Timeout    int                 `json:"timeout,omitempty"`
+	Strategy   string              `json:"strategy,omitempty"` // cycletls, standard, auto
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+
+	// 过滤正则（匹配规范化后的绝对 URL）
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// LinksResponse 链接提取响应
+type LinksResponse struct {
+	URL      string           `json:"url"`
+	FinalURL string           `json:"finalUrl"`
+	Links    []extractor.Link `json:"links"`
+	Strategy string           `json:"strategy"`
+	Duration int64            `json:"duration"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// handleLinks 抓取页面并提取全部链接（供站点爬取发现新 URL）
+func (h *Handler) handleLinks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req LinksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "URL is required")
+		return
+	}
+	if err := req.linkOptions().Validate(); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	resp := h.extractLinks(ctx, req)
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// extractLinks 抓取并提取链接（相对链接按跳转后的最终 URL 解析）
+func (h *Handler) extractLinks(ctx context.Context, req LinksRequest) LinksResponse {
+	start := time.Now()
+	resp := LinksResponse{URL: req.URL, Links: []extractor.Link{}}
+
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, fetcher.Options{
+		Headers:  req.Headers,
+		Strategy: req.Strategy,
+		Referer:  req.Referer,
+	})
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+
+	fetchResult := h.fetcher.FetchWithOptions(ctx, req.URL, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+	resp.FinalURL = fetchResult.FinalURL
+
+	links, err := extractor.ExtractLinks(fetchResult.HTML, fetchResult.FinalURL, req.linkOptions())
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Links = links
+	}
+	resp.Duration = time.Since(start).Milliseconds()
+	return resp
+}
+
+// linkOptions 转换为链接过滤选项
+func (req LinksRequest) linkOptions() extractor.LinkOptions {
+	return extractor.LinkOptions{Include: req.Include, Exclude: req.Exclude}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// RevisionRequest 文章修订检测请求（抓取和提取选项同 FetchRequest）
+type RevisionRequest struct {
+	FetchRequest
+	// 之前保存的正文指纹 contentHash（只判断是否变化）
+	PreviousHash string `json:"previousHash,omitempty"`
+	// 之前保存的 textContent（返回段落级差异，优先于 previousHash）
+	PreviousText string `json:"previousText,omitempty"`
+}
+
+// RevisionResponse 文章修订检测响应
+//
+// 包含重新提取的标题、作者、元数据、发布时间和指纹；正文未变化时不返回 content / textContent。
+type RevisionResponse struct {
+	FetchResponse
+	Changed      bool                `json:"changed"`
+	PreviousHash string              `json:"previousHash,omitempty"`
+	Diff         *extractor.TextDiff `json:"diff,omitempty"`
+}
+
+// handleRevision 重新抓取并提取文章，与之前保存的版本比较，检测更正、更新等修订
+func (h *Handler) handleRevision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req RevisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "URL is required")
+		return
+	}
+
+	if req.PreviousText == "" {
+		if req.PreviousHash == "" {
+			h.writeError(w, http.StatusBadRequest, "previousHash or previousText is required")
+			return
+		}
+		if !extractor.ValidContentHash(req.PreviousHash) {
+			h.writeError(w, http.StatusBadRequest, "Invalid previousHash (fingerprint contentHash)")
+			return
+		}
+	}
+
+	if _, ok := extractor.ParseOutputFormat(req.OutputFormat); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid outputFormat (html, markdown, text)")
+		return
+	}
+
+	if _, ok := extractor.ParseEmbedMode(req.EmbedMode); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid embedMode (iframe, placeholder)")
+		return
+	}
+
+	if _, ok := h.extractor.SanitizeProfile(req.SanitizeProfile); !ok {
+		h.writeError(w, http.StatusBadRequest, "Invalid sanitizeProfile ("+strings.Join(h.extractor.SanitizeProfileNames(), ", ")+")")
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp := RevisionResponse{FetchResponse: h.fetchAndExtract(ctx, req.FetchRequest)}
+	if resp.Error == "" {
+		rev, err := extractor.CheckRevision(req.PreviousHash, req.PreviousText, resp.TextContent)
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.Changed = rev.Changed
+			resp.PreviousHash = rev.PreviousHash
+			resp.Diff = rev.Diff
+			if !rev.Changed {
+				resp.Content, resp.TextContent = "", ""
+			}
+		}
+	}
+	resp.Duration = time.Since(start).Milliseconds()
+	h.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// ScrapeRequest 列表页抓取请求
+type ScrapeRequest struct {
+	URL        string              `json:"url"`
+	Referer    string              `json:"referer,omitempty"`
+	Headers    map[string]string   `json:"headers,omitempty"`
+	Timeout    int                 `json:"timeout,omitempty"`
+	Strategy   string              `json:"strategy,omitempty"` // cycletls, standard, auto
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+
+	// 选择器配置（与 Source.config.scrape 结构一致）
+	Config extractor.ScrapeConfig `json:"config"`
+}
+
+// ScrapeResponse 列表页抓取响应
+type ScrapeResponse struct {
+	URL            string                    `json:"url"`
+	FinalURL       string                    `json:"finalUrl"`
+	Items          []extractor.ScrapeItem    `json:"items"`
+	Pages          int                       `json:"pages,omitempty"`
+	PageURLs       []string                  `json:"pageUrls,omitempty"`
+	SelectorErrors []extractor.SelectorError `json:"selectorErrors,omitempty"` // 没有匹配的字段选择器
+	Strategy       string                    `json:"strategy"`
+	Duration       int64                     `json:"duration"`
+	Error          string                    `json:"error,omitempty"`
+}
+
+// handleScrape 按选择器抓取列表页条目
+func (h *Handler) handleScrape(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req ScrapeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "URL is required")
+		return
+	}
+	if err := req.Config.Validate(); err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时（翻页时按页数放宽）
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout * time.Duration(min(max(req.Config.MaxPages, 1), extractor.MaxPagesLimit))
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	resp := h.scrape(ctx, req)
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// scrape 抓取首页后按选择器提取条目，后续列表页沿用同一抓取选项
+func (h *Handler) scrape(ctx context.Context, req ScrapeRequest) ScrapeResponse {
+	start := time.Now()
+	resp := ScrapeResponse{URL: req.URL, Items: []extractor.ScrapeItem{}}
+
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, fetcher.Options{
+		Headers:  req.Headers,
+		Strategy: req.Strategy,
+		Referer:  req.Referer,
+	})
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+
+	fetchResult := h.fetcher.FetchWithOptions(ctx, req.URL, fetchOpts)
+	resp.Strategy = fetchResult.Strategy
+	if fetchResult.Error != nil {
+		resp.Error = fetchResult.Error.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+	resp.FinalURL = fetchResult.FinalURL
+
+	result, err := h.extractor.Scrape(ctx, fetchResult.HTML, fetchResult.FinalURL, req.Config, h.pageFetcher(req.URL, fetchOpts))
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Duration = time.Since(start).Milliseconds()
+		return resp
+	}
+
+	resp.Items = result.Items
+	resp.Pages = result.Pages
+	resp.PageURLs = result.PageURLs
+	resp.SelectorErrors = result.SelectorErrors
+	resp.Duration = time.Since(start).Milliseconds()
+	return resp
+}
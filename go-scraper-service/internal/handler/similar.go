@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// SimilarRequest 近似重复检测请求
+type SimilarRequest struct {
+	// 待比较的内容（最多 1000 项）：提供 text 时计算指纹，否则使用已保存的 simhash
+	Items []extractor.SimilarItem `json:"items"`
+	// 相似度阈值（0-1），默认 0.9
+	Threshold float64 `json:"threshold,omitempty"`
+}
+
+// SimilarResponse 近似重复检测响应
+type SimilarResponse struct {
+	*extractor.SimilarResult
+	Duration int64 `json:"duration"`
+}
+
+// handleSimilar 比较正文指纹或文本，报告近似重复的稿件（多来源转载的同一篇新闻）
+func (h *Handler) handleSimilar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req SimilarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// 获取信号量（指纹计算和两两比较均为 CPU 密集）
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	start := time.Now()
+	result, err := extractor.FindSimilar(req.Items, req.Threshold)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.writeJSON(w, http.StatusOK, SimilarResponse{SimilarResult: result, Duration: time.Since(start).Milliseconds()})
+}
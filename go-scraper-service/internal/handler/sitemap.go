@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/auth"
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+	"github.com/newsflow/go-scraper-service/internal/fetcher"
+)
+
+// sitemapFlushInterval 每写出多少行刷新一次响应
+const sitemapFlushInterval = 50
+
+// SitemapRequest sitemap 遍历请求
+type SitemapRequest struct {
+	URL        string              `json:"url"` // sitemap 地址，或站点根地址（从 robots.txt 查找 sitemap）
+	Referer    string              `json:"referer,omitempty"`
+	Headers    map[string]string   `json:"headers,omitempty"`
+	Timeout    int                 `json:"timeout,omitempty"`
+	Strategy   string              `json:"strategy,omitempty"` // cycletls, standard, auto
+	Credential *auth.CredentialRef `json:"credential,omitempty"`
+
+	// 只返回 lastmod 不早于该时间的 URL（RFC3339）
+	Since *time.Time `json:"since,omitempty"`
+	// 最多返回的 URL 数，默认不限制
+	Limit int `json:"limit,omitempty"`
+	// 最多抓取的 sitemap 文件数，默认 50
+	MaxSitemaps int `json:"maxSitemaps,omitempty"`
+}
+
+// SitemapEvent sitemap 遍历事件（NDJSON 的一行）
+type SitemapEvent struct {
+	Type     string                    `json:"type"` // url：发现的 URL；done：结束汇总（始终为最后一行）
+	Entry    *extractor.SitemapURL     `json:"entry,omitempty"`
+	Summary  *extractor.SitemapSummary `json:"summary,omitempty"`
+	Duration int64                     `json:"duration,omitempty"`
+	Error    string                    `json:"error,omitempty"`
+}
+
+// handleSitemap 遍历 sitemap，以 NDJSON 流式返回发现的 URL
+//
+// 每行一个 SitemapEvent，最后一行为 type=done 的汇总；流开始后的错误只能写在汇总中。
+func (h *Handler) handleSitemap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req SitemapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.URL == "" {
+		h.writeError(w, http.StatusBadRequest, "URL is required")
+		return
+	}
+
+	// 获取信号量
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	// 设置超时（需要抓取多个 sitemap 文件，默认放宽为十倍）
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if timeout <= 0 {
+		timeout = h.config.RequestTimeout * 10
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	lines := 0
+	emit := func(event SitemapEvent) error {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+		if lines++; flusher != nil && (lines%sitemapFlushInterval == 0 || event.Type == "done") {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	done := h.walkSitemaps(ctx, req, func(u extractor.SitemapURL) error {
+		return emit(SitemapEvent{Type: "url", Entry: &u})
+	})
+	emit(done)
+}
+
+// walkSitemaps 遍历 sitemap，返回结束汇总事件
+func (h *Handler) walkSitemaps(ctx context.Context, req SitemapRequest, emit func(extractor.SitemapURL) error) SitemapEvent {
+	start := time.Now()
+	done := SitemapEvent{Type: "done"}
+
+	fetchOpts, err := h.withCredential(req.Credential, req.URL, fetcher.Options{
+		Headers:  req.Headers,
+		Strategy: req.Strategy,
+		Referer:  req.Referer,
+	})
+	if err != nil {
+		done.Error = err.Error()
+		done.Duration = time.Since(start).Milliseconds()
+		return done
+	}
+
+	opts := extractor.SitemapOptions{Since: req.Since, Limit: req.Limit, MaxSitemaps: req.MaxSitemaps}
+	summary, err := h.extractor.WalkSitemaps(ctx, req.URL, h.pageFetcher(req.URL, fetchOpts), opts, emit)
+	if err != nil {
+		done.Error = err.Error()
+	}
+	done.Summary = summary
+	done.Duration = time.Since(start).Milliseconds()
+	return done
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/newsflow/go-scraper-service/internal/extractor"
+)
+
+// SummarizeRequest 摘要请求（text 和 html 二选一）
+type SummarizeRequest struct {
+	// 纯文本（如 textContent）
+	Text string `json:"text,omitempty"`
+	// 正文 HTML（净化后转为结构化纯文本）
+	HTML string `json:"html,omitempty"`
+	// 标题（可选，提高相关句子和词的权重）
+	Title string `json:"title,omitempty"`
+	// 摘要句数，默认 3，最多 20
+	Sentences int `json:"sentences,omitempty"`
+	// 关键词数，默认 8，最多 50
+	Keywords int `json:"keywords,omitempty"`
+}
+
+// SummarizeResponse 摘要响应
+type SummarizeResponse struct {
+	*extractor.Summary
+	Duration int64 `json:"duration"`
+}
+
+// handleSummarize 离线生成抽取式摘要和关键词（AI 摘要不可用时的兜底）
+func (h *Handler) handleSummarize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req SummarizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// 获取信号量（分词和 TextRank 迭代为 CPU 密集）
+	select {
+	case h.semaphore <- struct{}{}:
+		defer func() { <-h.semaphore }()
+	default:
+		h.writeError(w, http.StatusServiceUnavailable, "Server is busy")
+		return
+	}
+
+	start := time.Now()
+	summary, err := extractor.SummarizeContent(req.Text, req.HTML, extractor.SummaryOptions{
+		Sentences: req.Sentences,
+		Keywords:  req.Keywords,
+		Title:     req.Title,
+	})
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.writeJSON(w, http.StatusOK, SummarizeResponse{Summary: summary, Duration: time.Since(start).Milliseconds()})
+}
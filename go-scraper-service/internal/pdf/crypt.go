@@ -0,0 +1,307 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现标准安全处理器的解密（仅空用户密码，即"可直接打开、限制编辑 / 复制"的文档）
+
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rc4"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+)
+
+// passwordPadding 密码填充串（ISO 32000-1 7.6.3.3）
+var passwordPadding = []byte{
+	0x28, 0xBF, 0x4E, 0x5E, 0x4E, 0x75, 0x8A, 0x41, 0x64, 0x00, 0x4E, 0x56, 0xFF, 0xFA, 0x01, 0x08,
+	0x2E, 0x2E, 0x00, 0xB6, 0xD0, 0x68, 0x3E, 0x80, 0x2F, 0x0C, 0xA9, 0xFE, 0x64, 0x53, 0x69, 0x7A,
+}
+
+// 加密算法
+const (
+	cryptNone = iota
+	cryptRC4
+	cryptAESV2
+	cryptAESV3
+)
+
+// decrypter 文档解密器
+type decrypter struct {
+	key []byte
+	// 字符串和流的加密算法
+	strMethod int
+	stmMethod int
+}
+
+// setupEncryption 按 trailer 的 /Encrypt 初始化解密器
+func (r *reader) setupEncryption() error {
+	if r.trailer["Encrypt"] == nil {
+		return nil
+	}
+	enc, ok := r.resolve(r.trailer["Encrypt"]).(Dict)
+	if !ok {
+		return ErrEncrypted
+	}
+	if filter, _ := enc["Filter"].(Name); filter != "Standard" {
+		return ErrEncrypted
+	}
+	var id []byte
+	if ids, ok := r.resolve(r.trailer["ID"]).(Array); ok && len(ids) > 0 {
+		if s, ok := r.resolve(ids[0]).(String); ok {
+			id = []byte(s)
+		}
+	}
+	d, err := newDecrypter(enc, id)
+	if err != nil {
+		return err
+	}
+	r.crypt = d
+	// 加密初始化之前加载的对象未经解密，清空缓存（Encrypt 字典本身不加密，保留）
+	r.cache = map[int]Object{}
+	if ref, ok := r.trailer["Encrypt"].(Ref); ok {
+		r.cache[ref.Num] = enc
+	}
+	return nil
+}
+
+// newDecrypter 以空用户密码计算文件密钥并校验
+func newDecrypter(enc Dict, id []byte) (*decrypter, error) {
+	v := toInt(enc["V"])
+	rev := toInt(enc["R"])
+	o, _ := enc["O"].(String)
+	u, _ := enc["U"].(String)
+
+	switch {
+	case v == 5 || rev >= 5:
+		return newAESV3Decrypter(enc, rev, []byte(u))
+	case v < 1 || v > 4 || rev < 2 || rev > 4 || len(o) < 32 || len(u) < 32:
+		return nil, ErrEncrypted
+	}
+
+	length := 5
+	if v >= 2 {
+		if n := toInt(enc["Length"]); n >= 40 && n <= 128 && n%8 == 0 {
+			length = n / 8
+		} else if n != 0 {
+			return nil, ErrEncrypted
+		} else if v == 4 {
+			length = 16
+		}
+	}
+
+	// 算法 2：计算文件密钥
+	h := md5.New()
+	h.Write(passwordPadding)
+	h.Write([]byte(o)[:32])
+	var p [4]byte
+	binary.LittleEndian.PutUint32(p[:], uint32(int32(toInt(enc["P"]))))
+	h.Write(p[:])
+	h.Write(id)
+	if rev >= 4 && enc["EncryptMetadata"] == false {
+		h.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	}
+	key := h.Sum(nil)
+	if rev >= 3 {
+		for i := 0; i < 50; i++ {
+			sum := md5.Sum(key[:length])
+			key = sum[:]
+		}
+	}
+	key = key[:length]
+
+	// 算法 4 / 5：校验用户密码
+	var check []byte
+	if rev == 2 {
+		check = rc4Crypt(key, passwordPadding)
+	} else {
+		sum := md5.Sum(append(append([]byte{}, passwordPadding...), id...))
+		check = rc4Crypt(key, sum[:])
+		for i := 1; i <= 19; i++ {
+			k := make([]byte, len(key))
+			for j := range key {
+				k[j] = key[j] ^ byte(i)
+			}
+			check = rc4Crypt(k, check)
+		}
+	}
+	if !bytes.Equal(check[:16], []byte(u)[:16]) {
+		return nil, ErrEncrypted
+	}
+
+	d := &decrypter{key: key, strMethod: cryptRC4, stmMethod: cryptRC4}
+	if v == 4 {
+		cf, _ := enc["CF"].(Dict)
+		d.strMethod = cryptFilterMethod(cf, enc["StrF"])
+		d.stmMethod = cryptFilterMethod(cf, enc["StmF"])
+	}
+	return d, nil
+}
+
+// cryptFilterMethod 解析 V4 的加密过滤器
+func cryptFilterMethod(cf Dict, name Object) int {
+	n, _ := name.(Name)
+	if n == "" || n == "Identity" {
+		return cryptNone
+	}
+	filter, _ := cf[n].(Dict)
+	switch filter["CFM"] {
+	case Name("AESV2"):
+		return cryptAESV2
+	case Name("None"):
+		return cryptNone
+	}
+	return cryptRC4
+}
+
+// newAESV3Decrypter R5 / R6（AES-256）：校验空用户密码并用中间密钥解出 /UE 中的文件密钥
+func newAESV3Decrypter(enc Dict, rev int, u []byte) (*decrypter, error) {
+	ue, _ := enc["UE"].(String)
+	if len(u) < 48 || len(ue) < 32 {
+		return nil, ErrEncrypted
+	}
+	hashFn := func(salt []byte) []byte {
+		if rev >= 6 {
+			return hash2B(nil, salt, nil)
+		}
+		sum := sha256.Sum256(salt)
+		return sum[:]
+	}
+	if !bytes.Equal(hashFn(u[32:40]), u[:32]) {
+		return nil, ErrEncrypted
+	}
+	block, err := aes.NewCipher(hashFn(u[40:48]))
+	if err != nil {
+		return nil, ErrEncrypted
+	}
+	key := make([]byte, 32)
+	cipher.NewCBCDecrypter(block, make([]byte, aes.BlockSize)).CryptBlocks(key, []byte(ue)[:32])
+
+	d := &decrypter{key: key, strMethod: cryptAESV3, stmMethod: cryptAESV3}
+	cf, _ := enc["CF"].(Dict)
+	if cryptFilterMethod(cf, enc["StrF"]) == cryptNone {
+		d.strMethod = cryptNone
+	}
+	if cryptFilterMethod(cf, enc["StmF"]) == cryptNone {
+		d.stmMethod = cryptNone
+	}
+	return d, nil
+}
+
+// hash2B R6 的密码哈希（ISO 32000-2 算法 2.B）
+func hash2B(password, salt, udata []byte) []byte {
+	input := append(append(append([]byte{}, password...), salt...), udata...)
+	sum := sha256.Sum256(input)
+	k := sum[:]
+	for round := 0; ; round++ {
+		var k1 []byte
+		for i := 0; i < 64; i++ {
+			k1 = append(k1, password...)
+			k1 = append(k1, k...)
+			k1 = append(k1, udata...)
+		}
+		block, _ := aes.NewCipher(k[:16])
+		e := make([]byte, len(k1))
+		cipher.NewCBCEncrypter(block, k[16:32]).CryptBlocks(e, k1)
+
+		var mod int
+		for _, b := range e[:16] {
+			mod += int(b)
+		}
+		var h hash.Hash
+		switch mod % 3 {
+		case 0:
+			h = sha256.New()
+		case 1:
+			h = sha512.New384()
+		default:
+			h = sha512.New()
+		}
+		h.Write(e)
+		k = h.Sum(nil)
+		if round >= 63 && int(e[len(e)-1]) <= round+1-32 {
+			break
+		}
+	}
+	return k[:32]
+}
+
+// decryptObject 递归解密对象中的字符串
+func (d *decrypter) decryptObject(ref Ref, obj Object) Object {
+	switch v := obj.(type) {
+	case String:
+		return String(d.decrypt(ref, []byte(v), false))
+	case Array:
+		out := make(Array, len(v))
+		for i, item := range v {
+			out[i] = d.decryptObject(ref, item)
+		}
+		return out
+	case Dict:
+		out := make(Dict, len(v))
+		for k, item := range v {
+			out[k] = d.decryptObject(ref, item)
+		}
+		return out
+	}
+	return obj
+}
+
+// decrypt 解密字符串或流数据
+func (d *decrypter) decrypt(ref Ref, data []byte, stream bool) []byte {
+	method := d.strMethod
+	if stream {
+		method = d.stmMethod
+	}
+	switch method {
+	case cryptRC4:
+		return rc4Crypt(d.objectKey(ref, false), data)
+	case cryptAESV2:
+		return aesDecrypt(d.objectKey(ref, true), data)
+	case cryptAESV3:
+		return aesDecrypt(d.key, data)
+	}
+	return data
+}
+
+// objectKey 算法 1：按对象号和代号派生对象密钥
+func (d *decrypter) objectKey(ref Ref, aes bool) []byte {
+	b := append([]byte{}, d.key...)
+	b = append(b, byte(ref.Num), byte(ref.Num>>8), byte(ref.Num>>16), byte(ref.Gen), byte(ref.Gen>>8))
+	if aes {
+		b = append(b, "sAlT"...)
+	}
+	sum := md5.Sum(b)
+	return sum[:min(len(d.key)+5, 16)]
+}
+
+func rc4Crypt(key, data []byte) []byte {
+	c, err := rc4.NewCipher(key)
+	if err != nil {
+		return data
+	}
+	out := make([]byte, len(data))
+	c.XORKeyStream(out, data)
+	return out
+}
+
+// aesDecrypt AES-CBC 解密（前 16 字节为 IV，去除 PKCS#7 填充），数据不完整时返回空
+func aesDecrypt(key, data []byte) []byte {
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil
+	}
+	out := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(out, data[aes.BlockSize:])
+	if pad := int(out[len(out)-1]); pad >= 1 && pad <= aes.BlockSize && pad <= len(out) {
+		out = out[:len(out)-pad]
+	}
+	return out
+}
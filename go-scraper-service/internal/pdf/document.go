@@ -0,0 +1,483 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现文档加载：定位间接对象（含对象流）、合并 trailer、遍历页面树和读取文档信息
+
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNotPDF 数据不是 PDF 文档
+	ErrNotPDF = errors.New("pdf: not a PDF document")
+	// ErrEncrypted 文档已加密且无法以空密码打开（或使用了不支持的加密方式）
+	ErrEncrypted = errors.New("pdf: document is encrypted")
+	// ErrNoPages 文档没有可用的页面
+	ErrNoPages = errors.New("pdf: document has no pages")
+)
+
+// maxPages 最多提取的页数（页数统计不受此限制）
+const maxPages = 2000
+
+// Info 文档信息字典
+type Info struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords string
+	Creator  string
+	Producer string
+	// 创建 / 修改时间（无法解析时为零值）
+	Created  time.Time
+	Modified time.Time
+}
+
+// Document 解析后的文档
+type Document struct {
+	Info Info
+	// 页数
+	PageCount int
+	// 各页的文本块（最多 maxPages 页）
+	Pages []Page
+}
+
+var (
+	objHeaderRe = regexp.MustCompile(`(\d+)[ \t\r\n\f\x00]+(\d+)[ \t\r\n\f\x00]+obj\b`)
+	trailerRe   = regexp.MustCompile(`trailer[ \t\r\n\f\x00]*<<`)
+	objStmRe    = regexp.MustCompile(`/Type[ \t\r\n\f\x00]*/(ObjStm|XRef)\b`)
+	catalogRe   = regexp.MustCompile(`/Type[ \t\r\n\f\x00]*/Catalog\b`)
+)
+
+// reader 间接对象读取器
+//
+// 不依赖 xref 表（常见的损坏和偏移错误都在 xref 上），而是扫描全文的 "n g obj" 定位对象，
+// 增量更新时后出现的定义覆盖先出现的；压缩在对象流中的对象通过对象流头部定位。
+type reader struct {
+	data []byte
+	// 对象号 -> 文件偏移
+	offsets map[int]int
+	// 对象号 -> 所在对象流和序号
+	compressed map[int]objStmEntry
+	cache      map[int]Object
+	loading    map[int]bool
+	objStms    map[int][]int
+	trailer    Dict
+	crypt      *decrypter
+}
+
+type objStmEntry struct {
+	stream int
+	index  int
+}
+
+// Parse 解析 PDF 文档并提取各页文本
+func Parse(data []byte) (*Document, error) {
+	start := bytes.Index(data[:min(len(data), 1024)], []byte("%PDF-"))
+	if start < 0 {
+		return nil, ErrNotPDF
+	}
+	r := &reader{
+		data:       data,
+		offsets:    map[int]int{},
+		compressed: map[int]objStmEntry{},
+		cache:      map[int]Object{},
+		loading:    map[int]bool{},
+		objStms:    map[int][]int{},
+		trailer:    Dict{},
+	}
+	r.scanObjects()
+	// XRef 流不加密，其中的 trailer 条目（含 /Encrypt）需在加密初始化之前合并
+	r.scanStreams("XRef")
+	if err := r.setupEncryption(); err != nil {
+		return nil, err
+	}
+	r.scanStreams("ObjStm")
+
+	catalog, _ := r.resolve(r.trailer["Root"]).(Dict)
+	if catalog == nil {
+		catalog = r.findCatalog()
+	}
+	if catalog == nil {
+		return nil, ErrNoPages
+	}
+
+	var pages []Dict
+	r.walkPages(r.resolve(catalog["Pages"]), nil, map[Ref]bool{}, &pages, 0)
+	if len(pages) == 0 {
+		return nil, ErrNoPages
+	}
+
+	doc := &Document{PageCount: len(pages)}
+	if info, ok := r.resolve(r.trailer["Info"]).(Dict); ok {
+		doc.Info = r.readInfo(info)
+	}
+	ext := newTextExtractor(r)
+	for i, page := range pages {
+		if i >= maxPages {
+			break
+		}
+		doc.Pages = append(doc.Pages, Page{lines: ext.pageLines(page)})
+	}
+	layoutPages(doc.Pages)
+	return doc, nil
+}
+
+// scanObjects 扫描全文的间接对象定义和 trailer 字典
+func (r *reader) scanObjects() {
+	for _, m := range objHeaderRe.FindAllSubmatchIndex(r.data, -1) {
+		if m[0] > 0 && !isSpace(r.data[m[0]-1]) && !isDelimiter(r.data[m[0]-1]) {
+			continue
+		}
+		num, err := strconv.Atoi(string(r.data[m[2]:m[3]]))
+		if err != nil || num <= 0 {
+			continue
+		}
+		r.offsets[num] = m[0]
+	}
+	for _, m := range trailerRe.FindAllIndex(r.data, -1) {
+		l := &lexer{data: r.data, pos: m[1] - 2}
+		if dict, ok := mustObject(l).(Dict); ok {
+			r.mergeTrailer(dict)
+		}
+	}
+}
+
+// mergeTrailer 合并 trailer 中的文档级条目（后出现的覆盖先出现的）
+func (r *reader) mergeTrailer(dict Dict) {
+	for _, key := range []Name{"Root", "Info", "Encrypt", "ID"} {
+		if v, ok := dict[key]; ok && v != nil {
+			r.trailer[key] = v
+		}
+	}
+}
+
+// scanStreams 合并 XRef 流字典中的 trailer 条目，或登记对象流中的对象
+//
+// 直接定义的对象优先于对象流中的同号对象。
+func (r *reader) scanStreams(typ Name) {
+	starts := r.sortedOffsets()
+	seen := map[int]bool{}
+	for _, m := range objStmRe.FindAllSubmatchIndex(r.data, -1) {
+		if Name(r.data[m[2]:m[3]]) != typ {
+			continue
+		}
+		num := objectAt(starts, m[0])
+		if num == 0 || seen[num] {
+			continue
+		}
+		seen[num] = true
+		stm, ok := r.load(num).(*Stream)
+		if !ok || stm.Dict["Type"] != typ {
+			continue
+		}
+		switch typ {
+		case "XRef":
+			r.mergeTrailer(stm.Dict)
+		case "ObjStm":
+			for i, n := range r.objStmNumbers(num, stm) {
+				if _, direct := r.offsets[n]; !direct && n > 0 {
+					r.compressed[n] = objStmEntry{stream: num, index: i}
+				}
+			}
+		}
+	}
+}
+
+type offsetEntry struct {
+	pos int
+	num int
+}
+
+func (r *reader) sortedOffsets() []offsetEntry {
+	starts := make([]offsetEntry, 0, len(r.offsets))
+	for num, pos := range r.offsets {
+		starts = append(starts, offsetEntry{pos, num})
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].pos < starts[j].pos })
+	return starts
+}
+
+// objectAt 返回 pos 所在的（之前最近定义的）对象号
+func objectAt(starts []offsetEntry, pos int) int {
+	i := sort.Search(len(starts), func(i int) bool { return starts[i].pos > pos })
+	if i == 0 {
+		return 0
+	}
+	return starts[i-1].num
+}
+
+// objStmNumbers 解析对象流头部的对象号列表
+func (r *reader) objStmNumbers(num int, stm *Stream) []int {
+	if nums, ok := r.objStms[num]; ok {
+		return nums
+	}
+	var nums []int
+	if data, err := r.streamData(stm); err == nil {
+		l := &lexer{data: data}
+		n := toInt(stm.Dict["N"])
+		for i := 0; i < n && i < 1<<20; i++ {
+			objNum, err1 := l.readObject()
+			_, err2 := l.readObject()
+			if err1 != nil || err2 != nil {
+				break
+			}
+			nums = append(nums, toInt(objNum))
+		}
+	}
+	r.objStms[num] = nums
+	return nums
+}
+
+// findCatalog 在 trailer 缺失或损坏时查找 /Type /Catalog 对象
+func (r *reader) findCatalog() Dict {
+	starts := r.sortedOffsets()
+	for _, m := range catalogRe.FindAllIndex(r.data, -1) {
+		if dict, ok := r.load(objectAt(starts, m[0])).(Dict); ok && dict["Type"] == Name("Catalog") {
+			return dict
+		}
+	}
+	for num := range r.compressed {
+		if dict, ok := r.load(num).(Dict); ok && dict["Type"] == Name("Catalog") {
+			return dict
+		}
+	}
+	return nil
+}
+
+// resolve 解析间接引用
+func (r *reader) resolve(o Object) Object {
+	if ref, ok := o.(Ref); ok {
+		return r.load(ref.Num)
+	}
+	return o
+}
+
+// load 加载间接对象（带缓存，循环引用时返回 nil）
+func (r *reader) load(num int) Object {
+	if obj, ok := r.cache[num]; ok {
+		return obj
+	}
+	if r.loading[num] {
+		return nil
+	}
+	r.loading[num] = true
+	defer delete(r.loading, num)
+
+	var obj Object
+	if pos, ok := r.offsets[num]; ok {
+		obj = r.loadDirect(num, pos)
+	} else if entry, ok := r.compressed[num]; ok {
+		obj = r.loadCompressed(entry)
+	}
+	r.cache[num] = obj
+	return obj
+}
+
+// loadDirect 读取 pos 处的 "n g obj ... endobj"
+func (r *reader) loadDirect(num, pos int) Object {
+	l := &lexer{data: r.data, pos: pos}
+	mustObject(l) // n
+	gen := toInt(mustObject(l))
+	mustObject(l) // obj
+	obj, err := l.readObject()
+	if err != nil && obj == nil {
+		return nil
+	}
+	ref := Ref{Num: num, Gen: gen}
+	dict, ok := obj.(Dict)
+	if ok {
+		save := l.pos
+		if kw, _ := mustObject(l).(keyword); kw == "stream" {
+			return r.readStream(l, dict, ref)
+		}
+		l.pos = save
+	}
+	if r.crypt != nil {
+		obj = r.crypt.decryptObject(ref, obj)
+	}
+	return obj
+}
+
+// readStream 读取流数据（"stream" 关键字已读取），/Length 不可用或错误时搜索 endstream
+func (r *reader) readStream(l *lexer, dict Dict, ref Ref) *Stream {
+	start := l.pos
+	if start < len(r.data) && r.data[start] == '\r' {
+		start++
+	}
+	if start < len(r.data) && r.data[start] == '\n' {
+		start++
+	}
+	if r.crypt != nil && dict["Type"] != Name("XRef") {
+		dict = r.crypt.decryptObject(ref, dict).(Dict)
+	}
+	stm := &Stream{Dict: dict, ref: ref}
+
+	length := -1
+	if n, ok := r.resolve(dict["Length"]).(int64); ok {
+		length = int(n)
+	}
+	if length >= 0 && start+length <= len(r.data) {
+		rest := &lexer{data: r.data, pos: start + length}
+		rest.skipSpace()
+		if bytes.HasPrefix(r.data[rest.pos:], []byte("endstream")) {
+			stm.Data = r.data[start : start+length]
+			return stm
+		}
+	}
+	end := bytes.Index(r.data[start:], []byte("endstream"))
+	if end < 0 {
+		end = len(r.data) - start
+	}
+	data := r.data[start : start+end]
+	if n := len(data); n > 0 && data[n-1] == '\n' {
+		data = data[:n-1]
+	}
+	if n := len(data); n > 0 && data[n-1] == '\r' {
+		data = data[:n-1]
+	}
+	stm.Data = data
+	return stm
+}
+
+// loadCompressed 读取对象流中的对象（其中的字符串不单独加密）
+func (r *reader) loadCompressed(entry objStmEntry) Object {
+	stm, ok := r.load(entry.stream).(*Stream)
+	if !ok {
+		return nil
+	}
+	data, err := r.streamData(stm)
+	if err != nil {
+		return nil
+	}
+	l := &lexer{data: data}
+	n := toInt(stm.Dict["N"])
+	if entry.index >= n {
+		return nil
+	}
+	var offset int
+	for i := 0; i <= entry.index; i++ {
+		mustObject(l)
+		offset = toInt(mustObject(l))
+	}
+	l.pos = toInt(stm.Dict["First"]) + offset
+	if l.pos < 0 || l.pos >= len(data) {
+		return nil
+	}
+	obj, _ := l.readObject()
+	return obj
+}
+
+// streamData 解密并解码流数据
+func (r *reader) streamData(stm *Stream) ([]byte, error) {
+	data := stm.Data
+	if r.crypt != nil && stm.ref.Num > 0 && stm.Dict["Type"] != Name("XRef") {
+		data = r.crypt.decrypt(stm.ref, data, true)
+	}
+	return decodeFilters(data, r.resolveDeep(stm.Dict["Filter"]), r.resolveDeep(stm.Dict["DecodeParms"]))
+}
+
+// resolveDeep 解析引用，数组元素也一并解析
+func (r *reader) resolveDeep(o Object) Object {
+	o = r.resolve(o)
+	if arr, ok := o.(Array); ok {
+		out := make(Array, len(arr))
+		for i, v := range arr {
+			out[i] = r.resolve(v)
+		}
+		return out
+	}
+	return o
+}
+
+// mustObject 读取对象，出错时返回 nil
+func mustObject(l *lexer) Object {
+	obj, _ := l.readObject()
+	return obj
+}
+
+// walkPages 遍历页面树，继承 Resources
+func (r *reader) walkPages(node Object, resources Object, visited map[Ref]bool, pages *[]Dict, depth int) {
+	if ref, ok := node.(Ref); ok {
+		if visited[ref] {
+			return
+		}
+		visited[ref] = true
+		node = r.resolve(ref)
+	}
+	dict, ok := node.(Dict)
+	if !ok || depth > maxNesting {
+		return
+	}
+	if res, ok := dict["Resources"]; ok {
+		resources = res
+	}
+	kids, isTree := r.resolve(dict["Kids"]).(Array)
+	if !isTree || dict["Type"] == Name("Page") {
+		page := Dict{}
+		for k, v := range dict {
+			page[k] = v
+		}
+		page["Resources"] = resources
+		*pages = append(*pages, page)
+		return
+	}
+	for _, kid := range kids {
+		r.walkPages(kid, resources, visited, pages, depth+1)
+	}
+}
+
+// readInfo 读取文档信息字典
+func (r *reader) readInfo(info Dict) Info {
+	text := func(key Name) string {
+		s, _ := r.resolve(info[key]).(String)
+		return decodeTextString(s)
+	}
+	return Info{
+		Title:    text("Title"),
+		Author:   text("Author"),
+		Subject:  text("Subject"),
+		Keywords: text("Keywords"),
+		Creator:  text("Creator"),
+		Producer: text("Producer"),
+		Created:  parseDate(text("CreationDate")),
+		Modified: parseDate(text("ModDate")),
+	}
+}
+
+// pdfDateRe PDF 日期格式 D:YYYYMMDDHHmmSSOHH'mm'（除年份外各部分均可省略）
+var pdfDateRe = regexp.MustCompile(`^(?:D:)?(\d{4})(\d{2})?(\d{2})?(\d{2})?(\d{2})?(\d{2})?\s*(?:([+\-Z])(\d{2})?'?(\d{2})?'?)?`)
+
+// parseDate 解析 PDF 日期，无法解析时返回零值
+func parseDate(s string) time.Time {
+	m := pdfDateRe.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}
+	}
+	num := func(i, def int) int {
+		if m[i] == "" {
+			return def
+		}
+		n, _ := strconv.Atoi(m[i])
+		return n
+	}
+	year, month, day := num(1, 0), num(2, 1), num(3, 1)
+	hour, minute, sec := num(4, 0), num(5, 0), num(6, 0)
+	if year < 1900 || month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || sec > 59 {
+		return time.Time{}
+	}
+	loc := time.UTC
+	if m[7] == "+" || m[7] == "-" {
+		offset := num(8, 0)*3600 + num(9, 0)*60
+		if m[7] == "-" {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, sec, 0, loc)
+}
@@ -0,0 +1,315 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现流对象的解码过滤器
+
+package pdf
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxStreamSize 单个流解码后的最大长度（防止压缩炸弹）
+const maxStreamSize = 64 << 20
+
+// ErrUnsupportedFilter 不支持的过滤器（图片编码等与文本无关的过滤器）
+var ErrUnsupportedFilter = errors.New("pdf: unsupported filter")
+
+// decodeFilters 按 /Filter 和 /DecodeParms 依次解码
+func decodeFilters(data []byte, filter, params Object) ([]byte, error) {
+	var filters []Name
+	var parms []Dict
+	switch f := filter.(type) {
+	case Name:
+		filters = []Name{f}
+		p, _ := params.(Dict)
+		parms = []Dict{p}
+	case Array:
+		pa, _ := params.(Array)
+		for i, v := range f {
+			name, _ := v.(Name)
+			filters = append(filters, name)
+			var p Dict
+			if i < len(pa) {
+				p, _ = pa[i].(Dict)
+			}
+			parms = append(parms, p)
+		}
+	}
+
+	var err error
+	for i, name := range filters {
+		switch name {
+		case "FlateDecode", "Fl":
+			data, err = flateDecode(data)
+		case "LZWDecode", "LZW":
+			early := 1
+			if v, ok := parms[i]["EarlyChange"]; ok {
+				early = toInt(v)
+			}
+			data, err = lzwDecode(data, early == 1)
+		case "ASCIIHexDecode", "AHx":
+			end := bytes.IndexByte(data, '>')
+			if end >= 0 {
+				data = data[:end]
+			}
+			data = decodeHex(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85Decode(data)
+		case "RunLengthDecode", "RL":
+			data = runLengthDecode(data)
+		case "Crypt":
+			// 只支持 Identity 加密过滤器（文档级加密在解码前处理）
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedFilter, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxStreamSize {
+			return nil, errors.New("pdf: stream too large")
+		}
+		if name == "FlateDecode" || name == "Fl" || name == "LZWDecode" || name == "LZW" {
+			if data, err = applyPredictor(data, parms[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return data, nil
+}
+
+// flateDecode zlib 解压；数据损坏时保留已解压的部分，缺少 zlib 头时按原始 deflate 解压
+func flateDecode(data []byte) ([]byte, error) {
+	var r io.ReadCloser
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		r = flate.NewReader(bytes.NewReader(data))
+	} else {
+		r = zr
+	}
+	defer r.Close()
+	out, err := io.ReadAll(io.LimitReader(r, maxStreamSize+1))
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+// applyPredictor 处理 PNG（>=10）/ TIFF（2）预测器
+func applyPredictor(data []byte, parms Dict) ([]byte, error) {
+	predictor := toInt(parms["Predictor"])
+	if predictor <= 1 {
+		return data, nil
+	}
+	colors, bpc, columns := 1, 8, 1
+	if v, ok := parms["Colors"]; ok {
+		colors = toInt(v)
+	}
+	if v, ok := parms["BitsPerComponent"]; ok {
+		bpc = toInt(v)
+	}
+	if v, ok := parms["Columns"]; ok {
+		columns = toInt(v)
+	}
+	if colors <= 0 || bpc <= 0 || columns <= 0 || colors*bpc*columns > 1<<20 {
+		return nil, errors.New("pdf: invalid predictor parameters")
+	}
+	bpp := max(1, colors*bpc/8)
+	rowLen := (colors*bpc*columns + 7) / 8
+
+	if predictor == 2 {
+		if bpc != 8 {
+			return data, nil
+		}
+		for row := 0; row+rowLen <= len(data); row += rowLen {
+			for i := bpp; i < rowLen; i++ {
+				data[row+i] += data[row+i-bpp]
+			}
+		}
+		return data, nil
+	}
+
+	var out []byte
+	prev := make([]byte, rowLen)
+	for pos := 0; pos+1 <= len(data); pos += rowLen + 1 {
+		filterType := data[pos]
+		end := min(pos+1+rowLen, len(data))
+		cur := make([]byte, rowLen)
+		copy(cur, data[pos+1:end])
+		for i := 0; i < rowLen; i++ {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = cur[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filterType {
+			case 1:
+				cur[i] += left
+			case 2:
+				cur[i] += up
+			case 3:
+				cur[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				cur[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, cur...)
+		prev = cur
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// ascii85Decode ASCII85 解码（忽略空白，"~>" 结束，支持 z 缩写）
+func ascii85Decode(data []byte) ([]byte, error) {
+	var out []byte
+	var group [5]byte
+	n := 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case isSpace(c):
+			continue
+		case c == '~':
+			i = len(data)
+			continue
+		case c == 'z' && n == 0:
+			out = append(out, 0, 0, 0, 0)
+			continue
+		case c < '!' || c > 'u':
+			return nil, errors.New("pdf: invalid ASCII85 data")
+		}
+		group[n] = c - '!'
+		n++
+		if n == 5 {
+			out = appendA85(out, group, 4)
+			n = 0
+		}
+	}
+	if n > 1 {
+		for i := n; i < 5; i++ {
+			group[i] = 'u' - '!'
+		}
+		out = appendA85(out, group, n-1)
+	}
+	return out, nil
+}
+
+func appendA85(out []byte, group [5]byte, n int) []byte {
+	var v uint32
+	for _, d := range group {
+		v = v*85 + uint32(d)
+	}
+	b := [4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	return append(out, b[:n]...)
+}
+
+// runLengthDecode RunLength 解码
+func runLengthDecode(data []byte) []byte {
+	var out []byte
+	for i := 0; i < len(data); {
+		n := int(data[i])
+		i++
+		switch {
+		case n == 128:
+			return out
+		case n < 128:
+			end := min(i+n+1, len(data))
+			out = append(out, data[i:end]...)
+			i = end
+		default:
+			if i < len(data) {
+				out = append(out, bytes.Repeat(data[i:i+1], 257-n)...)
+				i++
+			}
+		}
+	}
+	return out
+}
+
+// lzwDecode PDF 的 LZW 解码（MSB 顺序，9-12 位码，earlyChange 时提前一个码增加码长）
+func lzwDecode(data []byte, earlyChange bool) ([]byte, error) {
+	const (
+		clearCode = 256
+		eodCode   = 257
+	)
+	var out []byte
+	table := make([][]byte, 258, 4096)
+	reset := func() {
+		table = table[:258]
+		for i := 0; i < 256; i++ {
+			table[i] = []byte{byte(i)}
+		}
+	}
+	reset()
+	width := 9
+	var bitBuf uint32
+	bits := 0
+	var prev []byte
+	early := 0
+	if earlyChange {
+		early = 1
+	}
+	for pos := 0; ; {
+		for bits < width && pos < len(data) {
+			bitBuf = bitBuf<<8 | uint32(data[pos])
+			bits += 8
+			pos++
+		}
+		if bits < width {
+			return out, nil
+		}
+		code := int(bitBuf>>(bits-width)) & (1<<width - 1)
+		bits -= width
+		switch {
+		case code == clearCode:
+			reset()
+			width, prev = 9, nil
+			continue
+		case code == eodCode:
+			return out, nil
+		}
+		var entry []byte
+		switch {
+		case code < len(table):
+			entry = table[code]
+		case code == len(table) && prev != nil:
+			entry = append(append([]byte{}, prev...), prev[0])
+		default:
+			return out, errors.New("pdf: invalid LZW code")
+		}
+		out = append(out, entry...)
+		if len(out) > maxStreamSize {
+			return nil, errors.New("pdf: stream too large")
+		}
+		if prev != nil && len(table) < 4096 {
+			table = append(table, append(append([]byte{}, prev...), entry[0]))
+		}
+		prev = entry
+		if len(table)+early >= 1<<width && width < 12 {
+			width++
+		}
+	}
+}
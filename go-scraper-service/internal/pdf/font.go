@@ -0,0 +1,547 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现字体编码到 Unicode 的映射（ToUnicode CMap、简单字体编码、预定义 CJK CMap）和字宽
+
+package pdf
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// glyph 解码后的字形
+type glyph struct {
+	// Unicode 文本（无法映射时为空）
+	text string
+	// 字宽（千分之一文本空间单位）
+	width float64
+	// 是否为单字节编码 32（适用字间距 Tw）
+	space bool
+}
+
+// font 字体的编码和字宽信息
+type font struct {
+	// ToUnicode CMap
+	toUnicode *cmap
+	// 编码 CMap 的码空间（Type0 字体，nil 时按双字节）
+	codespace *cmap
+	// 是否为复合字体（Type0）
+	composite bool
+	// 复合字体使用 Unicode 编码的预定义 CMap（Uni*-UCS2 / UTF16）
+	utf16 bool
+	// 复合字体使用多字节传统编码的预定义 CMap（GBK-EUC、B5pc 等）
+	legacy encoding.Encoding
+	// 简单字体的编码表
+	encoding *[256]rune
+	// 简单字体 Differences 中的字形名
+	differences map[int]string
+
+	firstChar    int
+	widths       []float64
+	cidWidths    map[int]float64
+	defaultWidth float64
+	// Type3 字体的 FontMatrix 缩放
+	scale float64
+}
+
+// loadFont 读取字体字典
+func (r *reader) loadFont(dict Dict) *font {
+	f := &font{scale: 1}
+	subtype, _ := dict["Subtype"].(Name)
+
+	if stm, ok := r.resolve(dict["ToUnicode"]).(*Stream); ok {
+		if data, err := r.streamData(stm); err == nil {
+			f.toUnicode = parseCMap(data)
+		}
+	}
+
+	if subtype == "Type0" {
+		f.composite = true
+		f.defaultWidth = 1000
+		switch enc := r.resolve(dict["Encoding"]).(type) {
+		case Name:
+			f.utf16, f.legacy = predefinedCMap(string(enc))
+		case *Stream:
+			if data, err := r.streamData(enc); err == nil {
+				f.codespace = parseCMap(data)
+			}
+			if name, ok := r.resolve(enc.Dict["UseCMap"]).(Name); ok {
+				f.utf16, f.legacy = predefinedCMap(string(name))
+			}
+		}
+		if descendants, ok := r.resolve(dict["DescendantFonts"]).(Array); ok && len(descendants) > 0 {
+			if cid, ok := r.resolve(descendants[0]).(Dict); ok {
+				if dw := r.resolve(cid["DW"]); isNumber(dw) {
+					f.defaultWidth = toFloat(dw)
+				}
+				f.cidWidths = r.readCIDWidths(r.resolveDeep(cid["W"]))
+			}
+		}
+		return f
+	}
+
+	// 简单字体
+	f.defaultWidth = 500
+	if fd, ok := r.resolve(dict["FontDescriptor"]).(Dict); ok {
+		if mw := toFloat(r.resolve(fd["MissingWidth"])); mw > 0 {
+			f.defaultWidth = mw
+		}
+	}
+	f.firstChar = toInt(r.resolve(dict["FirstChar"]))
+	if widths, ok := r.resolveDeep(dict["Widths"]).(Array); ok {
+		for _, w := range widths {
+			f.widths = append(f.widths, toFloat(w))
+		}
+	}
+	if subtype == "Type3" {
+		if m, ok := r.resolveDeep(dict["FontMatrix"]).(Array); ok && len(m) == 6 {
+			f.scale = toFloat(m[0]) * 1000
+		}
+	}
+
+	f.encoding = &standardEncoding
+	if subtype == "TrueType" {
+		f.encoding = &winAnsiEncoding
+	}
+	baseFont, _ := dict["BaseFont"].(Name)
+	if strings.Contains(string(baseFont), "Symbol") || strings.Contains(string(baseFont), "Dingbats") {
+		// 符号字体的内置编码不对应文字，只使用 ToUnicode 和 Differences
+		f.encoding = nil
+	}
+	switch enc := r.resolve(dict["Encoding"]).(type) {
+	case Name:
+		if e := namedEncoding(enc); e != nil {
+			f.encoding = e
+		}
+	case Dict:
+		if base, ok := enc["BaseEncoding"].(Name); ok {
+			if e := namedEncoding(base); e != nil {
+				f.encoding = e
+			}
+		}
+		if diffs, ok := r.resolveDeep(enc["Differences"]).(Array); ok {
+			f.differences = map[int]string{}
+			code := 0
+			for _, d := range diffs {
+				switch v := d.(type) {
+				case int64, float64:
+					code = toInt(v)
+				case Name:
+					if code >= 0 && code < 256 {
+						f.differences[code] = string(v)
+					}
+					code++
+				}
+			}
+		}
+	}
+	return f
+}
+
+// readCIDWidths 解析 CID 字体的 /W 数组（"c [w1 w2 ...]" 或 "c1 c2 w"）
+func (r *reader) readCIDWidths(w Object) map[int]float64 {
+	arr, ok := w.(Array)
+	if !ok {
+		return nil
+	}
+	widths := map[int]float64{}
+	for i := 0; i+1 < len(arr); {
+		first := toInt(arr[i])
+		if list, ok := r.resolveDeep(arr[i+1]).(Array); ok {
+			for j, v := range list {
+				widths[first+j] = toFloat(v)
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(arr) {
+			break
+		}
+		last, width := toInt(arr[i+1]), toFloat(arr[i+2])
+		for c := first; c <= last && c-first < 1<<16; c++ {
+			widths[c] = width
+		}
+		i += 3
+	}
+	return widths
+}
+
+// predefinedCMap 识别预定义 CMap 的编码：Unicode（UCS2 / UTF16）或可解码的传统多字节编码
+//
+// Identity-H / V 等其他预定义 CMap 的编码与 Unicode 无关，需依赖 ToUnicode。
+func predefinedCMap(name string) (utf16 bool, legacy encoding.Encoding) {
+	switch {
+	case strings.HasPrefix(name, "Uni") && (strings.Contains(name, "UCS2") || strings.Contains(name, "UTF16")):
+		return true, nil
+	case strings.HasPrefix(name, "GBK") || strings.HasPrefix(name, "GB-EUC") || strings.HasPrefix(name, "GBpc-EUC"):
+		return false, simplifiedchinese.GB18030
+	case strings.HasPrefix(name, "B5") || strings.HasPrefix(name, "ETen-B5") || strings.HasPrefix(name, "HKscs-B5"):
+		return false, traditionalchinese.Big5
+	case strings.HasPrefix(name, "90ms-RKSJ") || strings.HasPrefix(name, "83pv-RKSJ") || strings.HasPrefix(name, "90pv-RKSJ"):
+		return false, japanese.ShiftJIS
+	case strings.HasPrefix(name, "EUC-"):
+		return false, japanese.EUCJP
+	case strings.HasPrefix(name, "KSC-EUC") || strings.HasPrefix(name, "KSCms-UHC"):
+		return false, korean.EUCKR
+	}
+	return false, nil
+}
+
+// decode 将字符串解码为字形序列
+func (f *font) decode(s String) []glyph {
+	if !f.composite {
+		glyphs := make([]glyph, 0, len(s))
+		for i := 0; i < len(s); i++ {
+			code := int(s[i])
+			glyphs = append(glyphs, glyph{text: f.simpleText(code), width: f.simpleWidth(code), space: code == 32})
+		}
+		return glyphs
+	}
+
+	if f.toUnicode == nil && f.legacy != nil {
+		return f.decodeLegacy(s)
+	}
+
+	var glyphs []glyph
+	for i := 0; i < len(s); {
+		n := 2
+		if f.codespace != nil && len(f.codespace.codespace) > 0 {
+			n = f.codespace.codeLength([]byte(s[i:]))
+		} else if f.toUnicode != nil && len(f.toUnicode.codespace) > 0 {
+			n = f.toUnicode.codeLength([]byte(s[i:]))
+		}
+		n = min(n, len(s)-i)
+		code := 0
+		for _, b := range []byte(s[i : i+n]) {
+			code = code<<8 | int(b)
+		}
+		g := glyph{width: f.defaultWidth, space: n == 1 && code == 32}
+		if w, ok := f.cidWidths[code]; ok {
+			// Identity 编码下编码即 CID；其他编码的 CID 映射不可得时近似使用编码值
+			g.width = w
+		}
+		switch {
+		case f.toUnicode != nil:
+			g.text = f.toUnicode.lookup([]byte(s[i : i+n]))
+		case f.utf16:
+			g.text = decodeUTF16([]byte(s[i : i+n]))
+		}
+		glyphs = append(glyphs, g)
+		i += n
+	}
+	return glyphs
+}
+
+// decodeLegacy 按传统多字节编码整体解码（GBK、Big5 等），每个字符按默认字宽
+func (f *font) decodeLegacy(s String) []glyph {
+	text, err := f.legacy.NewDecoder().String(string(s))
+	if err != nil {
+		return nil
+	}
+	glyphs := make([]glyph, 0, len(text))
+	for _, r := range text {
+		width := f.defaultWidth
+		if r < 0x80 {
+			width = f.defaultWidth / 2
+		}
+		glyphs = append(glyphs, glyph{text: string(r), width: width, space: r == ' '})
+	}
+	return glyphs
+}
+
+// simpleText 简单字体的编码映射：ToUnicode > Differences 字形名 > 基础编码
+func (f *font) simpleText(code int) string {
+	if f.toUnicode != nil {
+		if s := f.toUnicode.lookup([]byte{byte(code)}); s != "" {
+			return s
+		}
+	}
+	if name, ok := f.differences[code]; ok {
+		if s := glyphNameToUnicode(name); s != "" {
+			return s
+		}
+	}
+	if f.encoding != nil {
+		if r := f.encoding[code]; r != 0 {
+			return string(r)
+		}
+	}
+	return ""
+}
+
+func (f *font) simpleWidth(code int) float64 {
+	if i := code - f.firstChar; i >= 0 && i < len(f.widths) && f.widths[i] > 0 {
+		return f.widths[i] * f.scale
+	}
+	if f.widths == nil {
+		// 标准 14 字体等未提供字宽时按平均字宽估算
+		if code == 32 {
+			return 250
+		}
+		return 500
+	}
+	return f.defaultWidth * f.scale
+}
+
+// decodeUTF16 解码 UTF-16BE
+func decodeUTF16(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// cmap ToUnicode / 编码 CMap
+type cmap struct {
+	codespace []codeRange
+	chars     map[string]string
+	ranges    []bfRange
+}
+
+// codeRange 码空间范围
+type codeRange struct {
+	low, high []byte
+}
+
+// bfRange bfrange 映射：dst 为起始 Unicode（随编码递增）或逐个编码的目标数组
+type bfRange struct {
+	low, high uint32
+	n         int
+	dst       []byte
+	list      []string
+}
+
+// parseCMap 解析 CMap 流（只处理 codespacerange、bfchar、bfrange）
+func parseCMap(data []byte) *cmap {
+	c := &cmap{chars: map[string]string{}}
+	l := &lexer{data: data}
+	var operands []Object
+	for !l.eof() {
+		start := l.pos
+		obj, err := l.readObject()
+		if l.pos == start {
+			l.pos++
+		}
+		if err != nil {
+			operands = operands[:0]
+			continue
+		}
+		kw, ok := obj.(keyword)
+		if !ok {
+			if len(operands) < 1<<16 {
+				operands = append(operands, obj)
+			}
+			continue
+		}
+		switch kw {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				low, ok1 := operands[i].(String)
+				high, ok2 := operands[i+1].(String)
+				if ok1 && ok2 && len(low) == len(high) && len(low) > 0 {
+					c.codespace = append(c.codespace, codeRange{[]byte(low), []byte(high)})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok := operands[i].(String)
+				if !ok {
+					continue
+				}
+				switch dst := operands[i+1].(type) {
+				case String:
+					c.chars[string(src)] = decodeUTF16([]byte(dst))
+				case Name:
+					c.chars[string(src)] = glyphNameToUnicode(string(dst))
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, ok1 := operands[i].(String)
+				high, ok2 := operands[i+1].(String)
+				if !ok1 || !ok2 || len(low) != len(high) || len(low) == 0 || len(low) > 4 {
+					continue
+				}
+				rg := bfRange{low: beUint(low), high: beUint(high), n: len(low)}
+				switch dst := operands[i+2].(type) {
+				case String:
+					rg.dst = []byte(dst)
+				case Array:
+					for _, d := range dst {
+						s, _ := d.(String)
+						rg.list = append(rg.list, decodeUTF16([]byte(s)))
+					}
+				default:
+					continue
+				}
+				c.ranges = append(c.ranges, rg)
+			}
+		}
+		operands = operands[:0]
+	}
+	return c
+}
+
+// codeLength 按码空间确定下一个编码的字节数（无匹配时取最短码长）
+func (c *cmap) codeLength(b []byte) int {
+	shortest := 0
+	for n := 1; n <= 4 && n <= len(b); n++ {
+		for _, rg := range c.codespace {
+			if len(rg.low) != n {
+				continue
+			}
+			if shortest == 0 || n < shortest {
+				shortest = n
+			}
+			match := true
+			for i := 0; i < n; i++ {
+				if b[i] < rg.low[i] || b[i] > rg.high[i] {
+					match = false
+					break
+				}
+			}
+			if match {
+				return n
+			}
+		}
+	}
+	if shortest == 0 {
+		shortest = 1
+	}
+	return shortest
+}
+
+// lookup 查找编码对应的 Unicode 文本
+func (c *cmap) lookup(code []byte) string {
+	if s, ok := c.chars[string(code)]; ok {
+		return s
+	}
+	v := beUint(String(code))
+	for _, rg := range c.ranges {
+		if rg.n != len(code) || v < rg.low || v > rg.high {
+			continue
+		}
+		offset := v - rg.low
+		if rg.list != nil {
+			if int(offset) < len(rg.list) {
+				return rg.list[offset]
+			}
+			return ""
+		}
+		// 目标为起始值，最后一个 UTF-16 码元随编码递增
+		dst := append([]byte{}, rg.dst...)
+		if len(dst) < 2 {
+			dst = append(make([]byte, 2-len(dst)), dst...)
+		}
+		last := uint32(dst[len(dst)-2])<<8 | uint32(dst[len(dst)-1]) + offset
+		dst[len(dst)-2], dst[len(dst)-1] = byte(last>>8), byte(last)
+		return decodeUTF16(dst)
+	}
+	return ""
+}
+
+func beUint(s String) uint32 {
+	var v uint32
+	for i := 0; i < len(s); i++ {
+		v = v<<8 | uint32(s[i])
+	}
+	return v
+}
+
+// decodeTextString 解码文本字符串（UTF-16BE / UTF-8 带 BOM，否则按 PDFDocEncoding）
+func decodeTextString(s String) string {
+	b := []byte(s)
+	switch {
+	case len(b) >= 2 && b[0] == 0xfe && b[1] == 0xff:
+		return strings.TrimSpace(decodeUTF16(b[2:]))
+	case len(b) >= 3 && b[0] == 0xef && b[1] == 0xbb && b[2] == 0xbf:
+		return strings.TrimSpace(strings.ToValidUTF8(string(b[3:]), ""))
+	}
+	var sb strings.Builder
+	for _, c := range b {
+		if r := pdfDocEncoding[c]; r != 0 {
+			sb.WriteRune(r)
+		} else if c >= 0x20 && c < 0x7f || c >= 0xa1 {
+			sb.WriteRune(rune(c))
+		} else if c == '\n' || c == '\r' || c == '\t' {
+			sb.WriteByte(' ')
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// pdfDocEncoding PDFDocEncoding 中与 Latin-1 不同的字符
+var pdfDocEncoding = map[byte]rune{
+	0x18: '˘', 0x19: 'ˇ', 0x1a: 'ˆ', 0x1b: '˙', 0x1c: '˝', 0x1d: '˛', 0x1e: '˚', 0x1f: '˜',
+	0x80: '•', 0x81: '†', 0x82: '‡', 0x83: '…', 0x84: '—', 0x85: '–', 0x86: 'ƒ', 0x87: '⁄',
+	0x88: '‹', 0x89: '›', 0x8a: '−', 0x8b: '‰', 0x8c: '„', 0x8d: '“', 0x8e: '”', 0x8f: '‘',
+	0x90: '’', 0x91: '‚', 0x92: '™', 0x93: 'ﬁ', 0x94: 'ﬂ', 0x95: 'Ł', 0x96: 'Œ', 0x97: 'Š',
+	0x98: 'Ÿ', 0x99: 'Ž', 0x9a: 'ı', 0x9b: 'ł', 0x9c: 'œ', 0x9d: 'š', 0x9e: 'ž', 0xa0: '€',
+}
+
+// namedEncoding 预定义的简单字体编码
+func namedEncoding(name Name) *[256]rune {
+	switch name {
+	case "WinAnsiEncoding":
+		return &winAnsiEncoding
+	case "MacRomanEncoding":
+		return &macRomanEncoding
+	case "StandardEncoding":
+		return &standardEncoding
+	}
+	return nil
+}
+
+var (
+	winAnsiEncoding  = charmapTable(charmap.Windows1252)
+	macRomanEncoding = charmapTable(charmap.Macintosh)
+	standardEncoding = standardTable()
+)
+
+// charmapTable 由单字节字符集生成编码表（控制字符留空）
+func charmapTable(cm *charmap.Charmap) [256]rune {
+	var table [256]rune
+	for i := 32; i < 256; i++ {
+		if r := cm.DecodeByte(byte(i)); r != utf8.RuneError && r >= 0x20 && (r < 0x7f || r > 0x9f) {
+			table[i] = r
+		}
+	}
+	return table
+}
+
+// standardTable Adobe StandardEncoding（ASCII 部分仅引号与 Latin-1 不同，高位部分按字形名映射）
+func standardTable() [256]rune {
+	var table [256]rune
+	for i := 32; i < 127; i++ {
+		table[i] = rune(i)
+	}
+	table['\''] = '’'
+	table['`'] = '‘'
+	high := map[int]string{
+		0xa1: "exclamdown", 0xa2: "cent", 0xa3: "sterling", 0xa4: "fraction", 0xa5: "yen", 0xa6: "florin",
+		0xa7: "section", 0xa8: "currency", 0xa9: "quotesingle", 0xaa: "quotedblleft", 0xab: "guillemotleft",
+		0xac: "guilsinglleft", 0xad: "guilsinglright", 0xae: "fi", 0xaf: "fl", 0xb1: "endash", 0xb2: "dagger",
+		0xb3: "daggerdbl", 0xb4: "periodcentered", 0xb6: "paragraph", 0xb7: "bullet", 0xb8: "quotesinglbase",
+		0xb9: "quotedblbase", 0xba: "quotedblright", 0xbb: "guillemotright", 0xbc: "ellipsis", 0xbd: "perthousand",
+		0xbf: "questiondown", 0xc1: "grave", 0xc2: "acute", 0xc3: "circumflex", 0xc4: "tilde", 0xc5: "macron",
+		0xc6: "breve", 0xc7: "dotaccent", 0xc8: "dieresis", 0xca: "ring", 0xcb: "cedilla", 0xcd: "hungarumlaut",
+		0xce: "ogonek", 0xcf: "caron", 0xd0: "emdash", 0xe1: "AE", 0xe3: "ordfeminine", 0xe8: "Lslash",
+		0xe9: "Oslash", 0xea: "OE", 0xeb: "ordmasculine", 0xf1: "ae", 0xf5: "dotlessi", 0xf8: "lslash",
+		0xf9: "oslash", 0xfa: "oe", 0xfb: "germandbls",
+	}
+	for code, name := range high {
+		if r, _ := utf8.DecodeRuneInString(glyphNameToUnicode(name)); r != utf8.RuneError {
+			table[code] = r
+		}
+	}
+	return table
+}
@@ -0,0 +1,94 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现字形名到 Unicode 的映射（Adobe Glyph List 的常用子集 + 组合规则）
+
+package pdf
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// glyphNameToUnicode 字形名转 Unicode 文本，无法识别时返回空
+//
+// 依次处理：去掉 ".sc" 等后缀、"_" 连接的连字、uniXXXX / uXXXX[XX]、单个字母、
+// 常用字形名表，以及"基字母 + 附加符号名"（如 eacute、Scaron）的组合。
+func glyphNameToUnicode(name string) string {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i]
+	}
+	if strings.Contains(name, "_") {
+		var sb strings.Builder
+		for _, part := range strings.Split(name, "_") {
+			sb.WriteString(glyphNameToUnicode(part))
+		}
+		return sb.String()
+	}
+	if s, ok := glyphNames[name]; ok {
+		return s
+	}
+	if len(name) == 1 && (name[0] >= 'A' && name[0] <= 'Z' || name[0] >= 'a' && name[0] <= 'z') {
+		return name
+	}
+	if strings.HasPrefix(name, "uni") && len(name) >= 7 && (len(name)-3)%4 == 0 {
+		var runes []rune
+		for i := 3; i < len(name); i += 4 {
+			v, err := strconv.ParseUint(name[i:i+4], 16, 16)
+			if err != nil || v >= 0xd800 && v <= 0xdfff {
+				return ""
+			}
+			runes = append(runes, rune(v))
+		}
+		return string(runes)
+	}
+	if strings.HasPrefix(name, "u") && len(name) >= 5 && len(name) <= 7 {
+		if v, err := strconv.ParseUint(name[1:], 16, 32); err == nil && v <= 0x10ffff && (v < 0xd800 || v > 0xdfff) {
+			return string(rune(v))
+		}
+	}
+	for suffix, mark := range accentMarks {
+		if base := strings.TrimSuffix(name, suffix); base != name && len(base) == 1 {
+			if b := glyphNameToUnicode(base); b != "" {
+				return norm.NFC.String(b + string(mark))
+			}
+		}
+	}
+	return ""
+}
+
+// accentMarks 附加符号名对应的组合字符
+var accentMarks = map[string]rune{
+	"grave": '̀', "acute": '́', "circumflex": '̂', "tilde": '̃', "macron": '̄',
+	"breve": '̆', "dotaccent": '̇', "dieresis": '̈', "ring": '̊', "hungarumlaut": '̋',
+	"caron": '̌', "cedilla": '̧', "ogonek": '̨', "commaaccent": '̦',
+}
+
+// glyphNames 常用字形名（ASCII 标点、数字、Latin-1 补充和排版符号）
+var glyphNames = map[string]string{
+	"space": " ", "nbspace": " ", "exclam": "!", "quotedbl": "\"", "numbersign": "#", "dollar": "$",
+	"percent": "%", "ampersand": "&", "quotesingle": "'", "parenleft": "(", "parenright": ")",
+	"asterisk": "*", "plus": "+", "comma": ",", "hyphen": "-", "period": ".", "slash": "/",
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4", "five": "5", "six": "6",
+	"seven": "7", "eight": "8", "nine": "9", "colon": ":", "semicolon": ";", "less": "<", "equal": "=",
+	"greater": ">", "question": "?", "at": "@", "bracketleft": "[", "backslash": "\\",
+	"bracketright": "]", "asciicircum": "^", "underscore": "_", "grave": "`", "braceleft": "{",
+	"bar": "|", "braceright": "}", "asciitilde": "~",
+	"quoteleft": "‘", "quoteright": "’", "quotesinglbase": "‚", "quotedblleft": "“", "quotedblright": "”",
+	"quotedblbase": "„", "guillemotleft": "«", "guillemotright": "»", "guilsinglleft": "‹",
+	"guilsinglright": "›", "endash": "–", "emdash": "—", "bullet": "•", "ellipsis": "…",
+	"dagger": "†", "daggerdbl": "‡", "periodcentered": "·", "middot": "·", "section": "§",
+	"paragraph": "¶", "copyright": "©", "registered": "®", "trademark": "™", "degree": "°",
+	"plusminus": "±", "multiply": "×", "divide": "÷", "minus": "−", "fraction": "⁄", "perthousand": "‰",
+	"exclamdown": "¡", "questiondown": "¿", "cent": "¢", "sterling": "£", "yen": "¥", "Euro": "€",
+	"euro": "€", "currency": "¤", "florin": "ƒ", "brokenbar": "¦", "logicalnot": "¬", "mu": "µ",
+	"onehalf": "½", "onequarter": "¼", "threequarters": "¾", "onesuperior": "¹", "twosuperior": "²",
+	"threesuperior": "³", "ordfeminine": "ª", "ordmasculine": "º", "sfthyphen": "­",
+	"acute": "´", "circumflex": "ˆ", "tilde": "˜", "macron": "¯", "breve": "˘", "dotaccent": "˙",
+	"dieresis": "¨", "ring": "˚", "cedilla": "¸", "hungarumlaut": "˝", "ogonek": "˛", "caron": "ˇ",
+	"fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl",
+	"AE": "Æ", "ae": "æ", "OE": "Œ", "oe": "œ", "Oslash": "Ø", "oslash": "ø", "Lslash": "Ł",
+	"lslash": "ł", "Eth": "Ð", "eth": "ð", "Thorn": "Þ", "thorn": "þ", "germandbls": "ß",
+	"dotlessi": "ı", "Dcroat": "Đ", "dcroat": "đ",
+}
@@ -0,0 +1,275 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现版面还原：去除页眉页脚和页码、合并文本行为段落、识别标题
+
+package pdf
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// HeadingSizeRatio 字号不小于正文字号的此倍数的短文本块视为标题
+	HeadingSizeRatio = 1.2
+	// maxHeadingLength 标题的最大长度（字符）
+	maxHeadingLength = 200
+)
+
+// Page 页面文本
+type Page struct {
+	// 按阅读顺序排列的文本块
+	Blocks []Block
+	// 版面还原之前的文本行
+	lines []line
+}
+
+// Block 文本块（段落或标题）
+type Block struct {
+	Text    string
+	Heading bool
+	// 字号（磅）
+	FontSize float64
+}
+
+// Text 页面的纯文本（块之间空一行）
+func (p Page) Text() string {
+	texts := make([]string, 0, len(p.Blocks))
+	for _, b := range p.Blocks {
+		texts = append(texts, b.Text)
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// Title 文档标题：文档信息中的标题（排除"Microsoft Word - xxx.docx"、"Untitled"等自动生成的值），
+// 否则为前两页中的第一个标题块
+func (d *Document) Title() string {
+	if title := cleanTitle(d.Info.Title); title != "" {
+		return title
+	}
+	for i, page := range d.Pages {
+		if i >= 2 {
+			break
+		}
+		for _, b := range page.Blocks {
+			if b.Heading {
+				return b.Text
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	appPrefixRe = regexp.MustCompile(`^(?i)(microsoft\s+(word|powerpoint|excel)|untitled)\s*[-–—:]\s*`)
+	fileNameRe  = regexp.MustCompile(`(?i)\.(pdf|docx?|rtf|odt|pptx?|xlsx?|indd|txt|tex|dvi|ps|qxd|pages|wps)$|^[\w\-]+_[\w\-]+$|^[\w\-]*\d{4,}[\w\-]*$`)
+	junkTitleRe = regexp.MustCompile(`^(?i)(untitled|document\d*|title|slide\s*\d*|无标题.*|新建.*文档.*|pdf)$`)
+)
+
+// cleanTitle 过滤自动生成的元数据标题
+func cleanTitle(title string) string {
+	title = strings.Join(strings.Fields(title), " ")
+	if stripped := appPrefixRe.ReplaceAllString(title, ""); stripped != title {
+		// 带应用前缀的标题是文件名
+		return ""
+	}
+	if title == "" || junkTitleRe.MatchString(title) || fileNameRe.MatchString(title) || utf8.RuneCountInString(title) > maxHeadingLength {
+		return ""
+	}
+	hasLetter := false
+	for _, r := range title {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			break
+		}
+	}
+	if !hasLetter {
+		return ""
+	}
+	return title
+}
+
+// layoutPages 对所有页面做版面还原（正文字号和重复的页眉页脚需要跨页统计）
+func layoutPages(pages []Page) {
+	body := bodySize(pages)
+	removeRunningLines(pages)
+	for i := range pages {
+		pages[i].Blocks = paragraphs(pages[i].lines, body)
+		pages[i].lines = nil
+	}
+}
+
+// bodySize 正文字号：按字符数加权出现最多的字号
+func bodySize(pages []Page) float64 {
+	counts := map[float64]int{}
+	for _, p := range pages {
+		for _, ln := range p.lines {
+			counts[math.Round(ln.size*2)/2] += utf8.RuneCountInString(ln.text)
+		}
+	}
+	var best float64
+	for size, n := range counts {
+		if n > counts[best] || n == counts[best] && size < best {
+			best = size
+		}
+	}
+	return best
+}
+
+var (
+	pageNumberRe = regexp.MustCompile(`^(?i)(?:[-–—]\s*)?\d{1,4}(?:\s*[-–—])?$|^(?i)(?:page|p\.)\s*\d{1,4}(?:\s*(?:of|/)\s*\d{1,4})?$|^\d{1,4}\s*/\s*\d{1,4}$|^第\s*\d{1,4}\s*页(?:\s*[,，/]?\s*共\s*\d{1,4}\s*页)?$|^(?i)[ivxlc]{1,7}$`)
+	digitsRe     = regexp.MustCompile(`\d+`)
+)
+
+// removeRunningLines 去除页码和重复的页眉页脚
+//
+// 只考虑每页最上方和最下方的两行；页码行直接去除，
+// 数字归一化后在至少 3 页且一半以上页面中重复出现的短行视为页眉页脚。
+func removeRunningLines(pages []Page) {
+	edges := make([]map[int]bool, len(pages))
+	counts := map[string]int{}
+	for i, p := range pages {
+		edges[i] = edgeLines(p.lines)
+		seen := map[string]bool{}
+		for j := range edges[i] {
+			key := runningKey(p.lines[j].text)
+			if !seen[key] {
+				seen[key] = true
+				counts[key]++
+			}
+		}
+	}
+	for i := range pages {
+		var kept []line
+		for j, ln := range pages[i].lines {
+			if edges[i][j] {
+				n := counts[runningKey(ln.text)]
+				if pageNumberRe.MatchString(ln.text) || (len(pages) >= 3 && n >= 3 && n*2 >= len(pages) && utf8.RuneCountInString(ln.text) <= 120) {
+					continue
+				}
+			}
+			kept = append(kept, ln)
+		}
+		pages[i].lines = kept
+	}
+}
+
+// edgeLines 页面最上方和最下方各两行的下标
+func edgeLines(lines []line) map[int]bool {
+	idx := make([]int, len(lines))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return lines[idx[a]].y > lines[idx[b]].y })
+	edges := map[int]bool{}
+	for k := 0; k < 2 && k < len(idx); k++ {
+		edges[idx[k]] = true
+		edges[idx[len(idx)-1-k]] = true
+	}
+	return edges
+}
+
+func runningKey(text string) string {
+	return digitsRe.ReplaceAllString(strings.ToLower(strings.Join(strings.Fields(text), " ")), "#")
+}
+
+// paragraphs 将文本行合并为段落和标题块
+func paragraphs(lines []line, body float64) []Block {
+	if len(lines) == 0 {
+		return nil
+	}
+	left, right := math.Inf(1), math.Inf(-1)
+	for _, ln := range lines {
+		left, right = min(left, ln.x), max(right, ln.endX)
+	}
+	spacing := lineSpacing(lines)
+	heading := func(ln line) bool {
+		return body > 0 && ln.size >= body*HeadingSizeRatio && utf8.RuneCountInString(ln.text) <= maxHeadingLength
+	}
+
+	var blocks []Block
+	var cur *Block
+	for i, ln := range lines {
+		if cur != nil && !paragraphBreak(lines[i-1], ln, heading, spacing, left, right) {
+			cur.Text = joinLines(cur.Text, ln.text)
+			cur.FontSize = max(cur.FontSize, ln.size)
+			continue
+		}
+		blocks = append(blocks, Block{Text: ln.text, Heading: heading(ln), FontSize: ln.size})
+		cur = &blocks[len(blocks)-1]
+	}
+	for i := range blocks {
+		blocks[i].FontSize = math.Round(blocks[i].FontSize*10) / 10
+		if blocks[i].Heading && utf8.RuneCountInString(blocks[i].Text) > maxHeadingLength {
+			blocks[i].Heading = false
+		}
+	}
+	return blocks
+}
+
+// lineSpacing 页面的典型行距：同字号相邻行纵向间距的下四分位数（段间距出现的次数远少于行间距）
+func lineSpacing(lines []line) float64 {
+	var gaps []float64
+	for i := 1; i < len(lines); i++ {
+		prev, cur := lines[i-1], lines[i]
+		if dy := prev.y - cur.y; dy > 0 && math.Abs(prev.size-cur.size) < 0.5 && dy < prev.size*3 {
+			gaps = append(gaps, dy)
+		}
+	}
+	if len(gaps) == 0 {
+		return 0
+	}
+	sort.Float64s(gaps)
+	return gaps[len(gaps)/4]
+}
+
+// sentenceEndRe 以句末标点结尾（可带右引号 / 括号）
+var sentenceEndRe = regexp.MustCompile(`[.!?:;。！？：；…]["'”’)）」』]?$`)
+
+// listItemRe 列表项开头
+var listItemRe = regexp.MustCompile(`^(?:[•●○■□◆◇▪\-–*]\s|\(?\d{1,3}[.)、）]\s*\S|[（(][一二三四五六七八九十\d]{1,3}[)）]|[一二三四五六七八九十]{1,3}、)`)
+
+// paragraphBreak 判断两行之间是否分段
+func paragraphBreak(prev, cur line, heading func(line) bool, spacing, left, right float64) bool {
+	size := max(prev.size, cur.size)
+	if heading(prev) != heading(cur) || math.Abs(prev.size-cur.size) > 0.1*size {
+		return true
+	}
+	dy := prev.y - cur.y
+	// 行数较少时统计出的行距可能就是段间距，按常见行距的上限（1.6 倍字号）截断
+	if spacing == 0 || spacing > 1.6*size {
+		spacing = 1.6 * size
+	}
+	// 行距明显变大，或回到上方（分栏）
+	if dy > spacing*1.4+0.1*size || dy < -0.5*size {
+		return true
+	}
+	// 上一行明显短于版心且以句末标点结尾
+	if prev.endX < right-0.15*(right-left) && sentenceEndRe.MatchString(prev.text) {
+		return true
+	}
+	// 首行缩进
+	if cur.x > prev.x+0.8*cur.size && cur.x > left+0.8*cur.size {
+		return true
+	}
+	return listItemRe.MatchString(cur.text)
+}
+
+// joinLines 合并段落内的两行：连字符断词还原、中日韩文字之间不加空格
+func joinLines(a, b string) string {
+	last, _ := utf8.DecodeLastRuneInString(a)
+	first, _ := utf8.DecodeRuneInString(b)
+	if last == '-' && unicode.IsLower(first) {
+		if r, _ := utf8.DecodeLastRuneInString(a[:len(a)-1]); unicode.IsLetter(r) {
+			return a[:len(a)-1] + b
+		}
+	}
+	if isCJK(last) && isCJK(first) {
+		return a + b
+	}
+	return a + " " + b
+}
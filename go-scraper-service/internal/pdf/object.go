@@ -0,0 +1,398 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件定义 PDF 对象类型并实现对象语法的词法 / 语法解析
+
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// Object PDF 对象：nil, bool, int64, float64, Name, String, Array, Dict, Ref, *Stream, keyword
+type Object = any
+
+// Name 名称对象（不含开头的 /）
+type Name string
+
+// String 字符串对象（原始字节）
+type String string
+
+// Array 数组对象
+type Array []Object
+
+// Dict 字典对象
+type Dict map[Name]Object
+
+// Ref 间接对象引用
+type Ref struct {
+	Num int
+	Gen int
+}
+
+// Stream 流对象（Data 为未解码、未解密的原始数据）
+type Stream struct {
+	Dict Dict
+	Data []byte
+	// 所在的间接对象（用于解密）
+	ref Ref
+}
+
+// keyword 关键字（true / false / null 之外的裸字，如 obj、R 和内容流的操作符）
+type keyword string
+
+// errSyntax 对象语法错误
+var errSyntax = errors.New("pdf: syntax error")
+
+// maxNesting 数组 / 字典的最大嵌套深度
+const maxNesting = 64
+
+// isSpace PDF 空白字符
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+// isDelimiter PDF 分隔符
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// lexer 对象语法解析器
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+// skipSpace 跳过空白和注释
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isSpace(c) {
+			l.pos++
+		} else if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		} else {
+			return
+		}
+	}
+}
+
+// eof 是否已到末尾（跳过空白后）
+func (l *lexer) eof() bool {
+	l.skipSpace()
+	return l.pos >= len(l.data)
+}
+
+// readObject 读取一个对象；数组 / 字典之外的 "]"、">>" 返回 errSyntax
+func (l *lexer) readObject() (Object, error) {
+	return l.readNested(0)
+}
+
+func (l *lexer) readNested(depth int) (Object, error) {
+	if depth > maxNesting {
+		return nil, errSyntax
+	}
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errSyntax
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.readName(), nil
+	case c == '(':
+		return l.readLiteralString(), nil
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.readDict(depth)
+	case c == '<':
+		return l.readHexString(), nil
+	case c == '[':
+		l.pos++
+		var arr Array
+		for {
+			l.skipSpace()
+			if l.pos >= len(l.data) {
+				return arr, errSyntax
+			}
+			if l.data[l.pos] == ']' {
+				l.pos++
+				return arr, nil
+			}
+			obj, err := l.readNested(depth + 1)
+			if err != nil {
+				return arr, err
+			}
+			arr = append(arr, obj)
+		}
+	case c == ']' || c == '>' || c == ')' || c == '}':
+		l.pos++
+		return nil, errSyntax
+	case c == '{':
+		l.pos++
+		return keyword("{"), nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.readNumberOrRef(), nil
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		l.pos++
+		return keyword(l.data[start:l.pos]), nil
+	}
+	switch word := string(l.data[start:l.pos]); word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	default:
+		return keyword(word), nil
+	}
+}
+
+// readDict 读取字典（"<<" 已读取）
+func (l *lexer) readDict(depth int) (Object, error) {
+	dict := Dict{}
+	for {
+		l.skipSpace()
+		if l.pos >= len(l.data) {
+			return dict, errSyntax
+		}
+		if l.data[l.pos] == '>' {
+			if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+				l.pos += 2
+			} else {
+				l.pos++
+			}
+			return dict, nil
+		}
+		key, err := l.readNested(depth + 1)
+		if err != nil {
+			return dict, err
+		}
+		name, ok := key.(Name)
+		if !ok {
+			continue
+		}
+		value, err := l.readNested(depth + 1)
+		if err != nil {
+			return dict, err
+		}
+		dict[name] = value
+	}
+}
+
+// readNumber 读取数字
+func (l *lexer) readNumber() Object {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if (c >= '0' && c <= '9') || c == '.' {
+			l.pos++
+			continue
+		}
+		break
+	}
+	s := string(l.data[start:l.pos])
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	// 不规范的数字（如 "--5"、"5.-"）按 0 处理
+	return int64(0)
+}
+
+// readNumberOrRef 读取数字，"n g R" 读取为引用
+func (l *lexer) readNumberOrRef() Object {
+	num := l.readNumber()
+	n, ok := num.(int64)
+	if !ok || n < 0 {
+		return num
+	}
+	save := l.pos
+	l.skipSpace()
+	if l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '9' {
+		if gen, ok := l.readNumber().(int64); ok {
+			l.skipSpace()
+			if l.pos < len(l.data) && l.data[l.pos] == 'R' && (l.pos+1 == len(l.data) || isSpace(l.data[l.pos+1]) || isDelimiter(l.data[l.pos+1])) {
+				l.pos++
+				return Ref{Num: int(n), Gen: int(gen)}
+			}
+		}
+	}
+	l.pos = save
+	return num
+}
+
+// readName 读取名称（处理 #xx 转义）
+func (l *lexer) readName() Name {
+	l.pos++
+	var b []byte
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, ok := unhex2(l.data[l.pos+1], l.data[l.pos+2]); ok {
+				b = append(b, v)
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return Name(b)
+}
+
+// readLiteralString 读取 (...) 字符串（处理转义和嵌套括号）
+func (l *lexer) readLiteralString() String {
+	l.pos++
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return String(b)
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return String(b)
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return String(b)
+}
+
+// readHexString 读取 <...> 十六进制字符串（奇数位补 0）
+func (l *lexer) readHexString() String {
+	l.pos++
+	end := bytes.IndexByte(l.data[l.pos:], '>')
+	if end < 0 {
+		end = len(l.data) - l.pos
+	}
+	s := decodeHex(l.data[l.pos : l.pos+end])
+	l.pos += end + 1
+	return String(s)
+}
+
+// decodeHex 解码十六进制（忽略空白和非法字符，奇数位补 0）
+func decodeHex(src []byte) []byte {
+	var out []byte
+	var hi byte
+	half := false
+	for _, c := range src {
+		v, ok := hexValue(c)
+		if !ok {
+			continue
+		}
+		if half {
+			out = append(out, hi<<4|v)
+		} else {
+			hi = v
+		}
+		half = !half
+	}
+	if half {
+		out = append(out, hi<<4)
+	}
+	return out
+}
+
+func hexValue(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+func unhex2(a, b byte) (byte, bool) {
+	x, ok1 := hexValue(a)
+	y, ok2 := hexValue(b)
+	return x<<4 | y, ok1 && ok2
+}
+
+// 对象取值辅助函数（类型不符时返回零值）
+
+func toInt(o Object) int {
+	switch v := o.(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+func toFloat(o Object) float64 {
+	switch v := o.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func isNumber(o Object) bool {
+	switch o.(type) {
+	case int64, float64:
+		return true
+	}
+	return false
+}
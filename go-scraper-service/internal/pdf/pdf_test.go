@@ -0,0 +1,316 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPDF 构造测试用 PDF：对象按添加顺序编号（从 1 开始）
+type testPDF struct {
+	objs []string
+}
+
+func (p *testPDF) add(body string) int {
+	p.objs = append(p.objs, body)
+	return len(p.objs)
+}
+
+func (p *testPDF) stream(dict string, data []byte) int {
+	return p.add(fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data))
+}
+
+func (p *testPDF) bytes(trailer string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(p.objs))
+	for i, body := range p.objs {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+	if trailer == "" {
+		fmt.Fprintf(&buf, "%%%%EOF\n")
+		return buf.Bytes()
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(p.objs)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", len(p.objs)+1, trailer, xref)
+	return buf.Bytes()
+}
+
+func deflate(data string) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write([]byte(data))
+	w.Close()
+	return buf.Bytes()
+}
+
+// simpleDocument 两页英文文档：第 1 页为标题、两个段落和页码，第 2 页内容流经 Flate 压缩
+func simpleDocument(title string) []byte {
+	p := &testPDF{}
+	p.add("<< /Type /Catalog /Pages 2 0 R >>")
+	p.add("<< /Type /Pages /Kids [4 0 R 6 0 R] /Count 2 /Resources << /Font << /F1 3 0 R >> >> >>")
+	p.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	p.add("<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>")
+	p.stream("", []byte("BT /F1 18 Tf 72 720 Td (Annual Report) Tj ET\n"+
+		"BT /F1 11 Tf 72 690 Td 14 TL (The company grew steadily in the first) Tj T* (quarter of the year.) Tj\n"+
+		"T* T* (Second para-) Tj T* (graph continues here \\(caf\\351\\).) Tj ET\n"+
+		"BT /F1 9 Tf 300 40 Td (1) Tj ET"))
+	p.add("<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>")
+	p.stream("/Filter /FlateDecode", deflate("BT /F1 11 Tf 72 700 Td [(Ker)30(ning)-250(works)] TJ ET\n"+
+		"BT /F1 9 Tf 300 40 Td (2) Tj ET"))
+	p.add(fmt.Sprintf("<< /Title (%s) /Author (Jane Doe) /CreationDate (D:20240315103000+08'00') >>", title))
+	return p.bytes("/Root 1 0 R /Info 8 0 R")
+}
+
+// cjkDocument 使用 Identity-H 编码和 ToUnicode CMap 的中文文档（内容流和 CMap 均压缩）
+func cjkDocument() []byte {
+	cmap := "/CIDInit /ProcSet findresource begin 12 dict begin begincmap\n" +
+		"1 begincodespacerange <0000> <FFFF> endcodespacerange\n" +
+		"3 beginbfchar <0001> <5E74> <0002> <5EA6> <0004> <544A> endbfchar\n" +
+		"1 beginbfrange <0002> <0003> <62A4> endbfrange\n" +
+		"1 beginbfrange <0010> <0011> [<6536> <5165>] endbfrange\n" +
+		"endcmap CMapName currentdict /CMap defineresource pop end end"
+	p := &testPDF{}
+	p.add("<< /Type /Catalog /Pages 2 0 R >>")
+	p.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	p.add("<< /Type /Page /Parent 2 0 R /Resources << /Font << /F2 5 0 R >> >> /Contents 4 0 R >>")
+	p.stream("/Filter /FlateDecode", deflate("BT /F2 20 Tf 72 720 Td <0001000200030004> Tj ET\n"+
+		"BT /F2 12 Tf 72 690 Td <00100011> Tj 0 -16 Td <00030004> Tj ET"))
+	p.add("<< /Type /Font /Subtype /Type0 /BaseFont /SimSun /Encoding /Identity-H /DescendantFonts [6 0 R] /ToUnicode 7 0 R >>")
+	p.add("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /SimSun /DW 1000 >>")
+	p.stream("/Filter /FlateDecode", deflate(cmap))
+	return p.bytes("/Root 1 0 R")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		title     string
+		pageCount int
+		// 各页的文本块，标题块以 "# " 开头
+		pages [][]string
+	}{
+		{
+			name:      "英文文档",
+			data:      simpleDocument("Annual Report 2023"),
+			title:     "Annual Report 2023",
+			pageCount: 2,
+			pages: [][]string{
+				{"# Annual Report", "The company grew steadily in the first quarter of the year.", "Second paragraph continues here (café)."},
+				{"Kerning works"},
+			},
+		},
+		{
+			name:      "元数据标题为文件名时使用首个标题块",
+			data:      simpleDocument("Microsoft Word - report_final.docx"),
+			title:     "Annual Report",
+			pageCount: 2,
+		},
+		{
+			name:      "中文 ToUnicode",
+			data:      cjkDocument(),
+			title:     "年度报告",
+			pageCount: 1,
+			pages:     [][]string{{"# 年度报告", "收入报告"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(tt.data)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if doc.PageCount != tt.pageCount || len(doc.Pages) != tt.pageCount {
+				t.Errorf("PageCount = %d, len(Pages) = %d, want %d", doc.PageCount, len(doc.Pages), tt.pageCount)
+			}
+			if got := doc.Title(); got != tt.title {
+				t.Errorf("Title() = %q, want %q", got, tt.title)
+			}
+			for i, want := range tt.pages {
+				var got []string
+				for _, b := range doc.Pages[i].Blocks {
+					if b.Heading {
+						got = append(got, "# "+b.Text)
+					} else {
+						got = append(got, b.Text)
+					}
+				}
+				if strings.Join(got, "|") != strings.Join(want, "|") {
+					t.Errorf("第 %d 页 = %q, want %q", i+1, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("文档信息", func(t *testing.T) {
+		doc, err := Parse(simpleDocument("Annual Report"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := time.Date(2024, 3, 15, 2, 30, 0, 0, time.UTC)
+		if doc.Info.Author != "Jane Doe" || !doc.Info.Created.Equal(want) {
+			t.Errorf("Info = %+v", doc.Info)
+		}
+	})
+}
+
+func TestParseObjectStream(t *testing.T) {
+	// 目录、页面树和字体压缩在对象流中，trailer 在 XRef 流字典中
+	objects := "<< /Type /Catalog /Pages 2 0 R >> << /Type /Pages /Kids [4 0 R] /Count 1 >> " +
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"
+	first := 0
+	var header []string
+	for i, obj := range strings.SplitAfter(objects, ">> ") {
+		header = append(header, fmt.Sprintf("%d %d", i+1, first))
+		first += len(obj)
+	}
+	data := strings.Join(header, " ") + "\n"
+
+	p := &testPDF{}
+	p.add("null")
+	p.add("null")
+	p.add("null")
+	p.add("<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 3 0 R >> >> /Contents 5 0 R >>")
+	p.stream("", []byte("BT /F1 12 Tf 72 700 Td (Compressed objects) Tj ET"))
+	p.stream(fmt.Sprintf("/Type /ObjStm /N 3 /First %d /Filter /FlateDecode", len(data)), deflate(data+objects))
+	p.stream("/Type /XRef /Root 1 0 R /Size 8", nil)
+	// 前三个对象只在对象流中定义
+	p.objs = p.objs[3:]
+	raw := p.bytes("")
+	for i := 4; i >= 1; i-- {
+		raw = bytes.Replace(raw, []byte(fmt.Sprintf("\n%d 0 obj", i)), []byte(fmt.Sprintf("\n%d 0 obj", i+3)), 1)
+	}
+
+	doc, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if doc.PageCount != 1 || doc.Pages[0].Text() != "Compressed objects" {
+		t.Errorf("Parse() = %d pages, text %q", doc.PageCount, doc.Pages[0].Text())
+	}
+}
+
+func TestParseEncrypted(t *testing.T) {
+	// RC4 40 位（R2）、空用户密码：文件密钥 = MD5(填充串 + O + P + ID)[:5]
+	o := strings.Repeat("\x01", 32)
+	id := "0123456789abcdef"
+	sum := md5.Sum([]byte(string(passwordPadding) + o + "\xfc\xff\xff\xff" + id))
+	key := sum[:5]
+	u := rc4Crypt(key, passwordPadding)
+	objKey := func(num int) []byte {
+		k := md5.Sum(append(append([]byte{}, key...), byte(num), 0, 0, 0, 0))
+		return k[:10]
+	}
+
+	p := &testPDF{}
+	p.add("<< /Type /Catalog /Pages 2 0 R >>")
+	p.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	p.add("<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>")
+	p.stream("", rc4Crypt(objKey(4), []byte("BT /F1 12 Tf 72 700 Td (Restricted but readable) Tj ET")))
+	p.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	p.add(fmt.Sprintf("<< /Title <%x> >>", rc4Crypt(objKey(6), []byte("Secured Report"))))
+	p.add(fmt.Sprintf("<< /Filter /Standard /V 1 /R 2 /O <%x> /U <%x> /P -4 >>", o, u))
+	raw := p.bytes(fmt.Sprintf("/Root 1 0 R /Info 6 0 R /Encrypt 7 0 R /ID [<%x> <%x>]", id, id))
+
+	doc, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if doc.Info.Title != "Secured Report" || doc.Pages[0].Text() != "Restricted but readable" {
+		t.Errorf("Parse() title %q, text %q", doc.Info.Title, doc.Pages[0].Text())
+	}
+
+	// 需要用户密码的文档无法打开
+	wrong := bytes.Replace(raw, []byte(fmt.Sprintf("/U <%x>", u)), []byte(fmt.Sprintf("/U <%x>", rc4Crypt([]byte("xxxxx"), passwordPadding))), 1)
+	if _, err := Parse(wrong); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("Parse() error = %v, want ErrEncrypted", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"不是 PDF", []byte("<html><body>not a pdf</body></html>"), ErrNotPDF},
+		{"没有页面", []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\ntrailer\n<< /Root 1 0 R >>\n%%EOF"), ErrNoPages},
+		{
+			"不支持的加密",
+			[]byte("%PDF-1.4\n1 0 obj\n<< /Filter /Adobe.PubSec /V 4 >>\nendobj\ntrailer\n<< /Root 2 0 R /Encrypt 1 0 R >>\n%%EOF"),
+			ErrEncrypted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		filter Object
+		want   string
+	}{
+		{"ASCIIHex", []byte("48 65 6c6C6f>"), Name("ASCIIHexDecode"), "Hello"},
+		{"ASCII85", []byte("87cURD]i,\"Ebo80~>"), Name("ASCII85Decode"), "Hello World!"},
+		{"RunLength", []byte{4, 'H', 'e', 'l', 'l', 'o', 254, '!', 128}, Name("RunLengthDecode"), "Hello!!!"},
+		{"LZW", []byte{0x80, 0x0b, 0x60, 0x50, 0x22, 0x0c, 0x0c, 0x85, 0x01}, Name("LZWDecode"), "-----A---B"},
+		{"多个过滤器", []byte(fmt.Sprintf("%x>", deflate("chained"))), Array{Name("AHx"), Name("Fl")}, "chained"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeFilters(tt.data, tt.filter, nil)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("decodeFilters() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := decodeFilters(nil, Name("DCTDecode"), nil); !errors.Is(err, ErrUnsupportedFilter) {
+		t.Errorf("DCTDecode error = %v", err)
+	}
+}
+
+func TestTextHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"UTF-16BE 文本字符串", decodeTextString("\xfe\xff\x5e\x74\x62\xa5"), "年报"},
+		{"PDFDocEncoding", decodeTextString("\x93nal \x84 draft"), "ﬁnal — draft"},
+		{"字形名 uni", glyphNameToUnicode("uni4E2D"), "中"},
+		{"字形名带重音", glyphNameToUnicode("eacute"), "é"},
+		{"字形名连字", glyphNameToUnicode("f_f_i"), "ffi"},
+		{"字形名后缀", glyphNameToUnicode("A.sc"), "A"},
+		{"正常标题", cleanTitle("  Quarterly   Outlook "), "Quarterly Outlook"},
+		{"自动生成的标题", cleanTitle("Untitled"), ""},
+		{"文件名标题", cleanTitle("scan_0001.pdf"), ""},
+		{"断词连字符", joinLines("inter-", "national trade"), "international trade"},
+		{"中文换行", joinLines("国内生产", "总值"), "国内生产总值"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+// Package pdf 提供纯 Go 的 PDF 文本提取
+//
+// 本文件实现内容流解释：跟踪图形状态和文本状态，输出带位置和字号的文本行
+
+package pdf
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// maxFormDepth Form XObject 的最大嵌套深度
+	maxFormDepth = 8
+	// maxGlyphsPerPage 每页最多处理的字形数
+	maxGlyphsPerPage = 1 << 20
+)
+
+// matrix 变换矩阵 [a b c d e f]
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul 返回 m × n（先应用 m，再应用 n）
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2], m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2], m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4], m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+// line 页面上的一行文本（设备空间坐标，y 向上）
+type line struct {
+	text string
+	x    float64
+	endX float64
+	y    float64
+	size float64
+}
+
+// gstate 图形状态中与文本相关的部分
+type gstate struct {
+	ctm  matrix
+	font *font
+	fs   float64
+	tc   float64
+	tw   float64
+	th   float64
+	tl   float64
+	ts   float64
+}
+
+// textExtractor 内容流解释器（跨页面复用字体缓存）
+type textExtractor struct {
+	r     *reader
+	fonts map[Ref]*font
+
+	// 当前页面的状态
+	gs     gstate
+	stack  []gstate
+	tm     matrix
+	tlm    matrix
+	lines  []line
+	cur    *line
+	glyphs int
+}
+
+func newTextExtractor(r *reader) *textExtractor {
+	return &textExtractor{r: r, fonts: map[Ref]*font{}}
+}
+
+// pageLines 解释页面内容流，返回按内容流顺序排列的文本行
+func (e *textExtractor) pageLines(page Dict) []line {
+	e.gs = gstate{ctm: identity, th: 1}
+	e.stack = nil
+	e.tm, e.tlm = identity, identity
+	e.lines, e.cur, e.glyphs = nil, nil, 0
+
+	resources, _ := e.r.resolve(page["Resources"]).(Dict)
+	var content []byte
+	switch c := e.r.resolve(page["Contents"]).(type) {
+	case *Stream:
+		content, _ = e.r.streamData(c)
+	case Array:
+		// 多个内容流按顺序拼接（操作符可能跨流）
+		for _, item := range c {
+			if stm, ok := e.r.resolve(item).(*Stream); ok {
+				if data, err := e.r.streamData(stm); err == nil {
+					content = append(append(content, data...), '\n')
+				}
+			}
+		}
+	}
+	e.run(content, resources, 0)
+	e.flushLine()
+	return e.lines
+}
+
+// run 解释内容流
+func (e *textExtractor) run(content []byte, resources Dict, depth int) {
+	l := &lexer{data: content}
+	var operands []Object
+	for !l.eof() && e.glyphs < maxGlyphsPerPage {
+		start := l.pos
+		obj, err := l.readObject()
+		if err != nil {
+			if l.pos == start {
+				l.pos++
+			}
+			operands = operands[:0]
+			continue
+		}
+		op, ok := obj.(keyword)
+		if !ok {
+			if len(operands) < 64 {
+				operands = append(operands, obj)
+			}
+			continue
+		}
+		e.operator(string(op), operands, resources, l, depth)
+		operands = operands[:0]
+	}
+}
+
+// operator 执行一个操作符
+func (e *textExtractor) operator(op string, args []Object, resources Dict, l *lexer, depth int) {
+	num := func(i int) float64 {
+		if i < len(args) {
+			return toFloat(args[i])
+		}
+		return 0
+	}
+	switch op {
+	case "q":
+		if len(e.stack) < 256 {
+			e.stack = append(e.stack, e.gs)
+		}
+	case "Q":
+		if n := len(e.stack); n > 0 {
+			e.gs = e.stack[n-1]
+			e.stack = e.stack[:n-1]
+		}
+	case "cm":
+		if len(args) == 6 {
+			e.gs.ctm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}.mul(e.gs.ctm)
+		}
+	case "BT":
+		e.tm, e.tlm = identity, identity
+	case "Tf":
+		if len(args) == 2 {
+			name, _ := args[0].(Name)
+			e.gs.font = e.font(resources, name)
+			e.gs.fs = num(1)
+		}
+	case "Tc":
+		e.gs.tc = num(0)
+	case "Tw":
+		e.gs.tw = num(0)
+	case "Tz":
+		e.gs.th = num(0) / 100
+	case "TL":
+		e.gs.tl = num(0)
+	case "Ts":
+		e.gs.ts = num(0)
+	case "Td":
+		e.moveText(num(0), num(1))
+	case "TD":
+		e.gs.tl = -num(1)
+		e.moveText(num(0), num(1))
+	case "Tm":
+		if len(args) == 6 {
+			e.tlm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+			e.tm = e.tlm
+		}
+	case "T*":
+		e.moveText(0, -e.gs.tl)
+	case "Tj":
+		if len(args) == 1 {
+			e.show(args[0])
+		}
+	case "'":
+		e.moveText(0, -e.gs.tl)
+		if len(args) == 1 {
+			e.show(args[0])
+		}
+	case "\"":
+		if len(args) == 3 {
+			e.gs.tw, e.gs.tc = num(0), num(1)
+			e.moveText(0, -e.gs.tl)
+			e.show(args[2])
+		}
+	case "TJ":
+		if len(args) != 1 {
+			return
+		}
+		arr, _ := args[0].(Array)
+		for _, item := range arr {
+			if isNumber(item) {
+				// 负值右移（常用于表示词间空白）
+				e.tm = matrix{1, 0, 0, 1, -toFloat(item) / 1000 * e.gs.fs * e.gs.th, 0}.mul(e.tm)
+				continue
+			}
+			e.show(item)
+		}
+	case "Do":
+		if len(args) == 1 && depth < maxFormDepth {
+			name, _ := args[0].(Name)
+			e.form(resources, name, depth)
+		}
+	case "BI":
+		skipInlineImage(l)
+	}
+}
+
+// moveText Td：移动到下一行的起点
+func (e *textExtractor) moveText(tx, ty float64) {
+	e.tlm = matrix{1, 0, 0, 1, tx, ty}.mul(e.tlm)
+	e.tm = e.tlm
+}
+
+// font 查找并缓存当前资源中的字体
+func (e *textExtractor) font(resources Dict, name Name) *font {
+	fonts, _ := e.r.resolve(resources["Font"]).(Dict)
+	ref, isRef := fonts[name].(Ref)
+	if isRef {
+		if f, ok := e.fonts[ref]; ok {
+			return f
+		}
+	}
+	dict, ok := e.r.resolve(fonts[name]).(Dict)
+	if !ok {
+		return nil
+	}
+	f := e.r.loadFont(dict)
+	if isRef {
+		e.fonts[ref] = f
+	}
+	return f
+}
+
+// form 执行 Form XObject（图片等其他 XObject 忽略）
+func (e *textExtractor) form(resources Dict, name Name, depth int) {
+	xobjects, _ := e.r.resolve(resources["XObject"]).(Dict)
+	stm, ok := e.r.resolve(xobjects[name]).(*Stream)
+	if !ok || stm.Dict["Subtype"] != Name("Form") {
+		return
+	}
+	data, err := e.r.streamData(stm)
+	if err != nil {
+		return
+	}
+	formResources, ok := e.r.resolve(stm.Dict["Resources"]).(Dict)
+	if !ok {
+		formResources = resources
+	}
+
+	saved, savedStack, tm, tlm := e.gs, len(e.stack), e.tm, e.tlm
+	if m, ok := e.r.resolveDeep(stm.Dict["Matrix"]).(Array); ok && len(m) == 6 {
+		e.gs.ctm = matrix{toFloat(m[0]), toFloat(m[1]), toFloat(m[2]), toFloat(m[3]), toFloat(m[4]), toFloat(m[5])}.mul(e.gs.ctm)
+	}
+	e.run(data, formResources, depth+1)
+	e.gs, e.stack, e.tm, e.tlm = saved, e.stack[:min(savedStack, len(e.stack))], tm, tlm
+}
+
+// show 显示字符串：逐字形计算位置并加入当前行
+func (e *textExtractor) show(obj Object) {
+	s, ok := obj.(String)
+	f := e.gs.font
+	if !ok || f == nil {
+		return
+	}
+	gs := &e.gs
+	for _, g := range f.decode(s) {
+		e.glyphs++
+		trm := matrix{gs.fs * gs.th, 0, 0, gs.fs, 0, gs.ts}.mul(e.tm).mul(gs.ctm)
+		x, y := trm[4], trm[5]
+		size := math.Hypot(trm[2], trm[3])
+
+		advance := g.width / 1000 * gs.fs
+		if g.space {
+			advance += gs.tw
+		}
+		e.tm = matrix{1, 0, 0, 1, (advance + gs.tc) * gs.th, 0}.mul(e.tm)
+		endX := matrix{gs.fs * gs.th, 0, 0, gs.fs, 0, gs.ts}.mul(e.tm).mul(gs.ctm)[4]
+
+		e.addGlyph(g.text, x, endX, y, size)
+	}
+}
+
+// addGlyph 将字形加入当前行，按位置判断换行和插入空格
+func (e *textExtractor) addGlyph(text string, x, endX, y, size float64) {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ' || unicode.IsSpace(r):
+			return ' '
+		case unicode.IsControl(r) || r == utf8.RuneError || r == '�':
+			return -1
+		}
+		return r
+	}, text)
+	if text == "" || size <= 0 {
+		return
+	}
+	if endX < x {
+		x, endX = endX, x
+	}
+
+	cur := e.cur
+	if cur != nil {
+		tolerance := 0.5 * max(size, cur.size)
+		sameLine := math.Abs(y-cur.y) <= tolerance && x >= cur.endX-max(size, cur.size)*2
+		if sameLine {
+			blank := text == " " || strings.HasSuffix(cur.text, " ")
+			if text == " " && strings.HasSuffix(cur.text, " ") {
+				return
+			}
+			if gap := x - cur.endX; !blank && gap > 0.18*min(size, cur.size) {
+				last, _ := utf8.DecodeLastRuneInString(cur.text)
+				first, _ := utf8.DecodeRuneInString(text)
+				if !(isCJK(last) && isCJK(first)) || gap > size {
+					cur.text += " "
+				}
+			}
+			cur.text += text
+			cur.endX = max(cur.endX, endX)
+			if text != " " {
+				cur.size = max(cur.size, size)
+			}
+			return
+		}
+	}
+	e.flushLine()
+	if text == " " {
+		return
+	}
+	e.cur = &line{text: text, x: x, endX: endX, y: y, size: size}
+}
+
+// flushLine 结束当前行
+func (e *textExtractor) flushLine() {
+	if e.cur == nil {
+		return
+	}
+	if text := strings.TrimSpace(e.cur.text); text != "" {
+		e.cur.text = text
+		e.lines = append(e.lines, *e.cur)
+	}
+	e.cur = nil
+}
+
+// skipInlineImage 跳过内联图片（BI 已读取）：跳过参数到 ID，再跳过数据直到独立的 EI
+func skipInlineImage(l *lexer) {
+	for !l.eof() {
+		obj, err := l.readObject()
+		if err != nil {
+			continue
+		}
+		if kw, ok := obj.(keyword); ok && kw == "ID" {
+			break
+		}
+	}
+	l.pos++
+	for l.pos < len(l.data) {
+		i := bytes.Index(l.data[l.pos:], []byte("EI"))
+		if i < 0 {
+			l.pos = len(l.data)
+			return
+		}
+		at := l.pos + i
+		l.pos = at + 2
+		if at > 0 && isSpace(l.data[at-1]) && (l.pos == len(l.data) || isSpace(l.data[l.pos])) {
+			return
+		}
+	}
+}
+
+// isCJK 判断中日韩文字和全角标点（这些字符之间不插入空格）
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef)
+}